@@ -3,7 +3,8 @@ options { caseInsensitive = true; }
 
 search_query
   : EOF
-  | search_expr EOF
+  | search_expr pipeline? EOF
+  | pipeline EOF
   ;
 
 top_col_expr
@@ -28,6 +29,7 @@ search_expr
   | search_expr or_op search_expr # or_search_expr
   | search_expr implicit_and_op search_expr # implicit_and_search_expr
   | search_key bin_op top_col_expr? # key_val_search_expr
+  | search_key in_op in_list # in_search_expr
   | search_key exists_op # exists_search_expr
   | top_col_expr # body_search_expr
   ;
@@ -66,12 +68,46 @@ search_value
   : STRING
   | ID
   | VALUE
+  | IN
+  ;
+
+in_op
+  : IN
+  ;
+
+// Values may be separated by commas or whitespace. Commas are part of the
+// VALUE token, so unquoted values are split on commas by the listener.
+in_list
+  : LPAREN search_value+ RPAREN
+  ;
+
+// Aggregation stages after the filter, e.g. `| stats count() by service_name`.
+// A pipe only starts a stage when it is followed by one of the commands, so
+// that values such as `/api|worker/` or `GET | 200` keep their pipe.
+// Stage arguments are interpreted by the pipeline parser rather than the
+// grammar.
+pipeline
+  : pipe_stage+
+  ;
+
+pipe_stage
+  : pipe_command pipe_arg*
+  ;
+
+// The command is part of the PIPE token.
+pipe_command
+  : PIPE
+  ;
+
+pipe_arg
+  : ~PIPE
   ;
 
 AND : 'AND' ;
 OR : 'OR' ;
 NOT : 'NOT' ;
 EXISTS : 'EXISTS' ;
+IN : 'IN' ;
 BANG : '!' ;
 EQ : '=' ;
 NEQ : '!=' ;
@@ -82,10 +118,14 @@ GTE : '>=' ;
 LPAREN : '(' ;
 RPAREN : ')' ;
 COLON : ':' ;
+PIPE : '|' WHITESPACE* PIPE_COMMAND ;
+// A pipe followed by a longer word, e.g. `| headers`, is part of a value
+PIPE_VALUE : '|' WHITESPACE* PIPE_COMMAND ~[ \t\n\r\f=><:!)(]+ -> type(VALUE) ;
 ID : [A-Z_0-9.\-*]+ ;
 STRING : ('"' ( '\\"' | ~["] )* '"' | '\'' ( '\\\'' | ~['] )* '\'') | '`' ( '\\`' | ~[`] )* '`' ;
 VALUE : ~[ \t\n\r\f=><:!)(]+ ;
 
+fragment PIPE_COMMAND : 'STATS' | 'TIMECHART' | 'WHERE' | 'SORT' | 'HEAD' ;
 fragment WHITESPACE : [ \t\n\r\f] ;
 WS : WHITESPACE+ -> channel(HIDDEN) ;
 
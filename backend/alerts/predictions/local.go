@@ -0,0 +1,187 @@
+package predictions
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"go.openly.dev/pointy"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+	// the trend window when there is no daily seasonality to follow
+	minTrendWindow = 5
+	// scales the median absolute deviation to the standard deviation of normally distributed residuals
+	madScale = 1.4826
+	// the band is at least this fraction of the typical magnitude of the series, so constant series don't alert on noise
+	minRelativeScale = 0.01
+	minScale         = 1e-6
+	maxIntervalWidth = 0.9999
+)
+
+// AddLocalPredictions fills the prediction bounds of the buckets of each group without the prediction service.
+// Each series is decomposed into a moving average trend and a daily seasonal profile, when it spans at least two days,
+// and the bounds are the fitted value plus or minus a band from the median absolute deviation of the residuals.
+// The band covers IntervalWidth of normally distributed residuals, matching the interval width of the service.
+func AddLocalPredictions(metricBuckets []*modelInputs.MetricBucket, settings modelInputs.PredictionSettings) {
+	partitioned := lo.PartitionBy(metricBuckets, func(bucket *modelInputs.MetricBucket) string {
+		return strings.Join(bucket.Group, ",")
+	})
+
+	z := intervalZScore(settings.IntervalWidth)
+	for _, buckets := range partitioned {
+		sorted := make([]*modelInputs.MetricBucket, len(buckets))
+		copy(sorted, buckets)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].BucketID < sorted[j].BucketID
+		})
+
+		y := make([]float64, len(sorted))
+		for idx, b := range sorted {
+			// missing buckets are zero, like for the prediction service
+			if b.MetricValue != nil {
+				y[idx] = *b.MetricValue
+			}
+		}
+
+		fitted, scale := forecast(y, seasonalPeriod(settings.IntervalSeconds, len(y)))
+		for idx, b := range sorted {
+			if settings.ThresholdCondition != modelInputs.ThresholdConditionBelow {
+				b.YhatUpper = pointy.Float64(fitted[idx] + z*scale)
+			}
+			if settings.ThresholdCondition != modelInputs.ThresholdConditionAbove {
+				b.YhatLower = pointy.Float64(fitted[idx] - z*scale)
+			}
+		}
+	}
+}
+
+// seasonalPeriod is the number of buckets in a day, or 0 if the series is too short to estimate a daily profile.
+func seasonalPeriod(intervalSeconds int, n int) int {
+	if intervalSeconds <= 0 {
+		return 0
+	}
+	period := int(math.Round(float64(secondsPerDay) / float64(intervalSeconds)))
+	if period < 2 || n < 2*period {
+		return 0
+	}
+	return period
+}
+
+// intervalZScore is the number of standard deviations of a normal distribution covering the interval width.
+func intervalZScore(intervalWidth float64) float64 {
+	width := math.Min(math.Max(intervalWidth, 0), maxIntervalWidth)
+	return math.Sqrt2 * math.Erfinv(width)
+}
+
+// forecast returns the fitted value of each point and the robust scale of the residuals.
+// The last point is the one evaluated by anomaly alerts, so it is left out of the fit and its trend is the average
+// of the points preceding it, so that an anomaly doesn't shift nor widen its own band.
+func forecast(y []float64, period int) ([]float64, float64) {
+	n := len(y)
+	if n == 0 {
+		return nil, 0
+	}
+
+	window := period
+	if window < minTrendWindow {
+		window = minTrendWindow
+	}
+
+	// the points the trend, profile and residuals are estimated from
+	history := n - 1
+	if history == 0 {
+		history = n
+	}
+	trend := movingAverage(y[:history], window)
+	if history < n {
+		preceding := y[max(history-window, 0):history]
+		trend = append(trend, lo.Sum(preceding)/float64(len(preceding)))
+	}
+
+	seasonal := make([]float64, n)
+	if period > 0 {
+		deviations := make([][]float64, period)
+		for i := 0; i < history; i++ {
+			deviations[i%period] = append(deviations[i%period], y[i]-trend[i])
+		}
+		phases := make([]float64, period)
+		for k := range phases {
+			phases[k] = median(deviations[k])
+		}
+		// smooth the profile over neighbouring phases, since there are only a few periods to estimate each from
+		profile := make([]float64, period)
+		for k := range profile {
+			profile[k] = (phases[(k+period-1)%period] + phases[k] + phases[(k+1)%period]) / 3
+		}
+		// center the profile so it doesn't shift the trend
+		offset := lo.Sum(profile) / float64(period)
+		for i := range seasonal {
+			seasonal[i] = profile[i%period] - offset
+		}
+	}
+
+	fitted := make([]float64, n)
+	for i := range y {
+		fitted[i] = trend[i] + seasonal[i]
+	}
+
+	residuals := make([]float64, history)
+	magnitudes := make([]float64, history)
+	for i := 0; i < history; i++ {
+		residuals[i] = y[i] - fitted[i]
+		magnitudes[i] = math.Abs(y[i])
+	}
+
+	residualMedian := median(residuals)
+	for i := range residuals {
+		residuals[i] = math.Abs(residuals[i] - residualMedian)
+	}
+	scale := madScale * median(residuals)
+	// each residual is part of the fit of its own point, so the band is widened for the error of the fit
+	if period > 0 {
+		scale *= math.Sqrt(1 + float64(period)/float64(history))
+	}
+	scale = math.Max(scale, minRelativeScale*median(magnitudes))
+	scale = math.Max(scale, minScale)
+
+	return fitted, scale
+}
+
+// movingAverage is the mean of the centered window around each point, which cancels out a seasonal period exactly.
+// At the ends of the series the window is shifted inwards, so that it still spans a whole seasonal period.
+func movingAverage(y []float64, window int) []float64 {
+	result := make([]float64, len(y))
+	if window > len(y) {
+		window = len(y)
+	}
+	for i := range y {
+		start := i - window/2
+		if start < 0 {
+			start = 0
+		}
+		if start > len(y)-window {
+			start = len(y) - window
+		}
+		result[i] = lo.Sum(y[start:start+window]) / float64(window)
+	}
+	return result
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
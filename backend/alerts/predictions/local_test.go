@@ -0,0 +1,164 @@
+package predictions
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+	"go.openly.dev/pointy"
+)
+
+const hourSeconds = 60 * 60
+
+// seasonalBuckets is a week of hourly buckets with a daily cycle, a slow upward trend and noise.
+func seasonalBuckets(group []string, seed int64) []*modelInputs.MetricBucket {
+	random := rand.New(rand.NewSource(seed))
+	var buckets []*modelInputs.MetricBucket
+	for i := 0; i < 7*24; i++ {
+		value := 100 + 0.1*float64(i) + 40*math.Sin(2*math.Pi*float64(i)/24) + random.NormFloat64()*3
+		buckets = append(buckets, &modelInputs.MetricBucket{
+			BucketID:    uint64(i),
+			Group:       group,
+			MetricValue: pointy.Float64(value),
+		})
+	}
+	return buckets
+}
+
+func settings(condition modelInputs.ThresholdCondition) modelInputs.PredictionSettings {
+	return modelInputs.PredictionSettings{
+		IntervalWidth:      0.99,
+		IntervalSeconds:    hourSeconds,
+		ThresholdCondition: condition,
+	}
+}
+
+func outside(bucket *modelInputs.MetricBucket) bool {
+	return (bucket.YhatUpper != nil && *bucket.MetricValue > *bucket.YhatUpper) ||
+		(bucket.YhatLower != nil && *bucket.MetricValue < *bucket.YhatLower)
+}
+
+func TestAddLocalPredictionsSeasonal(t *testing.T) {
+	buckets := seasonalBuckets(nil, 1)
+	AddLocalPredictions(buckets, settings(modelInputs.ThresholdConditionOutside))
+
+	var anomalies int
+	for _, b := range buckets {
+		assert.NotNil(t, b.YhatUpper)
+		assert.NotNil(t, b.YhatLower)
+		assert.Less(t, *b.YhatLower, *b.YhatUpper)
+		if outside(b) {
+			anomalies++
+		}
+	}
+	// the band follows the daily cycle, so only a few noisy points fall outside it
+	assert.LessOrEqual(t, anomalies, len(buckets)/20)
+
+	// the band is much narrower than the daily amplitude
+	peak, trough := buckets[6*24+6], buckets[6*24+18]
+	assert.Less(t, *peak.YhatUpper-*peak.YhatLower, 40.)
+	assert.Greater(t, *peak.YhatLower, *trough.YhatUpper)
+}
+
+func TestAddLocalPredictionsSpike(t *testing.T) {
+	buckets := seasonalBuckets(nil, 2)
+	// a spike at the daily trough would be normal at the daily peak
+	spike, drop := buckets[5*24+18], buckets[5*24+6]
+	spike.MetricValue = pointy.Float64(*spike.MetricValue + 50)
+	drop.MetricValue = pointy.Float64(*drop.MetricValue - 50)
+	AddLocalPredictions(buckets, settings(modelInputs.ThresholdConditionOutside))
+
+	assert.True(t, outside(spike))
+	assert.Greater(t, *spike.MetricValue, *spike.YhatUpper)
+	assert.True(t, outside(drop))
+	assert.Less(t, *drop.MetricValue, *drop.YhatLower)
+	assert.False(t, outside(buckets[5*24+17]))
+	assert.False(t, outside(buckets[5*24+7]))
+}
+
+func TestAddLocalPredictionsEvaluatedBucket(t *testing.T) {
+	// the last bucket is the one evaluated by anomaly alerts, so it is predicted from the preceding buckets only
+	normal := seasonalBuckets(nil, 7)
+	AddLocalPredictions(normal, settings(modelInputs.ThresholdConditionOutside))
+	last := normal[len(normal)-1]
+	assert.False(t, outside(last))
+
+	spiked := seasonalBuckets(nil, 7)
+	spike := spiked[len(spiked)-1]
+	spike.MetricValue = pointy.Float64(*spike.MetricValue + 30)
+	AddLocalPredictions(spiked, settings(modelInputs.ThresholdConditionOutside))
+	assert.True(t, outside(spike))
+	assert.InDelta(t, *last.YhatUpper, *spike.YhatUpper, 1e-9)
+	assert.InDelta(t, *last.YhatLower, *spike.YhatLower, 1e-9)
+}
+
+func TestAddLocalPredictionsThresholdCondition(t *testing.T) {
+	above := seasonalBuckets(nil, 3)
+	AddLocalPredictions(above, settings(modelInputs.ThresholdConditionAbove))
+	for _, b := range above {
+		assert.NotNil(t, b.YhatUpper)
+		assert.Nil(t, b.YhatLower)
+	}
+
+	below := seasonalBuckets(nil, 3)
+	AddLocalPredictions(below, settings(modelInputs.ThresholdConditionBelow))
+	for _, b := range below {
+		assert.Nil(t, b.YhatUpper)
+		assert.NotNil(t, b.YhatLower)
+	}
+}
+
+func TestAddLocalPredictionsGroups(t *testing.T) {
+	a := seasonalBuckets([]string{"a"}, 4)
+	b := seasonalBuckets([]string{"b"}, 5)
+	for _, bucket := range b {
+		bucket.MetricValue = pointy.Float64(*bucket.MetricValue * 10)
+	}
+	// groups are interleaved and out of order, as returned by clickhouse
+	var buckets []*modelInputs.MetricBucket
+	for i := len(a) - 1; i >= 0; i-- {
+		buckets = append(buckets, b[i], a[i])
+	}
+	AddLocalPredictions(buckets, settings(modelInputs.ThresholdConditionOutside))
+
+	var anomalies int
+	for _, bucket := range buckets {
+		if outside(bucket) {
+			anomalies++
+		}
+	}
+	assert.LessOrEqual(t, anomalies, len(buckets)/20)
+	assert.Greater(t, *b[100].YhatLower, *a[100].YhatUpper)
+}
+
+func TestAddLocalPredictionsShortSeries(t *testing.T) {
+	// too short for a daily profile, and missing buckets are zero
+	var buckets []*modelInputs.MetricBucket
+	for i := 0; i < 12; i++ {
+		bucket := &modelInputs.MetricBucket{BucketID: uint64(i)}
+		if i != 3 {
+			bucket.MetricValue = pointy.Float64(10)
+		}
+		buckets = append(buckets, bucket)
+	}
+	buckets = append(buckets, &modelInputs.MetricBucket{BucketID: 12, MetricValue: pointy.Float64(30)})
+	AddLocalPredictions(buckets, settings(modelInputs.ThresholdConditionAbove))
+
+	assert.Less(t, *buckets[0].YhatUpper, 20.)
+	assert.Greater(t, 30., *buckets[12].YhatUpper)
+	assert.Less(t, 10., *buckets[5].YhatUpper)
+
+	AddLocalPredictions(nil, settings(modelInputs.ThresholdConditionAbove))
+}
+
+func TestAddPredictionsWithoutEndpoint(t *testing.T) {
+	buckets := seasonalBuckets(nil, 6)
+	assert.NoError(t, AddPredictions(context.Background(), buckets, settings(modelInputs.ThresholdConditionOutside)))
+	for _, b := range buckets {
+		assert.NotNil(t, b.YhatUpper)
+		assert.NotNil(t, b.YhatLower)
+	}
+}
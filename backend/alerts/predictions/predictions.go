@@ -12,6 +12,7 @@ import (
 
 	"github.com/highlight-run/highlight/backend/env"
 	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
 	"go.openly.dev/pointy"
 
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
@@ -36,7 +37,22 @@ type PredictionResult struct {
 	YHatUpper map[int]float64 `json:"yhat_upper"`
 }
 
+// AddPredictions fills the prediction bounds of the metric buckets from the prediction service.
+// Without a configured prediction service, or if it fails, the bounds are forecast in-process.
 func AddPredictions(ctx context.Context, metricBuckets []*modelInputs.MetricBucket, settings modelInputs.PredictionSettings) error {
+	if env.Config.PredictionsEndpoint == "" {
+		AddLocalPredictions(metricBuckets, settings)
+		return nil
+	}
+
+	if err := addServicePredictions(ctx, metricBuckets, settings); err != nil {
+		log.WithContext(ctx).WithError(err).Warn("prediction service failed, falling back to local predictions")
+		AddLocalPredictions(metricBuckets, settings)
+	}
+	return nil
+}
+
+func addServicePredictions(ctx context.Context, metricBuckets []*modelInputs.MetricBucket, settings modelInputs.PredictionSettings) error {
 	// Partition all buckets by group, then get a prediction for each group
 	partitioned := lo.PartitionBy(metricBuckets, func(bucket *modelInputs.MetricBucket) string {
 		return strings.Join(bucket.Group, ",")
@@ -15,6 +15,8 @@ import (
 	discordV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/discord"
 	emailV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/email"
 	microsoftteamsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/microsoft-teams"
+	opsgenieV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/opsgenie"
+	pagerdutyV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/pagerduty"
 	slackV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/slack"
 	webhookV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/webhook"
 	"github.com/highlight-run/highlight/backend/env"
@@ -25,84 +27,193 @@ import (
 )
 
 func SendAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alert *model.Alert, alertGroup string, alertGroupValue string, value float64) error {
-	span, ctx := util.StartSpanFromContext(ctx, "SendAlerts")
-	span.SetAttribute("alert_id", alert.ID)
-	span.SetAttribute("project_id", alert.ProjectID)
-	span.SetAttribute("product_type", alert.ProductType)
+	span, ctx := startAlertSpan(ctx, "SendAlerts", alert)
 	defer span.Finish()
 
-	destinations := []model.AlertDestination{}
-	if err := db.WithContext(ctx).Where("alert_id = ?", alert.ID).Find(&destinations).Error; err != nil {
-		return err
-	}
-
-	if len(destinations) == 0 {
-		return nil
-	}
-
-	log.WithContext(ctx).WithFields(
-		log.Fields{
-			"alertID":          alert.ID,
-			"alertProductType": alert.ProductType,
-		}).Info("sending alerts")
-
-	destinationsByType := make(map[modelInputs.AlertDestinationType][]model.AlertDestination)
-	for _, destination := range destinations {
-		destinationsByType[destination.DestinationType] = append(destinationsByType[destination.DestinationType], destination)
-	}
-
-	var project model.Project
-	if err := db.WithContext(ctx).Model(&model.Project{}).Preload("Workspace").Where(&model.Project{Model: model.Model{ID: alert.ProjectID}}).Take(&project).Error; err != nil {
+	notification, err := loadAlertNotification(ctx, db, alert, alertGroup, alertGroupValue)
+	if err != nil || notification == nil {
 		return err
 	}
-
-	frontendURL := env.Config.FrontendUri
-	alertInput := destinationsV2.AlertInput{
-		Alert:       alert,
-		AlertLink:   fmt.Sprintf("%s/%d/alerts/%d", frontendURL, alert.ProjectID, alert.ID),
-		AlertValue:  value,
-		Group:       alertGroup,
-		GroupValue:  alertGroupValue,
-		ProjectName: *project.Name,
-	}
+	alertInput := &notification.input
+	alertInput.AlertValue = value
 
 	switch alert.ProductType {
 	case modelInputs.ProductTypeSessions:
-		sessionAlertInput, err := buildSessionAlertInput(ctx, db, &alertInput)
+		sessionAlertInput, err := buildSessionAlertInput(ctx, db, alertInput)
 		if err != nil {
 			return err
 		}
 		alertInput.SessionInput = sessionAlertInput
 	case modelInputs.ProductTypeErrors:
-		errorAlertInput, err := buildErrorAlertInput(ctx, db, &alertInput)
+		errorAlertInput, err := buildErrorAlertInput(ctx, db, alertInput)
 		if err != nil {
 			return err
 		}
 		alertInput.ErrorInput = errorAlertInput
 	case modelInputs.ProductTypeLogs:
-		alertInput.LogInput = buildLogAlertInput(ctx, db, &alertInput)
+		alertInput.LogInput = buildLogAlertInput(ctx, db, alertInput)
 	case modelInputs.ProductTypeTraces:
-		alertInput.TraceInput = buildTraceAlertInput(ctx, db, &alertInput)
+		alertInput.TraceInput = buildTraceAlertInput(ctx, db, alertInput)
 	case modelInputs.ProductTypeMetrics:
-		alertInput.MetricInput = buildMetricAlertInput(ctx, db, &alertInput)
+		alertInput.MetricInput = buildMetricAlertInput(ctx, db, alertInput)
 	case modelInputs.ProductTypeEvents:
 		// nothing extra needed
 	default:
 		return e.New("invalid product type")
 	}
 
-	for _, destinations := range destinationsByType {
-		switch destinations[0].DestinationType {
+	return sendAlerts(ctx, db, mailClient, lambdaClient, notification)
+}
+
+// SendChangeAlerts notifies the destinations of a change alert with the change of the alert group value
+// from the earlier window.
+func SendChangeAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alert *model.Alert, alertGroup string, alertGroupValue string, change float64, changeInput destinationsV2.ChangeInput) error {
+	span, ctx := startAlertSpan(ctx, "SendChangeAlerts", alert)
+	defer span.Finish()
+
+	notification, err := loadAlertNotification(ctx, db, alert, alertGroup, alertGroupValue)
+	if err != nil || notification == nil {
+		return err
+	}
+	notification.input.AlertValue = change
+	notification.input.ChangeInput = &changeInput
+
+	return sendAlerts(ctx, db, mailClient, lambdaClient, notification)
+}
+
+// SendCompositeAlerts notifies the destinations of a composite alert with the latest state of each child condition.
+func SendCompositeAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alert *model.Alert, composite destinationsV2.CompositeInput) error {
+	span, ctx := startAlertSpan(ctx, "SendCompositeAlerts", alert)
+	defer span.Finish()
+
+	notification, err := loadAlertNotification(ctx, db, alert, "", "")
+	if err != nil || notification == nil {
+		return err
+	}
+	notification.input.CompositeInput = &composite
+
+	return sendAlerts(ctx, db, mailClient, lambdaClient, notification)
+}
+
+// SendResolvedAlerts notifies the alert destinations that an alerting group returned to normal.
+func SendResolvedAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alert *model.Alert, alertGroup string, alertGroupValue string, resolved destinationsV2.ResolvedInput) error {
+	span, ctx := startAlertSpan(ctx, "SendResolvedAlerts", alert)
+	defer span.Finish()
+
+	notification, err := loadAlertNotification(ctx, db, alert, alertGroup, alertGroupValue)
+	if err != nil || notification == nil {
+		return err
+	}
+	if resolved.Value != nil {
+		notification.input.AlertValue = *resolved.Value
+	}
+	notification.input.ResolvedInput = &resolved
+
+	return sendAlerts(ctx, db, mailClient, lambdaClient, notification)
+}
+
+// alertNotification is a notification of an alert to its destinations.
+type alertNotification struct {
+	project            model.Project
+	destinationsByType map[modelInputs.AlertDestinationType][]model.AlertDestination
+	input              destinationsV2.AlertInput
+}
+
+func startAlertSpan(ctx context.Context, operationName string, alert *model.Alert) (util.MultiSpan, context.Context) {
+	span, ctx := util.StartSpanFromContext(ctx, operationName)
+	span.SetAttribute("alert_id", alert.ID)
+	span.SetAttribute("project_id", alert.ProjectID)
+	span.SetAttribute("product_type", alert.ProductType)
+	return span, ctx
+}
+
+// loadAlertNotification loads the destinations and project of the alert and builds the input shared by its notifications.
+// Returns nil if the alert has no destinations.
+func loadAlertNotification(ctx context.Context, db *gorm.DB, alert *model.Alert, alertGroup string, alertGroupValue string) (*alertNotification, error) {
+	destinationsByType, err := getDestinationsByType(ctx, db, alert)
+	if err != nil || len(destinationsByType) == 0 {
+		return nil, err
+	}
+
+	var project model.Project
+	if err := db.WithContext(ctx).Model(&model.Project{}).Preload("Workspace").Where(&model.Project{Model: model.Model{ID: alert.ProjectID}}).Take(&project).Error; err != nil {
+		return nil, err
+	}
+
+	return &alertNotification{
+		project:            project,
+		destinationsByType: destinationsByType,
+		input: destinationsV2.AlertInput{
+			Alert:       alert,
+			AlertLink:   fmt.Sprintf("%s/%d/alerts/%d", env.Config.FrontendUri, alert.ProjectID, alert.ID),
+			Group:       alertGroup,
+			GroupValue:  alertGroupValue,
+			ProjectName: *project.Name,
+		},
+	}, nil
+}
+
+// sendAlerts sends the notification to each destination of the alert, as a resolved notification if it has a ResolvedInput.
+func sendAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, lambdaClient *lambda.Client, notification *alertNotification) error {
+	alertInput := &notification.input
+	workspace := notification.project.Workspace
+	resolved := alertInput.ResolvedInput != nil
+
+	log.WithContext(ctx).WithFields(
+		log.Fields{
+			"alertID":          alertInput.Alert.ID,
+			"alertProductType": alertInput.Alert.ProductType,
+			"resolved":         resolved,
+		}).Info("sending alerts")
+
+	for destinationType, destinations := range notification.destinationsByType {
+		// incidents are resolved even when the alert opted out of resolved notifications or is silenced
+		if resolved && (alertInput.Alert.DisableResolvedNotifications || alertInput.ResolvedInput.Silenced) && !IsIncidentDestination(destinationType) {
+			continue
+		}
+		switch destinationType {
 		case modelInputs.AlertDestinationTypeSlack:
-			slackV2.SendAlerts(ctx, project.Workspace.SlackAccessToken, &alertInput, destinations)
+			if resolved {
+				slackV2.SendResolvedAlerts(ctx, workspace.SlackAccessToken, alertInput, destinations)
+			} else {
+				slackV2.SendAlerts(ctx, workspace.SlackAccessToken, alertInput, destinations)
+			}
 		case modelInputs.AlertDestinationTypeDiscord:
-			discordV2.SendAlerts(ctx, project.Workspace.DiscordGuildId, &alertInput, destinations)
+			if resolved {
+				discordV2.SendResolvedAlerts(ctx, workspace.DiscordGuildId, alertInput, destinations)
+			} else {
+				discordV2.SendAlerts(ctx, workspace.DiscordGuildId, alertInput, destinations)
+			}
 		case modelInputs.AlertDestinationTypeMicrosoftTeams:
-			microsoftteamsV2.SendAlerts(ctx, project.Workspace.MicrosoftTeamsTenantId, &alertInput, destinations)
+			if resolved {
+				microsoftteamsV2.SendResolvedAlerts(ctx, workspace.MicrosoftTeamsTenantId, alertInput, destinations)
+			} else {
+				microsoftteamsV2.SendAlerts(ctx, workspace.MicrosoftTeamsTenantId, alertInput, destinations)
+			}
 		case modelInputs.AlertDestinationTypeEmail:
-			emailV2.SendAlerts(ctx, mailClient, lambdaClient, &alertInput, destinations)
+			if resolved {
+				emailV2.SendResolvedAlerts(ctx, mailClient, lambdaClient, alertInput, destinations)
+			} else {
+				emailV2.SendAlerts(ctx, mailClient, lambdaClient, alertInput, destinations)
+			}
 		case modelInputs.AlertDestinationTypeWebhook:
-			webhookV2.SendAlerts(ctx, &alertInput, destinations)
+			if resolved {
+				webhookV2.SendResolvedAlerts(ctx, db, alertInput, destinations)
+			} else {
+				webhookV2.SendAlerts(ctx, db, alertInput, destinations)
+			}
+		case modelInputs.AlertDestinationTypePagerDuty:
+			if resolved {
+				pagerdutyV2.SendResolvedAlerts(ctx, alertInput, destinations)
+			} else {
+				pagerdutyV2.SendAlerts(ctx, alertInput, destinations)
+			}
+		case modelInputs.AlertDestinationTypeOpsgenie:
+			if resolved {
+				opsgenieV2.SendResolvedAlerts(ctx, alertInput, destinations)
+			} else {
+				opsgenieV2.SendAlerts(ctx, alertInput, destinations)
+			}
 		default:
 			return e.New("invalid destination type")
 		}
@@ -111,6 +222,25 @@ func SendAlerts(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Client, l
 	return nil
 }
 
+// IsIncidentDestination is whether the destination tracks the lifecycle of an incident
+// rather than receiving one-off notifications.
+func IsIncidentDestination(destinationType modelInputs.AlertDestinationType) bool {
+	return destinationType == modelInputs.AlertDestinationTypePagerDuty || destinationType == modelInputs.AlertDestinationTypeOpsgenie
+}
+
+func getDestinationsByType(ctx context.Context, db *gorm.DB, alert *model.Alert) (map[modelInputs.AlertDestinationType][]model.AlertDestination, error) {
+	destinations := []model.AlertDestination{}
+	if err := db.WithContext(ctx).Where("alert_id = ?", alert.ID).Find(&destinations).Error; err != nil {
+		return nil, err
+	}
+
+	destinationsByType := make(map[modelInputs.AlertDestinationType][]model.AlertDestination)
+	for _, destination := range destinations {
+		destinationsByType[destination.DestinationType] = append(destinationsByType[destination.DestinationType], destination)
+	}
+	return destinationsByType, nil
+}
+
 func buildSessionAlertInput(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput) (*destinationsV2.SessionInput, error) {
 	sessionSecureID := alertInput.GroupValue
 
@@ -258,7 +388,9 @@ func SendNotifications(ctx context.Context, db *gorm.DB, mailClient *sendgrid.Cl
 		case modelInputs.AlertDestinationTypeEmail:
 			emailV2.SendNotifications(ctx, mailClient, lambdaClient, notificationInput, destinations)
 		case modelInputs.AlertDestinationTypeWebhook:
-			webhookV2.SendNotifications(ctx, notificationInput, destinations)
+			webhookV2.SendNotifications(ctx, db, notificationInput, destinations)
+		case modelInputs.AlertDestinationTypePagerDuty, modelInputs.AlertDestinationTypeOpsgenie:
+			// paging destinations only receive incidents
 		default:
 			log.WithContext(ctx).WithFields(
 				log.Fields{
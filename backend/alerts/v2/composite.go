@@ -0,0 +1,126 @@
+package alertsV2
+
+import (
+	"encoding/json"
+
+	e "github.com/pkg/errors"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+// maxCompositeConditions bounds the number of child alerts of a composite alert.
+const maxCompositeConditions = 10
+
+// ValidateCompositeCondition checks that the condition is a well formed tree referencing two or more child alerts.
+func ValidateCompositeCondition(condition *modelInputs.CompositeCondition) error {
+	if err := validateCompositeCondition(condition); err != nil {
+		return err
+	}
+	alertIDs := CompositeAlertIDs(condition)
+	if len(alertIDs) < 2 {
+		return e.New("a composite alert requires at least two conditions")
+	}
+	if len(alertIDs) > maxCompositeConditions {
+		return e.Errorf("a composite alert can have at most %d conditions", maxCompositeConditions)
+	}
+	return nil
+}
+
+func validateCompositeCondition(condition *modelInputs.CompositeCondition) error {
+	if condition == nil {
+		return e.New("a composite condition is required")
+	}
+	if condition.Operator == nil {
+		if condition.AlertID == nil {
+			return e.New("a composite condition requires an operator or an alert")
+		}
+		if len(condition.Conditions) > 0 {
+			return e.New("an alert condition cannot have nested conditions")
+		}
+		return nil
+	}
+
+	if condition.AlertID != nil {
+		return e.Errorf("an %s condition cannot reference an alert", *condition.Operator)
+	}
+	switch *condition.Operator {
+	case modelInputs.CompositeOperatorAnd, modelInputs.CompositeOperatorOr:
+		if len(condition.Conditions) < 2 {
+			return e.Errorf("an %s condition requires at least two conditions", *condition.Operator)
+		}
+	case modelInputs.CompositeOperatorNot:
+		if len(condition.Conditions) != 1 {
+			return e.New("a Not condition requires exactly one condition")
+		}
+	default:
+		return e.Errorf("invalid composite operator %s", *condition.Operator)
+	}
+	for _, child := range condition.Conditions {
+		if err := validateCompositeCondition(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CompositeAlertIDs returns the distinct child alerts referenced by the condition, in order of appearance.
+func CompositeAlertIDs(condition *modelInputs.CompositeCondition) []int {
+	var alertIDs []int
+	seen := map[int]bool{}
+	var visit func(condition *modelInputs.CompositeCondition)
+	visit = func(condition *modelInputs.CompositeCondition) {
+		if condition == nil {
+			return
+		}
+		if condition.AlertID != nil && !seen[*condition.AlertID] {
+			seen[*condition.AlertID] = true
+			alertIDs = append(alertIDs, *condition.AlertID)
+		}
+		for _, child := range condition.Conditions {
+			visit(child)
+		}
+	}
+	visit(condition)
+	return alertIDs
+}
+
+// EvaluateCompositeCondition returns whether the condition holds given which child alerts are alerting.
+// Child alerts missing from alerting are considered normal.
+func EvaluateCompositeCondition(condition *modelInputs.CompositeCondition, alerting map[int]bool) bool {
+	if condition.Operator == nil {
+		return condition.AlertID != nil && alerting[*condition.AlertID]
+	}
+
+	switch *condition.Operator {
+	case modelInputs.CompositeOperatorAnd:
+		for _, child := range condition.Conditions {
+			if !EvaluateCompositeCondition(child, alerting) {
+				return false
+			}
+		}
+		return len(condition.Conditions) > 0
+	case modelInputs.CompositeOperatorOr:
+		for _, child := range condition.Conditions {
+			if EvaluateCompositeCondition(child, alerting) {
+				return true
+			}
+		}
+		return false
+	case modelInputs.CompositeOperatorNot:
+		return len(condition.Conditions) == 1 && !EvaluateCompositeCondition(condition.Conditions[0], alerting)
+	}
+	return false
+}
+
+// GetCompositeCondition parses the condition of a composite alert, returning nil for other alerts.
+func GetCompositeCondition(alert *model.Alert) (*modelInputs.CompositeCondition, error) {
+	if alert.CompositeCondition == nil {
+		return nil, nil
+	}
+	var condition modelInputs.CompositeCondition
+	if err := json.Unmarshal([]byte(*alert.CompositeCondition), &condition); err != nil {
+		return nil, e.Wrap(err, "error parsing composite condition")
+	}
+	return &condition, nil
+}
@@ -0,0 +1,56 @@
+package alertsV2
+
+import (
+	"testing"
+
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func leaf(alertID int) *modelInputs.CompositeCondition {
+	return &modelInputs.CompositeCondition{AlertID: pointy.Int(alertID)}
+}
+
+func op(operator modelInputs.CompositeOperator, conditions ...*modelInputs.CompositeCondition) *modelInputs.CompositeCondition {
+	return &modelInputs.CompositeCondition{Operator: lo.ToPtr(operator), Conditions: conditions}
+}
+
+func TestValidateCompositeCondition(t *testing.T) {
+	assert.NoError(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorAnd, leaf(1), leaf(2))))
+	assert.NoError(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorAnd, leaf(1), op(modelInputs.CompositeOperatorNot, leaf(2)))))
+
+	assert.Error(t, ValidateCompositeCondition(nil))
+	assert.Error(t, ValidateCompositeCondition(leaf(1)))
+	assert.Error(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorNot, leaf(1))))
+	assert.Error(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorAnd, leaf(1), leaf(1))))
+	assert.Error(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorOr, leaf(1))))
+	assert.Error(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorNot, leaf(1), leaf(2))))
+	assert.Error(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorAnd, leaf(1), &modelInputs.CompositeCondition{})))
+	assert.Error(t, ValidateCompositeCondition(&modelInputs.CompositeCondition{
+		Operator:   lo.ToPtr(modelInputs.CompositeOperatorAnd),
+		AlertID:    pointy.Int(3),
+		Conditions: []*modelInputs.CompositeCondition{leaf(1), leaf(2)},
+	}))
+
+	var leaves []*modelInputs.CompositeCondition
+	for i := 0; i <= maxCompositeConditions; i++ {
+		leaves = append(leaves, leaf(i))
+	}
+	assert.Error(t, ValidateCompositeCondition(op(modelInputs.CompositeOperatorOr, leaves...)))
+}
+
+func TestEvaluateCompositeCondition(t *testing.T) {
+	// error count spike but not during a deploy
+	spikeNotDeploy := op(modelInputs.CompositeOperatorAnd, leaf(1), op(modelInputs.CompositeOperatorNot, leaf(2)))
+	assert.True(t, EvaluateCompositeCondition(spikeNotDeploy, map[int]bool{1: true}))
+	assert.False(t, EvaluateCompositeCondition(spikeNotDeploy, map[int]bool{1: true, 2: true}))
+	assert.False(t, EvaluateCompositeCondition(spikeNotDeploy, map[int]bool{}))
+
+	either := op(modelInputs.CompositeOperatorOr, leaf(1), leaf(2))
+	assert.True(t, EvaluateCompositeCondition(either, map[int]bool{2: true}))
+	assert.False(t, EvaluateCompositeCondition(either, map[int]bool{3: true}))
+
+	assert.Equal(t, []int{1, 2}, CompositeAlertIDs(op(modelInputs.CompositeOperatorOr, spikeNotDeploy, leaf(1))))
+}
@@ -1,7 +1,12 @@
 package destinationsV2
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/highlight-run/highlight/backend/model"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
@@ -39,6 +44,12 @@ type AlertInput struct {
 	TraceInput   *TraceInput
 	MetricInput  *MetricInput
 	WorkspaceID  int
+	// set when the alert group returned to normal rather than started alerting
+	ResolvedInput *ResolvedInput
+	// set for composite alerts, which alert on the combined states of their child alerts
+	CompositeInput *CompositeInput
+	// set for change alerts, which alert on the change of the value from an earlier window
+	ChangeInput *ChangeInput
 }
 
 type SessionInput struct {
@@ -76,3 +87,244 @@ type TraceInput struct {
 type MetricInput struct {
 	DashboardLink string
 }
+
+type ResolvedInput struct {
+	FiringSince time.Time
+	ResolvedAt  time.Time
+	// the value at recovery, nil when the group had no data
+	Value *float64
+	// set when a silence matches the group, only incidents are resolved
+	Silenced bool
+}
+
+// DurationText is how long the alert group was alerting, ie. `1h5m`.
+func (r *ResolvedInput) DurationText() string {
+	duration := r.ResolvedAt.Sub(r.FiringSince).Round(time.Minute)
+	if duration < time.Minute {
+		return "less than a minute"
+	}
+	return strings.TrimSuffix(duration.String(), "0s")
+}
+
+// ValueText is the value of the alert group at recovery, formatted like the alerting value.
+func (r *ResolvedInput) ValueText(alert *model.Alert) string {
+	return FormatValue(alert, r.Value)
+}
+
+type CompositeInput struct {
+	Conditions []*CompositeConditionInput
+}
+
+// CompositeConditionInput is the latest state of a child alert of a composite alert.
+type CompositeConditionInput struct {
+	Alert    *model.Alert
+	Alerting bool
+	// the group of the value, empty when the child alert is not grouped
+	GroupValue string
+	// nil when the child alert has no recent data
+	Value *float64
+}
+
+// Title names the child condition, with its group when grouped.
+func (c *CompositeConditionInput) Title() string {
+	if c.GroupValue == "" {
+		return c.Alert.Name
+	}
+	return fmt.Sprintf("%s (%s)", c.Alert.Name, c.GroupValue)
+}
+
+// ValueText is the value and state of the child condition, ie. `12 - alerting`.
+func (c *CompositeConditionInput) ValueText() string {
+	state := "normal"
+	if c.Alerting {
+		state = "alerting"
+	}
+	return fmt.Sprintf("%s - %s", FormatValue(c.Alert, c.Value), state)
+}
+
+// Text describes the child condition, ie. `Checkout errors (checkout): 12 - alerting`.
+func (c *CompositeConditionInput) Text() string {
+	return fmt.Sprintf("%s: %s", c.Title(), c.ValueText())
+}
+
+// ChangeInput compares the value of a change alert group with the value of the earlier window.
+// The AlertValue of the alert input is the change.
+type ChangeInput struct {
+	Value float64
+	// nil when the earlier window had no data
+	PreviousValue *float64
+}
+
+// Text compares the values of the windows, ie. `45 compared with 10 1 day earlier`.
+func (c *ChangeInput) Text(alert *model.Alert) string {
+	return fmt.Sprintf("%s compared with %s %s", FormatValue(alert, &c.Value), FormatValue(alert, c.PreviousValue), ChangeWindowText(alert))
+}
+
+// GetChangeWindow is how much earlier the window a change alert compares with is, an hour by default.
+func GetChangeWindow(alert *model.Alert) time.Duration {
+	if alert.ThresholdChangeWindow == nil || *alert.ThresholdChangeWindow <= 0 {
+		return time.Hour
+	}
+	return time.Duration(*alert.ThresholdChangeWindow) * time.Second
+}
+
+// ChangeWindowText describes the window a change alert compares with, ie. `1 day earlier`.
+func ChangeWindowText(alert *model.Alert) string {
+	window := GetChangeWindow(alert)
+	for _, unit := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"week", 7 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	} {
+		if window%unit.duration == 0 {
+			count := int(window / unit.duration)
+			if count == 1 {
+				return fmt.Sprintf("1 %s earlier", unit.name)
+			}
+			return fmt.Sprintf("%d %ss earlier", count, unit.name)
+		}
+	}
+	return fmt.Sprintf("%s earlier", window)
+}
+
+// FormatChange formats the change of a change alert, ie. `+350%` or `-2.5/min`.
+func FormatChange(alert *model.Alert, change float64) string {
+	if alert.ThresholdChangeType == modelInputs.ChangeTypeRate {
+		return fmt.Sprintf("%+g/min", roundChange(change))
+	}
+	return fmt.Sprintf("%+g%%", roundChange(change))
+}
+
+// ChangeThresholdText describes the threshold of a change alert, ie. `increase of 300%`.
+func ChangeThresholdText(alert *model.Alert) string {
+	var threshold float64
+	if alert.ThresholdValue != nil {
+		threshold = math.Abs(*alert.ThresholdValue)
+	}
+	change := strings.TrimPrefix(FormatChange(alert, threshold), "+")
+	switch alert.ThresholdCondition {
+	case modelInputs.ThresholdConditionBelow:
+		return fmt.Sprintf("decrease of %s", change)
+	case modelInputs.ThresholdConditionOutside:
+		return fmt.Sprintf("change of %s", change)
+	default:
+		return fmt.Sprintf("increase of %s", change)
+	}
+}
+
+func roundChange(change float64) float64 {
+	return math.Round(change*100) / 100
+}
+
+// FormatValue formats an alert value, counts as integers.
+func FormatValue(alert *model.Alert, value *float64) string {
+	if value == nil {
+		return "no data"
+	}
+	if IsCountAggregator(alert.FunctionType) {
+		return fmt.Sprintf("%d", int(*value))
+	}
+	return fmt.Sprintf("%f", *value)
+}
+
+func IsCountAggregator(functionType modelInputs.MetricAggregator) bool {
+	return functionType == modelInputs.MetricAggregatorCount || functionType == modelInputs.MetricAggregatorCountDistinct || functionType == modelInputs.MetricAggregatorCountDistinctKey
+}
+
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityError    Severity = "error"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// GetSeverity is the incident severity of the alert. Alerts without a valid severity
+// default to error for error alerts and warning for everything else.
+func GetSeverity(alert *model.Alert) Severity {
+	if alert.Severity != nil {
+		switch severity := Severity(*alert.Severity); severity {
+		case SeverityCritical, SeverityError, SeverityWarning, SeverityInfo:
+			return severity
+		}
+	}
+	if alert.ProductType == modelInputs.ProductTypeErrors {
+		return SeverityError
+	}
+	return SeverityWarning
+}
+
+// maxIncidentKeyLength fits the PagerDuty dedup key limit, the strictest of the paging destinations.
+const maxIncidentKeyLength = 255
+
+// IncidentKey deduplicates the incidents of an alert group, so that repeated alerts update
+// the open incident and the resolved alert closes it. Long group values are hashed.
+func IncidentKey(alert *model.Alert, groupValue string) string {
+	key := fmt.Sprintf("highlight-alert-%d-%s", alert.ID, groupValue)
+	if len(key) > maxIncidentKeyLength {
+		key = fmt.Sprintf("highlight-alert-%d-%x", alert.ID, sha256.Sum256([]byte(groupValue)))
+	}
+	return key
+}
+
+// TruncateIncidentText limits text sent to a paging destination to maxLength characters,
+// without splitting a multi-byte character.
+func TruncateIncidentText(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	return string([]rune(text)[:maxLength])
+}
+
+// IncidentSummary is the title of the incident opened for the alert group.
+func IncidentSummary(alertInput *AlertInput) string {
+	if alertInput.GroupValue == "" || alertInput.Group == "" {
+		return fmt.Sprintf("%s is alerting", alertInput.Alert.Name)
+	}
+	return fmt.Sprintf("%s is alerting for %s %s", alertInput.Alert.Name, alertInput.Group, alertInput.GroupValue)
+}
+
+// IncidentDetails are the alert fields attached to the incident opened for the alert group.
+func IncidentDetails(alertInput *AlertInput) map[string]interface{} {
+	details := map[string]interface{}{
+		"alert_id":     alertInput.Alert.ID,
+		"alert_name":   alertInput.Alert.Name,
+		"alert_url":    alertInput.AlertLink,
+		"product_type": alertInput.Alert.ProductType,
+		"project":      alertInput.ProjectName,
+		"value":        alertInput.AlertValue,
+	}
+	if alertInput.Alert.Query != nil {
+		details["query"] = *alertInput.Alert.Query
+	}
+	if alertInput.Alert.ThresholdValue != nil {
+		details["threshold"] = *alertInput.Alert.ThresholdValue
+	}
+	if alertInput.Group != "" {
+		details["group"] = alertInput.Group
+		details["group_value"] = alertInput.GroupValue
+	}
+	if alertInput.ChangeInput != nil {
+		details["current_value"] = alertInput.ChangeInput.Value
+		details["previous_value"] = alertInput.ChangeInput.PreviousValue
+	}
+	if alertInput.CompositeInput != nil {
+		var conditions []map[string]interface{}
+		for _, condition := range alertInput.CompositeInput.Conditions {
+			conditions = append(conditions, map[string]interface{}{
+				"alert_id":    condition.Alert.ID,
+				"alert_name":  condition.Alert.Name,
+				"alerting":    condition.Alerting,
+				"group_value": condition.GroupValue,
+				"value":       condition.Value,
+			})
+		}
+		details["conditions"] = conditions
+	}
+	return details
+}
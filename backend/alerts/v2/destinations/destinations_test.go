@@ -0,0 +1,92 @@
+package destinationsV2
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvedInput(t *testing.T) {
+	resolvedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	resolved := ResolvedInput{FiringSince: resolvedAt.Add(-65 * time.Minute), ResolvedAt: resolvedAt, Value: pointy.Float64(3.5)}
+	assert.Equal(t, "1h5m", resolved.DurationText())
+	assert.Equal(t, "3", resolved.ValueText(&model.Alert{FunctionType: modelInputs.MetricAggregatorCount}))
+	assert.Equal(t, "3.500000", resolved.ValueText(&model.Alert{FunctionType: modelInputs.MetricAggregatorAvg}))
+
+	resolved = ResolvedInput{FiringSince: resolvedAt.Add(-20 * time.Second), ResolvedAt: resolvedAt}
+	assert.Equal(t, "less than a minute", resolved.DurationText())
+	assert.Equal(t, "no data", resolved.ValueText(&model.Alert{FunctionType: modelInputs.MetricAggregatorCount}))
+}
+
+func TestGetSeverity(t *testing.T) {
+	assert.Equal(t, SeverityError, GetSeverity(&model.Alert{ProductType: modelInputs.ProductTypeErrors}))
+	assert.Equal(t, SeverityWarning, GetSeverity(&model.Alert{ProductType: modelInputs.ProductTypeLogs}))
+	assert.Equal(t, SeverityCritical, GetSeverity(&model.Alert{ProductType: modelInputs.ProductTypeLogs, Severity: pointy.String("critical")}))
+	assert.Equal(t, SeverityWarning, GetSeverity(&model.Alert{ProductType: modelInputs.ProductTypeLogs, Severity: pointy.String("sev1")}))
+}
+
+func TestIncidentKey(t *testing.T) {
+	alert := &model.Alert{Model: model.Model{ID: 5}}
+	assert.Equal(t, "highlight-alert-5-checkout", IncidentKey(alert, "checkout"))
+	assert.Equal(t, IncidentKey(alert, "checkout"), IncidentKey(alert, "checkout"))
+	assert.NotEqual(t, IncidentKey(alert, "checkout"), IncidentKey(&model.Alert{Model: model.Model{ID: 6}}, "checkout"))
+
+	long := IncidentKey(alert, strings.Repeat("a", 300))
+	assert.LessOrEqual(t, len(long), 255)
+	assert.Equal(t, long, IncidentKey(alert, strings.Repeat("a", 300)))
+	assert.NotEqual(t, long, IncidentKey(alert, strings.Repeat("b", 300)))
+}
+
+func TestTruncateIncidentText(t *testing.T) {
+	assert.Equal(t, "checkout", TruncateIncidentText("checkout", 10))
+	assert.Equal(t, "check", TruncateIncidentText("checkout", 5))
+	// multi-byte characters are kept whole
+	assert.Equal(t, "日本", TruncateIncidentText("日本語のエラー", 2))
+	assert.True(t, utf8.ValidString(TruncateIncidentText(strings.Repeat("é", 2000), 1024)))
+}
+
+func TestCompositeConditionInput(t *testing.T) {
+	condition := CompositeConditionInput{
+		Alert:      &model.Alert{Name: "Checkout errors", FunctionType: modelInputs.MetricAggregatorCount},
+		Alerting:   true,
+		GroupValue: "checkout",
+		Value:      pointy.Float64(12),
+	}
+	assert.Equal(t, "Checkout errors (checkout): 12 - alerting", condition.Text())
+
+	condition = CompositeConditionInput{Alert: &model.Alert{Name: "Deploys"}}
+	assert.Equal(t, "Deploys: no data - normal", condition.Text())
+}
+
+func TestChangeInput(t *testing.T) {
+	alert := &model.Alert{
+		FunctionType:          modelInputs.MetricAggregatorCount,
+		ThresholdType:         modelInputs.ThresholdTypeChange,
+		ThresholdValue:        pointy.Float64(300),
+		ThresholdChangeWindow: pointy.Int(24 * 60 * 60),
+		ThresholdChangeType:   modelInputs.ChangeTypePercent,
+	}
+	change := ChangeInput{Value: 45, PreviousValue: pointy.Float64(10)}
+	assert.Equal(t, "45 compared with 10 1 day earlier", change.Text(alert))
+	assert.Equal(t, "+350%", FormatChange(alert, 350))
+	assert.Equal(t, "increase of 300%", ChangeThresholdText(alert))
+
+	alert.ThresholdChangeType = modelInputs.ChangeTypeRate
+	alert.ThresholdCondition = modelInputs.ThresholdConditionBelow
+	alert.ThresholdChangeWindow = pointy.Int(2 * 7 * 24 * 60 * 60)
+	assert.Equal(t, "-2.57/min", FormatChange(alert, -2.5714))
+	assert.Equal(t, "decrease of 300/min", ChangeThresholdText(alert))
+	assert.Equal(t, "45 compared with no data 2 weeks earlier", (&ChangeInput{Value: 45}).Text(alert))
+
+	alert.ThresholdChangeWindow = nil
+	assert.Equal(t, time.Hour, GetChangeWindow(alert))
+	assert.Equal(t, "1 hour earlier", ChangeWindowText(alert))
+	alert.ThresholdChangeWindow = pointy.Int(90)
+	assert.Equal(t, "1m30s earlier", ChangeWindowText(alert))
+}
@@ -51,6 +51,22 @@ func SendAlerts(ctx context.Context, discordGuildId *string, alertInput *destina
 		return
 	}
 
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	sendTemplatedAlerts(ctx, *discordGuildId, alertInput, templated)
+	if len(destinations) == 0 {
+		return
+	}
+
+	if alertInput.CompositeInput != nil {
+		sendCompositeAlert(ctx, *discordGuildId, alertInput, destinations)
+		return
+	}
+
+	if alertInput.ChangeInput != nil {
+		sendChangeAlert(ctx, *discordGuildId, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, *discordGuildId, alertInput, destinations)
@@ -479,6 +495,188 @@ func sendEventAlert(ctx context.Context, discordGuildId string, alertInput *dest
 	deliverAlerts(ctx, discordGuildId, &messageSend, destinations)
 }
 
+func sendCompositeAlert(ctx context.Context, discordGuildId string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	embed := newMessageEmbed()
+	embed.Color = RED_ALERT
+
+	// HEADER
+	embed.Title = fmt.Sprintf("%s Alert", alertInput.Alert.Name)
+
+	// BODY
+	fields := []*discordgo.MessageEmbedField{}
+	for _, condition := range alertInput.CompositeInput.Conditions {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   condition.Title(),
+			Value:  condition.ValueText(),
+			Inline: false,
+		})
+	}
+	embed.Fields = fields
+
+	// action buttons
+	actionButtons := discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Emoji:    highlightEmoji,
+				Label:    "View Alert",
+				Style:    discordgo.LinkButton,
+				Disabled: false,
+				URL:      alertInput.AlertLink,
+			},
+		},
+	}
+
+	messageSend := discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{actionButtons},
+	}
+
+	deliverAlerts(ctx, discordGuildId, &messageSend, destinations)
+}
+
+func sendChangeAlert(ctx context.Context, discordGuildId string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	embed := newMessageEmbed()
+	embed.Color = YELLOW_ALERT
+
+	// HEADER
+	embed.Title = fmt.Sprintf("%s Alert", alertInput.Alert.Name)
+	if alertInput.GroupValue != "" {
+		embed.Title = fmt.Sprintf("%s Alert for %s", alertInput.Alert.Name, alertInput.GroupValue)
+	}
+
+	// BODY
+	query := "[empty query]"
+	if alertInput.Alert.Query != nil {
+		query = *alertInput.Alert.Query
+	}
+
+	embed.Description = fmt.Sprintf(
+		"%s for query **%s** was %s.",
+		alertInput.Alert.FunctionType,
+		query,
+		alertInput.ChangeInput.Text(alertInput.Alert),
+	)
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{
+			Name:   "Change",
+			Value:  destinationsV2.FormatChange(alertInput.Alert, alertInput.AlertValue),
+			Inline: true,
+		},
+		{
+			Name:   "Threshold",
+			Value:  destinationsV2.ChangeThresholdText(alertInput.Alert),
+			Inline: true,
+		},
+	}
+
+	// action buttons
+	actionButtons := discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Emoji:    highlightEmoji,
+				Label:    "View Alert",
+				Style:    discordgo.LinkButton,
+				Disabled: false,
+				URL:      alertInput.AlertLink,
+			},
+		},
+	}
+
+	messageSend := discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{actionButtons},
+	}
+
+	deliverAlerts(ctx, discordGuildId, &messageSend, destinations)
+}
+
+func sendTemplatedAlerts(ctx context.Context, discordGuildId string, alertInput *destinationsV2.AlertInput, templated []destinationsV2.TemplatedDestination) {
+	for _, t := range templated {
+		embed := newMessageEmbed()
+		embed.Color = RED_ALERT
+		if alertInput.ResolvedInput != nil {
+			embed.Color = GREEN_ALERT
+		}
+		embed.Title = t.Title
+		embed.Description = t.Body
+
+		// action buttons
+		actionButtons := discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Emoji:    highlightEmoji,
+					Label:    "View Alert",
+					Style:    discordgo.LinkButton,
+					Disabled: false,
+					URL:      alertInput.AlertLink,
+				},
+			},
+		}
+
+		messageSend := discordgo.MessageSend{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{actionButtons},
+		}
+
+		deliverAlerts(ctx, discordGuildId, &messageSend, []model.AlertDestination{t.Destination})
+	}
+}
+
+func SendResolvedAlerts(ctx context.Context, discordGuildId *string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.Discord")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	if discordGuildId == nil {
+		log.WithContext(ctx).Error("discord access token is nil")
+		return
+	}
+
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	sendTemplatedAlerts(ctx, *discordGuildId, alertInput, templated)
+	if len(destinations) == 0 {
+		return
+	}
+
+	resolved := alertInput.ResolvedInput
+	embed := newMessageEmbed()
+	embed.Color = GREEN_ALERT
+
+	// HEADER
+	embed.Title = fmt.Sprintf("✅ %s Alert Resolved", alertInput.Alert.Name)
+	if alertInput.GroupValue != "" {
+		embed.Title = fmt.Sprintf("✅ %s Alert Resolved for %s", alertInput.Alert.Name, alertInput.GroupValue)
+	}
+
+	// BODY
+	embed.Description = fmt.Sprintf(
+		"Returned to normal after alerting for %s.\n_Value_: %s",
+		resolved.DurationText(),
+		resolved.ValueText(alertInput.Alert),
+	)
+
+	// action buttons
+	actionButtons := discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Emoji:    highlightEmoji,
+				Label:    "View Alert",
+				Style:    discordgo.LinkButton,
+				Disabled: false,
+				URL:      alertInput.AlertLink,
+			},
+		},
+	}
+
+	messageSend := discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{actionButtons},
+	}
+
+	deliverAlerts(ctx, *discordGuildId, &messageSend, destinations)
+}
+
 func SendNotifications(ctx context.Context, discordGuildId *string, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	if discordGuildId == nil {
 		log.WithContext(ctx).Error("discord access token is nil")
@@ -30,6 +30,16 @@ func SendAlerts(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *
 	span.SetAttribute("product_type", alertInput.Alert.ProductType)
 	defer span.Finish()
 
+	if alertInput.CompositeInput != nil {
+		sendCompositeAlert(ctx, mailClient, lambdaClient, alertInput, destinations)
+		return
+	}
+
+	if alertInput.ChangeInput != nil {
+		sendChangeAlert(ctx, mailClient, lambdaClient, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, mailClient, lambdaClient, alertInput, destinations)
@@ -249,6 +259,91 @@ func sendEventAlert(ctx context.Context, mailClient *sendgrid.Client, lambdaClie
 	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
 }
 
+func sendCompositeAlert(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	conditions := []map[string]interface{}{}
+	for _, condition := range alertInput.CompositeInput.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"name":     condition.Title(),
+			"value":    destinationsV2.FormatValue(condition.Alert, condition.Value),
+			"alerting": condition.Alerting,
+		})
+	}
+
+	emailData := &EmailData{
+		SubjectLine: fmt.Sprintf("%s Alert", alertInput.Alert.Name),
+		Template:    lambda.ReactEmailTemplateCompositeAlert,
+		TemplateData: map[string]interface{}{
+			"alertLink":   alertInput.AlertLink,
+			"alertName":   alertInput.Alert.Name,
+			"conditions":  conditions,
+			"projectName": alertInput.ProjectName,
+		},
+	}
+
+	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
+}
+
+func sendChangeAlert(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	query := "[empty query]"
+	if alertInput.Alert.Query != nil {
+		query = *alertInput.Alert.Query
+	}
+
+	functionName := alertInput.Alert.FunctionType.String()
+	if destinationsV2.IsCountAggregator(alertInput.Alert.FunctionType) {
+		functionName = "Count"
+	}
+
+	emailData := &EmailData{
+		SubjectLine: fmt.Sprintf("%s Alert", alertInput.Alert.Name),
+		Template:    lambda.ReactEmailTemplateChangeAlert,
+		TemplateData: map[string]interface{}{
+			"alertLink":        alertInput.AlertLink,
+			"alertName":        alertInput.Alert.Name,
+			"changeText":       destinationsV2.FormatChange(alertInput.Alert, alertInput.AlertValue),
+			"changeWindowText": destinationsV2.ChangeWindowText(alertInput.Alert),
+			"functionName":     functionName,
+			"groupValue":       alertInput.GroupValue,
+			"previousValue":    destinationsV2.FormatValue(alertInput.Alert, alertInput.ChangeInput.PreviousValue),
+			"projectName":      alertInput.ProjectName,
+			"query":            query,
+			"thresholdText":    destinationsV2.ChangeThresholdText(alertInput.Alert),
+			"value":            destinationsV2.FormatValue(alertInput.Alert, &alertInput.ChangeInput.Value),
+		},
+	}
+
+	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
+}
+
+func SendResolvedAlerts(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *lambda.Client, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.Email")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	resolved := alertInput.ResolvedInput
+	functionName := alertInput.Alert.FunctionType.String()
+	if destinationsV2.IsCountAggregator(alertInput.Alert.FunctionType) {
+		functionName = "Count"
+	}
+
+	emailData := &EmailData{
+		SubjectLine: fmt.Sprintf("%s Alert Resolved", alertInput.Alert.Name),
+		Template:    lambda.ReactEmailTemplateAlertResolved,
+		TemplateData: map[string]interface{}{
+			"alertLink":     alertInput.AlertLink,
+			"alertName":     alertInput.Alert.Name,
+			"duration":      resolved.DurationText(),
+			"functionName":  functionName,
+			"functionValue": resolved.ValueText(alertInput.Alert),
+			"groupValue":    alertInput.GroupValue,
+			"projectName":   alertInput.ProjectName,
+		},
+	}
+
+	deliverAlerts(ctx, mailClient, lambdaClient, emailData, destinations)
+}
+
 func SendNotifications(ctx context.Context, mailClient *sendgrid.Client, lambdaClient *lambda.Client, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	switch notificationInput.NotificationType {
 	case destinationsV2.NotificationTypeAlertCreated:
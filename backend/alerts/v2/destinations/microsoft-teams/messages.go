@@ -2,6 +2,7 @@ package microsoftteamsV2
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -29,6 +30,22 @@ func SendAlerts(ctx context.Context, microsoftTeamsTenantId *string, alertInput
 		return
 	}
 
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	sendTemplatedAlerts(ctx, *microsoftTeamsTenantId, alertInput, templated)
+	if len(destinations) == 0 {
+		return
+	}
+
+	if alertInput.CompositeInput != nil {
+		sendCompositeAlert(ctx, *microsoftTeamsTenantId, alertInput, destinations)
+		return
+	}
+
+	if alertInput.ChangeInput != nil {
+		sendChangeAlert(ctx, *microsoftTeamsTenantId, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, *microsoftTeamsTenantId, alertInput, destinations)
@@ -324,6 +341,101 @@ func sendEventAlert(ctx context.Context, microsoftTeamsTenantId string, alertInp
 	deliverAlerts(ctx, microsoftTeamsTenantId, microsoftteamsV2_templates.MetricAlertMessageTemplate, messagePayload, destinations)
 }
 
+func sendCompositeAlert(ctx context.Context, microsoftTeamsTenantId string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	conditions := []microsoftteamsV2_templates.CompositeConditionFact{}
+	for _, condition := range alertInput.CompositeInput.Conditions {
+		conditions = append(conditions, microsoftteamsV2_templates.CompositeConditionFact{
+			Title: condition.Title(),
+			Value: condition.ValueText(),
+		})
+	}
+
+	messagePayload := microsoftteamsV2_templates.CompositeAlertPayload{
+		AlertName:  alertInput.Alert.Name,
+		Conditions: conditions,
+		AlertLink:  alertInput.AlertLink,
+	}
+
+	deliverAlerts(ctx, microsoftTeamsTenantId, microsoftteamsV2_templates.CompositeAlertMessageTemplate, messagePayload, destinations)
+}
+
+func sendChangeAlert(ctx context.Context, microsoftTeamsTenantId string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	alertName := alertInput.Alert.Name
+	if alertInput.GroupValue != "" {
+		alertName = fmt.Sprintf("%s (%s)", alertInput.Alert.Name, alertInput.GroupValue)
+	}
+
+	query := "[empty query]"
+	if alertInput.Alert.Query != nil {
+		query = *alertInput.Alert.Query
+	}
+
+	messagePayload := microsoftteamsV2_templates.ChangeAlertPayload{
+		AlertName: alertName,
+		AlertText: fmt.Sprintf(
+			"%s for query **%s** was %s.",
+			alertInput.Alert.FunctionType,
+			query,
+			alertInput.ChangeInput.Text(alertInput.Alert),
+		),
+		ChangeText: fmt.Sprintf(
+			"*Change*: %s | *Threshold*: %s",
+			destinationsV2.FormatChange(alertInput.Alert, alertInput.AlertValue),
+			destinationsV2.ChangeThresholdText(alertInput.Alert),
+		),
+		AlertLink: alertInput.AlertLink,
+	}
+
+	deliverAlerts(ctx, microsoftTeamsTenantId, microsoftteamsV2_templates.ChangeAlertMessageTemplate, messagePayload, destinations)
+}
+
+func sendTemplatedAlerts(ctx context.Context, microsoftTeamsTenantId string, alertInput *destinationsV2.AlertInput, templated []destinationsV2.TemplatedDestination) {
+	for _, t := range templated {
+		title, _ := json.Marshal(t.Title)
+		body, _ := json.Marshal(t.Body)
+		messagePayload := microsoftteamsV2_templates.TemplatedAlertPayload{
+			Title:     string(title),
+			Body:      string(body),
+			AlertLink: alertInput.AlertLink,
+		}
+
+		deliverAlerts(ctx, microsoftTeamsTenantId, microsoftteamsV2_templates.TemplatedAlertMessageTemplate, messagePayload, []model.AlertDestination{t.Destination})
+	}
+}
+
+func SendResolvedAlerts(ctx context.Context, microsoftTeamsTenantId *string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.MicrosoftTeams")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	if microsoftTeamsTenantId == nil {
+		log.WithContext(ctx).Error("microsoft teams access token is nil")
+		return
+	}
+
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	sendTemplatedAlerts(ctx, *microsoftTeamsTenantId, alertInput, templated)
+	if len(destinations) == 0 {
+		return
+	}
+
+	resolved := alertInput.ResolvedInput
+	alertName := alertInput.Alert.Name
+	if alertInput.GroupValue != "" {
+		alertName = fmt.Sprintf("%s (%s)", alertInput.Alert.Name, alertInput.GroupValue)
+	}
+
+	messagePayload := microsoftteamsV2_templates.AlertResolvedPayload{
+		AlertName:    alertName,
+		ResolvedText: fmt.Sprintf("Returned to normal after alerting for %s.", resolved.DurationText()),
+		ValueText:    fmt.Sprintf("*Value*: %s", resolved.ValueText(alertInput.Alert)),
+		AlertLink:    alertInput.AlertLink,
+	}
+
+	deliverAlerts(ctx, *microsoftTeamsTenantId, microsoftteamsV2_templates.AlertResolvedMessageTemplate, messagePayload, destinations)
+}
+
 func SendNotifications(ctx context.Context, microsoftTeamsTenantId *string, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	if microsoftTeamsTenantId == nil {
 		log.WithContext(ctx).Error("microsoft teams access token is nil")
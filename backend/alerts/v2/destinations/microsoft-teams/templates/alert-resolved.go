@@ -0,0 +1,38 @@
+package microsoftteamsV2_templates
+
+type AlertResolvedPayload struct {
+	AlertName    string
+	ResolvedText string
+	ValueText    string
+	AlertLink    string
+}
+
+var AlertResolvedMessageTemplate = []byte(`{
+	"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+	"type": "AdaptiveCard",
+	"version": "1.6",
+	"body": [
+		{
+			"type":   "TextBlock",
+			"size":   "Large",
+			"weight": "Bolder",
+			"color":  "Good",
+			"text":   "{{.AlertName}} Alert Resolved"
+		},
+		{
+			"type":   "TextBlock",
+			"text":   "{{.ResolvedText}}"
+		},
+		{
+			"type":   "TextBlock",
+			"text":   "{{.ValueText}}"
+		}
+	],
+	"actions": [
+		{
+			"type":  "Action.OpenUrl",
+			"title": "View Alert",
+			"url":   "{{.AlertLink}}"
+		}
+	]
+  }`)
@@ -0,0 +1,37 @@
+package microsoftteamsV2_templates
+
+type ChangeAlertPayload struct {
+	AlertName  string
+	AlertText  string
+	ChangeText string
+	AlertLink  string
+}
+
+var ChangeAlertMessageTemplate = []byte(`{
+	"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+	"type": "AdaptiveCard",
+	"version": "1.6",
+	"body": [
+		{
+			"type":   "TextBlock",
+			"size":   "Large",
+			"weight": "Bolder",
+			"text":   "{{.AlertName}} Alert"
+		},
+		{
+			"type":   "TextBlock",
+			"text":   "{{.AlertText}}"
+		},
+		{
+			"type":   "TextBlock",
+			"text":   "{{.ChangeText}}"
+		}
+	],
+	"actions": [
+		{
+			"type":  "Action.OpenUrl",
+			"title": "View Alert",
+			"url":   "{{.AlertLink}}"
+		}
+	]
+  }`)
@@ -0,0 +1,43 @@
+package microsoftteamsV2_templates
+
+type CompositeAlertPayload struct {
+	AlertName  string
+	Conditions []CompositeConditionFact
+	AlertLink  string
+}
+
+type CompositeConditionFact struct {
+	Title string
+	Value string
+}
+
+var CompositeAlertMessageTemplate = []byte(`{
+	"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+	"type": "AdaptiveCard",
+	"version": "1.6",
+	"body": [
+		{
+			"type":   "TextBlock",
+			"size":   "Large",
+			"weight": "Bolder",
+			"text":   "{{.AlertName}} Alert"
+		},
+		{
+			"type":  "FactSet",
+			"facts": [
+				{{range $i, $condition := .Conditions}}{{if $i}},{{end}}
+				{
+					"title": "{{$condition.Title}}",
+					"value": "{{$condition.Value}}"
+				}{{end}}
+			]
+		}
+	],
+	"actions": [
+		{
+			"type":  "Action.OpenUrl",
+			"title": "View Alert",
+			"url":   "{{.AlertLink}}"
+		}
+	]
+  }`)
@@ -0,0 +1,36 @@
+package microsoftteamsV2_templates
+
+// TemplatedAlertPayload holds the rendered destination templates. Title and Body are JSON encoded strings,
+// since the rendered templates are user content that may contain quotes.
+type TemplatedAlertPayload struct {
+	Title     string
+	Body      string
+	AlertLink string
+}
+
+var TemplatedAlertMessageTemplate = []byte(`{
+	"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+	"type": "AdaptiveCard",
+	"version": "1.6",
+	"body": [
+		{
+			"type":   "TextBlock",
+			"size":   "Large",
+			"weight": "Bolder",
+			"wrap":   true,
+			"text":   {{.Title}}
+		},
+		{
+			"type":   "TextBlock",
+			"wrap":   true,
+			"text":   {{.Body}}
+		}
+	],
+	"actions": [
+		{
+			"type":  "Action.OpenUrl",
+			"title": "View Alert",
+			"url":   "{{.AlertLink}}"
+		}
+	]
+  }`)
@@ -0,0 +1,184 @@
+package opsgenieV2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// APIURL is the Opsgenie API of US accounts. Destinations of EU accounts set their
+// TypeID to the EU API, https://api.eu.opsgenie.com.
+var APIURL = "https://api.opsgenie.com"
+
+// apiHosts are the Opsgenie APIs a destination may select, so that its api key is only sent to Opsgenie.
+var apiHosts = []string{"api.opsgenie.com", "api.eu.opsgenie.com"}
+
+// maxMessageLength is the max length of an Opsgenie alert message.
+const maxMessageLength = 130
+
+type Priority string
+
+const (
+	PriorityP1 Priority = "P1"
+	PriorityP2 Priority = "P2"
+	PriorityP3 Priority = "P3"
+	PriorityP4 Priority = "P4"
+	PriorityP5 Priority = "P5"
+)
+
+type CreateAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    Priority          `json:"priority"`
+	Tags        []string          `json:"tags,omitempty"`
+}
+
+type CloseAlertRequest struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+func GetPriority(severity destinationsV2.Severity) Priority {
+	switch severity {
+	case destinationsV2.SeverityCritical:
+		return PriorityP1
+	case destinationsV2.SeverityError:
+		return PriorityP2
+	case destinationsV2.SeverityWarning:
+		return PriorityP3
+	case destinationsV2.SeverityInfo:
+		return PriorityP5
+	default:
+		return PriorityP3
+	}
+}
+
+// SendAlerts creates an alert per destination. The alias deduplicates the alerts of
+// an alert group, so repeated alerts increase the count of the open alert.
+func SendAlerts(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendAlerts.Opsgenie")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	span.SetAttribute("product_type", alertInput.Alert.ProductType)
+	defer span.Finish()
+
+	request := buildCreateAlertRequest(alertInput)
+	sendRequests(ctx, destinations, func(apiURL string) string {
+		return fmt.Sprintf("%s/v2/alerts", apiURL)
+	}, request)
+}
+
+// SendResolvedAlerts closes the alert of an alert group that returned to normal.
+func SendResolvedAlerts(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.Opsgenie")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	alias := destinationsV2.IncidentKey(alertInput.Alert, alertInput.GroupValue)
+	request := CloseAlertRequest{
+		Source: "Highlight",
+		Note:   "The alert returned to normal.",
+	}
+	if alertInput.ResolvedInput != nil {
+		request.Note = fmt.Sprintf("The alert returned to normal after %s.", alertInput.ResolvedInput.DurationText())
+	}
+	sendRequests(ctx, destinations, func(apiURL string) string {
+		return fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", apiURL, url.PathEscape(alias))
+	}, request)
+}
+
+func buildCreateAlertRequest(alertInput *destinationsV2.AlertInput) CreateAlertRequest {
+	message := destinationsV2.TruncateIncidentText(destinationsV2.IncidentSummary(alertInput), maxMessageLength)
+
+	details := map[string]string{}
+	for key, value := range destinationsV2.IncidentDetails(alertInput) {
+		details[key] = fmt.Sprint(value)
+	}
+
+	return CreateAlertRequest{
+		Message:     message,
+		Alias:       destinationsV2.IncidentKey(alertInput.Alert, alertInput.GroupValue),
+		Description: fmt.Sprintf("View the alert in Highlight: %s", alertInput.AlertLink),
+		Details:     details,
+		Entity:      alertInput.GroupValue,
+		Source:      "Highlight",
+		Priority:    GetPriority(destinationsV2.GetSeverity(alertInput.Alert)),
+		Tags:        []string{"highlight", strings.ToLower(string(alertInput.Alert.ProductType))},
+	}
+}
+
+func getAPIURL(destination model.AlertDestination) string {
+	if u, err := url.Parse(destination.TypeID); err == nil && u.Scheme == "https" && slices.Contains(apiHosts, u.Host) {
+		return "https://" + u.Host
+	}
+	return APIURL
+}
+
+func sendRequests(ctx context.Context, destinations []model.AlertDestination, getURL func(apiURL string) string, request interface{}) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		log.WithContext(ctx).Error(errors.Wrap(err, "couldn't marshal opsgenie request"))
+		return
+	}
+
+	for _, destination := range destinations {
+		if destination.Authorization == nil || *destination.Authorization == "" {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID":       destination.AlertID,
+					"destinationID": destination.ID,
+				}).Error("opsgenie destination is missing an api key")
+			continue
+		}
+
+		requestURL := getURL(getAPIURL(destination))
+		apiKey := *destination.Authorization
+		go func() {
+			if err := sendRequest(ctx, requestURL, apiKey, body); err != nil {
+				log.WithContext(ctx).Error(err)
+			}
+		}()
+	}
+}
+
+func sendRequest(ctx context.Context, requestURL string, apiKey string, body []byte) error {
+	// the request outlives the alert evaluation that sent it
+	req, err := retryablehttp.NewRequestWithContext(context.WithoutCancel(ctx), http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "couldn't build opsgenie request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", apiKey))
+
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "couldn't send opsgenie request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("opsgenie request %s received unexpected response code %d", requestURL, resp.StatusCode)
+	}
+
+	log.WithContext(ctx).WithField("url", requestURL).Info("opsgenie request sent successfully")
+	return nil
+}
@@ -0,0 +1,76 @@
+package opsgenieV2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCreateAlertRequest(t *testing.T) {
+	request := buildCreateAlertRequest(&destinationsV2.AlertInput{
+		Alert: &model.Alert{
+			Model:       model.Model{ID: 3},
+			Name:        "Payment failures",
+			ProductType: modelInputs.ProductTypeErrors,
+		},
+		AlertLink:  "https://app.highlight.io/1/alerts/3",
+		AlertValue: 4,
+		Group:      "error group",
+		GroupValue: "abc123",
+	})
+	assert.Equal(t, "highlight-alert-3-abc123", request.Alias)
+	assert.Equal(t, PriorityP2, request.Priority)
+	assert.Equal(t, "abc123", request.Entity)
+	assert.Equal(t, "4", request.Details["value"])
+	assert.Contains(t, request.Tags, "errors")
+}
+
+func TestGetPriority(t *testing.T) {
+	assert.Equal(t, PriorityP1, GetPriority(destinationsV2.SeverityCritical))
+	assert.Equal(t, PriorityP2, GetPriority(destinationsV2.SeverityError))
+	assert.Equal(t, PriorityP3, GetPriority(destinationsV2.SeverityWarning))
+	assert.Equal(t, PriorityP5, GetPriority(destinationsV2.SeverityInfo))
+}
+
+// setAPIURL points the default Opsgenie API at a test server until the test completes.
+func setAPIURL(t *testing.T, url string) {
+	apiURL := APIURL
+	APIURL = url
+	t.Cleanup(func() {
+		APIURL = apiURL
+	})
+}
+
+func TestSendRequest(t *testing.T) {
+	var authorization, path, identifierType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		path = r.URL.Path
+		identifierType = r.URL.Query().Get("identifierType")
+		var request CloseAlertRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	setAPIURL(t, server.URL)
+	destination := model.AlertDestination{TypeID: "on-call"}
+	body, _ := json.Marshal(CloseAlertRequest{Source: "Highlight"})
+	assert.NoError(t, sendRequest(context.Background(), getAPIURL(destination)+"/v2/alerts/highlight-alert-3-abc123/close?identifierType=alias", "api-key", body))
+	assert.Equal(t, "GenieKey api-key", authorization)
+	assert.Equal(t, "/v2/alerts/highlight-alert-3-abc123/close", path)
+	assert.Equal(t, "alias", identifierType)
+
+	assert.Equal(t, "https://api.eu.opsgenie.com", getAPIURL(model.AlertDestination{TypeID: "https://api.eu.opsgenie.com/"}))
+	// the api key is never sent to other hosts
+	for _, typeID := range []string{"https://example.com", "https://api.opsgenie.com.example.com", "http://api.eu.opsgenie.com", "https://user@example.com/api.opsgenie.com"} {
+		assert.Equal(t, APIURL, getAPIURL(model.AlertDestination{TypeID: typeID}), typeID)
+	}
+}
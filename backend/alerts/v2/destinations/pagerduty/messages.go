@@ -0,0 +1,167 @@
+package pagerdutyV2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// EventsURL is the PagerDuty Events API v2 endpoint.
+var EventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// maxSummaryLength is the max length of a PagerDuty event summary.
+const maxSummaryLength = 1024
+
+type EventAction string
+
+const (
+	EventActionTrigger EventAction = "trigger"
+	EventActionResolve EventAction = "resolve"
+)
+
+type Event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction EventAction   `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Client      string        `json:"client,omitempty"`
+	ClientURL   string        `json:"client_url,omitempty"`
+	Payload     *EventPayload `json:"payload,omitempty"`
+	Links       []EventLink   `json:"links,omitempty"`
+}
+
+type EventPayload struct {
+	Summary       string                  `json:"summary"`
+	Source        string                  `json:"source"`
+	Severity      destinationsV2.Severity `json:"severity"`
+	Component     string                  `json:"component,omitempty"`
+	Group         string                  `json:"group,omitempty"`
+	Class         string                  `json:"class,omitempty"`
+	CustomDetails map[string]interface{}  `json:"custom_details,omitempty"`
+}
+
+type EventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// SendAlerts triggers an incident per destination, deduplicated by the alert group
+// so that repeated alerts update the open incident.
+func SendAlerts(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendAlerts.PagerDuty")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	span.SetAttribute("product_type", alertInput.Alert.ProductType)
+	defer span.Finish()
+
+	sendEvents(ctx, destinations, func(routingKey string) Event {
+		return buildTriggerEvent(alertInput, routingKey)
+	})
+}
+
+// SendResolvedAlerts resolves the incident of an alert group that returned to normal.
+func SendResolvedAlerts(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.PagerDuty")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	sendEvents(ctx, destinations, func(routingKey string) Event {
+		return buildResolveEvent(alertInput, routingKey)
+	})
+}
+
+func buildTriggerEvent(alertInput *destinationsV2.AlertInput, routingKey string) Event {
+	summary := destinationsV2.TruncateIncidentText(destinationsV2.IncidentSummary(alertInput), maxSummaryLength)
+	source := alertInput.ProjectName
+	if source == "" {
+		source = "highlight"
+	}
+
+	return Event{
+		RoutingKey:  routingKey,
+		EventAction: EventActionTrigger,
+		DedupKey:    destinationsV2.IncidentKey(alertInput.Alert, alertInput.GroupValue),
+		Client:      "Highlight",
+		ClientURL:   alertInput.AlertLink,
+		Payload: &EventPayload{
+			Summary:       summary,
+			Source:        source,
+			Severity:      destinationsV2.GetSeverity(alertInput.Alert),
+			Component:     alertInput.GroupValue,
+			Group:         alertInput.Group,
+			Class:         string(alertInput.Alert.ProductType),
+			CustomDetails: destinationsV2.IncidentDetails(alertInput),
+		},
+		Links: []EventLink{{Href: alertInput.AlertLink, Text: "View alert in Highlight"}},
+	}
+}
+
+func buildResolveEvent(alertInput *destinationsV2.AlertInput, routingKey string) Event {
+	return Event{
+		RoutingKey:  routingKey,
+		EventAction: EventActionResolve,
+		DedupKey:    destinationsV2.IncidentKey(alertInput.Alert, alertInput.GroupValue),
+	}
+}
+
+func sendEvents(ctx context.Context, destinations []model.AlertDestination, buildEvent func(routingKey string) Event) {
+	for _, destination := range destinations {
+		if destination.Authorization == nil || *destination.Authorization == "" {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID":       destination.AlertID,
+					"destinationID": destination.ID,
+				}).Error("pagerduty destination is missing a routing key")
+			continue
+		}
+
+		event := buildEvent(*destination.Authorization)
+		go func() {
+			if err := sendEvent(ctx, event); err != nil {
+				log.WithContext(ctx).WithField("dedupKey", event.DedupKey).Error(err)
+			}
+		}()
+	}
+}
+
+func sendEvent(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "couldn't marshal pagerduty event")
+	}
+
+	// the request outlives the alert evaluation that sent it
+	req, err := retryablehttp.NewRequestWithContext(context.WithoutCancel(ctx), http.MethodPost, EventsURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "couldn't build pagerduty event request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "couldn't send pagerduty event")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("pagerduty event received unexpected response code %d", resp.StatusCode)
+	}
+
+	log.WithContext(ctx).WithFields(
+		log.Fields{
+			"dedupKey":    event.DedupKey,
+			"eventAction": event.EventAction,
+		}).Info("pagerduty event sent successfully")
+	return nil
+}
@@ -0,0 +1,78 @@
+package pagerdutyV2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+// setEventsURL points the events API at a test server until the test completes.
+func setEventsURL(t *testing.T, url string) {
+	eventsURL := EventsURL
+	EventsURL = url
+	t.Cleanup(func() {
+		EventsURL = eventsURL
+	})
+}
+
+func TestSendEvent(t *testing.T) {
+	var events []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	setEventsURL(t, server.URL)
+
+	alertInput := &destinationsV2.AlertInput{
+		Alert: &model.Alert{
+			Model:       model.Model{ID: 7},
+			Name:        "Checkout errors",
+			ProductType: modelInputs.ProductTypeLogs,
+			Severity:    pointy.String("critical"),
+		},
+		AlertLink:   "https://app.highlight.io/1/alerts/7",
+		AlertValue:  12,
+		Group:       "service_name",
+		GroupValue:  "checkout",
+		ProjectName: "shop",
+	}
+
+	ctx := context.Background()
+	assert.NoError(t, sendEvent(ctx, buildTriggerEvent(alertInput, "routing-key")))
+	assert.NoError(t, sendEvent(ctx, buildResolveEvent(alertInput, "routing-key")))
+
+	if assert.Len(t, events, 2) {
+		trigger, resolve := events[0], events[1]
+		assert.Equal(t, EventActionTrigger, trigger.EventAction)
+		assert.Equal(t, "routing-key", trigger.RoutingKey)
+		assert.Equal(t, "highlight-alert-7-checkout", trigger.DedupKey)
+		assert.Equal(t, "Checkout errors is alerting for service_name checkout", trigger.Payload.Summary)
+		assert.Equal(t, destinationsV2.SeverityCritical, trigger.Payload.Severity)
+		assert.Equal(t, "shop", trigger.Payload.Source)
+
+		assert.Equal(t, EventActionResolve, resolve.EventAction)
+		assert.Equal(t, trigger.DedupKey, resolve.DedupKey)
+		assert.Nil(t, resolve.Payload)
+	}
+}
+
+func TestSendEventError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	setEventsURL(t, server.URL)
+
+	assert.Error(t, sendEvent(context.Background(), Event{EventAction: EventActionTrigger}))
+}
@@ -41,6 +41,22 @@ func SendAlerts(ctx context.Context, slackAccessToken *string, alertInput *desti
 		return
 	}
 
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	sendTemplatedAlerts(ctx, *slackAccessToken, alertInput, templated)
+	if len(destinations) == 0 {
+		return
+	}
+
+	if alertInput.CompositeInput != nil {
+		sendCompositeAlert(ctx, *slackAccessToken, alertInput, destinations)
+		return
+	}
+
+	if alertInput.ChangeInput != nil {
+		sendChangeAlert(ctx, *slackAccessToken, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
 		sendSessionAlert(ctx, *slackAccessToken, alertInput, destinations)
@@ -578,6 +594,215 @@ func sendEventAlert(ctx context.Context, slackAccessToken string, alertInput *de
 	deliverAlerts(ctx, slackAccessToken, destinations, previewText, headerBlockSet, attachment)
 }
 
+func sendCompositeAlert(ctx context.Context, slackAccessToken string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	previewText := fmt.Sprintf("%s Alert", alertInput.Alert.Name)
+
+	// HEADER
+	var headerBlockSet []slack.Block
+	headerText := fmt.Sprintf("*%s* Alert", alertInput.Alert.Name)
+	headerBlock := slack.NewTextBlockObject(slack.MarkdownType, headerText, false, false)
+	headerBlockSet = append(headerBlockSet, slack.NewSectionBlock(headerBlock, nil, nil))
+
+	// BODY
+	var bodyBlockSet []slack.Block
+
+	// conditions
+	var conditionLines []string
+	for _, condition := range alertInput.CompositeInput.Conditions {
+		conditionLines = append(conditionLines, fmt.Sprintf("• %s", condition.Text()))
+	}
+	conditionsText := fmt.Sprintf("*Conditions*\n%s", strings.Join(conditionLines, "\n"))
+	conditionsBlock := slack.NewTextBlockObject(slack.MarkdownType, conditionsText, false, false)
+	bodyBlockSet = append(bodyBlockSet, slack.NewSectionBlock(conditionsBlock, nil, nil))
+
+	// actions
+	var actionBlocks []slack.BlockElement
+	button := slack.NewButtonBlockElement(
+		"",
+		"click",
+		slack.NewTextBlockObject(
+			slack.PlainTextType,
+			"View Alert",
+			false,
+			false,
+		),
+	)
+	button.URL = alertInput.AlertLink
+	actionBlocks = append(actionBlocks, button)
+
+	bodyBlockSet = append(bodyBlockSet, slack.NewActionBlock("", actionBlocks...))
+
+	attachment := &slack.Attachment{
+		Color:  RED_ALERT,
+		Blocks: slack.Blocks{BlockSet: bodyBlockSet},
+	}
+
+	deliverAlerts(ctx, slackAccessToken, destinations, previewText, headerBlockSet, attachment)
+}
+
+func sendChangeAlert(ctx context.Context, slackAccessToken string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	previewText := fmt.Sprintf("%s Alert", alertInput.Alert.Name)
+
+	// HEADER
+	var headerBlockSet []slack.Block
+	headerText := fmt.Sprintf("*%s* Alert", alertInput.Alert.Name)
+	if alertInput.GroupValue != "" {
+		headerText = fmt.Sprintf("*%s* Alert for *%s*", alertInput.Alert.Name, alertInput.GroupValue)
+	}
+	headerBlock := slack.NewTextBlockObject(slack.MarkdownType, headerText, false, false)
+	headerBlockSet = append(headerBlockSet, slack.NewSectionBlock(headerBlock, nil, nil))
+
+	// BODY
+	var bodyBlockSet []slack.Block
+
+	query := "[empty query]"
+	if alertInput.Alert.Query != nil {
+		query = *alertInput.Alert.Query
+	}
+
+	changeText := fmt.Sprintf(
+		"%s for query *%s* was %s.\n_Change_: %s | _Threshold_: %s",
+		alertInput.Alert.FunctionType,
+		query,
+		alertInput.ChangeInput.Text(alertInput.Alert),
+		destinationsV2.FormatChange(alertInput.Alert, alertInput.AlertValue),
+		destinationsV2.ChangeThresholdText(alertInput.Alert),
+	)
+	changeBlock := slack.NewTextBlockObject(slack.MarkdownType, changeText, false, false)
+	bodyBlockSet = append(bodyBlockSet, slack.NewSectionBlock(changeBlock, nil, nil))
+
+	// actions
+	var actionBlocks []slack.BlockElement
+	button := slack.NewButtonBlockElement(
+		"",
+		"click",
+		slack.NewTextBlockObject(
+			slack.PlainTextType,
+			"View Alert",
+			false,
+			false,
+		),
+	)
+	button.URL = alertInput.AlertLink
+	actionBlocks = append(actionBlocks, button)
+
+	bodyBlockSet = append(bodyBlockSet, slack.NewActionBlock("", actionBlocks...))
+
+	attachment := &slack.Attachment{
+		Color:  YELLOW_ALERT,
+		Blocks: slack.Blocks{BlockSet: bodyBlockSet},
+	}
+
+	deliverAlerts(ctx, slackAccessToken, destinations, previewText, headerBlockSet, attachment)
+}
+
+func sendTemplatedAlerts(ctx context.Context, slackAccessToken string, alertInput *destinationsV2.AlertInput, templated []destinationsV2.TemplatedDestination) {
+	color := RED_ALERT
+	if alertInput.ResolvedInput != nil {
+		color = GREEN_ALERT
+	}
+
+	for _, t := range templated {
+		// HEADER
+		var headerBlockSet []slack.Block
+		headerBlock := slack.NewTextBlockObject(slack.MarkdownType, t.Title, false, false)
+		headerBlockSet = append(headerBlockSet, slack.NewSectionBlock(headerBlock, nil, nil))
+
+		// BODY
+		var bodyBlockSet []slack.Block
+		if t.Body != "" {
+			bodyBlock := slack.NewTextBlockObject(slack.MarkdownType, t.Body, false, false)
+			bodyBlockSet = append(bodyBlockSet, slack.NewSectionBlock(bodyBlock, nil, nil))
+		}
+
+		// actions
+		button := slack.NewButtonBlockElement(
+			"",
+			"click",
+			slack.NewTextBlockObject(
+				slack.PlainTextType,
+				"View Alert",
+				false,
+				false,
+			),
+		)
+		button.URL = alertInput.AlertLink
+		bodyBlockSet = append(bodyBlockSet, slack.NewActionBlock("", button))
+
+		attachment := &slack.Attachment{
+			Color:  color,
+			Blocks: slack.Blocks{BlockSet: bodyBlockSet},
+		}
+
+		deliverAlerts(ctx, slackAccessToken, []model.AlertDestination{t.Destination}, t.Title, headerBlockSet, attachment)
+	}
+}
+
+func SendResolvedAlerts(ctx context.Context, slackAccessToken *string, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.Slack")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	if slackAccessToken == nil {
+		log.WithContext(ctx).Error("slack access token is nil")
+		return
+	}
+
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	sendTemplatedAlerts(ctx, *slackAccessToken, alertInput, templated)
+	if len(destinations) == 0 {
+		return
+	}
+
+	resolved := alertInput.ResolvedInput
+	previewText := fmt.Sprintf("%s Alert Resolved", alertInput.Alert.Name)
+
+	// HEADER
+	var headerBlockSet []slack.Block
+	headerText := fmt.Sprintf("✅ *%s* Alert Resolved", alertInput.Alert.Name)
+	if alertInput.GroupValue != "" {
+		headerText = fmt.Sprintf("✅ *%s* Alert Resolved for *%s*", alertInput.Alert.Name, alertInput.GroupValue)
+	}
+	headerBlock := slack.NewTextBlockObject(slack.MarkdownType, headerText, false, false)
+	headerBlockSet = append(headerBlockSet, slack.NewSectionBlock(headerBlock, nil, nil))
+
+	// BODY
+	var bodyBlockSet []slack.Block
+
+	resolvedText := fmt.Sprintf(
+		"Returned to normal after alerting for %s.\n_Value_: %s",
+		resolved.DurationText(),
+		resolved.ValueText(alertInput.Alert),
+	)
+	resolvedBlock := slack.NewTextBlockObject(slack.MarkdownType, resolvedText, false, false)
+	bodyBlockSet = append(bodyBlockSet, slack.NewSectionBlock(resolvedBlock, nil, nil))
+
+	// actions
+	var actionBlocks []slack.BlockElement
+	button := slack.NewButtonBlockElement(
+		"",
+		"click",
+		slack.NewTextBlockObject(
+			slack.PlainTextType,
+			"View Alert",
+			false,
+			false,
+		),
+	)
+	button.URL = alertInput.AlertLink
+	actionBlocks = append(actionBlocks, button)
+
+	bodyBlockSet = append(bodyBlockSet, slack.NewActionBlock("", actionBlocks...))
+
+	attachment := &slack.Attachment{
+		Color:  GREEN_ALERT,
+		Blocks: slack.Blocks{BlockSet: bodyBlockSet},
+	}
+
+	deliverAlerts(ctx, *slackAccessToken, destinations, previewText, headerBlockSet, attachment)
+}
+
 func SendNotifications(ctx context.Context, slackAccessToken *string, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	if slackAccessToken == nil {
 		log.WithContext(ctx).Error("slack access token is nil")
@@ -0,0 +1,353 @@
+package destinationsV2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+const (
+	maxTemplateLength = 10_000
+	maxRenderedLength = 64 * 1024
+	// integer literals are limited so that templates can't range over large numbers
+	maxTemplateInteger = 1_000_000
+)
+
+// printf verbs with a width or precision of more than 3 digits, which could render huge strings
+var largePrintfVerb = regexp.MustCompile(`%[-+# 0]*(\d{4,}|\d*\.\d{4,})`)
+
+var templateFuncs = template.FuncMap{
+	// json encodes a value, ie. `{"alert": {{json .Alert.Name}}}` in a webhook body
+	"json": func(value interface{}) (string, error) {
+		b, err := json.Marshal(value)
+		return string(b), err
+	},
+	// formatValue formats an alert value like the default messages, ie. `{{formatValue .Alert .AlertValue}}`
+	"formatValue": func(alert *model.Alert, value float64) string {
+		return FormatValue(alert, &value)
+	},
+	"printf": func(format string, args ...interface{}) (string, error) {
+		if largePrintfVerb.MatchString(format) {
+			return "", errors.New("printf width and precision are limited to 3 digits")
+		}
+		return fmt.Sprintf(format, args...), nil
+	},
+}
+
+// SupportsTemplates is whether notifications to the destination type can be customized with templates.
+func SupportsTemplates(destinationType modelInputs.AlertDestinationType) bool {
+	switch destinationType {
+	case modelInputs.AlertDestinationTypeSlack, modelInputs.AlertDestinationTypeDiscord, modelInputs.AlertDestinationTypeMicrosoftTeams, modelInputs.AlertDestinationTypeWebhook:
+		return true
+	}
+	return false
+}
+
+// HasTemplates is whether the destination customizes its notifications with a title or body template.
+func HasTemplates(destination model.AlertDestination) bool {
+	return lo.FromPtr(destination.TitleTemplate) != "" || lo.FromPtr(destination.BodyTemplate) != ""
+}
+
+// RenderTemplates evaluates the title and body templates of the destination against the alert input.
+// The result is empty for a template that is not set. Webhooks only have a body, which must render to JSON.
+func RenderTemplates(destination model.AlertDestination, alertInput *AlertInput) (string, string, error) {
+	if destination.DestinationType == modelInputs.AlertDestinationTypeWebhook && lo.FromPtr(destination.TitleTemplate) != "" {
+		return "", "", errors.New("webhook destinations only support a body template")
+	}
+
+	title, err := renderTemplate("title", lo.FromPtr(destination.TitleTemplate), alertInput)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := renderTemplate("body", lo.FromPtr(destination.BodyTemplate), alertInput)
+	if err != nil {
+		return "", "", err
+	}
+
+	if destination.DestinationType == modelInputs.AlertDestinationTypeWebhook && body != "" && !json.Valid([]byte(body)) {
+		return "", "", errors.New("webhook body template did not render to valid JSON")
+	}
+
+	return title, body, nil
+}
+
+// TemplatedDestination is a destination with its rendered templates.
+type TemplatedDestination struct {
+	Destination model.AlertDestination
+	Title       string
+	Body        string
+}
+
+// RenderDestinationTemplates renders the templates of the destinations with templates. The destinations without
+// templates are returned separately, along with those whose templates failed to render, to be sent the default message.
+func RenderDestinationTemplates(ctx context.Context, alertInput *AlertInput, destinations []model.AlertDestination) ([]TemplatedDestination, []model.AlertDestination) {
+	var templated []TemplatedDestination
+	var remaining []model.AlertDestination
+	for _, destination := range destinations {
+		if !HasTemplates(destination) || !SupportsTemplates(destination.DestinationType) {
+			remaining = append(remaining, destination)
+			continue
+		}
+
+		title, body, err := RenderTemplates(destination, alertInput)
+		if err != nil {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID":       alertInput.Alert.ID,
+					"destinationID": destination.ID,
+				}).WithError(err).Warn("failed to render alert destination templates, sending the default message")
+			remaining = append(remaining, destination)
+			continue
+		}
+
+		if title == "" {
+			title = DefaultTitle(alertInput)
+		}
+		templated = append(templated, TemplatedDestination{Destination: destination, Title: title, Body: body})
+	}
+	return templated, remaining
+}
+
+// DefaultTitle is the title of templated messages without a title template, ie. `Checkout Errors Alert for checkout`.
+func DefaultTitle(alertInput *AlertInput) string {
+	title := fmt.Sprintf("%s Alert", alertInput.Alert.Name)
+	if alertInput.ResolvedInput != nil {
+		title = fmt.Sprintf("%s Alert Resolved", alertInput.Alert.Name)
+	}
+	if alertInput.GroupValue != "" {
+		title = fmt.Sprintf("%s for %s", title, alertInput.GroupValue)
+	}
+	return title
+}
+
+// ValidateTemplates checks that the templates of a destination are supported, parse and render against
+// sample data for the type of the alert.
+func ValidateTemplates(destination model.AlertDestination, productType modelInputs.ProductType, thresholdType modelInputs.ThresholdType) error {
+	if !HasTemplates(destination) {
+		return nil
+	}
+	if !SupportsTemplates(destination.DestinationType) {
+		return errors.Errorf("%s destinations do not support templates", destination.DestinationType)
+	}
+	_, _, err := RenderTemplates(destination, SampleAlertInput(productType, thresholdType, false))
+	return err
+}
+
+func renderTemplate(name string, text string, alertInput *AlertInput) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	output := &limitedBuffer{limit: maxRenderedLength}
+	if err := tmpl.Execute(output, alertInput); err != nil {
+		return "", errors.Wrapf(err, "failed to render %s template", name)
+	}
+	return output.String(), nil
+}
+
+func parseTemplate(name string, text string) (*template.Template, error) {
+	if len(text) > maxTemplateLength {
+		return nil, errors.Errorf("%s template is longer than %d characters", name, maxTemplateLength)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s template", name)
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if err := checkIntegers(t.Tree.Root); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s template", name)
+		}
+	}
+	return tmpl, nil
+}
+
+// checkIntegers rejects integer literals larger than maxTemplateInteger.
+func checkIntegers(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.NumberNode:
+		if n.IsInt && (n.Int64 > maxTemplateInteger || n.Int64 < -maxTemplateInteger) {
+			return errors.Errorf("integers are limited to %d", maxTemplateInteger)
+		}
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkIntegers(child); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkIntegers(n.Pipe)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			return checkIntegers(n.Pipe)
+		}
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := checkIntegers(cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := checkIntegers(arg); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return checkIntegers(n.Node)
+	case *parse.IfNode:
+		return checkBranchIntegers(&n.BranchNode)
+	case *parse.RangeNode:
+		return checkBranchIntegers(&n.BranchNode)
+	case *parse.WithNode:
+		return checkBranchIntegers(&n.BranchNode)
+	}
+	return nil
+}
+
+func checkBranchIntegers(n *parse.BranchNode) error {
+	for _, node := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+		if err := checkIntegers(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitedBuffer fails writes past its limit, which stops the execution of the template.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errors.Errorf("rendered template is longer than %d bytes", b.limit)
+	}
+	return b.Buffer.Write(p)
+}
+
+// SampleAlertInput is an alert input with sample data for the type of alert, used to validate and preview templates.
+// Composite alerts have no product type. The sample of a resolved notification has no product specific input,
+// like resolved notifications.
+func SampleAlertInput(productType modelInputs.ProductType, thresholdType modelInputs.ThresholdType, resolved bool) *AlertInput {
+	now := time.Now().Truncate(time.Minute)
+	if thresholdType == "" {
+		thresholdType = modelInputs.ThresholdTypeConstant
+	}
+	alert := &model.Alert{
+		Model:              model.Model{ID: 1},
+		ProjectID:          1,
+		Name:               "Sample Alert",
+		ProductType:        productType,
+		FunctionType:       modelInputs.MetricAggregatorCount,
+		Query:              lo.ToPtr("service_name=checkout"),
+		GroupByKey:         lo.ToPtr("service_name"),
+		ThresholdValue:     lo.ToPtr(10.),
+		ThresholdWindow:    lo.ToPtr(60 * 60),
+		ThresholdType:      thresholdType,
+		ThresholdCondition: modelInputs.ThresholdConditionAbove,
+	}
+
+	alertInput := &AlertInput{
+		Alert:       alert,
+		AlertLink:   "https://app.highlight.io/1/alerts/1",
+		AlertValue:  24,
+		Group:       "service_name",
+		GroupValue:  "checkout",
+		ProjectName: "Sample Project",
+		WorkspaceID: 1,
+	}
+
+	if resolved {
+		alertInput.ResolvedInput = &ResolvedInput{
+			FiringSince: now.Add(-30 * time.Minute),
+			ResolvedAt:  now,
+			Value:       lo.ToPtr(3.),
+		}
+		return alertInput
+	}
+
+	if thresholdType == modelInputs.ThresholdTypeChange {
+		alert.ThresholdChangeWindow = lo.ToPtr(24 * 60 * 60)
+		alert.ThresholdChangeType = modelInputs.ChangeTypePercent
+		alertInput.AlertValue = 50
+		alertInput.ChangeInput = &ChangeInput{Value: 24, PreviousValue: lo.ToPtr(16.)}
+	}
+
+	switch productType {
+	case "":
+		alertInput.Group = ""
+		alertInput.GroupValue = ""
+		alertInput.CompositeInput = &CompositeInput{
+			Conditions: []*CompositeConditionInput{
+				{Alert: &model.Alert{Model: model.Model{ID: 2}, Name: "Checkout Errors"}, Alerting: true, Value: lo.ToPtr(24.)},
+				{Alert: &model.Alert{Model: model.Model{ID: 3}, Name: "Checkout Latency"}, Alerting: false, GroupValue: "checkout", Value: lo.ToPtr(120.)},
+			},
+		}
+	case modelInputs.ProductTypeSessions:
+		alertInput.SessionInput = &SessionInput{
+			SecureID:         "sample",
+			Identifier:       "user@example.com",
+			SessionLink:      "https://app.highlight.io/1/sessions/sample",
+			MoreSessionsLink: "https://app.highlight.io/1/sessions",
+		}
+	case modelInputs.ProductTypeErrors:
+		alertInput.ErrorInput = &ErrorInput{
+			Event:             "TypeError: Cannot read properties of undefined",
+			State:             modelInputs.ErrorStateOpen,
+			Stacktrace:        "at checkout (checkout.js:10:5)",
+			ErrorLink:         "https://app.highlight.io/1/errors/sample",
+			ProjectName:       alertInput.ProjectName,
+			ServiceName:       "checkout",
+			SessionSecureID:   "sample",
+			SessionIdentifier: "user@example.com",
+			SessionLink:       "https://app.highlight.io/1/sessions/sample",
+		}
+	case modelInputs.ProductTypeLogs:
+		alertInput.LogInput = &LogInput{
+			LogsLink:  "https://app.highlight.io/1/logs",
+			StartDate: now.Add(-time.Hour),
+			EndDate:   now,
+		}
+	case modelInputs.ProductTypeTraces:
+		alertInput.TraceInput = &TraceInput{
+			TracesLink: "https://app.highlight.io/1/traces",
+			StartDate:  now.Add(-time.Hour),
+			EndDate:    now,
+		}
+	case modelInputs.ProductTypeMetrics:
+		alertInput.MetricInput = &MetricInput{
+			DashboardLink: "https://app.highlight.io/1/metrics",
+		}
+	}
+	return alertInput
+}
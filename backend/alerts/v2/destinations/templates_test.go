@@ -0,0 +1,93 @@
+package destinationsV2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplates(t *testing.T) {
+	destination := model.AlertDestination{
+		DestinationType: modelInputs.AlertDestinationTypeSlack,
+		TitleTemplate:   pointy.String("{{.Alert.Name}} fired for {{.GroupValue}}"),
+		BodyTemplate:    pointy.String("{{formatValue .Alert .AlertValue}} errors{{with .ErrorInput}}: {{.Event}}{{end}}"),
+	}
+	title, body, err := RenderTemplates(destination, SampleAlertInput(modelInputs.ProductTypeErrors, modelInputs.ThresholdTypeConstant, false))
+	assert.NoError(t, err)
+	assert.Equal(t, "Sample Alert fired for checkout", title)
+	assert.Equal(t, "24 errors: TypeError: Cannot read properties of undefined", body)
+
+	title, body, err = RenderTemplates(destination, SampleAlertInput(modelInputs.ProductTypeErrors, modelInputs.ThresholdTypeConstant, true))
+	assert.NoError(t, err)
+	assert.Equal(t, "Sample Alert fired for checkout", title)
+	assert.Equal(t, "24 errors", body)
+
+	webhook := model.AlertDestination{
+		DestinationType: modelInputs.AlertDestinationTypeWebhook,
+		BodyTemplate:    pointy.String(`{"alert": {{json .Alert.Name}}, "value": {{.AlertValue}}}`),
+	}
+	_, body, err = RenderTemplates(webhook, SampleAlertInput(modelInputs.ProductTypeLogs, modelInputs.ThresholdTypeConstant, false))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"alert": "Sample Alert", "value": 24}`, body)
+
+	webhook.BodyTemplate = pointy.String(`{"alert": {{.Alert.Name}}}`)
+	_, _, err = RenderTemplates(webhook, SampleAlertInput(modelInputs.ProductTypeLogs, modelInputs.ThresholdTypeConstant, false))
+	assert.ErrorContains(t, err, "valid JSON")
+}
+
+func TestValidateTemplates(t *testing.T) {
+	valid := model.AlertDestination{
+		DestinationType: modelInputs.AlertDestinationTypeDiscord,
+		BodyTemplate:    pointy.String("{{.LogInput.LogsLink}}"),
+	}
+	assert.NoError(t, ValidateTemplates(valid, modelInputs.ProductTypeLogs, modelInputs.ThresholdTypeConstant))
+	// missing product inputs fail for other product types
+	assert.Error(t, ValidateTemplates(valid, modelInputs.ProductTypeTraces, modelInputs.ThresholdTypeConstant))
+	change := model.AlertDestination{
+		DestinationType: modelInputs.AlertDestinationTypeSlack,
+		BodyTemplate:    pointy.String("{{.ChangeInput.Text .Alert}}"),
+	}
+	assert.NoError(t, ValidateTemplates(change, modelInputs.ProductTypeMetrics, modelInputs.ThresholdTypeChange))
+	assert.Error(t, ValidateTemplates(change, modelInputs.ProductTypeMetrics, modelInputs.ThresholdTypeConstant))
+	composite := model.AlertDestination{
+		DestinationType: modelInputs.AlertDestinationTypeSlack,
+		BodyTemplate:    pointy.String("{{range .CompositeInput.Conditions}}{{.Title}} {{end}}"),
+	}
+	assert.NoError(t, ValidateTemplates(composite, "", modelInputs.ThresholdTypeConstant))
+	assert.NoError(t, ValidateTemplates(model.AlertDestination{DestinationType: modelInputs.AlertDestinationTypeEmail}, modelInputs.ProductTypeLogs, modelInputs.ThresholdTypeConstant))
+
+	for name, destination := range map[string]model.AlertDestination{
+		"unsupported type": {DestinationType: modelInputs.AlertDestinationTypeEmail, BodyTemplate: pointy.String("hi")},
+		"webhook title":    {DestinationType: modelInputs.AlertDestinationTypeWebhook, TitleTemplate: pointy.String("hi")},
+		"syntax":           {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String("{{.Alert.Name")},
+		"missing field":    {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String("{{.Alert.Missing}}")},
+		"too long":         {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String(strings.Repeat("a", maxTemplateLength+1))},
+		"large range":      {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String("{{range $i := 100000000}}a{{end}}")},
+		"large variable":   {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String("{{$n := 100000000}}{{range $n}}a{{end}}")},
+		"large printf":     {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String(`{{printf "%100000d" 1}}`)},
+		"large output":     {DestinationType: modelInputs.AlertDestinationTypeSlack, BodyTemplate: pointy.String("{{range 1000000}}{{printf `%999s` `a`}}{{end}}")},
+	} {
+		assert.Error(t, ValidateTemplates(destination, modelInputs.ProductTypeLogs, modelInputs.ThresholdTypeConstant), name)
+	}
+}
+
+func TestRenderDestinationTemplates(t *testing.T) {
+	destinations := []model.AlertDestination{
+		{DestinationType: modelInputs.AlertDestinationTypeSlack, TypeID: "default"},
+		{DestinationType: modelInputs.AlertDestinationTypeSlack, TypeID: "templated", BodyTemplate: pointy.String("{{.Alert.Name}}")},
+		{DestinationType: modelInputs.AlertDestinationTypeSlack, TypeID: "failing", BodyTemplate: pointy.String("{{.ErrorInput.Event}}")},
+	}
+	alertInput := SampleAlertInput(modelInputs.ProductTypeLogs, modelInputs.ThresholdTypeConstant, false)
+	templated, remaining := RenderDestinationTemplates(context.Background(), alertInput, destinations)
+
+	assert.Len(t, templated, 1)
+	assert.Equal(t, "templated", templated[0].Destination.TypeID)
+	assert.Equal(t, "Sample Alert Alert for checkout", templated[0].Title)
+	assert.Equal(t, "Sample Alert", templated[0].Body)
+	assert.Equal(t, []string{"default", "failing"}, []string{remaining[0].TypeID, remaining[1].TypeID})
+}
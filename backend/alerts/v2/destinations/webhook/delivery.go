@@ -0,0 +1,218 @@
+package webhookV2
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/model"
+)
+
+const (
+	// DeliveryHeader identifies a notification, shared by its retries and redeliveries
+	DeliveryHeader = "X-Highlight-Delivery"
+	// TimestampHeader is the unix time in seconds at which the payload was signed
+	TimestampHeader = "X-Highlight-Timestamp"
+	// SignatureHeader holds the signatures of the payload, ie. `v1=<hex>`, separated by spaces while a secret is rotated
+	SignatureHeader = "X-Highlight-Signature"
+
+	signatureVersion    = "v1"
+	signingSecretPrefix = "whsec_"
+	// the previous secret keeps signing payloads after a rotation, so that receivers can update their secret
+	rotationGracePeriod = 24 * time.Hour
+
+	maxAttempts        = 4
+	maxResponseExcerpt = 1024
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// retryBackoff is the wait before the retry of a failed attempt, doubling from a second up to 30s with jitter.
+var retryBackoff = func(attempt int) time.Duration {
+	backoff := time.Second << (attempt - 1)
+	if backoff > 30*time.Second {
+		backoff = 30 * time.Second
+	}
+	return backoff/2 + rand.N(backoff/2)
+}
+
+// NewSigningSecret generates a random secret for signing the payloads of a webhook destination.
+func NewSigningSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := cryptorand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "couldn't generate webhook signing secret")
+	}
+	return signingSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+// Sign computes the signature of a payload sent at the timestamp, the hex HMAC-SHA256 of `<timestamp>.<payload>`
+// keyed with the secret. Receivers verify a payload by computing the signature with their secret.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signatures lists the signatures of the payload by the current secret of the destination, followed by the
+// previous secret during the grace period of a rotation.
+func signatures(destination model.AlertDestination, timestamp time.Time, payload []byte) string {
+	var result []string
+	for _, secret := range signingSecrets(destination, timestamp) {
+		result = append(result, fmt.Sprintf("%s=%s", signatureVersion, Sign(secret, timestamp.Unix(), payload)))
+	}
+	return strings.Join(result, " ")
+}
+
+func signingSecrets(destination model.AlertDestination, now time.Time) []string {
+	var secrets []string
+	if destination.SigningSecret != nil && *destination.SigningSecret != "" {
+		secrets = append(secrets, *destination.SigningSecret)
+	}
+	if destination.PreviousSigningSecret != nil && *destination.PreviousSigningSecret != "" &&
+		destination.SigningSecretRotatedAt != nil && now.Sub(*destination.SigningSecretRotatedAt) < rotationGracePeriod {
+		secrets = append(secrets, *destination.PreviousSigningSecret)
+	}
+	return secrets
+}
+
+type delivery struct {
+	db          *gorm.DB
+	projectID   int
+	deliveryID  string
+	destination model.AlertDestination
+	payload     []byte
+	redelivery  bool
+}
+
+func newDelivery(db *gorm.DB, projectID int, destination model.AlertDestination, payload []byte) delivery {
+	return delivery{
+		db:          db,
+		projectID:   projectID,
+		deliveryID:  uuid.New().String(),
+		destination: destination,
+		payload:     payload,
+	}
+}
+
+// deliver posts the payload to the webhook, retrying attempts that fail with a network error, a 429 or a 5xx
+// response. Each attempt is recorded, and the last one is returned.
+func deliver(ctx context.Context, d delivery) *model.WebhookDelivery {
+	var attempt *model.WebhookDelivery
+	for idx := 1; idx <= maxAttempts; idx++ {
+		var retry bool
+		attempt, retry = attemptDelivery(ctx, d, idx)
+		if !retry || idx == maxAttempts {
+			break
+		}
+		time.Sleep(retryBackoff(idx))
+	}
+
+	logFields := log.Fields{
+		"Destination": d.destination.TypeID,
+		"DeliveryID":  d.deliveryID,
+		"Attempts":    attempt.Attempt,
+	}
+	if attempt.Success {
+		logFields["StatusCode"] = *attempt.StatusCode
+		log.WithContext(ctx).WithFields(logFields).Info("webhook sent successfully")
+	} else if attempt.StatusCode != nil {
+		log.WithContext(ctx).WithFields(logFields).Error(fmt.Sprintf("webhook %s received unexpected response code %d", d.destination.TypeID, *attempt.StatusCode))
+	} else {
+		log.WithContext(ctx).WithFields(logFields).Error(errors.Wrap(errors.New(*attempt.Error), "couldn't send to webhook"))
+	}
+	return attempt
+}
+
+// attemptDelivery posts the payload once, and returns the recorded attempt and whether it should be retried.
+func attemptDelivery(ctx context.Context, d delivery, attempt int) (*model.WebhookDelivery, bool) {
+	record := &model.WebhookDelivery{
+		ProjectID:     d.projectID,
+		AlertID:       d.destination.AlertID,
+		DestinationID: d.destination.ID,
+		DeliveryID:    d.deliveryID,
+		URL:           d.destination.TypeID,
+		Payload:       sanitize(string(d.payload)),
+		Attempt:       attempt,
+		Redelivery:    d.redelivery,
+	}
+
+	retry := false
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.destination.TypeID, bytes.NewReader(d.payload))
+	if err != nil {
+		record.Error = lo.ToPtr(err.Error())
+	} else {
+		now := time.Now()
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(DeliveryHeader, d.deliveryID)
+		req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+		if signature := signatures(d.destination, now, d.payload); signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		if d.destination.Authorization != nil && *d.destination.Authorization != "" {
+			req.Header.Set("Authorization", *d.destination.Authorization)
+		}
+
+		resp, err := httpClient.Do(req)
+		record.LatencyMs = int(time.Since(now).Milliseconds())
+		if err != nil {
+			record.Error = lo.ToPtr(err.Error())
+			retry = true
+		} else {
+			excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseExcerpt))
+			_ = resp.Body.Close()
+			record.StatusCode = &resp.StatusCode
+			record.ResponseExcerpt = sanitize(string(excerpt))
+			record.Success = resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
+			retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		}
+	}
+
+	if d.db != nil {
+		if err := d.db.WithContext(ctx).Create(record).Error; err != nil {
+			log.WithContext(ctx).WithField("DeliveryID", d.deliveryID).Error(errors.Wrap(err, "couldn't save webhook delivery"))
+		}
+	}
+	return record, retry
+}
+
+// Redeliver sends the payload of a previous delivery to the webhook destination again, signed with its current
+// secret. The redelivery is a single attempt sharing the delivery id of the original notification.
+func Redeliver(ctx context.Context, db *gorm.DB, previous *model.WebhookDelivery, destination model.AlertDestination) (*model.WebhookDelivery, error) {
+	if destination.TypeID != previous.URL {
+		return nil, errors.New("webhook destination does not match the delivery")
+	}
+
+	d := delivery{
+		db:          db,
+		projectID:   previous.ProjectID,
+		deliveryID:  previous.DeliveryID,
+		destination: destination,
+		payload:     []byte(previous.Payload),
+		redelivery:  true,
+	}
+	attempt, _ := attemptDelivery(ctx, d, 1)
+	return attempt, nil
+}
+
+// sanitize drops the bytes postgres can't store in a text column.
+func sanitize(s string) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(s, ""), "\x00", "")
+}
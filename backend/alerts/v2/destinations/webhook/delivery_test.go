@@ -0,0 +1,163 @@
+package webhookV2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	retryBackoff = func(attempt int) time.Duration {
+		return 0
+	}
+}
+
+func webhookDestination(url string) model.AlertDestination {
+	return model.AlertDestination{
+		Model:           model.Model{ID: 3},
+		AlertID:         2,
+		DestinationType: modelInputs.AlertDestinationTypeWebhook,
+		TypeID:          url,
+		SigningSecret:   pointy.String("whsec_current"),
+	}
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("whsec_current", 1700000000, []byte(`{"Event":"ALERT"}`))
+	assert.Equal(t, "f2358aaebe830e8fa7bbb2d0712f47860fe024cd59cb6397756c15c6c80afb3d", signature)
+	assert.NotEqual(t, signature, Sign("whsec_current", 1700000001, []byte(`{"Event":"ALERT"}`)))
+	assert.NotEqual(t, signature, Sign("whsec_other", 1700000000, []byte(`{"Event":"ALERT"}`)))
+
+	secret, err := NewSigningSecret()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, signingSecretPrefix))
+	assert.Len(t, secret, len(signingSecretPrefix)+64)
+}
+
+func TestSignatures(t *testing.T) {
+	now := time.Now()
+	payload := []byte(`{}`)
+	destination := webhookDestination("")
+	current := "v1=" + Sign("whsec_current", now.Unix(), payload)
+	previous := "v1=" + Sign("whsec_previous", now.Unix(), payload)
+	assert.Equal(t, current, signatures(destination, now, payload))
+
+	destination.PreviousSigningSecret = pointy.String("whsec_previous")
+	destination.SigningSecretRotatedAt = lo.ToPtr(now.Add(-time.Hour))
+	assert.Equal(t, current+" "+previous, signatures(destination, now, payload))
+
+	destination.SigningSecretRotatedAt = lo.ToPtr(now.Add(-rotationGracePeriod))
+	assert.Equal(t, current, signatures(destination, now, payload))
+
+	assert.Equal(t, "", signatures(model.AlertDestination{}, now, payload))
+}
+
+func TestDeliver(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		if len(requests) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(strings.Repeat("x", 2*maxResponseExcerpt)))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	destination := webhookDestination(server.URL)
+	destination.Authorization = pointy.String("Bearer token")
+	payload := []byte(`{"Event":"ALERT_RESOLVED"}`)
+	d := newDelivery(nil, 1, destination, payload)
+	attempt := deliver(context.Background(), d)
+
+	assert.True(t, attempt.Success)
+	assert.Equal(t, 3, attempt.Attempt)
+	assert.Equal(t, http.StatusOK, *attempt.StatusCode)
+	assert.Equal(t, "ok", attempt.ResponseExcerpt)
+	assert.Equal(t, 1, attempt.ProjectID)
+	assert.Equal(t, 2, attempt.AlertID)
+	assert.Equal(t, 3, attempt.DestinationID)
+	assert.Equal(t, string(payload), attempt.Payload)
+
+	if assert.Len(t, requests, 3) {
+		for idx, r := range requests {
+			assert.Equal(t, string(payload), bodies[idx])
+			assert.Equal(t, d.deliveryID, r.Header.Get(DeliveryHeader))
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, "v1="+Sign("whsec_current", timestamp, payload), r.Header.Get(SignatureHeader))
+		}
+	}
+
+	first, retry := attemptDelivery(context.Background(), newDelivery(nil, 1, webhookDestination(server.URL), payload), 1)
+	assert.False(t, retry)
+	assert.True(t, first.Success)
+}
+
+func TestDeliverFailures(t *testing.T) {
+	var count int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(strings.Repeat("x", 2*maxResponseExcerpt)))
+	}))
+	defer server.Close()
+
+	// client errors are not retried
+	attempt := deliver(context.Background(), newDelivery(nil, 1, webhookDestination(server.URL), []byte(`{}`)))
+	assert.False(t, attempt.Success)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, http.StatusBadRequest, *attempt.StatusCode)
+	assert.Len(t, attempt.ResponseExcerpt, maxResponseExcerpt)
+
+	// network errors are retried up to the max attempts
+	server.Close()
+	attempt = deliver(context.Background(), newDelivery(nil, 1, webhookDestination(server.URL), []byte(`{}`)))
+	assert.False(t, attempt.Success)
+	assert.Equal(t, maxAttempts, attempt.Attempt)
+	assert.Nil(t, attempt.StatusCode)
+	assert.NotNil(t, attempt.Error)
+}
+
+func TestRedeliver(t *testing.T) {
+	var deliveryIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliveryIDs = append(deliveryIDs, r.Header.Get(DeliveryHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	previous := &model.WebhookDelivery{
+		ProjectID:  1,
+		DeliveryID: "delivery",
+		URL:        server.URL,
+		Payload:    `{"Event":"ALERT"}`,
+		Attempt:    4,
+	}
+	attempt, err := Redeliver(context.Background(), nil, previous, webhookDestination(server.URL))
+	assert.NoError(t, err)
+	assert.True(t, attempt.Success)
+	assert.True(t, attempt.Redelivery)
+	assert.Equal(t, "delivery", attempt.DeliveryID)
+	assert.Equal(t, []string{"delivery"}, deliveryIDs)
+
+	_, err = Redeliver(context.Background(), nil, previous, webhookDestination("https://example.com"))
+	assert.Error(t, err)
+}
@@ -4,10 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"time"
 
-	"github.com/hashicorp/go-retryablehttp"
 	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
 	"github.com/highlight-run/highlight/backend/env"
 	"github.com/highlight-run/highlight/backend/model"
@@ -16,28 +14,47 @@ import (
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
-func SendAlerts(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func SendAlerts(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	span, ctx := util.StartSpanFromContext(ctx, "SendAlerts.Webhooks")
 	span.SetAttribute("alert_id", alertInput.Alert.ID)
 	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
 	span.SetAttribute("product_type", alertInput.Alert.ProductType)
 	defer span.Finish()
 
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	for _, t := range templated {
+		deliverAlerts(ctx, db, alertInput.Alert.ProjectID, []byte(t.Body), []model.AlertDestination{t.Destination})
+	}
+	if len(destinations) == 0 {
+		return
+	}
+
+	if alertInput.CompositeInput != nil {
+		sendCompositeAlert(ctx, db, alertInput, destinations)
+		return
+	}
+
+	if alertInput.ChangeInput != nil {
+		sendChangeAlert(ctx, db, alertInput, destinations)
+		return
+	}
+
 	switch alertInput.Alert.ProductType {
 	case modelInputs.ProductTypeSessions:
-		sendSessionAlert(ctx, alertInput, destinations)
+		sendSessionAlert(ctx, db, alertInput, destinations)
 	case modelInputs.ProductTypeErrors:
-		sendErrorAlert(ctx, alertInput, destinations)
+		sendErrorAlert(ctx, db, alertInput, destinations)
 	case modelInputs.ProductTypeLogs:
-		sendLogAlert(ctx, alertInput, destinations)
+		sendLogAlert(ctx, db, alertInput, destinations)
 	case modelInputs.ProductTypeTraces:
-		sendTraceAlert(ctx, alertInput, destinations)
+		sendTraceAlert(ctx, db, alertInput, destinations)
 	case modelInputs.ProductTypeMetrics:
-		sendMetricAlert(ctx, alertInput, destinations)
+		sendMetricAlert(ctx, db, alertInput, destinations)
 	case modelInputs.ProductTypeEvents:
-		sendEventAlert(ctx, alertInput, destinations)
+		sendEventAlert(ctx, db, alertInput, destinations)
 	default:
 		log.WithContext(ctx).WithFields(
 			log.Fields{
@@ -56,7 +73,7 @@ type SessionAlertPayload struct {
 	SessionURL string
 }
 
-func sendSessionAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func sendSessionAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	query := ""
 	if alertInput.Alert.Query != nil {
 		query = *alertInput.Alert.Query
@@ -71,7 +88,7 @@ func sendSessionAlert(ctx context.Context, alertInput *destinationsV2.AlertInput
 		SessionURL: alertInput.SessionInput.SessionLink,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
 type ErrorAlertPayload struct {
@@ -90,7 +107,7 @@ type ErrorAlertPayload struct {
 	ErrorSnoozeURL  string
 }
 
-func sendErrorAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func sendErrorAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	query := ""
 	if alertInput.Alert.Query != nil {
 		query = *alertInput.Alert.Query
@@ -112,7 +129,7 @@ func sendErrorAlert(ctx context.Context, alertInput *destinationsV2.AlertInput,
 		ErrorSnoozeURL:  routing.AttachQueryParam(ctx, alertInput.ErrorInput.ErrorLink, "action", "snooze"),
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
 type LogAlertPayload struct {
@@ -129,7 +146,7 @@ type LogAlertPayload struct {
 	LogsURL        string
 }
 
-func sendLogAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func sendLogAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	query := ""
 	if alertInput.Alert.Query != nil {
 		query = *alertInput.Alert.Query
@@ -155,7 +172,7 @@ func sendLogAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, de
 		LogsURL:        alertInput.LogInput.LogsLink,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
 type TraceAlertPayload struct {
@@ -172,7 +189,7 @@ type TraceAlertPayload struct {
 	TracesURL      string
 }
 
-func sendTraceAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func sendTraceAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	query := ""
 	if alertInput.Alert.Query != nil {
 		query = *alertInput.Alert.Query
@@ -198,7 +215,7 @@ func sendTraceAlert(ctx context.Context, alertInput *destinationsV2.AlertInput,
 		TracesURL:      alertInput.TraceInput.TracesLink,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
 type MetricAlertPayload struct {
@@ -213,7 +230,7 @@ type MetricAlertPayload struct {
 	DashboardURL   string
 }
 
-func sendMetricAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func sendMetricAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	query := ""
 	if alertInput.Alert.Query != nil {
 		query = *alertInput.Alert.Query
@@ -237,7 +254,7 @@ func sendMetricAlert(ctx context.Context, alertInput *destinationsV2.AlertInput,
 		DashboardURL:   alertInput.MetricInput.DashboardLink,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
 type EventAlertPayload struct {
@@ -252,7 +269,7 @@ type EventAlertPayload struct {
 	AlertURL       string
 }
 
-func sendEventAlert(ctx context.Context, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+func sendEventAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
 	query := ""
 	if alertInput.Alert.Query != nil {
 		query = *alertInput.Alert.Query
@@ -276,15 +293,156 @@ func sendEventAlert(ctx context.Context, alertInput *destinationsV2.AlertInput,
 		AlertURL:       alertInput.AlertLink,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
+}
+
+type CompositeAlertPayload struct {
+	Event      string
+	AlertName  string
+	AlertURL   string
+	Conditions []CompositeConditionPayload
 }
 
-func SendNotifications(ctx context.Context, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
+type CompositeConditionPayload struct {
+	AlertID    int
+	AlertName  string
+	GroupValue string
+	Alerting   bool
+	// nil when the child alert has no recent data
+	Value *float64
+}
+
+func sendCompositeAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	conditions := []CompositeConditionPayload{}
+	for _, condition := range alertInput.CompositeInput.Conditions {
+		conditions = append(conditions, CompositeConditionPayload{
+			AlertID:    condition.Alert.ID,
+			AlertName:  condition.Alert.Name,
+			GroupValue: condition.GroupValue,
+			Alerting:   condition.Alerting,
+			Value:      condition.Value,
+		})
+	}
+
+	messagePayload := CompositeAlertPayload{
+		Event:      "COMPOSITE_ALERT",
+		AlertName:  alertInput.Alert.Name,
+		AlertURL:   alertInput.AlertLink,
+		Conditions: conditions,
+	}
+
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
+}
+
+type ChangeAlertPayload struct {
+	Event          string
+	AlertName      string
+	AlertURL       string
+	Query          string
+	ProductType    modelInputs.ProductType
+	Function       modelInputs.MetricAggregator
+	FunctionColumn string
+	Group          string
+	GroupValue     string
+	Value          float64
+	// nil when the earlier window had no data
+	PreviousValue *float64
+	// seconds between the compared windows
+	ChangeWindow int64
+	ChangeType   modelInputs.ChangeType
+	Change       float64
+	Threshold    float64
+	Condition    modelInputs.ThresholdCondition
+}
+
+func sendChangeAlert(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	query := ""
+	if alertInput.Alert.Query != nil {
+		query = *alertInput.Alert.Query
+	}
+
+	functionColumn := ""
+	if alertInput.Alert.FunctionColumn != nil {
+		functionColumn = *alertInput.Alert.FunctionColumn
+	}
+
+	var threshold float64
+	if alertInput.Alert.ThresholdValue != nil {
+		threshold = *alertInput.Alert.ThresholdValue
+	}
+
+	messagePayload := ChangeAlertPayload{
+		Event:          "CHANGE_ALERT",
+		AlertName:      alertInput.Alert.Name,
+		AlertURL:       alertInput.AlertLink,
+		Query:          query,
+		ProductType:    alertInput.Alert.ProductType,
+		Function:       alertInput.Alert.FunctionType,
+		FunctionColumn: functionColumn,
+		Group:          alertInput.Group,
+		GroupValue:     alertInput.GroupValue,
+		Value:          alertInput.ChangeInput.Value,
+		PreviousValue:  alertInput.ChangeInput.PreviousValue,
+		ChangeWindow:   int64(destinationsV2.GetChangeWindow(alertInput.Alert).Seconds()),
+		ChangeType:     alertInput.Alert.ThresholdChangeType,
+		Change:         alertInput.AlertValue,
+		Threshold:      threshold,
+		Condition:      alertInput.Alert.ThresholdCondition,
+	}
+
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
+}
+
+type AlertResolvedPayload struct {
+	Event       string
+	AlertName   string
+	AlertURL    string
+	Group       string
+	GroupValue  string
+	FiringSince time.Time
+	ResolvedAt  time.Time
+	// seconds the group was alerting
+	Duration int64
+	// nil when the group had no data at recovery
+	Value *float64
+}
+
+func SendResolvedAlerts(ctx context.Context, db *gorm.DB, alertInput *destinationsV2.AlertInput, destinations []model.AlertDestination) {
+	span, ctx := util.StartSpanFromContext(ctx, "SendResolvedAlerts.Webhooks")
+	span.SetAttribute("alert_id", alertInput.Alert.ID)
+	span.SetAttribute("project_id", alertInput.Alert.ProjectID)
+	defer span.Finish()
+
+	templated, destinations := destinationsV2.RenderDestinationTemplates(ctx, alertInput, destinations)
+	for _, t := range templated {
+		deliverAlerts(ctx, db, alertInput.Alert.ProjectID, []byte(t.Body), []model.AlertDestination{t.Destination})
+	}
+	if len(destinations) == 0 {
+		return
+	}
+
+	resolved := alertInput.ResolvedInput
+	messagePayload := AlertResolvedPayload{
+		Event:       "ALERT_RESOLVED",
+		AlertName:   alertInput.Alert.Name,
+		AlertURL:    alertInput.AlertLink,
+		Group:       alertInput.Group,
+		GroupValue:  alertInput.GroupValue,
+		FiringSince: resolved.FiringSince,
+		ResolvedAt:  resolved.ResolvedAt,
+		Duration:    int64(resolved.ResolvedAt.Sub(resolved.FiringSince).Seconds()),
+		Value:       resolved.Value,
+	}
+
+	sendAlerts(ctx, db, alertInput.Alert.ProjectID, messagePayload, destinations)
+}
+
+func SendNotifications(ctx context.Context, db *gorm.DB, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	switch notificationInput.NotificationType {
 	case destinationsV2.NotificationTypeAlertCreated:
-		sendAlertCreatedNotification(ctx, notificationInput, destinations)
+		sendAlertCreatedNotification(ctx, db, notificationInput, destinations)
 	case destinationsV2.NotificationTypeAlertUpdated:
-		sendAlertUpdatedNotification(ctx, notificationInput, destinations)
+		sendAlertUpdatedNotification(ctx, db, notificationInput, destinations)
 	default:
 		log.WithContext(ctx).WithFields(
 			log.Fields{
@@ -301,7 +459,7 @@ type AlertCreatedPayload struct {
 	AdminName string
 }
 
-func sendAlertCreatedNotification(ctx context.Context, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
+func sendAlertCreatedNotification(ctx context.Context, db *gorm.DB, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	frontendURL := env.Config.FrontendUri
 	alertURL := fmt.Sprintf("%s/%d/alerts/%d", frontendURL, notificationInput.AlertUpsertInput.Alert.ProjectID, notificationInput.AlertUpsertInput.Alert.ID)
 
@@ -317,7 +475,7 @@ func sendAlertCreatedNotification(ctx context.Context, notificationInput destina
 		AdminName: *name,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, notificationInput.AlertUpsertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
 type AlertUpdatedPayload struct {
@@ -327,7 +485,7 @@ type AlertUpdatedPayload struct {
 	AdminName string
 }
 
-func sendAlertUpdatedNotification(ctx context.Context, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
+func sendAlertUpdatedNotification(ctx context.Context, db *gorm.DB, notificationInput destinationsV2.NotificationInput, destinations []model.AlertDestination) {
 	frontendURL := env.Config.FrontendUri
 	alertURL := fmt.Sprintf("%s/alerts/%d", frontendURL, notificationInput.AlertUpsertInput.Alert.ID)
 
@@ -343,35 +501,23 @@ func sendAlertUpdatedNotification(ctx context.Context, notificationInput destina
 		AdminName: *name,
 	}
 
-	sendAlerts(ctx, messagePayload, destinations)
+	sendAlerts(ctx, db, notificationInput.AlertUpsertInput.Alert.ProjectID, messagePayload, destinations)
 }
 
-func sendAlerts(ctx context.Context, messagePayload interface{}, destinations []model.AlertDestination) {
+func sendAlerts(ctx context.Context, db *gorm.DB, projectID int, messagePayload interface{}, destinations []model.AlertDestination) {
 	payloadJson, err := json.Marshal(messagePayload)
 	if err != nil {
 		log.WithContext(ctx).Error(errors.Wrap(err, "couldn't marshal message payload"))
 		return
 	}
 
+	deliverAlerts(ctx, db, projectID, payloadJson, destinations)
+}
+
+func deliverAlerts(ctx context.Context, db *gorm.DB, projectID int, payloadJson []byte, destinations []model.AlertDestination) {
+	// deliveries are retried in the background, after the context of the alert is done
+	ctx = context.WithoutCancel(ctx)
 	for _, destination := range destinations {
-		go func(webhookUrl string) {
-			resp, err := retryablehttp.Post(webhookUrl, "application/json", payloadJson)
-			if err != nil {
-				log.WithContext(ctx).Error(errors.Wrap(err, "couldn't send to webhook"))
-				return
-			}
-
-			if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
-				logFields := log.Fields{}
-				if err := json.Unmarshal(payloadJson, &logFields); err == nil {
-					ctx := context.TODO()
-					logFields["Destination"] = webhookUrl
-					logFields["StatusCode"] = resp.StatusCode
-					log.WithContext(ctx).WithFields(logFields).Info("webhook sent successfully")
-				}
-			} else {
-				log.WithContext(ctx).Error(errors.New(fmt.Sprintf("webhook %s received unexpected response code %d", webhookUrl, resp.StatusCode)))
-			}
-		}(destination.TypeID)
+		go deliver(ctx, newDelivery(db, projectID, destination, payloadJson))
 	}
 }
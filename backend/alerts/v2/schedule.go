@@ -0,0 +1,167 @@
+package alertsV2
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a standard 5 field cron expression: minute, hour, day of month, month and day of week.
+// Fields support `*`, lists, ranges and steps, ie. `*/15`, `1-5` or `0,30`.
+type Schedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// cron matches either day field when both are restricted
+	dayOfMonthStar bool
+	dayOfWeekStar  bool
+}
+
+var scheduleMacros = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// 7 is also sunday
+	{"day of week", 0, 7},
+}
+
+func ParseSchedule(expr string) (*Schedule, error) {
+	if macro, ok := scheduleMacros[strings.TrimSpace(expr)]; ok {
+		expr = macro
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(scheduleFields) {
+		return nil, fmt.Errorf("invalid schedule %q: expected %d fields", expr, len(scheduleFields))
+	}
+
+	bits := make([]uint64, len(scheduleFields))
+	for idx, field := range scheduleFields {
+		b, err := parseScheduleField(parts[idx], field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+		bits[idx] = b
+	}
+	// sunday is 0
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &Schedule{
+		minute:         bits[0],
+		hour:           bits[1],
+		dayOfMonth:     bits[2],
+		month:          bits[3],
+		dayOfWeek:      bits[4],
+		dayOfMonthStar: parts[2] == "*",
+		dayOfWeekStar:  parts[4] == "*",
+	}, nil
+}
+
+func parseScheduleField(expr string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", field.name, stepExpr)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangeExpr != "*" {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = strconv.Atoi(startExpr); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", field.name, item)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(endExpr); err != nil {
+					return 0, fmt.Errorf("invalid %s %q", field.name, item)
+				}
+			} else if hasStep {
+				end = field.max
+			}
+		}
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("%s %q out of range %d-%d", field.name, item, field.min, field.max)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+// Matches returns whether the schedule fires in the minute of t, in the location of t.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.minute&(1<<t.Minute()) != 0 && s.hour&(1<<t.Hour()) != 0 && s.matchesDay(t)
+}
+
+// Prev returns the last minute at or before t when the schedule fired, in the location of t,
+// if it is not before since. Days are checked from the day of t back to the day of since.
+func (s *Schedule) Prev(t time.Time, since time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	for day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()); day.AddDate(0, 0, 1).After(since); day = day.AddDate(0, 0, -1) {
+		if !s.matchesDay(day) {
+			continue
+		}
+		lastHour, lastMinute := 23, 59
+		if day.Day() == t.Day() && day.Month() == t.Month() && day.Year() == t.Year() {
+			lastHour, lastMinute = t.Hour(), t.Minute()
+		}
+		for hour := lastSet(s.hour, lastHour); hour >= 0; hour = lastSet(s.hour, hour-1) {
+			last := 59
+			if hour == lastHour {
+				last = lastMinute
+			}
+			if minute := lastSet(s.minute, last); minute >= 0 {
+				fired := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, t.Location())
+				return fired, !fired.Before(since)
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// lastSet returns the highest bit set up to max, or -1.
+func lastSet(b uint64, max int) int {
+	if max < 0 {
+		return -1
+	}
+	return bits.Len64(b&(1<<(max+1)-1)) - 1
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	if s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
@@ -0,0 +1,126 @@
+package alertsV2
+
+import (
+	"context"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	e "github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/highlight-run/highlight/backend/model"
+)
+
+// maxSilenceDuration bounds the length of each recurring maintenance window.
+const maxSilenceDuration = 7 * 24 * time.Hour
+
+// schedules caches the parsed schedules of recurring silences, which are checked for every alert group.
+var schedules, _ = lru.New[string, *Schedule](1000)
+
+func getSchedule(expr string) (*Schedule, error) {
+	if schedule, ok := schedules.Get(expr); ok {
+		return schedule, nil
+	}
+	schedule, err := ParseSchedule(expr)
+	if err != nil {
+		return nil, err
+	}
+	schedules.Add(expr, schedule)
+	return schedule, nil
+}
+
+// ValidateSilence checks that the silence has a valid one-off or recurring schedule.
+func ValidateSilence(silence *model.AlertSilence) error {
+	if (silence.GroupByKey == nil) != (silence.GroupByValue == nil) {
+		return e.New("a silence matching an alert group requires both the group by key and value")
+	}
+
+	if silence.StartsAt != nil && silence.EndsAt != nil && !silence.EndsAt.After(*silence.StartsAt) {
+		return e.New("a silence must end after it starts")
+	}
+
+	if silence.Cron == nil {
+		if silence.EndsAt == nil {
+			return e.New("a silence requires an end time or a recurring schedule")
+		}
+		if silence.DurationMinutes != nil {
+			return e.New("a silence duration requires a recurring schedule")
+		}
+		return nil
+	}
+
+	if _, err := ParseSchedule(*silence.Cron); err != nil {
+		return err
+	}
+	if silence.DurationMinutes == nil || *silence.DurationMinutes <= 0 {
+		return e.New("a recurring silence requires a positive duration")
+	}
+	if time.Duration(*silence.DurationMinutes)*time.Minute > maxSilenceDuration {
+		return e.Errorf("a recurring silence can last at most %s", maxSilenceDuration)
+	}
+	return nil
+}
+
+// IsSilenceActive returns whether the silence applies at t.
+func IsSilenceActive(silence *model.AlertSilence, t time.Time) bool {
+	if silence.StartsAt != nil && t.Before(*silence.StartsAt) {
+		return false
+	}
+	if silence.EndsAt != nil && !t.Before(*silence.EndsAt) {
+		return false
+	}
+	if silence.Cron == nil {
+		return true
+	}
+
+	schedule, err := getSchedule(*silence.Cron)
+	if err != nil || silence.DurationMinutes == nil || *silence.DurationMinutes <= 0 {
+		return false
+	}
+	// active if a window started within the duration of the silence
+	minute := t.UTC().Truncate(time.Minute)
+	_, ok := schedule.Prev(minute, minute.Add(-time.Duration(*silence.DurationMinutes-1)*time.Minute))
+	return ok
+}
+
+func silenceMatches(silence *model.AlertSilence, alert *model.Alert, groupValue string) bool {
+	if silence.AlertID != nil && *silence.AlertID != alert.ID {
+		return false
+	}
+	if silence.ProductType != nil && *silence.ProductType != alert.ProductType {
+		return false
+	}
+	if silence.GroupByKey != nil {
+		if alert.GroupByKey == nil || *alert.GroupByKey != *silence.GroupByKey {
+			return false
+		}
+		if silence.GroupByValue == nil || *silence.GroupByValue != groupValue {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSilenced returns whether an active silence matches the alert group at t.
+func IsSilenced(silences []*model.AlertSilence, alert *model.Alert, groupValue string, t time.Time) bool {
+	for _, silence := range silences {
+		if silenceMatches(silence, alert, groupValue) && IsSilenceActive(silence, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAlertSilences returns the silences of the project of the alert which may apply to it at t.
+func GetAlertSilences(ctx context.Context, db *gorm.DB, alert *model.Alert, t time.Time) ([]*model.AlertSilence, error) {
+	var silences []*model.AlertSilence
+	if err := db.WithContext(ctx).
+		Where("project_id = ?", alert.ProjectID).
+		Where("alert_id IS NULL OR alert_id = ?", alert.ID).
+		Where("product_type IS NULL OR product_type = ?", alert.ProductType).
+		Where("ends_at IS NULL OR ends_at > ?", t).
+		Find(&silences).Error; err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
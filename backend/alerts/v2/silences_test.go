@@ -0,0 +1,230 @@
+package alertsV2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// a monday
+	monday := time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC)
+
+	for expr, expected := range map[string]bool{
+		"* * * * *":      true,
+		"30 2 * * *":     true,
+		"*/15 * * * *":   true,
+		"*/7 * * * *":    false,
+		"0,30 1-3 * * *": true,
+		"30 2 * * 1-5":   true,
+		"30 2 * * 0,6":   false,
+		"30 2 15 * 1":    true,
+		"30 2 15 * 0":    false,
+		"30 2 1 1 *":     true,
+		"30 2 * 2 *":     false,
+		"@hourly":        false,
+	} {
+		schedule, err := ParseSchedule(expr)
+		if assert.NoError(t, err, expr) {
+			assert.Equal(t, expected, schedule.Matches(monday), expr)
+		}
+	}
+
+	sunday, err := ParseSchedule("0 0 * * 7")
+	assert.NoError(t, err)
+	assert.True(t, sunday.Matches(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)))
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseScheduleFields(t *testing.T) {
+	setValues := func(bits uint64) []int {
+		var values []int
+		for value := 0; value < 64; value++ {
+			if bits&(1<<value) != 0 {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		field    func(s *Schedule) uint64
+		expected []int
+	}{
+		{"minute range", "10-13 * * * *", func(s *Schedule) uint64 { return s.minute }, []int{10, 11, 12, 13}},
+		{"minute step", "*/20 * * * *", func(s *Schedule) uint64 { return s.minute }, []int{0, 20, 40}},
+		{"minute step from", "45/5 * * * *", func(s *Schedule) uint64 { return s.minute }, []int{45, 50, 55}},
+		{"hour range step", "0 1-10/3 * * *", func(s *Schedule) uint64 { return s.hour }, []int{1, 4, 7, 10}},
+		{"hour list of ranges", "0 1-2,20-21 * * *", func(s *Schedule) uint64 { return s.hour }, []int{1, 2, 20, 21}},
+		{"day of month step", "0 0 1-31/10 * *", func(s *Schedule) uint64 { return s.dayOfMonth }, []int{1, 11, 21, 31}},
+		{"month list", "0 0 1 1,6,12 *", func(s *Schedule) uint64 { return s.month }, []int{1, 6, 12}},
+		{"day of week range", "0 0 * * 1-5", func(s *Schedule) uint64 { return s.dayOfWeek }, []int{1, 2, 3, 4, 5}},
+		{"day of week sunday as 7", "0 0 * * 5-7", func(s *Schedule) uint64 { return s.dayOfWeek }, []int{0, 5, 6, 7}},
+		{"day of week step", "0 0 * * */2", func(s *Schedule) uint64 { return s.dayOfWeek }, []int{0, 2, 4, 6}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.expr)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, setValues(tc.field(schedule)))
+			}
+		})
+	}
+}
+
+func TestScheduleDays(t *testing.T) {
+	// the 15th of january 2024 is a monday
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		day      time.Time
+		expected bool
+	}{
+		{"day of month only", "0 0 15 * *", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), true},
+		{"other day of month", "0 0 15 * *", time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), false},
+		{"day of week only", "0 0 * * 2", time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), true},
+		{"either day field matching day of month", "0 0 15 * 5", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), true},
+		{"either day field matching day of week", "0 0 15 * 5", time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC), true},
+		{"neither day field", "0 0 15 * 5", time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), false},
+		{"day of month with any day of week", "0 0 1-7 * *", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), false},
+		{"day of week with any day of month", "0 0 * * 1", time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC), true},
+		{"other month", "0 0 15 2 *", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.expr)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, schedule.Matches(tc.day))
+			}
+		})
+	}
+}
+
+func TestSchedulePrev(t *testing.T) {
+	// the 6th of january 2024 is a saturday
+	now := time.Date(2024, 1, 6, 3, 10, 30, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		since    time.Time
+		expected *time.Time
+	}{
+		{"this minute", "* * * * *", now.Add(-time.Hour), lo.ToPtr(time.Date(2024, 1, 6, 3, 10, 0, 0, time.UTC))},
+		{"earlier this hour", "5 * * * *", now.Add(-time.Hour), lo.ToPtr(time.Date(2024, 1, 6, 3, 5, 0, 0, time.UTC))},
+		{"previous hour", "30 * * * *", now.Add(-time.Hour), lo.ToPtr(time.Date(2024, 1, 6, 2, 30, 0, 0, time.UTC))},
+		{"previous hour after a later minute", "15 1-3 * * *", now.Add(-3 * time.Hour), lo.ToPtr(time.Date(2024, 1, 6, 2, 15, 0, 0, time.UTC))},
+		{"previous day", "0 22 * * *", now.Add(-24 * time.Hour), lo.ToPtr(time.Date(2024, 1, 5, 22, 0, 0, 0, time.UTC))},
+		{"previous week", "0 4 * * 6", now.Add(-7 * 24 * time.Hour), lo.ToPtr(time.Date(2023, 12, 30, 4, 0, 0, 0, time.UTC))},
+		{"previous month", "0 0 31 12 *", now.Add(-7 * 24 * time.Hour), lo.ToPtr(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))},
+		{"before since", "0 22 * * *", now.Add(-time.Hour), nil},
+		{"later this day", "0 4 * * 6", now.Add(-24 * time.Hour), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.expr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			fired, ok := schedule.Prev(now, tc.since)
+			if tc.expected == nil {
+				assert.False(t, ok)
+			} else if assert.True(t, ok) {
+				assert.Equal(t, *tc.expected, fired)
+			}
+		})
+	}
+}
+
+func TestValidateSilence(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, ValidateSilence(&model.AlertSilence{EndsAt: lo.ToPtr(now.Add(time.Hour))}))
+	assert.NoError(t, ValidateSilence(&model.AlertSilence{Cron: pointy.String("0 2 * * 6"), DurationMinutes: pointy.Int(120)}))
+
+	assert.Error(t, ValidateSilence(&model.AlertSilence{}))
+	assert.Error(t, ValidateSilence(&model.AlertSilence{StartsAt: lo.ToPtr(now), EndsAt: lo.ToPtr(now.Add(-time.Hour))}))
+	assert.Error(t, ValidateSilence(&model.AlertSilence{EndsAt: lo.ToPtr(now), GroupByKey: pointy.String("service_name")}))
+	assert.Error(t, ValidateSilence(&model.AlertSilence{Cron: pointy.String("0 2 * * 6")}))
+	assert.Error(t, ValidateSilence(&model.AlertSilence{Cron: pointy.String("0 2 * *"), DurationMinutes: pointy.Int(60)}))
+	assert.Error(t, ValidateSilence(&model.AlertSilence{Cron: pointy.String("0 2 * * 6"), DurationMinutes: pointy.Int(8 * 24 * 60)}))
+}
+
+func TestIsSilenced(t *testing.T) {
+	now := time.Date(2024, 1, 6, 3, 0, 0, 0, time.UTC)
+	alert := &model.Alert{
+		Model:       model.Model{ID: 1},
+		ProductType: modelInputs.ProductTypeLogs,
+		GroupByKey:  pointy.String("service_name"),
+	}
+
+	for name, tc := range map[string]struct {
+		silence    model.AlertSilence
+		groupValue string
+		expected   bool
+	}{
+		"active window": {
+			silence:  model.AlertSilence{StartsAt: lo.ToPtr(now.Add(-time.Hour)), EndsAt: lo.ToPtr(now.Add(time.Hour))},
+			expected: true,
+		},
+		"expired window": {
+			silence:  model.AlertSilence{EndsAt: lo.ToPtr(now)},
+			expected: false,
+		},
+		"future window": {
+			silence:  model.AlertSilence{StartsAt: lo.ToPtr(now.Add(time.Minute)), EndsAt: lo.ToPtr(now.Add(time.Hour))},
+			expected: false,
+		},
+		"other alert": {
+			silence:  model.AlertSilence{AlertID: pointy.Int(2), EndsAt: lo.ToPtr(now.Add(time.Hour))},
+			expected: false,
+		},
+		"other product type": {
+			silence:  model.AlertSilence{ProductType: lo.ToPtr(modelInputs.ProductTypeTraces), EndsAt: lo.ToPtr(now.Add(time.Hour))},
+			expected: false,
+		},
+		"matching group": {
+			silence:    model.AlertSilence{GroupByKey: pointy.String("service_name"), GroupByValue: pointy.String("checkout"), EndsAt: lo.ToPtr(now.Add(time.Hour))},
+			groupValue: "checkout",
+			expected:   true,
+		},
+		"other group": {
+			silence:    model.AlertSilence{GroupByKey: pointy.String("service_name"), GroupByValue: pointy.String("checkout"), EndsAt: lo.ToPtr(now.Add(time.Hour))},
+			groupValue: "payments",
+			expected:   false,
+		},
+		"in recurring window": {
+			// saturdays from 2am for 2 hours
+			silence:  model.AlertSilence{Cron: pointy.String("0 2 * * 6"), DurationMinutes: pointy.Int(120)},
+			expected: true,
+		},
+		"after recurring window": {
+			silence:  model.AlertSilence{Cron: pointy.String("0 2 * * 6"), DurationMinutes: pointy.Int(60)},
+			expected: false,
+		},
+		"in recurring window from the previous day": {
+			// fridays from 11pm for 5 hours
+			silence:  model.AlertSilence{Cron: pointy.String("0 23 * * 5"), DurationMinutes: pointy.Int(300)},
+			expected: true,
+		},
+		"in week long recurring window": {
+			silence:  model.AlertSilence{Cron: pointy.String("0 4 * * 6"), DurationMinutes: pointy.Int(7 * 24 * 60)},
+			expected: true,
+		},
+		"after a recurring window of the previous week": {
+			silence:  model.AlertSilence{Cron: pointy.String("0 4 * * 6"), DurationMinutes: pointy.Int(7*24*60 - 60)},
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			silence := tc.silence
+			assert.Equal(t, tc.expected, IsSilenced([]*model.AlertSilence{&silence}, alert, tc.groupValue, now))
+		})
+	}
+}
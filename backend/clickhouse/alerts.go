@@ -20,6 +20,7 @@ type AlertStateChangeRow struct {
 	Timestamp  time.Time
 	State      string
 	GroupByKey string
+	Value      *float64
 }
 
 func (client *Client) GetLastAlertingStates(ctx context.Context, projectId int, alertId int, startDate time.Time, endDate time.Time) ([]modelInputs.AlertStateChange, error) {
@@ -53,6 +54,155 @@ func (client *Client) GetLastAlertingStates(ctx context.Context, projectId int,
 	return results, nil
 }
 
+type FiringAlertState struct {
+	GroupByKey  string
+	FiringSince time.Time
+}
+
+// the state of every evaluated or firing group is written on each evaluation, so the latest state of a firing
+// group is within this lookback unless the alert was not evaluated for as long.
+const latestAlertStateLookback = time.Hour
+
+// GetFiringAlertStates returns the groups of the alert whose last state is alerting, with the start of the alerting period.
+// Groups that only alerted silently since they were last normal are omitted as no alert was sent for them.
+// The states since `startDate` are only read for the groups whose latest state is not normal.
+func (client *Client) GetFiringAlertStates(ctx context.Context, projectId int, alertId int, startDate time.Time, endDate time.Time) ([]FiringAlertState, error) {
+	groupByKeys, err := client.getNonNormalAlertGroups(ctx, projectId, alertId, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupByKeys) == 0 {
+		return []FiringAlertState{}, nil
+	}
+
+	innerSb := sqlbuilder.NewSelectBuilder()
+	innerSb.Select(
+		"GroupByKey",
+		"Timestamp",
+		"State",
+		fmt.Sprintf("maxIf(Timestamp, State = %s) OVER (PARTITION BY GroupByKey) AS LastNormal", innerSb.Var(modelInputs.AlertStateNormal)),
+	)
+	innerSb.From(AlertStateChangesTable)
+	innerSb.Where(innerSb.Equal("ProjectID", projectId))
+	innerSb.Where(innerSb.Equal("AlertID", alertId))
+	innerSb.Where(innerSb.GreaterEqualThan("Timestamp", startDate))
+	innerSb.Where(innerSb.LessEqualThan("Timestamp", endDate))
+	innerSb.Where(innerSb.In("GroupByKey", groupByKeys))
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("GroupByKey", "minIf(Timestamp, Timestamp > LastNormal) AS FiringSince")
+	sb.From(sb.BuilderAs(innerSb, "s"))
+	sb.GroupBy("GroupByKey")
+	sb.Having(
+		fmt.Sprintf("argMax(State, Timestamp) != %s", sb.Var(modelInputs.AlertStateNormal)),
+		fmt.Sprintf("countIf(State = %s AND Timestamp > LastNormal) > 0", sb.Var(modelInputs.AlertStateAlerting)),
+	)
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []FiringAlertState{}
+	for rows.Next() {
+		var result FiringAlertState
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// getNonNormalAlertGroups returns the groups of the alert whose latest state within latestAlertStateLookback
+// of `endDate` is not normal.
+func (client *Client) getNonNormalAlertGroups(ctx context.Context, projectId int, alertId int, startDate time.Time, endDate time.Time) ([]string, error) {
+	if latestStartDate := endDate.Add(-latestAlertStateLookback); latestStartDate.After(startDate) {
+		startDate = latestStartDate
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("GroupByKey")
+	sb.From(AlertStateChangesTable)
+	sb.Where(sb.Equal("ProjectID", projectId))
+	sb.Where(sb.Equal("AlertID", alertId))
+	sb.Where(sb.GreaterEqualThan("Timestamp", startDate))
+	sb.Where(sb.LessEqualThan("Timestamp", endDate))
+	sb.GroupBy("GroupByKey")
+	sb.Having(fmt.Sprintf("argMax(State, Timestamp) != %s", sb.Var(modelInputs.AlertStateNormal)))
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupByKeys []string
+	for rows.Next() {
+		var groupByKey string
+		if err := rows.Scan(&groupByKey); err != nil {
+			return nil, err
+		}
+		groupByKeys = append(groupByKeys, groupByKey)
+	}
+
+	return groupByKeys, rows.Err()
+}
+
+// GetLatestAlertStates returns the latest state and value of each group of the alerts between the dates.
+func (client *Client) GetLatestAlertStates(ctx context.Context, projectId int, alertIds []int, startDate time.Time, endDate time.Time) ([]modelInputs.AlertStateChange, error) {
+	if len(alertIds) == 0 {
+		return nil, nil
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		"AlertID",
+		"GroupByKey",
+		"max(Timestamp) AS LastTimestamp",
+		"argMax(State, Timestamp) AS LastState",
+		"argMax(Value, Timestamp) AS LastValue",
+	)
+	sb.From(AlertStateChangesTable)
+	sb.Where(sb.Equal("ProjectID", projectId))
+	sb.Where(sb.In("AlertID", alertIds))
+	sb.Where(sb.GreaterEqualThan("Timestamp", startDate))
+	sb.Where(sb.LessEqualThan("Timestamp", endDate))
+	sb.GroupBy("AlertID", "GroupByKey")
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []modelInputs.AlertStateChange{}
+	for rows.Next() {
+		var (
+			alertId    uint32
+			groupByKey string
+			timestamp  time.Time
+			state      string
+			value      *float64
+		)
+		if err := rows.Scan(&alertId, &groupByKey, &timestamp, &state, &value); err != nil {
+			return nil, err
+		}
+		results = append(results, modelInputs.AlertStateChange{
+			ProjectID:  projectId,
+			AlertID:    int(alertId),
+			Timestamp:  timestamp,
+			State:      modelInputs.AlertState(state),
+			GroupByKey: groupByKey,
+			Value:      value,
+		})
+	}
+
+	return results, rows.Err()
+}
+
 func (client *Client) WriteAlertStateChanges(ctx context.Context, projectId int, alertStates []modelInputs.AlertStateChange) error {
 	if len(alertStates) == 0 {
 		return nil
@@ -66,10 +216,10 @@ func (client *Client) WriteAlertStateChanges(ctx context.Context, projectId int,
 	ib := sqlbuilder.
 		NewStruct(new(modelInputs.AlertStateChange)).
 		InsertInto(AlertStateChangesTable).
-		Cols("ProjectID", "AlertID", "Timestamp", "State", "GroupByKey")
+		Cols("ProjectID", "AlertID", "Timestamp", "State", "GroupByKey", "Value")
 
 	for _, state := range alertStates {
-		ib.Values(projectId, state.AlertID, state.Timestamp, state.State, state.GroupByKey)
+		ib.Values(projectId, state.AlertID, state.Timestamp, state.State, state.GroupByKey, state.Value)
 	}
 
 	sql, args := ib.BuildWithFlavor(sqlbuilder.ClickHouse)
@@ -100,6 +250,7 @@ func (client *Client) GetAlertingAlertStateChanges(ctx context.Context, projectI
 			Timestamp:  result.Timestamp,
 			State:      modelInputs.AlertState(result.State),
 			GroupByKey: result.GroupByKey,
+			Value:      result.Value,
 		}, nil
 	}
 
@@ -121,7 +272,7 @@ func (client *Client) GetAlertingAlertStateChanges(ctx context.Context, projectI
 	}
 
 	sb := sqlbuilder.NewSelectBuilder()
-	sb.Select("ProjectID", "AlertID", "Timestamp", "State", "GroupByKey").
+	sb.Select("ProjectID", "AlertID", "Timestamp", "State", "GroupByKey", "Value").
 		From(AlertStateChangesTable).
 		Where(sb.Equal("ProjectID", projectId)).
 		Where(sb.Equal("AlertID", alertId)).
@@ -164,6 +315,7 @@ func (client *Client) GetLastAlertStateChanges(ctx context.Context, projectId in
 			Timestamp:  result.Timestamp,
 			State:      modelInputs.AlertState(result.State),
 			GroupByKey: result.GroupByKey,
+			Value:      result.Value,
 		}, nil
 	}
 
@@ -174,7 +326,7 @@ func (client *Client) GetLastAlertStateChanges(ctx context.Context, projectId in
 		Where(innerSb.Equal("AlertID", alertId))
 
 	sb := sqlbuilder.NewSelectBuilder()
-	sb.Select("ProjectID", "AlertID", "Timestamp", "State", "GroupByKey").
+	sb.Select("ProjectID", "AlertID", "Timestamp", "State", "GroupByKey", "Value").
 		From(AlertStateChangesTable).
 		Where(sb.Equal("ProjectID", projectId)).
 		Where(sb.Equal("AlertID", alertId)).
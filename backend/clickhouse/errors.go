@@ -630,6 +630,16 @@ var reservedErrorsJoinedKeys = lo.Map(modelInputs.AllReservedErrorsJoinedKey, fu
 	return string(key)
 })
 
+var numericErrorsJoinedKeys = map[string]bool{
+	string(modelInputs.ReservedErrorsJoinedKeyID):           true,
+	string(modelInputs.ReservedErrorsJoinedKeySnoozedUntil): true,
+	string(modelInputs.ReservedErrorsJoinedKeyTimestamp):    true,
+}
+
+var timeErrorsJoinedKeys = map[string]bool{
+	string(modelInputs.ReservedErrorsJoinedKeyTimestamp): true,
+}
+
 var ErrorsJoinedTableConfig = model.TableConfig{
 	TableName: "errors_joined_vw",
 	KeysToColumns: map[string]string{
@@ -654,6 +664,8 @@ var ErrorsJoinedTableConfig = model.TableConfig{
 	},
 	BodyColumn:   "Event",
 	ReservedKeys: reservedErrorsJoinedKeys,
+	NumericKeys:  numericErrorsJoinedKeys,
+	TimeKeys:     timeErrorsJoinedKeys,
 }
 
 var BackendErrorObjectInputConfig = model.TableConfig{
@@ -680,7 +692,7 @@ func ErrorMatchesQuery(errorObject *model2.BackendErrorObjectInput, filters list
 }
 
 func (client *Client) ReadErrorsMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, groupBy []string, nBuckets *int, bucketBy string, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
-	return client.ReadMetrics(ctx, ReadMetricsInput{
+	return client.readSearchMetrics(ctx, ReadMetricsInput{
 		SampleableConfig: ErrorsSampleableTableConfig,
 		ProjectIDs:       []int{projectID},
 		Params:           params,
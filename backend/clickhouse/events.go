@@ -63,11 +63,24 @@ var reservedEventKeys = lo.Map(modelInputs.AllReservedEventKey, func(key modelIn
 	return string(key)
 })
 
+var numericEventKeys = map[string]bool{
+	string(modelInputs.ReservedEventKeySessionActiveLength): true,
+	string(modelInputs.ReservedEventKeySessionLength):       true,
+	string(modelInputs.ReservedEventKeySessionPagesVisited): true,
+	string(modelInputs.ReservedEventKeyTimestamp):           true,
+}
+
+var timeEventKeys = map[string]bool{
+	string(modelInputs.ReservedEventKeyTimestamp): true,
+}
+
 var eventsTableConfig = model.TableConfig{
 	AttributesColumns: []model.ColumnMapping{{Column: "Attributes"}},
 	BodyColumn:        "Event",
 	KeysToColumns:     eventKeysToColumns,
 	ReservedKeys:      reservedEventKeys,
+	NumericKeys:       numericEventKeys,
+	TimeKeys:          timeEventKeys,
 	TableName:         SessionEventsView,
 }
 
@@ -132,7 +145,7 @@ func (client *Client) BatchWriteSessionEventRows(ctx context.Context, eventRows
 }
 
 func (client *Client) ReadEventsMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, groupBy []string, nBuckets *int, bucketBy string, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
-	return client.ReadMetrics(ctx, ReadMetricsInput{
+	return client.readSearchMetrics(ctx, ReadMetricsInput{
 		SampleableConfig: EventsSampleableTableConfig,
 		ProjectIDs:       []int{projectID},
 		Params:           params,
@@ -30,6 +30,7 @@ type LogRow struct {
 	Body            string
 	LogAttributes   map[string]string
 	Environment     string
+	PatternId       string
 }
 
 func NewLogRow(timestamp time.Time, projectID uint32, opts ...LogRowOption) *LogRow {
@@ -131,6 +132,25 @@ func WithEnvironment(environment string) LogRowOption {
 	}
 }
 
+// AsLog returns the GraphQL log of the row.
+func (l *LogRow) AsLog() *modelInputs.Log {
+	source := string(l.Source)
+	return &modelInputs.Log{
+		Timestamp:       l.Timestamp,
+		Level:           makeLogLevel(l.SeverityText),
+		Message:         l.Body,
+		LogAttributes:   expandJSON(l.LogAttributes),
+		TraceID:         &l.TraceId,
+		SpanID:          &l.SpanId,
+		SecureSessionID: &l.SecureSessionId,
+		Source:          &source,
+		ServiceName:     &l.ServiceName,
+		ServiceVersion:  &l.ServiceVersion,
+		Environment:     &l.Environment,
+		ProjectID:       int(l.ProjectId),
+	}
+}
+
 func makeLogLevel(severityText string) modelInputs.LogLevel {
 	switch strings.ToLower(severityText) {
 	case "console.error":
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,7 +15,9 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/google/uuid"
+	"github.com/highlight-run/highlight/backend/logpattern"
 	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
 	"github.com/highlight-run/highlight/backend/parser/listener"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/util"
@@ -37,6 +40,7 @@ var logKeysToColumns = map[string]string{
 	string(modelInputs.ReservedLogKeyServiceVersion):  "ServiceVersion",
 	string(modelInputs.ReservedLogKeyEnvironment):     "Environment",
 	string(modelInputs.ReservedLogKeyMessage):         "Body",
+	string(modelInputs.ReservedLogKeyPatternID):       "PatternId",
 	string(modelInputs.ReservedLogKeyTimestamp):       "Timestamp",
 }
 
@@ -46,6 +50,7 @@ var defaultLogKeys = []*modelInputs.QueryKey{
 	{Name: string(modelInputs.ReservedLogKeySpanID), Type: modelInputs.KeyTypeString},
 	{Name: string(modelInputs.ReservedLogKeyTraceID), Type: modelInputs.KeyTypeString},
 	{Name: string(modelInputs.ReservedLogKeyMessage), Type: modelInputs.KeyTypeString},
+	{Name: string(modelInputs.ReservedLogKeyPatternID), Type: modelInputs.KeyTypeString},
 	{Name: string(modelInputs.ReservedLogKeyTimestamp), Type: modelInputs.KeyTypeNumeric},
 }
 
@@ -53,10 +58,20 @@ var reservedLogKeys = lo.Map(modelInputs.AllReservedLogKey, func(key modelInputs
 	return string(key)
 })
 
+var numericLogKeys = map[string]bool{
+	string(modelInputs.ReservedLogKeyTimestamp): true,
+}
+
+var timeLogKeys = map[string]bool{
+	string(modelInputs.ReservedLogKeyTimestamp): true,
+}
+
 var LogsTableConfig = model.TableConfig{
 	TableName:         LogsTable,
 	KeysToColumns:     logKeysToColumns,
 	ReservedKeys:      reservedLogKeys,
+	NumericKeys:       numericLogKeys,
+	TimeKeys:          timeLogKeys,
 	BodyColumn:        "Body",
 	SeverityColumn:    "SeverityText",
 	AttributesColumns: []model.ColumnMapping{{Column: "LogAttributes"}},
@@ -81,6 +96,8 @@ var logsSamplingTableConfig = model.TableConfig{
 	TableName:         LogsSamplingTable,
 	KeysToColumns:     logKeysToColumns,
 	ReservedKeys:      reservedLogKeys,
+	NumericKeys:       numericLogKeys,
+	TimeKeys:          timeLogKeys,
 	BodyColumn:        "Body",
 	AttributesColumns: []model.ColumnMapping{{Column: "LogAttributes"}},
 }
@@ -120,6 +137,13 @@ func (client *Client) BatchWriteLogRows(ctx context.Context, logRows []*LogRow)
 
 const LogsLimit int = 50
 const KeyValuesLimit int = 50
+const LogPatternsLimit int = 50
+const MaxLogPatternsLimit int = 1000
+
+// logPatternCandidatesFactor is the number of most frequent templates read per returned pattern,
+// as similar templates are clustered into a single pattern.
+const logPatternCandidatesFactor = 10
+const maxLogPatternCandidates = 5000
 
 const OrderBackwardNatural = "Timestamp ASC, UUID ASC"
 const OrderForwardNatural = "Timestamp DESC, UUID DESC"
@@ -176,6 +200,10 @@ func (client *Client) ReadLogs(ctx context.Context, projectID int, params modelI
 		}, nil
 	}
 
+	if err := parser.ValidateQuery(params.Query, LogsTableConfig); err != nil {
+		return nil, err
+	}
+
 	conn, err := readObjects(ctx, client, LogsTableConfig, logsSamplingTableConfig, projectID, params, pagination, scanLog)
 	if err != nil {
 		return nil, err
@@ -289,6 +317,107 @@ func (client *Client) ReadLogsTotalCount(ctx context.Context, projectID int, par
 	return count, err
 }
 
+// ReadLogPatterns returns the most frequent patterns of the logs matching the query, with a sample body of each.
+// Templates of the most frequent pattern ids are clustered by similarity, so a pattern may cover several ids.
+func (client *Client) ReadLogPatterns(ctx context.Context, projectID int, params modelInputs.QueryInput, limit *int) ([]*modelInputs.LogPattern, error) {
+	if err := parser.ValidateQuery(params.Query, LogsTableConfig); err != nil {
+		return nil, err
+	}
+
+	patternsLimit := min(max(pointy.IntValue(limit, LogPatternsLimit), 1), MaxLogPatternsLimit)
+
+	sb, _, err := makeSelectBuilder(
+		LogsTableConfig,
+		[]string{"PatternId", "count() AS Count", "any(Body) AS Sample", "min(Timestamp) AS FirstSeen", "max(Timestamp) AS LastSeen"},
+		[]int{projectID},
+		params,
+		Pagination{CountOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	// logs ingested before patterns were assigned have no pattern id
+	sb.Where(sb.NotEqual("PatternId", ""))
+	sb.GroupBy("PatternId")
+	sb.OrderBy("Count DESC", "PatternId")
+	sb.Limit(min(patternsLimit*logPatternCandidatesFactor, maxLogPatternCandidates))
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+
+	rows, err := client.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []logPatternRow
+	for rows.Next() {
+		var result logPatternRow
+		if err := rows.ScanStruct(&result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return clusterLogPatterns(results, patternsLimit), nil
+}
+
+type logPatternRow struct {
+	PatternId string
+	Count     uint64
+	Sample    string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// clusterLogPatterns merges the rows of similar templates into a pattern per cluster and returns the
+// most frequent patterns. Rows must be ordered by count so that a pattern takes the id and sample of its
+// most frequent template.
+func clusterLogPatterns(rows []logPatternRow, limit int) []*modelInputs.LogPattern {
+	miner := logpattern.NewMiner()
+	clusters := map[*logpattern.Cluster]*modelInputs.LogPattern{}
+	patterns := []*modelInputs.LogPattern{}
+	for _, row := range rows {
+		cluster := miner.Add(logpattern.Tokenize(row.Sample))
+		pattern, ok := clusters[cluster]
+		if !ok {
+			pattern = &modelInputs.LogPattern{
+				PatternID: row.PatternId,
+				Sample:    row.Sample,
+				FirstSeen: row.FirstSeen,
+				LastSeen:  row.LastSeen,
+			}
+			clusters[cluster] = pattern
+			patterns = append(patterns, pattern)
+		}
+		pattern.PatternIDs = append(pattern.PatternIDs, row.PatternId)
+		pattern.Count += row.Count
+		if row.FirstSeen.Before(pattern.FirstSeen) {
+			pattern.FirstSeen = row.FirstSeen
+		}
+		if row.LastSeen.After(pattern.LastSeen) {
+			pattern.LastSeen = row.LastSeen
+		}
+	}
+
+	// templates joining a cluster may have widened its template
+	for cluster, pattern := range clusters {
+		pattern.Pattern = cluster.Template()
+	}
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		if patterns[i].Count != patterns[j].Count {
+			return patterns[i].Count > patterns[j].Count
+		}
+		return patterns[i].PatternID < patterns[j].PatternID
+	})
+	if len(patterns) > limit {
+		patterns = patterns[:limit]
+	}
+	return patterns
+}
+
 type number interface {
 	uint64 | float64
 }
@@ -449,7 +578,7 @@ func (client *Client) ReadLogsHistogram(ctx context.Context, projectID int, para
 }
 
 func (client *Client) ReadLogsMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, groupBy []string, nBuckets *int, bucketBy string, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
-	return client.ReadMetrics(ctx, ReadMetricsInput{
+	return client.readSearchMetrics(ctx, ReadMetricsInput{
 		SampleableConfig: LogsSampleableTableConfig,
 		ProjectIDs:       []int{projectID},
 		Params:           params,
@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/highlight-run/highlight/backend/logpattern"
 	"github.com/highlight-run/highlight/backend/parser"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 )
@@ -206,6 +208,88 @@ func TestReadLogsTotalCount(t *testing.T) {
 	assert.Equal(t, uint64(1), count)
 }
 
+func TestReadLogPatterns(t *testing.T) {
+	ctx := context.Background()
+	client, teardown := setupTest(t)
+	defer teardown(t)
+
+	now := time.Now()
+	rows := []*LogRow{
+		NewLogRow(now.Add(-time.Minute), 1, WithBody(ctx, "user 1 logged in")),
+		NewLogRow(now, 1, WithBody(ctx, "user 2 logged in")),
+		NewLogRow(now, 1, WithBody(ctx, "user 3 logged in"), WithServiceName("auth")),
+		NewLogRow(now, 1, WithBody(ctx, "cache miss for 0xdeadbeef1")),
+		NewLogRow(now.Add(-48*time.Hour), 1, WithBody(ctx, "cache miss for 0xdeadbeef2")), // out of range
+		NewLogRow(now, 1, WithBody(ctx, "ingested before patterns")),
+	}
+	for _, row := range rows[:len(rows)-1] {
+		row.PatternId = logpattern.ID(row.Body)
+	}
+	assert.NoError(t, client.BatchWriteLogRows(ctx, rows))
+
+	patterns, err := client.ReadLogPatterns(ctx, 1, modelInputs.QueryInput{
+		DateRange: makeDateWithinRange(now),
+	}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, patterns, 2)
+
+	assert.Equal(t, logpattern.ID("user 1 logged in"), patterns[0].PatternID)
+	assert.Equal(t, "user <num> logged in", patterns[0].Pattern)
+	assert.Equal(t, []string{logpattern.ID("user 1 logged in")}, patterns[0].PatternIDs)
+	assert.Equal(t, uint64(3), patterns[0].Count)
+	assert.Equal(t, "user <num> logged in", logpattern.Template(patterns[0].Sample))
+	assert.Equal(t, now.Add(-time.Minute).Truncate(time.Second).Unix(), patterns[0].FirstSeen.Unix())
+	assert.Equal(t, now.Truncate(time.Second).Unix(), patterns[0].LastSeen.Unix())
+
+	assert.Equal(t, "cache miss for <hex>", patterns[1].Pattern)
+	assert.Equal(t, uint64(1), patterns[1].Count)
+
+	patterns, err = client.ReadLogPatterns(ctx, 1, modelInputs.QueryInput{
+		DateRange: makeDateWithinRange(now),
+		Query:     "service_name:auth",
+	}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, patterns, 1)
+	assert.Equal(t, uint64(1), patterns[0].Count)
+
+	patterns, err = client.ReadLogPatterns(ctx, 1, modelInputs.QueryInput{
+		DateRange: makeDateWithinRange(now),
+		Query:     "pattern_id:" + logpattern.ID("cache miss for 0x1"),
+	}, ptr.Int(1))
+	assert.NoError(t, err)
+	assert.Len(t, patterns, 1)
+	assert.Equal(t, "cache miss for <hex>", patterns[0].Pattern)
+}
+
+func TestClusterLogPatterns(t *testing.T) {
+	now := time.Now()
+	rows := []logPatternRow{
+		{PatternId: "a", Count: 5, Sample: "user alice logged in", FirstSeen: now.Add(-time.Hour), LastSeen: now},
+		{PatternId: "b", Count: 4, Sample: "cache miss for 0xdeadbeef", FirstSeen: now, LastSeen: now},
+		{PatternId: "c", Count: 3, Sample: "user bob logged in", FirstSeen: now.Add(-2 * time.Hour), LastSeen: now.Add(-time.Hour)},
+		{PatternId: "d", Count: 1, Sample: "connection reset by peer", FirstSeen: now, LastSeen: now},
+	}
+
+	patterns := clusterLogPatterns(rows, 10)
+	assert.Len(t, patterns, 3)
+
+	assert.Equal(t, "a", patterns[0].PatternID)
+	assert.Equal(t, []string{"a", "c"}, patterns[0].PatternIDs)
+	assert.Equal(t, "user <*> logged in", patterns[0].Pattern)
+	assert.Equal(t, uint64(8), patterns[0].Count)
+	assert.Equal(t, "user alice logged in", patterns[0].Sample)
+	assert.Equal(t, now.Add(-2*time.Hour), patterns[0].FirstSeen)
+	assert.Equal(t, now, patterns[0].LastSeen)
+
+	assert.Equal(t, "b", patterns[1].PatternID)
+	assert.Equal(t, "cache miss for <hex>", patterns[1].Pattern)
+	assert.Equal(t, "d", patterns[2].PatternID)
+
+	patterns = clusterLogPatterns(rows, 1)
+	assert.Len(t, patterns, 1)
+	assert.Equal(t, "a", patterns[0].PatternID)
+}
+
 func TestReadLogsHistogram(t *testing.T) {
 	ctx := context.Background()
 	client, teardown := setupTest(t)
@@ -1337,6 +1421,68 @@ func Test_LogMatchesQuery(t *testing.T) {
 	assert.False(t, matches)
 }
 
+func Test_LogMatchesQuery_InCIDRRelativeTime(t *testing.T) {
+	logRow := LogRow{
+		Timestamp:   time.Now().Add(-10 * time.Minute),
+		ServiceName: "private-graph",
+		LogAttributes: map[string]string{
+			"client_ip":  "10.1.2.3",
+			"status":     "404",
+			"subnet":     "10.0.0.0/8",
+			"updated_at": time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339),
+			"created_at": strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10),
+		},
+	}
+
+	for query, expected := range map[string]bool{
+		"status IN (200, 404)":                      true,
+		"status IN (200, 500)":                      false,
+		"service_name in (public-graph, private-*)": true,
+		"client_ip=10.0.0.0/8":                      true,
+		"client_ip=192.168.0.0/16":                  false,
+		"client_ip!=192.168.0.0/16":                 true,
+		"status=10.0.0.0/8":                         false,
+		"subnet=10.0.0.0/8":                         true,
+		"timestamp>now-15m":                         true,
+		"timestamp>now-5m":                          false,
+		"timestamp<=now timestamp>=now-1d":          true,
+		"updated_at>now-15m":                        true,
+		"updated_at>now-5m":                         false,
+		"created_at<now-30m":                        true,
+		"created_at>now-30m":                        false,
+		"status>now-1d":                             false,
+	} {
+		filters := parser.Parse(query, LogsTableConfig)
+		assert.Equal(t, expected, LogMatchesQuery(&logRow, filters), query)
+	}
+}
+
+func Test_LogMatchesQuery_PatternID(t *testing.T) {
+	logRow := LogRow{Body: "user 42 logged in", PatternId: logpattern.ID("user 42 logged in")}
+
+	filters := parser.Parse("pattern_id:"+logpattern.ID("user 7 logged in"), LogsTableConfig)
+	assert.True(t, LogMatchesQuery(&logRow, filters))
+
+	filters = parser.Parse("pattern_id:"+logpattern.ID("user 7 logged out"), LogsTableConfig)
+	assert.False(t, LogMatchesQuery(&logRow, filters))
+}
+
+func Test_LogMatchesQuery_RangeOperators(t *testing.T) {
+	logRow := LogRow{LogAttributes: map[string]string{"status": "500"}}
+
+	// numeric attribute values are compared numerically, as in the clickhouse query
+	assert.True(t, LogMatchesQuery(&logRow, parser.Parse("status:500", LogsTableConfig)))
+	assert.True(t, LogMatchesQuery(&logRow, parser.Parse("status>=500", LogsTableConfig)))
+	assert.True(t, LogMatchesQuery(&logRow, parser.Parse("status>400", LogsTableConfig)))
+	assert.False(t, LogMatchesQuery(&logRow, parser.Parse("status<500", LogsTableConfig)))
+	assert.False(t, LogMatchesQuery(&logRow, parser.Parse("status>=501", LogsTableConfig)))
+
+	// non-numeric attribute values match exactly
+	logRow = LogRow{LogAttributes: map[string]string{"status": "error"}}
+	assert.True(t, LogMatchesQuery(&logRow, parser.Parse("status>=error", LogsTableConfig)))
+	assert.False(t, LogMatchesQuery(&logRow, parser.Parse("status>400", LogsTableConfig)))
+}
+
 func Test_LogMatchesQuery_Body(t *testing.T) {
 	for _, body := range []string{
 		"hello world a test",
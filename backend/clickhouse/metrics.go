@@ -3,11 +3,13 @@ package clickhouse
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser/listener"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/openlyinc/pointy"
@@ -32,6 +34,26 @@ var reservedMetricsKeys = lo.Map(modelInputs.AllReservedMetricKey, func(key mode
 	return string(key)
 })
 
+var numericMetricsKeys = map[string]bool{
+	string(modelInputs.ReservedMetricKeyCount):          true,
+	string(modelInputs.ReservedMetricKeyMax):            true,
+	string(modelInputs.ReservedMetricKeyMin):            true,
+	string(modelInputs.ReservedMetricKeyRetentionDays):  true,
+	string(modelInputs.ReservedMetricKeyStartTimestamp): true,
+	string(modelInputs.ReservedMetricKeySum):            true,
+	string(modelInputs.ReservedMetricKeyTimestamp):      true,
+	string(modelInputs.ReservedMetricKeyValue):          true,
+}
+
+var timeMetricsKeys = map[string]bool{
+	string(modelInputs.ReservedMetricKeyStartTimestamp): true,
+	string(modelInputs.ReservedMetricKeyTimestamp):      true,
+}
+
+// metricValueColumn is the expression of the value key, the average of a data point. It is also the key of value
+// filters once parsed.
+const metricValueColumn = "Sum / Count"
+
 var metricsKeysToColumns = map[string]string{
 	string(modelInputs.ReservedMetricKeyServiceName):       "ServiceName",
 	string(modelInputs.ReservedMetricKeyMetricName):        "MetricName",
@@ -40,7 +62,7 @@ var metricsKeysToColumns = map[string]string{
 	string(modelInputs.ReservedMetricKeyMetricDescription): "MetricDescription",
 	string(modelInputs.ReservedMetricKeyMetricUnit):        "MetricUnit",
 	string(modelInputs.ReservedMetricKeyStartTimestamp):    "StartTimestamp",
-	string(modelInputs.ReservedMetricKeyValue):             "Sum / Count",
+	string(modelInputs.ReservedMetricKeyValue):             metricValueColumn,
 	string(modelInputs.ReservedMetricKeySecureSessionID):   "Exemplars.SecureSessionID",
 	string(modelInputs.ReservedMetricKeyTraceID):           "Exemplars.TraceID",
 	string(modelInputs.ReservedMetricKeySpanID):            "Exemplars.SpanID",
@@ -83,6 +105,8 @@ var MetricsTableNoDefaultConfig = model.TableConfig{
 	KeysToColumns:     metricsKeysToColumns,
 	ArrayColumns:      metricsArrayColumns,
 	ReservedKeys:      reservedMetricsKeys,
+	NumericKeys:       numericMetricsKeys,
+	TimeKeys:          timeMetricsKeys,
 	BodyColumn:        "MetricName",
 	SelectColumns:     metricsColumns,
 	AttributesColumns: []model.ColumnMapping{{Column: "Attributes"}},
@@ -93,6 +117,8 @@ var MetricsTableConfig = model.TableConfig{
 	KeysToColumns:     MetricsTableNoDefaultConfig.KeysToColumns,
 	ArrayColumns:      MetricsTableNoDefaultConfig.ArrayColumns,
 	ReservedKeys:      MetricsTableNoDefaultConfig.ReservedKeys,
+	NumericKeys:       MetricsTableNoDefaultConfig.NumericKeys,
+	TimeKeys:          MetricsTableNoDefaultConfig.TimeKeys,
 	BodyColumn:        MetricsTableNoDefaultConfig.BodyColumn,
 	AttributesColumns: MetricsTableNoDefaultConfig.AttributesColumns,
 	SelectColumns:     MetricsTableNoDefaultConfig.SelectColumns,
@@ -195,6 +221,107 @@ func (m *MetricSummaryRow) GetTimestamp() time.Time {
 	return m.Timestamp
 }
 
+// metricMatchRow flattens the metric row types so that they can be matched by a query.
+// Its fields correspond to the columns of the metrics table that queries are parsed into.
+type metricMatchRow struct {
+	ServiceName       string
+	ServiceVersion    string
+	MetricName        string
+	MetricDescription string
+	MetricUnit        string
+	Type              string
+	Value             float64
+	Min               float64
+	Max               float64
+	Count             uint64
+	Sum               float64
+	Attributes        map[string]string
+}
+
+// metricsMatchConfig maps the keys of filters parsed with MetricsTableConfig to the fields of metricMatchRow.
+// Value filters are parsed to the value expression, which the row holds as a field.
+var metricsMatchConfig = model.TableConfig{
+	TableName: MetricsTable,
+	KeysToColumns: map[string]string{
+		metricValueColumn: "Value",
+		string(modelInputs.ReservedMetricKeyServiceVersion): "ServiceVersion",
+		string(modelInputs.ReservedMetricKeyMin):            "Min",
+		string(modelInputs.ReservedMetricKeyMax):            "Max",
+		string(modelInputs.ReservedMetricKeyCount):          "Count",
+		string(modelInputs.ReservedMetricKeySum):            "Sum",
+	},
+	// the values compared numerically by range operators
+	NumericKeys: map[string]bool{
+		metricValueColumn:                          true,
+		string(modelInputs.ReservedMetricKeyMin):   true,
+		string(modelInputs.ReservedMetricKeyMax):   true,
+		string(modelInputs.ReservedMetricKeyCount): true,
+		string(modelInputs.ReservedMetricKeySum):   true,
+	},
+	BodyColumn:        MetricsTableConfig.BodyColumn,
+	AttributesColumns: MetricsTableConfig.AttributesColumns,
+}
+
+func getMetricBaseRow(metric MetricRow) *MetricBaseRow {
+	switch m := metric.(type) {
+	case *MetricSumRow:
+		return &m.MetricBaseRow
+	case *MetricHistogramRow:
+		return &m.MetricBaseRow
+	case *MetricSummaryRow:
+		return &m.MetricBaseRow
+	}
+	return &MetricBaseRow{}
+}
+
+// MetricSeriesKey identifies the series of a metric data point by its project, service, name and attributes.
+func MetricSeriesKey(metric MetricRow) string {
+	base := getMetricBaseRow(metric)
+	keys := lo.Keys(base.Attributes)
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d-%s-%s", base.ProjectId, base.ServiceName, base.MetricName))
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("-%s=%s", k, base.Attributes[k]))
+	}
+	return sb.String()
+}
+
+func newMetricMatchRow(metric MetricRow) *metricMatchRow {
+	base := getMetricBaseRow(metric)
+	row := &metricMatchRow{Type: metric.GetType().String()}
+	switch m := metric.(type) {
+	case *MetricSumRow:
+		row.Value, row.Min, row.Max, row.Sum, row.Count = m.Value, m.Value, m.Value, m.Value, 1
+	case *MetricHistogramRow:
+		row.Min, row.Max, row.Sum, row.Count = m.Min, m.Max, m.Sum, m.Count
+		if m.Count > 0 {
+			row.Value = m.Sum / float64(m.Count)
+		}
+	case *MetricSummaryRow:
+		row.Sum, row.Count = m.Sum, m.Count
+		if m.Count > 0 {
+			row.Value = m.Sum / float64(m.Count)
+		}
+		if len(m.ValueAtQuantilesValue) > 0 {
+			row.Min, row.Max = lo.Min(m.ValueAtQuantilesValue), lo.Max(m.ValueAtQuantilesValue)
+		}
+	}
+	row.ServiceName = base.ServiceName
+	row.ServiceVersion = base.ServiceVersion
+	row.MetricName = base.MetricName
+	row.MetricDescription = base.MetricDescription
+	row.MetricUnit = base.MetricUnit
+	row.Attributes = base.Attributes
+	return row
+}
+
+// MetricMatchesQuery evaluates filters parsed with MetricsTableConfig against a metric row.
+// The value of a histogram or summary is its average, matching the `value` key of metrics queries.
+func MetricMatchesQuery(metric MetricRow, filters listener.Filters) bool {
+	return matchesQuery(newMetricMatchRow(metric), metricsMatchConfig, filters, listener.OperatorAnd)
+}
+
 func (client *Client) BatchWriteMetricRows(ctx context.Context, metricRows []MetricRow) error {
 	for table, rows := range map[string][]MetricRow{
 		MetricsSumTable: lo.Filter(metricRows, func(item MetricRow, _ int) bool {
@@ -264,7 +391,7 @@ func (client *Client) ReadMetricsDailyAverage(ctx context.Context, projectIds []
 }
 
 func (client *Client) ReadMetricsAggregated(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, groupBy []string, nBuckets *int, bucketBy string, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
-	return client.ReadMetrics(ctx, ReadMetricsInput{
+	return client.readSearchMetrics(ctx, ReadMetricsInput{
 		SampleableConfig: MetricsSampleableTableConfig,
 		ProjectIDs:       []int{projectID},
 		Params:           params,
@@ -0,0 +1,61 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/parser"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_MetricMatchesQuery(t *testing.T) {
+	sum := &MetricSumRow{
+		MetricBaseRow: MetricBaseRow{
+			ServiceName: "api",
+			MetricName:  "http.server.duration",
+			Attributes: map[string]string{
+				"http.route": "/healthz",
+			},
+			MetricType: pmetric.MetricTypeSum,
+		},
+		Value: 12.5,
+	}
+	histogram := &MetricHistogramRow{
+		MetricBaseRow: MetricBaseRow{
+			ServiceName: "worker",
+			MetricName:  "kafka.process.latency",
+			MetricType:  pmetric.MetricTypeHistogram,
+		},
+		Count: 4,
+		Sum:   100,
+		Min:   5,
+		Max:   50,
+	}
+
+	for query, expected := range map[string][2]bool{
+		"":                    {true, true},
+		"service_name:api":    {true, false},
+		"metric_name:http.*":  {true, false},
+		"http.route:/healthz": {true, false},
+		"http.route:/healthz OR service_name:worker": {true, true},
+		"value>20":                    {false, true},
+		"value<=12.5":                 {true, false},
+		"max>=50 service_name:worker": {false, true},
+		"count>1":                     {false, true},
+		"NOT value>20":                {true, false},
+		"type:Histogram":              {false, true},
+		"service_name:other":          {false, false},
+	} {
+		filters := parser.Parse(query, MetricsTableConfig)
+		assert.Equal(t, expected[0], MetricMatchesQuery(sum, filters), "sum %s", query)
+		assert.Equal(t, expected[1], MetricMatchesQuery(histogram, filters), "histogram %s", query)
+	}
+}
+
+func Test_MetricSeriesKey(t *testing.T) {
+	a := &MetricSumRow{MetricBaseRow: MetricBaseRow{ProjectId: 1, MetricName: "cpu", Attributes: map[string]string{"host": "a", "region": "us"}}, Value: 1}
+	b := &MetricSumRow{MetricBaseRow: MetricBaseRow{ProjectId: 1, MetricName: "cpu", Attributes: map[string]string{"region": "us", "host": "a"}}, Value: 2}
+	c := &MetricSumRow{MetricBaseRow: MetricBaseRow{ProjectId: 1, MetricName: "cpu", Attributes: map[string]string{"host": "b", "region": "us"}}, Value: 1}
+	assert.Equal(t, MetricSeriesKey(a), MetricSeriesKey(b))
+	assert.NotEqual(t, MetricSeriesKey(a), MetricSeriesKey(c))
+}
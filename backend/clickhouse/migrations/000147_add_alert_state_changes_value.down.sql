@@ -0,0 +1 @@
+ALTER TABLE alert_state_changes DROP COLUMN IF EXISTS Value;
@@ -0,0 +1 @@
+ALTER TABLE alert_state_changes ADD COLUMN IF NOT EXISTS Value Nullable(Float64);
@@ -0,0 +1,2 @@
+alter table logs
+    drop column PatternId;
\ No newline at end of file
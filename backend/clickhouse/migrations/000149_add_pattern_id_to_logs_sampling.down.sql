@@ -0,0 +1,2 @@
+alter table logs_sampling
+    drop column PatternId;
\ No newline at end of file
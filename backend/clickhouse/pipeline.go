@@ -0,0 +1,186 @@
+package clickhouse
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/highlight-run/highlight/backend/parser"
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/samber/lo"
+)
+
+// applyPipeline compiles the stats or timechart stage of a search query pipeline into the metrics input,
+// replacing the requested expressions and grouping.
+func applyPipeline(input ReadMetricsInput, pipeline *parser.Pipeline) ReadMetricsInput {
+	input.Expressions = lo.Map(pipeline.Aggregations, func(a parser.PipelineAggregation, _ int) *modelInputs.MetricExpressionInput {
+		return &modelInputs.MetricExpressionInput{Aggregator: a.Aggregator, Column: a.Column}
+	})
+	input.GroupBy = pipeline.GroupBy
+	input.Limit, input.LimitAggregator, input.LimitColumn = pipelineGroupLimit(pipeline)
+
+	if pipeline.Timechart {
+		input.BucketBy = modelInputs.MetricBucketByTimestamp.String()
+		if pipeline.Span != nil {
+			input.BucketWindow = pipeline.Span
+		}
+	} else {
+		input.BucketBy = modelInputs.MetricBucketByNone.String()
+		input.BucketWindow = nil
+	}
+	return input
+}
+
+// pipelineGroupsLimit caps the groups read for a pipeline whose head stage can't be applied by the query.
+const pipelineGroupsLimit = 1000
+
+// pipelineGroupLimit returns the top groups to read for a pipeline. A head stage, optionally after a descending
+// sort on a stats aggregation, is applied by the query. Otherwise the groups with the most rows are read
+// so that high cardinality groupings are capped before the where and sort stages are applied.
+func pipelineGroupLimit(pipeline *parser.Pipeline) (*int, *modelInputs.MetricAggregator, *string) {
+	limit := pipelineGroupsLimit
+	aggregator := modelInputs.MetricAggregatorCount
+	var column *string
+
+	var sorted *parser.PipelineAggregation
+	for _, step := range pipeline.Steps {
+		if step.Command == parser.PipelineCommandHead {
+			if step.Limit < limit {
+				limit = step.Limit
+				if sorted != nil {
+					aggregator, column = sorted.Aggregator, &sorted.Column
+				}
+			}
+			break
+		}
+		if step.Command != parser.PipelineCommandSort || sorted != nil || pipeline.Timechart ||
+			len(step.Sort) != 1 || !step.Sort[0].Descending || pipeline.AggregationIndex(step.Sort[0].Field) == -1 {
+			// the groups kept by the head stage depend on the aggregated values
+			break
+		}
+		sorted = &pipeline.Aggregations[pipeline.AggregationIndex(step.Sort[0].Field)]
+	}
+	return &limit, &aggregator, column
+}
+
+// pipelineSeries is the buckets of one group of the aggregated rows.
+type pipelineSeries struct {
+	group   []string
+	buckets []*modelInputs.MetricBucket
+	// values of each aggregation, the peak across buckets for a timechart
+	values []*float64
+}
+
+func (s *pipelineSeries) field(pipeline *parser.Pipeline, field string) (*float64, string) {
+	if idx := pipeline.AggregationIndex(field); idx != -1 {
+		return s.values[idx], ""
+	}
+	if idx := lo.IndexOf(pipeline.GroupBy, field); idx != -1 && idx < len(s.group) {
+		return nil, s.group[idx]
+	}
+	return nil, ""
+}
+
+// applyPipelineSteps applies the where, sort and head stages of the pipeline to the aggregated rows,
+// keeping the buckets of each group together in the resulting order.
+func applyPipelineSteps(metrics *modelInputs.MetricsBuckets, pipeline *parser.Pipeline) *modelInputs.MetricsBuckets {
+	if len(pipeline.Aggregations) == 0 {
+		return metrics
+	}
+
+	var series []*pipelineSeries
+	byGroup := map[string]*pipelineSeries{}
+	for _, bucket := range metrics.Buckets {
+		key := strings.Join(bucket.Group, "\x00")
+		s, ok := byGroup[key]
+		if !ok {
+			s = &pipelineSeries{group: bucket.Group, values: make([]*float64, len(pipeline.Aggregations))}
+			byGroup[key] = s
+			series = append(series, s)
+		}
+		s.buckets = append(s.buckets, bucket)
+
+		// buckets are read with one bucket per expression, which the same aggregation given twice shares
+		for aggregation, a := range pipeline.Aggregations {
+			if bucket.MetricType != a.Aggregator || bucket.Column != a.Column || bucket.MetricValue == nil {
+				continue
+			}
+			if s.values[aggregation] == nil || *bucket.MetricValue > *s.values[aggregation] {
+				s.values[aggregation] = bucket.MetricValue
+			}
+		}
+	}
+
+	for _, step := range pipeline.Steps {
+		switch step.Command {
+		case parser.PipelineCommandWhere:
+			series = lo.Filter(series, func(s *pipelineSeries, _ int) bool {
+				value, _ := s.field(pipeline, step.Condition.Field)
+				return value != nil && compareFloats(*value, step.Condition.Value, step.Condition.Op)
+			})
+		case parser.PipelineCommandSort:
+			sort.SliceStable(series, func(i, j int) bool {
+				for _, order := range step.Sort {
+					cmp := compareSeries(pipeline, series[i], series[j], order.Field)
+					if cmp == 0 {
+						continue
+					}
+					if order.Descending {
+						return cmp > 0
+					}
+					return cmp < 0
+				}
+				return false
+			})
+		case parser.PipelineCommandHead:
+			if len(series) > step.Limit {
+				series = series[:step.Limit]
+			}
+		}
+	}
+
+	metrics.Buckets = lo.FlatMap(series, func(s *pipelineSeries, _ int) []*modelInputs.MetricBucket {
+		return s.buckets
+	})
+	return metrics
+}
+
+// compareSeries orders series by a field, with missing values lowest.
+func compareSeries(pipeline *parser.Pipeline, a *pipelineSeries, b *pipelineSeries, field string) int {
+	aValue, aGroup := a.field(pipeline, field)
+	bValue, bGroup := b.field(pipeline, field)
+	if pipeline.AggregationIndex(field) == -1 {
+		return strings.Compare(aGroup, bGroup)
+	}
+	switch {
+	case aValue == nil && bValue == nil:
+		return 0
+	case aValue == nil:
+		return -1
+	case bValue == nil:
+		return 1
+	case *aValue < *bValue:
+		return -1
+	case *aValue > *bValue:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a float64, b float64, op listener.Operator) bool {
+	switch op {
+	case listener.OperatorEqual:
+		return a == b
+	case listener.OperatorNotEqual:
+		return a != b
+	case listener.OperatorGreaterThan:
+		return a > b
+	case listener.OperatorGreaterThanOrEqualTo:
+		return a >= b
+	case listener.OperatorLessThan:
+		return a < b
+	case listener.OperatorLessThanOrEqualTo:
+		return a <= b
+	}
+	return false
+}
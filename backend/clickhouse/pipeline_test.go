@@ -0,0 +1,109 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/parser"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApplyPipeline(t *testing.T) {
+	pipeline, err := parser.ParsePipeline("level=error | stats count(), p95(duration) by service_name | head 5")
+	assert.NoError(t, err)
+
+	limitAggregator := modelInputs.MetricAggregatorCount
+	input := applyPipeline(ReadMetricsInput{
+		BucketBy:        modelInputs.MetricBucketByTimestamp.String(),
+		BucketWindow:    ptr.Int(60),
+		GroupBy:         []string{"level"},
+		Limit:           ptr.Int(3),
+		LimitAggregator: &limitAggregator,
+	}, pipeline)
+	assert.Equal(t, []*modelInputs.MetricExpressionInput{
+		{Aggregator: modelInputs.MetricAggregatorCount},
+		{Aggregator: modelInputs.MetricAggregatorP95, Column: "duration"},
+	}, input.Expressions)
+	assert.Equal(t, []string{"service_name"}, input.GroupBy)
+	assert.Equal(t, modelInputs.MetricBucketByNone.String(), input.BucketBy)
+	assert.Nil(t, input.BucketWindow)
+	assert.Equal(t, 5, *input.Limit)
+	assert.Equal(t, modelInputs.MetricAggregatorCount, *input.LimitAggregator)
+	assert.Nil(t, input.LimitColumn)
+
+	pipeline, err = parser.ParsePipeline("| timechart span=1h count()")
+	assert.NoError(t, err)
+	input = applyPipeline(ReadMetricsInput{}, pipeline)
+	assert.Equal(t, modelInputs.MetricBucketByTimestamp.String(), input.BucketBy)
+	assert.Equal(t, 3600, *input.BucketWindow)
+}
+
+func Test_ApplyPipelineGroupLimit(t *testing.T) {
+	for query, expected := range map[string]struct {
+		limit      int
+		aggregator modelInputs.MetricAggregator
+		column     *string
+	}{
+		"| stats count() by service_name":                                               {pipelineGroupsLimit, modelInputs.MetricAggregatorCount, nil},
+		"| stats count() by service_name | head 3":                                      {3, modelInputs.MetricAggregatorCount, nil},
+		"| stats count(), p95(duration) by service_name | sort -p95(duration) | head 3": {3, modelInputs.MetricAggregatorP95, ptr.String("duration")},
+		"| stats count(), p95(duration) by service_name | sort p95(duration) | head 3":  {pipelineGroupsLimit, modelInputs.MetricAggregatorCount, nil},
+		"| stats count() by service_name | where count > 10 | head 3":                   {pipelineGroupsLimit, modelInputs.MetricAggregatorCount, nil},
+		"| stats count() by service_name | sort -service_name | head 3":                 {pipelineGroupsLimit, modelInputs.MetricAggregatorCount, nil},
+		"| timechart max(duration) by service_name | sort -max(duration) | head 3":      {pipelineGroupsLimit, modelInputs.MetricAggregatorCount, nil},
+	} {
+		pipeline, err := parser.ParsePipeline(query)
+		assert.NoError(t, err)
+		input := applyPipeline(ReadMetricsInput{}, pipeline)
+		assert.Equal(t, expected.limit, *input.Limit, query)
+		assert.Equal(t, expected.aggregator, *input.LimitAggregator, query)
+		assert.Equal(t, expected.column, input.LimitColumn, query)
+	}
+}
+
+func Test_ApplyPipelineSteps(t *testing.T) {
+	bucket := func(group string, aggregator modelInputs.MetricAggregator, value *float64) *modelInputs.MetricBucket {
+		b := &modelInputs.MetricBucket{Group: []string{group}, MetricType: aggregator, MetricValue: value}
+		if aggregator == modelInputs.MetricAggregatorP95 {
+			b.Column = "duration"
+		}
+		return b
+	}
+	metrics := func() *modelInputs.MetricsBuckets {
+		return &modelInputs.MetricsBuckets{Buckets: []*modelInputs.MetricBucket{
+			bucket("api", modelInputs.MetricAggregatorCount, ptr.Float64(10)),
+			bucket("api", modelInputs.MetricAggregatorP95, ptr.Float64(200)),
+			// buckets of a group are matched to the aggregations whatever their order
+			bucket("worker", modelInputs.MetricAggregatorP95, ptr.Float64(50)),
+			bucket("worker", modelInputs.MetricAggregatorCount, ptr.Float64(30)),
+			bucket("frontend", modelInputs.MetricAggregatorCount, ptr.Float64(20)),
+			bucket("frontend", modelInputs.MetricAggregatorP95, nil),
+			bucket("cron", modelInputs.MetricAggregatorCount, ptr.Float64(1)),
+			bucket("cron", modelInputs.MetricAggregatorP95, ptr.Float64(900)),
+		}}
+	}
+	groups := func(metrics *modelInputs.MetricsBuckets) []string {
+		var groups []string
+		for _, b := range metrics.Buckets {
+			if len(groups) == 0 || groups[len(groups)-1] != b.Group[0] {
+				groups = append(groups, b.Group[0])
+			}
+		}
+		return groups
+	}
+
+	for query, expected := range map[string][]string{
+		"| stats count(), p95(duration) by service_name":                                            {"api", "worker", "frontend", "cron"},
+		"| stats count(), p95(duration) by service_name | sort -count | head 2":                     {"worker", "frontend"},
+		"| stats count(), p95(duration) by service_name | sort service_name":                        {"api", "cron", "frontend", "worker"},
+		"| stats count(), p95(duration) by service_name | where count >= 10 | sort -p95(duration)":  {"api", "worker", "frontend"},
+		"| stats count() as c, p95(duration) by service_name | where c < 20 | head":                 {"api", "cron"},
+		"| stats p95(duration), count() by service_name | sort -count | head 1":                     {"worker"},
+		"| stats count(), count() as c, p95(duration) by service_name | where c > 15 | sort -count": {"worker", "frontend"},
+	} {
+		pipeline, err := parser.ParsePipeline(query)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, groups(applyPipelineSteps(metrics(), pipeline)), query)
+	}
+}
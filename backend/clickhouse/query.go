@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
 	"sort"
@@ -66,6 +67,26 @@ type ReadMetricsInput struct {
 	Expressions        []*modelInputs.MetricExpressionInput
 }
 
+// GetSearchTableConfig returns the table config used to search the product type.
+func GetSearchTableConfig(productType modelInputs.ProductType) (model.TableConfig, error) {
+	switch productType {
+	case modelInputs.ProductTypeErrors:
+		return ErrorsSampleableTableConfig.tableConfig, nil
+	case modelInputs.ProductTypeLogs:
+		return LogsSampleableTableConfig.tableConfig, nil
+	case modelInputs.ProductTypeSessions:
+		return SessionsSampleableTableConfig.tableConfig, nil
+	case modelInputs.ProductTypeMetrics:
+		return MetricsSampleableTableConfig.tableConfig, nil
+	case modelInputs.ProductTypeTraces:
+		return TracesSampleableTableConfig.tableConfig, nil
+	case modelInputs.ProductTypeEvents:
+		return EventsSampleableTableConfig.tableConfig, nil
+	default:
+		return model.TableConfig{}, e.Errorf("unknown product type: %s", productType)
+	}
+}
+
 func readObjects[TObj interface{}](ctx context.Context, client *Client, config model.TableConfig, samplingConfig model.TableConfig, projectID int, params modelInputs.QueryInput, pagination Pagination, scanObject func(driver.Rows) (*Edge[TObj], error)) (*Connection[TObj], error) {
 	limit := LogsLimit
 	if pagination.Limit != nil {
@@ -1209,6 +1230,12 @@ func matchFilter[TObj interface{}](row *TObj, config model.TableConfig, filter *
 					return false, nil
 				}
 			}
+		} else if filter.Operator == listener.OperatorIPInRange {
+			_, ipNet, err := net.ParseCIDR(v)
+			ip := net.ParseIP(rowValue)
+			if err != nil || ip == nil || !ipNet.Contains(ip) {
+				return false, nil
+			}
 		} else if strings.Contains(v, "%") {
 			if matched, _ := regexp.Match(strings.ReplaceAll(v, "%", ".*"), []byte(rowValue)); !matched {
 				return false, nil
@@ -1217,6 +1244,20 @@ func matchFilter[TObj interface{}](row *TObj, config model.TableConfig, filter *
 			if rowValue == strings.Replace(v, "-", "", 1) {
 				return false, nil
 			}
+		} else if isComparisonOperator(filter.Operator) {
+			// like the clickhouse query, values are compared numerically when both are numbers.
+			// other values of numeric keys never match, while other attribute values match exactly.
+			if matched, numeric := compareValues(rowValue, v, filter.Operator); numeric {
+				if !matched {
+					return false, nil
+				}
+			} else if matched, isTime := compareTimes(rowValue, v, filter.Operator); isTime {
+				if !matched {
+					return false, nil
+				}
+			} else if isNumericKey(config, key) || v != rowValue {
+				return false, nil
+			}
 		} else if v != rowValue {
 			return false, nil
 		}
@@ -1224,6 +1265,74 @@ func matchFilter[TObj interface{}](row *TObj, config model.TableConfig, filter *
 	return true, nil
 }
 
+// isNumericKey returns whether a parsed filter key is one of the numeric keys of the table, or the column of one.
+func isNumericKey(config model.TableConfig, key string) bool {
+	for numericKey := range config.NumericKeys {
+		if numericKey == key || config.KeysToColumns[numericKey] == key {
+			return true
+		}
+	}
+	return false
+}
+
+func isComparisonOperator(op listener.Operator) bool {
+	switch op {
+	case listener.OperatorGreaterThan, listener.OperatorGreaterThanOrEqualTo, listener.OperatorLessThan, listener.OperatorLessThanOrEqualTo:
+		return true
+	}
+	return false
+}
+
+// compareValues numerically compares a row value with a filter value.
+// Returns whether the values match and whether both values are numeric.
+func compareValues(rowValue string, filterValue string, op listener.Operator) (bool, bool) {
+	a, err := strconv.ParseFloat(rowValue, 64)
+	if err != nil {
+		return false, false
+	}
+	b, err := strconv.ParseFloat(filterValue, 64)
+	if err != nil {
+		return false, false
+	}
+	return compareNumbers(a, b, op), true
+}
+
+// compareTimes compares an attribute value with a relative time value, resolved by the parser to RFC3339.
+// Like the clickhouse query, attribute values that are not times never match.
+// Returns whether the values match and whether the filter value is a time.
+func compareTimes(rowValue string, filterValue string, op listener.Operator) (bool, bool) {
+	b, err := time.Parse(time.RFC3339, filterValue)
+	if err != nil {
+		return false, false
+	}
+	a, err := time.Parse(time.RFC3339Nano, rowValue)
+	if err != nil {
+		a, err = time.Parse(time.DateTime, rowValue)
+	}
+	if err != nil {
+		seconds, err := strconv.ParseInt(rowValue, 10, 64)
+		if err != nil {
+			return false, true
+		}
+		a = time.Unix(seconds, 0)
+	}
+	return compareNumbers(float64(a.Compare(b)), 0, op), true
+}
+
+func compareNumbers(a float64, b float64, op listener.Operator) bool {
+	switch op {
+	case listener.OperatorGreaterThan:
+		return a > b
+	case listener.OperatorGreaterThanOrEqualTo:
+		return a >= b
+	case listener.OperatorLessThan:
+		return a < b
+	case listener.OperatorLessThanOrEqualTo:
+		return a <= b
+	}
+	return false
+}
+
 func matchesQuery[TObj interface{}](row *TObj, config model.TableConfig, filters listener.Filters, op listener.Operator) bool {
 	// if multiple filters are passed in, assume an AND operation between them
 	for _, filter := range filters {
@@ -1724,6 +1833,28 @@ func getBucketing(config model.TableConfig, input ReadMetricsInput, fromSb *sqlb
 	}
 }
 
+// readSearchMetrics aggregates the rows matching a search query of a dashboard or the query builder.
+// A query with a pipeline such as `level=error | stats count() by service_name | sort -count | head 10`
+// replaces the requested expressions and grouping.
+func (client *Client) readSearchMetrics(ctx context.Context, input ReadMetricsInput) (*modelInputs.MetricsBuckets, error) {
+	if input.Sql != nil {
+		return client.ReadMetrics(ctx, input)
+	}
+
+	pipeline, err := parser.ParsePipeline(input.Params.Query)
+	if err != nil {
+		return nil, err
+	} else if pipeline == nil {
+		return client.ReadMetrics(ctx, input)
+	}
+
+	metrics, err := client.ReadMetrics(ctx, applyPipeline(input, pipeline))
+	if err != nil || metrics == nil {
+		return metrics, err
+	}
+	return applyPipelineSteps(metrics, pipeline), nil
+}
+
 func (client *Client) ReadMetrics(ctx context.Context, input ReadMetricsInput) (*modelInputs.MetricsBuckets, error) {
 	if input.Params.DateRange == nil {
 		input.Params.DateRange = &modelInputs.DateRangeRequiredInput{
@@ -2137,6 +2268,20 @@ func repr(val reflect.Value) string {
 		return repr(val.Elem())
 	case reflect.Bool:
 		return fmt.Sprintf("%t", val.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64)
+	case reflect.Struct:
+		// times are compared as unix seconds, matching resolved relative time values
+		if val.CanInterface() {
+			if t, ok := val.Interface().(time.Time); ok {
+				return strconv.FormatInt(t.Unix(), 10)
+			}
+		}
+		return val.String()
 	default:
 		return val.String()
 	}
@@ -468,6 +468,20 @@ var reservedSessionKeys = lo.Map(modelInputs.AllReservedSessionKey, func(key mod
 	return string(key)
 })
 
+var numericSessionKeys = map[string]bool{
+	string(modelInputs.ReservedSessionKeyActiveLength): true,
+	string(modelInputs.ReservedSessionKeyLength):       true,
+	string(modelInputs.ReservedSessionKeyNormalness):   true,
+	string(modelInputs.ReservedSessionKeyPagesVisited): true,
+	string(modelInputs.ReservedSessionKeyTimestamp):    true,
+	string(modelInputs.ReservedSessionKeyUpdatedAt):    true,
+}
+
+var timeSessionKeys = map[string]bool{
+	string(modelInputs.ReservedSessionKeyTimestamp): true,
+	string(modelInputs.ReservedSessionKeyUpdatedAt): true,
+}
+
 var SessionsJoinedTableConfig = model.TableConfig{
 	TableName:         SessionsJoinedTable,
 	AttributesColumns: []model.ColumnMapping{{Column: "RelevantFields"}},
@@ -508,6 +522,8 @@ var SessionsJoinedTableConfig = model.TableConfig{
 		string(modelInputs.ReservedSessionKeyLocState):  "State",
 	},
 	ReservedKeys: reservedSessionKeys,
+	NumericKeys:  numericSessionKeys,
+	TimeKeys:     timeSessionKeys,
 	IgnoredFilters: map[string]bool{
 		modelInputs.ReservedSessionKeySample.String():     true,
 		modelInputs.ReservedSessionKeyViewedByMe.String(): true,
@@ -520,7 +536,7 @@ var SessionsSampleableTableConfig = SampleableTableConfig{
 }
 
 func (client *Client) ReadSessionsMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, groupBy []string, nBuckets *int, bucketBy string, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
-	return client.ReadMetrics(ctx, ReadMetricsInput{
+	return client.readSearchMetrics(ctx, ReadMetricsInput{
 		SampleableConfig: SessionsSampleableTableConfig,
 		ProjectIDs:       []int{projectID},
 		Params:           params,
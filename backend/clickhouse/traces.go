@@ -8,6 +8,7 @@ import (
 
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
 	"github.com/highlight-run/highlight/backend/parser/listener"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/util"
@@ -112,6 +113,16 @@ var reservedTraceKeys = lo.Map(modelInputs.AllReservedTraceKey, func(key modelIn
 	return string(key)
 })
 
+var numericTraceKeys = map[string]bool{
+	string(modelInputs.ReservedTraceKeyDuration):    true,
+	string(modelInputs.ReservedTraceKeyMetricValue): true,
+	string(modelInputs.ReservedTraceKeyTimestamp):   true,
+}
+
+var timeTraceKeys = map[string]bool{
+	string(modelInputs.ReservedTraceKeyTimestamp): true,
+}
+
 var attributesColumns = []model.ColumnMapping{
 	{Prefix: HttpPrefix, Column: "HttpAttributes"},
 	{Prefix: ProcessPrefix, Column: "ProcessAttributes"},
@@ -127,6 +138,8 @@ var TracesTableNoDefaultConfig = model.TableConfig{
 	TableName:         TracesTable,
 	KeysToColumns:     traceKeysToColumns,
 	ReservedKeys:      reservedTraceKeys,
+	NumericKeys:       numericTraceKeys,
+	TimeKeys:          timeTraceKeys,
 	BodyColumn:        "SpanName",
 	AttributesColumns: attributesColumns,
 	SelectColumns:     traceColumns,
@@ -136,6 +149,8 @@ var TracesTableConfig = model.TableConfig{
 	TableName:         TracesTableNoDefaultConfig.TableName,
 	KeysToColumns:     TracesTableNoDefaultConfig.KeysToColumns,
 	ReservedKeys:      TracesTableNoDefaultConfig.ReservedKeys,
+	NumericKeys:       TracesTableNoDefaultConfig.NumericKeys,
+	TimeKeys:          TracesTableNoDefaultConfig.TimeKeys,
 	BodyColumn:        TracesTableNoDefaultConfig.BodyColumn,
 	AttributesColumns: TracesTableNoDefaultConfig.AttributesColumns,
 	SelectColumns:     TracesTableNoDefaultConfig.SelectColumns,
@@ -147,6 +162,8 @@ var tracesSamplingTableConfig = model.TableConfig{
 	BodyColumn:        TracesTableNoDefaultConfig.BodyColumn,
 	KeysToColumns:     TracesTableNoDefaultConfig.KeysToColumns,
 	ReservedKeys:      TracesTableNoDefaultConfig.ReservedKeys,
+	NumericKeys:       TracesTableNoDefaultConfig.NumericKeys,
+	TimeKeys:          TracesTableNoDefaultConfig.TimeKeys,
 	AttributesColumns: TracesTableNoDefaultConfig.AttributesColumns,
 	SelectColumns:     TracesTableNoDefaultConfig.SelectColumns,
 	DefaultFilter:     TracesTableConfig.DefaultFilter,
@@ -377,6 +394,30 @@ func mergeAttributes(result ClickhouseTraceRow) map[string]string {
 	return allAttributes
 }
 
+// AsTrace returns the GraphQL trace of the row.
+func (row *ClickhouseTraceRow) AsTrace() *modelInputs.Trace {
+	return &modelInputs.Trace{
+		Timestamp:       row.Timestamp,
+		TraceID:         row.TraceId,
+		SpanID:          row.SpanId,
+		ParentSpanID:    row.ParentSpanId,
+		ProjectID:       int(row.ProjectId),
+		SecureSessionID: row.SecureSessionId,
+		TraceState:      row.TraceState,
+		SpanName:        row.SpanName,
+		SpanKind:        row.SpanKind,
+		Duration:        int(row.Duration),
+		ServiceName:     row.ServiceName,
+		ServiceVersion:  row.ServiceVersion,
+		Environment:     row.Environment,
+		HasErrors:       row.HasErrors,
+		TraceAttributes: expandJSON(mergeAttributes(*row)),
+		StatusCode:      row.StatusCode,
+		StatusMessage:   row.StatusMessage,
+		Events:          extractEvents(*row),
+	}
+}
+
 func (client *Client) ReadTraces(ctx context.Context, projectID int, params modelInputs.QueryInput, pagination Pagination) (*modelInputs.TraceConnection, error) {
 	scanTrace := func(rows driver.Rows) (*Edge[modelInputs.Trace], error) {
 		var result ClickhouseTraceRow
@@ -384,33 +425,16 @@ func (client *Client) ReadTraces(ctx context.Context, projectID int, params mode
 			return nil, err
 		}
 
-		allAttributes := mergeAttributes(result)
-
 		return &Edge[modelInputs.Trace]{
 			Cursor: encodeCursor(result.Timestamp, result.UUID),
-			Node: &modelInputs.Trace{
-				Timestamp:       result.Timestamp,
-				TraceID:         result.TraceId,
-				SpanID:          result.SpanId,
-				ParentSpanID:    result.ParentSpanId,
-				ProjectID:       int(result.ProjectId),
-				SecureSessionID: result.SecureSessionId,
-				TraceState:      result.TraceState,
-				SpanName:        result.SpanName,
-				SpanKind:        result.SpanKind,
-				Duration:        int(result.Duration),
-				ServiceName:     result.ServiceName,
-				ServiceVersion:  result.ServiceVersion,
-				Environment:     result.Environment,
-				HasErrors:       result.HasErrors,
-				TraceAttributes: expandJSON(allAttributes),
-				StatusCode:      result.StatusCode,
-				StatusMessage:   result.StatusMessage,
-				Events:          extractEvents(result),
-			},
+			Node:   result.AsTrace(),
 		}, nil
 	}
 
+	if err := parser.ValidateQuery(params.Query, TracesTableConfig); err != nil {
+		return nil, err
+	}
+
 	conn, err := readObjects(ctx, client, TracesTableConfig, tracesSamplingTableConfig, projectID, params, pagination, scanTrace)
 	if err != nil {
 		return nil, err
@@ -483,28 +507,7 @@ func getTracesFromRows(rows driver.Rows) ([]*modelInputs.Trace, error) {
 		}
 		seenUUIDs[result.UUID] = struct{}{}
 
-		allAttributes := mergeAttributes(result)
-
-		traces = append(traces, &modelInputs.Trace{
-			Timestamp:       result.Timestamp,
-			TraceID:         result.TraceId,
-			SpanID:          result.SpanId,
-			ParentSpanID:    result.ParentSpanId,
-			ProjectID:       int(result.ProjectId),
-			SecureSessionID: result.SecureSessionId,
-			TraceState:      result.TraceState,
-			SpanName:        result.SpanName,
-			SpanKind:        result.SpanKind,
-			Duration:        int(result.Duration),
-			ServiceName:     result.ServiceName,
-			ServiceVersion:  result.ServiceVersion,
-			Environment:     result.Environment,
-			HasErrors:       result.HasErrors,
-			TraceAttributes: expandJSON(allAttributes),
-			StatusCode:      result.StatusCode,
-			StatusMessage:   result.StatusMessage,
-			Events:          extractEvents(result),
-		})
+		traces = append(traces, result.AsTrace())
 	}
 
 	// Order by timestamp
@@ -593,7 +596,7 @@ func (client *Client) ReadTrace(ctx context.Context, projectID int, traceID stri
 }
 
 func (client *Client) ReadTracesMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, groupBy []string, nBuckets *int, bucketBy string, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
-	return client.ReadMetrics(ctx, ReadMetricsInput{
+	return client.readSearchMetrics(ctx, ReadMetricsInput{
 		SampleableConfig: TracesSampleableTableConfig,
 		ProjectIDs:       []int{projectID},
 		Params:           params,
@@ -655,6 +658,14 @@ func TraceMatchesQuery(trace *TraceRow, filters listener.Filters) bool {
 	return matchesQuery(trace, TracesTableConfig, filters, listener.OperatorAnd)
 }
 
+// ClickhouseTraceMatchesQuery matches a trace row as written to clickhouse, searching attributes across
+// all of its attribute columns.
+func ClickhouseTraceMatchesQuery(trace *ClickhouseTraceRow, filters listener.Filters) bool {
+	row := *trace
+	row.TraceAttributes = mergeAttributes(row)
+	return matchesQuery(&row, TracesTableConfig, filters, listener.OperatorAnd)
+}
+
 func (client *Client) TracesLogLines(ctx context.Context, projectID int, params modelInputs.QueryInput) ([]*modelInputs.LogLine, error) {
 	return logLines(ctx, client, TracesTableConfig, projectID, params)
 }
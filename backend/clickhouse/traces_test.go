@@ -45,6 +45,35 @@ func Test_TraceMatchesQuery(t *testing.T) {
 	assert.True(t, matches)
 }
 
+func Test_TraceMatchesQuery_RangeOperators(t *testing.T) {
+	trace := TraceRow{Duration: 100, TraceAttributes: map[string]string{"retries": "3"}}
+
+	// numeric keys and numeric attribute values are compared numerically
+	assert.True(t, TraceMatchesQuery(&trace, parser.Parse("duration>50", TracesTableNoDefaultConfig)))
+	assert.False(t, TraceMatchesQuery(&trace, parser.Parse("duration<100", TracesTableNoDefaultConfig)))
+	assert.True(t, TraceMatchesQuery(&trace, parser.Parse("retries<=3", TracesTableNoDefaultConfig)))
+	assert.True(t, TraceMatchesQuery(&trace, parser.Parse("retries<5", TracesTableNoDefaultConfig)))
+	assert.False(t, TraceMatchesQuery(&trace, parser.Parse("retries>3", TracesTableNoDefaultConfig)))
+}
+
+func Test_ClickhouseTraceMatchesQuery(t *testing.T) {
+	trace := ConvertTraceRow(&TraceRow{
+		ServiceName: "api",
+		TraceAttributes: map[string]string{
+			"http.method": "POST",
+			"os.type":     "linux",
+			"custom":      "value",
+		},
+	})
+	filters := parser.Parse("service_name=api http.method=POST os.type=linux custom=value", TracesTableConfig)
+	assert.True(t, ClickhouseTraceMatchesQuery(trace, filters))
+
+	filters = parser.Parse("service_name=api http.method=GET", TracesTableConfig)
+	assert.False(t, ClickhouseTraceMatchesQuery(trace, filters))
+	// the attributes of the row are left as split by column
+	assert.Empty(t, trace.TraceAttributes["http.method"])
+}
+
 func Test_TraceMatchesQuery_v2(t *testing.T) {
 	trace := TraceRow{}
 	filters := parser.Parse("span_name=fs* OR highlight.type=highlight.internal OR span_name=highlight-metric", TracesTableNoDefaultConfig)
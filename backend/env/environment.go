@@ -98,6 +98,7 @@ type Configuration struct {
 	OAuthRedirectUrl            string `mapstructure:"OAUTH_REDIRECT_URL"`
 	OTLPDogfoodEndpoint         string `mapstructure:"OTLP_DOGFOOD_ENDPOINT"`
 	OTLPEndpoint                string `mapstructure:"OTLP_ENDPOINT"`
+	OTLPGRPCPort                string `mapstructure:"OTLP_GRPC_PORT"`
 	ObjectStorageFS             string `mapstructure:"OBJECT_STORAGE_FS"`
 	OnPrem                      string `mapstructure:"ON_PREM"`
 	OpenAIApiKey                string `mapstructure:"OPENAI_API_KEY"`
@@ -157,9 +158,10 @@ func (c *Configuration) CopyTo(destination *Configuration) {
 }
 
 var (
-	Config      Configuration
-	RuntimeFlag = flag.String("runtime", "all", "the runtime of the backend; either 1) dev (all runtimes) 2) worker 3) public-graph 4) private-graph")
-	HandlerFlag = flag.String("worker-handler", "", "applies for runtime=worker; if specified, a handler function will be called instead of Start")
+	Config            Configuration
+	RuntimeFlag       = flag.String("runtime", "all", "the runtime of the backend; either 1) dev (all runtimes) 2) worker 3) public-graph 4) private-graph")
+	HandlerFlag       = flag.String("worker-handler", "", "applies for runtime=worker; if specified, a handler function will be called instead of Start")
+	InMemoryQueueFlag = flag.Bool("in-memory-queue", false, "applies for runtime=all; if specified, an in-process message queue is used instead of kafka")
 )
 
 func init() {
@@ -41,13 +41,36 @@ func GetFingerprints(projectID int, errorTraces []*privateModel.ErrorTrace) []*m
 	return fingerprints
 }
 
-func GetKey(projectID int, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace) string {
+// GetGroupingFingerprints returns the fingerprints of the error after applying the project grouping rules.
+func GetGroupingFingerprints(projectID int, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace, rules Rules) []*model.ErrorFingerprint {
+	fingerprints := GetFingerprints(projectID, rules.FilterFrames(structuredStackTrace))
+	if event := rules.NormalizeEvent(errorObj.Event); event != errorObj.Event {
+		fingerprints = append(fingerprints, &model.ErrorFingerprint{
+			ProjectID: projectID,
+			Type:      model.Fingerprint.Message,
+			Value:     event,
+		})
+	}
+	if fingerprint, ok := rules.GetFingerprint(errorObj); ok {
+		fingerprints = append(fingerprints, &model.ErrorFingerprint{
+			ProjectID: projectID,
+			Type:      model.Fingerprint.Custom,
+			Value:     fingerprint,
+		})
+	}
+	return fingerprints
+}
+
+func GetKey(projectID int, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace, rules Rules) string {
+	if fingerprint, ok := rules.GetFingerprint(errorObj); ok {
+		return fmt.Sprintf("error-object-group-%d-custom-%s", projectID, fingerprint)
+	}
 	var fingerprintsStr string
-	for _, fp := range GetFingerprints(projectID, structuredStackTrace) {
+	for _, fp := range GetFingerprints(projectID, rules.FilterFrames(structuredStackTrace)) {
 		fingerprintsStr = fmt.Sprintf("%s%s%s%d ", fingerprintsStr, fp.Type, fp.Value, fp.Index)
 	}
 	stackBody := joinStringPtrs(errorObj.StackTrace, ptr.String(fingerprintsStr))
-	return fmt.Sprintf("error-object-group-%d-%s-%s", projectID, errorObj.Event, stackBody)
+	return fmt.Sprintf("error-object-group-%d-%s-%s", projectID, rules.NormalizeEvent(errorObj.Event), stackBody)
 }
 
 // A key for deduping error objects with the same event and untransformed stack trace
@@ -0,0 +1,165 @@
+package errorgroups
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+type RuleType string
+
+const (
+	// RuleStripMessage removes the parts of the error event matching Pattern, ie. ids or timestamps.
+	RuleStripMessage RuleType = "strip_message"
+	// RuleIgnoreFrames excludes the stack frames whose file or function name match Pattern, ie. `node_modules`.
+	RuleIgnoreFrames RuleType = "ignore_frames"
+	// RuleGroupByAttribute groups errors by the value of Attribute in the error payload.
+	RuleGroupByAttribute RuleType = "group_by_attribute"
+	// RuleFingerprint merges all errors with an event matching Pattern into the group of Fingerprint.
+	RuleFingerprint RuleType = "fingerprint"
+)
+
+// strippedPlaceholder replaces the parts of an event removed by a RuleStripMessage.
+const strippedPlaceholder = "<*>"
+
+type Rule struct {
+	Type RuleType `json:"type"`
+	// Pattern is a regex matched against the error event for strip_message and fingerprint rules,
+	// and against the frame file name or function name for ignore_frames rules.
+	Pattern     string `json:"pattern,omitempty"`
+	Attribute   string `json:"attribute,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+type compiledRule struct {
+	Rule
+	pattern *regexp.Regexp
+}
+
+// Rules are the compiled error grouping rules of a project. The zero value applies no rules.
+type Rules struct {
+	rules []compiledRule
+}
+
+func NewRules(rules []Rule) (Rules, error) {
+	var result Rules
+	for idx, rule := range rules {
+		compiled := compiledRule{Rule: rule}
+		switch rule.Type {
+		case RuleStripMessage, RuleIgnoreFrames, RuleFingerprint:
+			if rule.Pattern == "" {
+				return Rules{}, fmt.Errorf("grouping rule %d: %s requires a pattern", idx, rule.Type)
+			}
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return Rules{}, e.Wrapf(err, "grouping rule %d: invalid pattern", idx)
+			}
+			compiled.pattern = pattern
+			if rule.Type == RuleFingerprint && rule.Fingerprint == "" {
+				return Rules{}, fmt.Errorf("grouping rule %d: fingerprint requires a fingerprint", idx)
+			}
+		case RuleGroupByAttribute:
+			if rule.Attribute == "" {
+				return Rules{}, fmt.Errorf("grouping rule %d: group_by_attribute requires an attribute", idx)
+			}
+		default:
+			return Rules{}, fmt.Errorf("grouping rule %d: unknown type %q", idx, rule.Type)
+		}
+		result.rules = append(result.rules, compiled)
+	}
+	return result, nil
+}
+
+// ParseRules compiles the rules stored in the grouping settings of a project.
+func ParseRules(settings *model.ProjectErrorGroupingSettings) (Rules, error) {
+	if settings == nil || settings.Rules == nil || *settings.Rules == "" {
+		return Rules{}, nil
+	}
+	var rules []Rule
+	if err := json.Unmarshal([]byte(*settings.Rules), &rules); err != nil {
+		return Rules{}, e.Wrap(err, "failed to parse error grouping rules")
+	}
+	return NewRules(rules)
+}
+
+func (r Rules) ofType(ruleType RuleType) []compiledRule {
+	return lo.Filter(r.rules, func(rule compiledRule, _ int) bool {
+		return rule.Type == ruleType
+	})
+}
+
+// NormalizeEvent returns the event used to group the error, with the parts matching strip rules replaced.
+func (r Rules) NormalizeEvent(event string) string {
+	for _, rule := range r.ofType(RuleStripMessage) {
+		event = rule.pattern.ReplaceAllString(event, strippedPlaceholder)
+	}
+	return event
+}
+
+// FilterFrames returns the stack frames used to group the error, without the frames matching ignore rules.
+// The original frames are returned if every frame is ignored.
+func (r Rules) FilterFrames(frames []*privateModel.ErrorTrace) []*privateModel.ErrorTrace {
+	ignoreRules := r.ofType(RuleIgnoreFrames)
+	if len(ignoreRules) == 0 {
+		return frames
+	}
+	filtered := lo.Filter(frames, func(frame *privateModel.ErrorTrace, _ int) bool {
+		return !lo.SomeBy(ignoreRules, func(rule compiledRule) bool {
+			return (frame.FileName != nil && rule.pattern.MatchString(*frame.FileName)) ||
+				(frame.FunctionName != nil && rule.pattern.MatchString(*frame.FunctionName))
+		})
+	})
+	if len(filtered) == 0 {
+		return frames
+	}
+	return filtered
+}
+
+// GetFingerprint returns the value that forces the error into a group, from the first
+// fingerprint or group_by_attribute rule that applies to the error.
+func (r Rules) GetFingerprint(errorObj *model.ErrorObject) (string, bool) {
+	var payload map[string]interface{}
+	for _, rule := range r.rules {
+		switch rule.Type {
+		case RuleFingerprint:
+			if rule.pattern.MatchString(errorObj.Event) {
+				return rule.Fingerprint, true
+			}
+		case RuleGroupByAttribute:
+			if payload == nil && errorObj.Payload != nil {
+				if err := json.Unmarshal([]byte(*errorObj.Payload), &payload); err != nil {
+					payload = map[string]interface{}{}
+				}
+			}
+			if value, ok := getAttribute(payload, rule.Attribute); ok {
+				return fmt.Sprintf("%s=%v", rule.Attribute, value), true
+			}
+		}
+	}
+	return "", false
+}
+
+// getAttribute looks up a flattened key such as `http.route`, falling back to a nested path.
+func getAttribute(payload map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := payload[key]; ok && value != nil {
+		return value, true
+	}
+	var current interface{} = payload
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok || current == nil {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,92 @@
+package errorgroups
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/highlight-run/highlight/backend/model"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRulesValidation(t *testing.T) {
+	for name, rule := range map[string]Rule{
+		"invalid pattern":     {Type: RuleStripMessage, Pattern: "("},
+		"missing pattern":     {Type: RuleIgnoreFrames},
+		"missing fingerprint": {Type: RuleFingerprint, Pattern: "timeout"},
+		"missing attribute":   {Type: RuleGroupByAttribute},
+		"unknown rule type":   {Type: "regroup", Pattern: ".*"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRules([]Rule{rule})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(&model.ProjectErrorGroupingSettings{})
+	assert.NoError(t, err)
+	assert.Equal(t, "user 123 not found", rules.NormalizeEvent("user 123 not found"))
+
+	rules, err = ParseRules(&model.ProjectErrorGroupingSettings{Rules: ptr.String(`[{"type": "strip_message", "pattern": "\\d+"}]`)})
+	assert.NoError(t, err)
+	assert.Equal(t, "user <*> not found", rules.NormalizeEvent("user 123 not found"))
+}
+
+func TestGetGroupingFingerprints(t *testing.T) {
+	rules, err := NewRules([]Rule{
+		{Type: RuleStripMessage, Pattern: `[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`},
+		{Type: RuleIgnoreFrames, Pattern: `node_modules|^vendor/`},
+	})
+	assert.NoError(t, err)
+
+	trace := []*privateModel.ErrorTrace{
+		{FileName: ptr.String("/app/node_modules/express/lib/router.js"), FunctionName: ptr.String("handle"), LineNumber: ptr.Int(10)},
+		{FileName: ptr.String("/app/src/cart.js"), FunctionName: ptr.String("total"), LineNumber: ptr.Int(42)},
+	}
+	errorObj := &model.ErrorObject{Event: "cart 5c1b7f0e-8a1e-4c5a-9f5e-2b1d3c4e5f60 is empty"}
+
+	fingerprints := GetGroupingFingerprints(1, errorObj, trace, rules)
+	if assert.Len(t, fingerprints, 2) {
+		assert.Equal(t, model.Fingerprint.StackFrameMetadata, fingerprints[0].Type)
+		assert.Equal(t, "/app/src/cart.js;total;42;", fingerprints[0].Value)
+		assert.Equal(t, 0, fingerprints[0].Index)
+		assert.Equal(t, model.Fingerprint.Message, fingerprints[1].Type)
+		assert.Equal(t, "cart <*> is empty", fingerprints[1].Value)
+	}
+
+	other := &model.ErrorObject{Event: "cart 00000000-8a1e-4c5a-9f5e-2b1d3c4e5f60 is empty"}
+	assert.Equal(t, GetKey(1, errorObj, trace, rules), GetKey(1, other, trace, rules))
+	assert.NotEqual(t, GetKey(1, errorObj, trace, Rules{}), GetKey(1, other, trace, Rules{}))
+
+	// every frame ignored keeps the original frames
+	assert.Len(t, rules.FilterFrames(trace[:1]), 1)
+}
+
+func TestGetFingerprint(t *testing.T) {
+	rules, err := NewRules([]Rule{
+		{Type: RuleFingerprint, Pattern: `^context deadline exceeded`, Fingerprint: "timeouts"},
+		{Type: RuleGroupByAttribute, Attribute: "error.code"},
+	})
+	assert.NoError(t, err)
+
+	fingerprint, ok := rules.GetFingerprint(&model.ErrorObject{Event: "context deadline exceeded while calling db", Payload: ptr.String(`{"error.code": "E42"}`)})
+	assert.True(t, ok)
+	assert.Equal(t, "timeouts", fingerprint)
+
+	fingerprint, ok = rules.GetFingerprint(&model.ErrorObject{Event: "bad request", Payload: ptr.String(`{"error.code": "E42"}`)})
+	assert.True(t, ok)
+	assert.Equal(t, "error.code=E42", fingerprint)
+
+	fingerprint, ok = rules.GetFingerprint(&model.ErrorObject{Event: "bad request", Payload: ptr.String(`{"error": {"code": 400}}`)})
+	assert.True(t, ok)
+	assert.Equal(t, "error.code=400", fingerprint)
+
+	_, ok = rules.GetFingerprint(&model.ErrorObject{Event: "bad request", Payload: ptr.String(`"not an object"`)})
+	assert.False(t, ok)
+
+	errorObj := &model.ErrorObject{Event: "context deadline exceeded", StackTrace: ptr.String("a")}
+	other := &model.ErrorObject{Event: "context deadline exceeded after 5s", StackTrace: ptr.String("b")}
+	assert.Equal(t, GetKey(1, errorObj, nil, rules), GetKey(1, other, nil, rules))
+}
@@ -96,6 +96,7 @@ require (
 	golang.org/x/sync v0.8.0
 	golang.org/x/text v0.23.0
 	google.golang.org/api v0.185.0
+	google.golang.org/grpc v1.69.2
 	gorm.io/driver/postgres v1.5.7
 	gorm.io/gorm v1.25.7
 )
@@ -247,6 +248,5 @@ require (
 	golang.org/x/time v0.5.0 // indirect
 	google.golang.org/appengine v1.6.8 // indirect
 	google.golang.org/genproto v0.0.0-20240617180043-68d350f18fd4 // indirect
-	google.golang.org/grpc v1.69.2 // indirect
 	google.golang.org/protobuf v1.36.2 // indirect
 )
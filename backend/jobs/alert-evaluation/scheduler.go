@@ -0,0 +1,154 @@
+package alert_evaluation
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highlight-run/highlight/backend/redis"
+	"github.com/highlight-run/highlight/backend/util"
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxCatchUpTicks bounds the missed ticks of an alert evaluated after a failover.
+const maxCatchUpTicks = 10
+
+// lastTickExpiry forgets the last tick of alerts that were not evaluated for a while,
+// ie. disabled alerts, so that they restart from the current tick.
+const lastTickExpiry = 24 * time.Hour
+
+// Scheduler spreads the evaluation of alerts across worker replicas. A redis lease per alert tick, renewed while
+// the tick is evaluated, makes sure each tick is evaluated by a single replica. Ticks missed or failed while no
+// replica was evaluating are caught up. The alerts are not sharded: every replica still lists every alert on each
+// pass and races to claim its ticks, so the leases remove duplicate evaluations but do not divide the claims.
+type Scheduler struct {
+	redis   *redis.Client
+	kind    string
+	replica string
+}
+
+// NewScheduler returns the scheduler of a kind of alert, ie. `metric` or `log`.
+func NewScheduler(redis *redis.Client, kind string) *Scheduler {
+	replica, err := os.Hostname()
+	if err != nil {
+		replica = "unknown"
+	}
+	return &Scheduler{redis: redis, kind: kind, replica: replica}
+}
+
+// DueTicks returns the ticks of an alert evaluated every `frequency` which are due at `now`, oldest first.
+// Without a previous evaluation only the current tick is due, and at most maxCatchUpTicks are returned.
+func DueTicks(now time.Time, frequency time.Duration, lastTick *time.Time) []time.Time {
+	current := now.Truncate(frequency)
+	start := current
+	if lastTick != nil {
+		start = lastTick.Truncate(frequency).Add(frequency)
+		if earliest := current.Add(-time.Duration(maxCatchUpTicks-1) * frequency); start.Before(earliest) {
+			start = earliest
+		}
+	}
+
+	var ticks []time.Time
+	for tick := start; !tick.After(current); tick = tick.Add(frequency) {
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
+
+// Evaluate runs `evaluate` for the due ticks of the alert, oldest first. The last evaluated tick only advances
+// after a successful evaluation, so a tick that failed or whose replica crashed is evaluated again on a later pass.
+// An evaluation must therefore persist its state before sending notifications and not fail once they are sent,
+// otherwise the notifications of a failed tick are sent again.
+func (s *Scheduler) Evaluate(ctx context.Context, alertID int, frequency time.Duration, evaluate func(ctx context.Context, tick time.Time) error) error {
+	lastTick, err := s.redis.GetLastAlertEvaluationTick(ctx, s.kind, alertID)
+	if err != nil {
+		return err
+	}
+
+	for _, tick := range DueTicks(time.Now(), frequency, lastTick) {
+		// the lease is renewed during the evaluation, so the lease of a crashed replica expires by the next tick
+		owner := uuid.New().String()
+		claimed, err := s.redis.ClaimAlertEvaluation(ctx, s.kind, alertID, tick, owner, frequency)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			// the tick is being evaluated by another replica, which continues with the later ticks
+			return nil
+		}
+
+		// another replica may have evaluated the tick and released its lease since the last tick was read
+		lastTick, err = s.redis.GetLastAlertEvaluationTick(ctx, s.kind, alertID)
+		if err != nil {
+			s.release(ctx, alertID, tick, owner)
+			return err
+		}
+		if lastTick != nil && !tick.After(*lastTick) {
+			s.release(ctx, alertID, tick, owner)
+			continue
+		}
+
+		lag := time.Since(tick)
+		hmetric.Histogram(ctx, "alerts.evaluation.lag", lag.Seconds(), []attribute.KeyValue{
+			attribute.String("kind", s.kind),
+			attribute.Int("alert_id", alertID),
+			attribute.String("replica", s.replica),
+		}, 1)
+
+		stopRenewing := s.renew(ctx, alertID, tick, owner, frequency)
+		err = evaluate(ctx, tick)
+		stopRenewing()
+		if err != nil {
+			s.release(ctx, alertID, tick, owner)
+			return errors.Wrapf(err, "failed to evaluate %s alert %d at %s after %s", s.kind, alertID, tick, lag)
+		}
+
+		if err := s.redis.SetLastAlertEvaluationTick(ctx, s.kind, alertID, tick, lastTickExpiry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renew extends the lease of a tick every third of its expiry until the returned function is called,
+// so that an evaluation running longer than the lease is not claimed by another replica.
+func (s *Scheduler) renew(ctx context.Context, alertID int, tick time.Time, owner string, expiry time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer util.Recover()
+		defer close(stopped)
+		ticker := time.NewTicker(expiry / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				renewed, err := s.redis.RenewAlertEvaluation(ctx, s.kind, alertID, tick, owner, expiry)
+				if err != nil {
+					log.WithContext(ctx).WithError(err).Error("failed to renew alert evaluation")
+				} else if !renewed {
+					log.WithContext(ctx).WithField("alert_id", alertID).WithField("tick", tick).Warn("lost the lease of an alert evaluation")
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (s *Scheduler) release(ctx context.Context, alertID int, tick time.Time, owner string) {
+	if err := s.redis.ReleaseAlertEvaluation(ctx, s.kind, alertID, tick, owner); err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to release alert evaluation")
+	}
+}
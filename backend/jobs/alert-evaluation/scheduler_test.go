@@ -0,0 +1,112 @@
+package alert_evaluation
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/redis"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueTicks(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 5, 30, 0, time.UTC)
+	minute := func(m int) time.Time {
+		return time.Date(2024, 1, 1, 12, m, 0, 0, time.UTC)
+	}
+
+	assert.Equal(t, []time.Time{minute(5)}, DueTicks(now, time.Minute, nil))
+	assert.Empty(t, DueTicks(now, time.Minute, lo.ToPtr(minute(5))))
+	assert.Equal(t, []time.Time{minute(5)}, DueTicks(now, time.Minute, lo.ToPtr(minute(4))))
+	// missed ticks are caught up after a failover
+	assert.Equal(t, []time.Time{minute(2), minute(3), minute(4), minute(5)}, DueTicks(now, time.Minute, lo.ToPtr(minute(1))))
+
+	ticks := DueTicks(now, time.Minute, lo.ToPtr(now.Add(-time.Hour)))
+	assert.Len(t, ticks, maxCatchUpTicks)
+	assert.Equal(t, minute(5), ticks[len(ticks)-1])
+
+	assert.Equal(t, []time.Time{time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)}, DueTicks(now, 5*time.Minute, lo.ToPtr(minute(0))))
+	assert.Equal(t, []time.Time{time.Date(2024, 1, 1, 12, 5, 30, 0, time.UTC)}, DueTicks(now, 15*time.Second, lo.ToPtr(now.Add(-15*time.Second))))
+}
+
+func TestScheduler_EvaluationOutlivesLease(t *testing.T) {
+	ctx := context.TODO()
+	r := redis.NewClient()
+	alertID := rand.Int()
+	frequency := 2 * time.Second
+
+	// the previous tick was evaluated, so both replicas consider the current tick due
+	previous := time.Now().Truncate(frequency).Add(-frequency)
+	assert.NoError(t, r.SetLastAlertEvaluationTick(ctx, "test", alertID, previous, time.Minute))
+
+	var mu sync.Mutex
+	evaluations := map[time.Time]int{}
+	evaluate := func(delay time.Duration) func(ctx context.Context, tick time.Time) error {
+		return func(ctx context.Context, tick time.Time) error {
+			mu.Lock()
+			evaluations[tick]++
+			mu.Unlock()
+			time.Sleep(delay)
+			return nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// the first replica evaluates for longer than the lease expiry
+		assert.NoError(t, NewScheduler(r, "test").Evaluate(ctx, alertID, frequency, evaluate(3*frequency)))
+	}()
+
+	time.Sleep(frequency + frequency/2)
+	assert.NoError(t, NewScheduler(r, "test").Evaluate(ctx, alertID, frequency, evaluate(0)))
+	wg.Wait()
+
+	assert.NotEmpty(t, evaluations)
+	for tick, count := range evaluations {
+		assert.Equal(t, 1, count, "tick %s was evaluated %d times", tick, count)
+	}
+}
+
+func TestScheduler_RetriesFailedEvaluation(t *testing.T) {
+	ctx := context.TODO()
+	r := redis.NewClient()
+	alertID := rand.Int()
+	frequency := time.Minute
+
+	previous := time.Now().Truncate(frequency).Add(-frequency)
+	assert.NoError(t, r.SetLastAlertEvaluationTick(ctx, "test", alertID, previous, time.Minute))
+
+	// the evaluation notifies once its state is persisted, so the first evaluation fails before notifying
+	evaluations := map[time.Time]int{}
+	persisted := map[time.Time]bool{}
+	var notifications int
+	evaluate := func(ctx context.Context, tick time.Time) error {
+		evaluations[tick]++
+		if evaluations[tick] == 1 {
+			return errors.New("failed to write alert state changes")
+		}
+		persisted[tick] = true
+		notifications++
+		return nil
+	}
+
+	scheduler := NewScheduler(r, "test")
+	assert.Error(t, scheduler.Evaluate(ctx, alertID, frequency, evaluate))
+	lastTick, err := r.GetLastAlertEvaluationTick(ctx, "test", alertID)
+	assert.NoError(t, err)
+	assert.Equal(t, previous.Unix(), lastTick.Unix())
+
+	// the failed tick is released and evaluated again, then not evaluated a third time
+	assert.NoError(t, scheduler.Evaluate(ctx, alertID, frequency, evaluate))
+	assert.NoError(t, scheduler.Evaluate(ctx, alertID, frequency, evaluate))
+	current := previous.Add(frequency)
+	assert.Equal(t, 2, evaluations[current])
+	assert.True(t, persisted[current])
+	assert.Equal(t, 1, notifications)
+}
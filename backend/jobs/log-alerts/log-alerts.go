@@ -8,6 +8,7 @@ import (
 
 	"github.com/highlight-run/highlight/backend/alerts"
 	"github.com/highlight-run/highlight/backend/clickhouse"
+	alert_evaluation "github.com/highlight-run/highlight/backend/jobs/alert-evaluation"
 	"github.com/highlight-run/highlight/backend/lambda"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/redis"
@@ -64,29 +65,27 @@ func WatchLogAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Clien
 	alertWorkerpool := workerpool.New(maxWorkers)
 	alertWorkerpool.SetPanicHandler(util.Recover)
 
-	startTime := time.Now().Unix()
+	scheduler := alert_evaluation.NewScheduler(redis, "log")
 	for range time.NewTicker(alertEvalFreq).C {
-		curTime := time.Now().Unix()
 		alerts := *alertsByFrequency
 		for freq, alerts := range alerts {
-			// If at least one tick has passed since the last loop,
-			// evaluate the alerts for this bucket
-			if (curTime / freq) > (startTime / freq) {
-				for _, alert := range alerts {
-					// copy `alert` by value so each call to processLogAlert references a different alert
-					alert := alert
-					alertWorkerpool.SubmitRecover(
-						func() {
-							ctx := context.Background()
-							err := processLogAlert(ctx, DB, MailClient, alert, rh, redis, ccClient, lambdaClient)
-							if err != nil {
-								log.WithContext(ctx).Error(err)
-							}
+			for _, alert := range alerts {
+				// copy `alert` by value so each call to processLogAlert references a different alert
+				alert := alert
+				frequency := time.Duration(freq) * time.Second
+				alertWorkerpool.SubmitRecover(
+					func() {
+						ctx := context.Background()
+						// the scheduler only evaluates the alert once per tick of its frequency across workers
+						err := scheduler.Evaluate(ctx, alert.ID, frequency, func(ctx context.Context, tick time.Time) error {
+							return processLogAlert(ctx, DB, MailClient, alert, rh, redis, ccClient, lambdaClient, tick)
 						})
-				}
+						if err != nil {
+							log.WithContext(ctx).Error(err)
+						}
+					})
 			}
 		}
-		startTime = curTime
 	}
 }
 
@@ -101,13 +100,14 @@ func getLogAlerts(ctx context.Context, DB *gorm.DB) []*model.LogAlert {
 	return alerts
 }
 
-func processLogAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.LogAlert, rh *resthooks.Resthook, redis *redis.Client, ccClient *clickhouse.Client, lambdaClient *lambda.Client) error {
+// processLogAlert evaluates the alert for the evaluation tick starting at `tick`.
+func processLogAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.LogAlert, rh *resthooks.Resthook, redis *redis.Client, ccClient *clickhouse.Client, lambdaClient *lambda.Client, tick time.Time) error {
 	thresholdWindow := alert.Frequency
 	if alert.ThresholdWindow != nil {
 		thresholdWindow = *alert.ThresholdWindow
 	}
 
-	end := time.Now().Add(ingestDelay)
+	end := tick.Add(ingestDelay)
 	start := end.Add(-time.Duration(thresholdWindow) * time.Second)
 
 	count64, err := ccClient.ReadLogsTotalCount(ctx, alert.ProjectID, modelInputs.QueryInput{Query: alert.Query, DateRange: &modelInputs.DateRangeRequiredInput{
@@ -209,9 +209,11 @@ func processLogAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Clie
 		}
 
 		subjectLine := alert.Name
+		// the slack and discord alerts are sent, so a failure is not returned as that would evaluate the tick again
 		emailHtml, err := lambdaClient.FetchReactEmailHTML(ctx, lambda.ReactEmailTemplateLogAlert, templateData)
 		if err != nil {
-			return errors.Wrap(err, "error fetching email html")
+			log.WithContext(ctx).WithError(err).Error("error fetching email html")
+			return nil
 		}
 
 		for _, email := range emailsToNotify {
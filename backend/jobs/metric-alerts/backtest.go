@@ -0,0 +1,245 @@
+package metric_alerts
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+// the evaluation interval of a backtest is widened from alertEvalFreq to limit the number of metric queries
+const maxBacktestEvaluations = 1440
+
+// each evaluation of an anomaly alert also requests a prediction of its history, so fewer are made
+const maxAnomalyBacktestEvaluations = 96
+const backtestWorkers = 8
+
+// backtestEvaluation holds the metric values read for an evaluation of the alert at curDate.
+type backtestEvaluation struct {
+	curDate        time.Time
+	buckets        []*modelInputs.MetricBucket
+	previousValues map[string]*float64
+}
+
+// backtestGroup tracks the state changes of a group of the alert, as they would have been written to alert_state_changes.
+type backtestGroup struct {
+	state modelInputs.AlertState
+	// start of the period since the group was last normal
+	firingSince *time.Time
+	// whether an alert was sent since the group was last normal
+	alerted bool
+}
+
+// Backtest replays the evaluation of an unsaved alert between the dates, returning the state changes and notifications
+// that the alert would have produced. Nothing is sent nor written to alert_state_changes.
+//
+// The alert is evaluated every alertEvalFreq like WatchMetricAlerts, or less often over long date ranges and for
+// anomaly alerts, whose evaluations each request a prediction. Each evaluation reads the alert window ending at the
+// evaluation with ReadMetrics, including for alerts whose metric state would be saved on ingest, since an unsaved
+// alert has no saved state.
+func Backtest(ctx context.Context, DB *gorm.DB, ccClient *clickhouse.Client, alert *model.Alert, startDate time.Time, endDate time.Time) (*modelInputs.AlertBacktest, error) {
+	if alert.CompositeCondition != nil {
+		return nil, errors.New("composite alerts can't be backtested")
+	}
+	if !endDate.After(startDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+	if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly && alert.ThresholdWindow == nil {
+		return nil, errors.New("anomaly alerts require a threshold window")
+	}
+
+	if _, err := getSampleableTableConfig(alert.ProductType); err != nil {
+		return nil, err
+	}
+
+	maxEvaluations := maxBacktestEvaluations
+	if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
+		maxEvaluations = maxAnomalyBacktestEvaluations
+	}
+	interval := getBacktestInterval(startDate, endDate, maxEvaluations)
+
+	var thresholdValue float64
+	if alert.ThresholdValue != nil {
+		thresholdValue = *alert.ThresholdValue
+	}
+
+	var cooldown time.Duration
+	if alert.ThresholdCooldown != nil {
+		cooldown = time.Duration(*alert.ThresholdCooldown) * time.Second
+	}
+
+	silences, err := alertsV2.GetAlertSilences(ctx, DB, alert, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluations []*backtestEvaluation
+	for curDate := startDate.Truncate(time.Minute).Add(interval); !curDate.After(endDate); curDate = curDate.Add(interval) {
+		evaluations = append(evaluations, &backtestEvaluation{curDate: curDate})
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(backtestWorkers)
+	for _, evaluation := range evaluations {
+		evaluation := evaluation
+		g.Go(func() error {
+			return readBacktestEvaluation(gCtx, ccClient, alert, thresholdValue, evaluation)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := replayEvaluations(alert, evaluations, thresholdValue, cooldown, silences)
+	result.EvaluationInterval = int(interval.Seconds())
+	return result, nil
+}
+
+// getBacktestInterval returns the interval between at most maxEvaluations evaluations of a backtest,
+// a whole number of minutes.
+func getBacktestInterval(startDate time.Time, endDate time.Time, maxEvaluations int) time.Duration {
+	interval := alertEvalFreq
+	if endDate.Sub(startDate)/interval > time.Duration(maxEvaluations) {
+		interval = (endDate.Sub(startDate)/time.Duration(maxEvaluations) + time.Minute - 1).Truncate(time.Minute)
+	}
+	return interval
+}
+
+// readBacktestEvaluation reads the values of the alert window ending at the evaluation, with the same bucketing as
+// processMetricAlert.
+func readBacktestEvaluation(ctx context.Context, ccClient *clickhouse.Client, alert *model.Alert, thresholdValue float64, evaluation *backtestEvaluation) error {
+	thresholdWindow := 1 * time.Hour
+	if alert.ThresholdWindow != nil {
+		thresholdWindow = time.Duration(*alert.ThresholdWindow) * time.Second
+	}
+
+	startDate := evaluation.curDate.Add(-1 * thresholdWindow)
+	bucketCount := 1
+	if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
+		startDate = evaluation.curDate.Add(-anomalyBucketCount * thresholdWindow)
+		bucketCount = anomalyBucketCount
+	}
+
+	readMetricsInput, err := getReadMetricsInput(alert, startDate, evaluation.curDate, bucketCount)
+	if err != nil {
+		return err
+	}
+
+	buckets, err := ccClient.ReadMetrics(ctx, readMetricsInput)
+	if err != nil {
+		return err
+	}
+	if buckets != nil {
+		evaluation.buckets = buckets.Buckets
+	}
+
+	if alert.ThresholdType == modelInputs.ThresholdTypeChange {
+		evaluation.previousValues, err = getPreviousValues(ctx, ccClient, alert, readMetricsInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
+		evaluation.buckets, err = addAnomalyPredictions(ctx, alert, evaluation.buckets, thresholdValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replayEvaluations evaluates the alert in order of the evaluations, keeping the last alerts and firing groups in memory
+// in place of alert_state_changes. Only the evaluations which change the state of a group are returned.
+func replayEvaluations(alert *model.Alert, evaluations []*backtestEvaluation, thresholdValue float64, cooldown time.Duration, silences []*model.AlertSilence) *modelInputs.AlertBacktest {
+	result := &modelInputs.AlertBacktest{
+		Evaluations:   len(evaluations),
+		StateChanges:  []*modelInputs.AlertStateChange{},
+		Notifications: []*modelInputs.AlertBacktestNotification{},
+	}
+
+	lastAlerts := map[string]time.Time{}
+	groups := map[string]*backtestGroup{}
+	for _, evaluation := range evaluations {
+		curDate := evaluation.curDate
+
+		var stateChanges []modelInputs.AlertStateChange
+		for _, metricEvaluation := range evaluateBuckets(alert, curDate, evaluation.buckets, evaluation.previousValues, thresholdValue, lastAlerts, cooldown, silences) {
+			stateChanges = append(stateChanges, metricEvaluation.stateChange)
+		}
+
+		// the groups GetFiringAlertStates would return before this evaluation is written
+		var firingStates []clickhouse.FiringAlertState
+		for groupByKey, group := range groups {
+			if group.alerted && group.state != modelInputs.AlertStateNormal {
+				firingStates = append(firingStates, clickhouse.FiringAlertState{GroupByKey: groupByKey, FiringSince: *group.firingSince})
+			}
+		}
+		sort.Slice(firingStates, func(i, j int) bool {
+			return firingStates[i].GroupByKey < firingStates[j].GroupByKey
+		})
+
+		resolvedStates, missingStateChanges := getResolvedAlertStates(curDate, alert.ID, firingStates, stateChanges)
+		stateChanges = append(stateChanges, missingStateChanges...)
+
+		groupValues := map[string]*float64{}
+		for _, stateChange := range stateChanges {
+			stateChange := stateChange
+			groupValues[stateChange.GroupByKey] = stateChange.Value
+
+			if stateChange.State == modelInputs.AlertStateAlerting {
+				lastAlerts[stateChange.GroupByKey] = curDate
+				result.Notifications = append(result.Notifications, &modelInputs.AlertBacktestNotification{
+					Timestamp:  curDate,
+					Type:       modelInputs.AlertBacktestNotificationTypeAlert,
+					GroupByKey: stateChange.GroupByKey,
+					Value:      stateChange.Value,
+				})
+			}
+
+			group, ok := groups[stateChange.GroupByKey]
+			if !ok {
+				group = &backtestGroup{state: modelInputs.AlertStateNormal}
+				groups[stateChange.GroupByKey] = group
+			}
+			if stateChange.State != group.state {
+				result.StateChanges = append(result.StateChanges, &stateChange)
+			}
+
+			group.state = stateChange.State
+			if stateChange.State == modelInputs.AlertStateNormal {
+				group.firingSince = nil
+				group.alerted = false
+			} else {
+				if group.firingSince == nil {
+					group.firingSince = &curDate
+				}
+				group.alerted = group.alerted || stateChange.State == modelInputs.AlertStateAlerting
+			}
+		}
+
+		for _, resolvedState := range resolvedStates {
+			if alert.DisableResolvedNotifications || alertsV2.IsSilenced(silences, alert, resolvedState.GroupByKey, curDate) {
+				continue
+			}
+			firingSince := resolvedState.FiringSince
+			result.Notifications = append(result.Notifications, &modelInputs.AlertBacktestNotification{
+				Timestamp:   curDate,
+				Type:        modelInputs.AlertBacktestNotificationTypeResolved,
+				GroupByKey:  resolvedState.GroupByKey,
+				Value:       groupValues[resolvedState.GroupByKey],
+				FiringSince: &firingSince,
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package metric_alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayEvaluations(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	minute := func(idx int) time.Time {
+		return start.Add(time.Duration(idx) * time.Minute)
+	}
+
+	alert := &model.Alert{
+		ThresholdType:      modelInputs.ThresholdTypeConstant,
+		ThresholdCondition: modelInputs.ThresholdConditionAbove,
+	}
+	var evaluations []*backtestEvaluation
+	for idx, value := range []*float64{
+		pointy.Float64(5),
+		pointy.Float64(12),
+		pointy.Float64(15),
+		pointy.Float64(12),
+		pointy.Float64(3),
+		pointy.Float64(20),
+		nil,
+		pointy.Float64(20),
+		nil,
+	} {
+		evaluation := &backtestEvaluation{curDate: minute(idx + 1)}
+		if value != nil {
+			evaluation.buckets = []*modelInputs.MetricBucket{{Group: []string{"a"}, MetricValue: value}}
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	result := replayEvaluations(alert, evaluations, 10, 5*time.Minute, nil)
+	assert.Equal(t, 9, result.Evaluations)
+
+	type stateChange struct {
+		timestamp time.Time
+		group     string
+		state     modelInputs.AlertState
+	}
+	var stateChanges []stateChange
+	for _, s := range result.StateChanges {
+		stateChanges = append(stateChanges, stateChange{s.Timestamp, s.GroupByKey, s.State})
+	}
+	assert.Equal(t, []stateChange{
+		{minute(2), "a", modelInputs.AlertStateAlerting},
+		// within the cooldown of the alert at minute 2
+		{minute(3), "a", modelInputs.AlertStateAlertingSilently},
+		{minute(5), "a", modelInputs.AlertStateNormal},
+		{minute(6), "a", modelInputs.AlertStateAlertingSilently},
+		{minute(8), "a", modelInputs.AlertStateAlerting},
+		// no data for the firing group
+		{minute(9), "a", modelInputs.AlertStateNormal},
+	}, stateChanges)
+
+	if assert.Len(t, result.Notifications, 4) {
+		assert.Equal(t, modelInputs.AlertBacktestNotificationTypeAlert, result.Notifications[0].Type)
+		assert.Equal(t, minute(2), result.Notifications[0].Timestamp)
+		assert.Equal(t, 12., *result.Notifications[0].Value)
+
+		assert.Equal(t, modelInputs.AlertBacktestNotificationTypeResolved, result.Notifications[1].Type)
+		assert.Equal(t, minute(5), result.Notifications[1].Timestamp)
+		assert.Equal(t, minute(2), *result.Notifications[1].FiringSince)
+		assert.Equal(t, 3., *result.Notifications[1].Value)
+
+		assert.Equal(t, modelInputs.AlertBacktestNotificationTypeAlert, result.Notifications[2].Type)
+		assert.Equal(t, minute(8), result.Notifications[2].Timestamp)
+
+		// the alerting period started with the silent alert at minute 6
+		assert.Equal(t, modelInputs.AlertBacktestNotificationTypeResolved, result.Notifications[3].Type)
+		assert.Equal(t, minute(9), result.Notifications[3].Timestamp)
+		assert.Equal(t, minute(6), *result.Notifications[3].FiringSince)
+		assert.Nil(t, result.Notifications[3].Value)
+	}
+
+	alert.DisableResolvedNotifications = true
+	result = replayEvaluations(alert, evaluations, 10, 5*time.Minute, nil)
+	assert.Len(t, result.Notifications, 2)
+}
+
+func TestGetBacktestInterval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Minute, getBacktestInterval(start, start.Add(24*time.Hour), maxBacktestEvaluations))
+	assert.Equal(t, 2*time.Minute, getBacktestInterval(start, start.Add(24*time.Hour+time.Minute), maxBacktestEvaluations))
+	assert.Equal(t, 7*time.Minute, getBacktestInterval(start, start.Add(7*24*time.Hour), maxBacktestEvaluations))
+	assert.Equal(t, 15*time.Minute, getBacktestInterval(start, start.Add(24*time.Hour), maxAnomalyBacktestEvaluations))
+	assert.Equal(t, 105*time.Minute, getBacktestInterval(start, start.Add(7*24*time.Hour), maxAnomalyBacktestEvaluations))
+}
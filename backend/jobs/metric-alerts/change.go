@@ -0,0 +1,47 @@
+package metric_alerts
+
+import (
+	"math"
+
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+)
+
+// getChange is the change of the value from the earlier window, in percent or per minute depending on the change type
+// of the alert. An earlier window without data counts as zero, from which the percent change is undefined and nil.
+func getChange(alert *model.Alert, value *float64, previous *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	var previousValue float64
+	if previous != nil {
+		previousValue = *previous
+	}
+
+	var change float64
+	if alert.ThresholdChangeType == modelInputs.ChangeTypeRate {
+		change = (*value - previousValue) / destinationsV2.GetChangeWindow(alert).Minutes()
+	} else {
+		if previousValue == 0 {
+			return nil
+		}
+		change = (*value - previousValue) / math.Abs(previousValue) * 100
+	}
+	return &change
+}
+
+// isChangeAlerting compares the change with the magnitude of the threshold, as an increase for Above,
+// a decrease for Below and either for Outside.
+func isChangeAlerting(condition modelInputs.ThresholdCondition, change float64, threshold float64) bool {
+	threshold = math.Abs(threshold)
+	switch condition {
+	case modelInputs.ThresholdConditionBelow:
+		return change <= -threshold
+	case modelInputs.ThresholdConditionOutside:
+		return math.Abs(change) >= threshold
+	default:
+		return change >= threshold
+	}
+}
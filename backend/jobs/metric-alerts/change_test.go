@@ -0,0 +1,39 @@
+package metric_alerts
+
+import (
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChange(t *testing.T) {
+	alert := &model.Alert{ThresholdChangeType: modelInputs.ChangeTypePercent}
+	assert.Equal(t, 350., *getChange(alert, pointy.Float64(45), pointy.Float64(10)))
+	assert.Equal(t, -50., *getChange(alert, pointy.Float64(5), pointy.Float64(10)))
+	assert.Equal(t, 150., *getChange(alert, pointy.Float64(5), pointy.Float64(-10)))
+	assert.Nil(t, getChange(alert, pointy.Float64(45), nil))
+	assert.Nil(t, getChange(alert, pointy.Float64(45), pointy.Float64(0)))
+	assert.Nil(t, getChange(alert, nil, pointy.Float64(10)))
+
+	alert = &model.Alert{ThresholdChangeType: modelInputs.ChangeTypeRate, ThresholdChangeWindow: pointy.Int(24 * 60 * 60)}
+	assert.Equal(t, 0.5, *getChange(alert, pointy.Float64(730), pointy.Float64(10)))
+	assert.Equal(t, 0.5, *getChange(alert, pointy.Float64(720), nil))
+
+	// an hour earlier by default
+	alert.ThresholdChangeWindow = nil
+	assert.Equal(t, -1., *getChange(alert, pointy.Float64(0), pointy.Float64(60)))
+}
+
+func TestIsChangeAlerting(t *testing.T) {
+	assert.True(t, isChangeAlerting(modelInputs.ThresholdConditionAbove, 350, 300))
+	assert.False(t, isChangeAlerting(modelInputs.ThresholdConditionAbove, -350, 300))
+	assert.True(t, isChangeAlerting(modelInputs.ThresholdConditionBelow, -60, 50))
+	assert.True(t, isChangeAlerting(modelInputs.ThresholdConditionBelow, -60, -50))
+	assert.False(t, isChangeAlerting(modelInputs.ThresholdConditionBelow, 60, 50))
+	assert.True(t, isChangeAlerting(modelInputs.ThresholdConditionOutside, -60, 50))
+	assert.True(t, isChangeAlerting(modelInputs.ThresholdConditionOutside, 60, 50))
+	assert.False(t, isChangeAlerting(modelInputs.ThresholdConditionOutside, 40, 50))
+}
@@ -0,0 +1,161 @@
+package metric_alerts
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/lambda"
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/samber/lo"
+	"github.com/sendgrid/sendgrid-go"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// how recent the state of a child alert must be to count towards a composite alert
+const compositeStateLookback = 15 * time.Minute
+
+// processCompositeAlert evaluates the composite alert from the latest state changes of its child alerts.
+// A child alert evaluated in the same tick is only reflected in the next evaluation of the composite alert.
+func processCompositeAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.Alert, ccClient *clickhouse.Client, lambdaClient *lambda.Client, tick time.Time) error {
+	span, ctx := util.StartSpanFromContext(ctx, "WatchMetricAlerts.processCompositeAlert")
+	span.SetAttribute("alert_id", alert.ID)
+	span.SetAttribute("project_id", alert.ProjectID)
+	defer span.Finish()
+
+	curDate := tick.Round(time.Minute).Add(-1 * time.Minute)
+
+	condition, err := alertsV2.GetCompositeCondition(alert)
+	if err != nil {
+		return err
+	}
+	alertIDs := alertsV2.CompositeAlertIDs(condition)
+
+	var children []*model.Alert
+	if err := DB.WithContext(ctx).Where("id IN ?", alertIDs).Find(&children).Error; err != nil {
+		return err
+	}
+
+	childStates, err := ccClient.GetLatestAlertStates(ctx, alert.ProjectID, alertIDs, curDate.Add(-compositeStateLookback), curDate)
+	if err != nil {
+		return err
+	}
+	conditions, alerting := getCompositeConditions(alertIDs, children, childStates)
+
+	var cooldown time.Duration
+	if alert.ThresholdCooldown != nil {
+		cooldown = time.Duration(*alert.ThresholdCooldown) * time.Second
+	}
+
+	alertingStates, err := ccClient.GetLastAlertingStates(ctx, alert.ProjectID, alert.ID, curDate.Add(-1*cooldown), curDate)
+	if err != nil {
+		return err
+	}
+
+	lastAlerts := lo.SliceToMap(alertingStates, func(alertingState modelInputs.AlertStateChange) (string, time.Time) {
+		return alertingState.GroupByKey, alertingState.Timestamp
+	})
+
+	silences, err := alertsV2.GetAlertSilences(ctx, DB, alert, curDate)
+	if err != nil {
+		return err
+	}
+
+	alertStateChange := getAlertStateChange(curDate, alertsV2.EvaluateCompositeCondition(condition, alerting), alert.ID, "", lastAlerts, cooldown)
+	if alertStateChange.State == modelInputs.AlertStateAlerting && alertsV2.IsSilenced(silences, alert, "", curDate) {
+		alertStateChange.State = modelInputs.AlertStateAlertingSilently
+	}
+
+	stateChanges := []modelInputs.AlertStateChange{alertStateChange}
+
+	firingStates, err := ccClient.GetFiringAlertStates(ctx, alert.ProjectID, alert.ID, curDate.Add(-resolvedLookback), curDate)
+	if err != nil {
+		log.WithContext(ctx).WithFields(
+			log.Fields{
+				"alertID": alert.ID,
+			}).WithError(err).Error("failed to get firing alert states")
+	}
+
+	resolvedStates, missingStateChanges := getResolvedAlertStates(curDate, alert.ID, firingStates, stateChanges)
+	stateChanges = append(stateChanges, missingStateChanges...)
+	// the states are written before notifying, so that a failed write does not notify again on the next run
+	if err := ccClient.WriteAlertStateChanges(ctx, alert.ProjectID, stateChanges); err != nil {
+		return err
+	}
+
+	if alertStateChange.State == modelInputs.AlertStateAlerting {
+		log.WithContext(ctx).WithFields(
+			log.Fields{
+				"alertID": alert.ID,
+			}).Info("alerting composite alert")
+
+		err := alertsV2.SendCompositeAlerts(ctx, DB, MailClient, lambdaClient, alert, destinationsV2.CompositeInput{Conditions: conditions})
+		if err != nil {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID": alert.ID,
+				}).Error(err)
+		}
+	}
+
+	for _, resolvedState := range resolvedStates {
+		err := alertsV2.SendResolvedAlerts(ctx, DB, MailClient, lambdaClient, alert, "", resolvedState.GroupByKey, destinationsV2.ResolvedInput{
+			FiringSince: resolvedState.FiringSince,
+			ResolvedAt:  curDate,
+			Silenced:    alertsV2.IsSilenced(silences, alert, resolvedState.GroupByKey, curDate),
+		})
+		if err != nil {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID": alert.ID,
+				}).Error(err)
+		}
+	}
+
+	return nil
+}
+
+// getCompositeConditions returns the latest state of each child alert, in the order of alertIDs, and which are alerting.
+// A child alert is alerting when any of its groups is, in which case the value of the first alerting group is reported.
+// Deleted child alerts are omitted and considered normal.
+func getCompositeConditions(alertIDs []int, children []*model.Alert, states []modelInputs.AlertStateChange) ([]*destinationsV2.CompositeConditionInput, map[int]bool) {
+	childrenByID := lo.KeyBy(children, func(child *model.Alert) int { return child.ID })
+	statesByAlert := lo.GroupBy(states, func(state modelInputs.AlertStateChange) int { return state.AlertID })
+
+	var conditions []*destinationsV2.CompositeConditionInput
+	alerting := map[int]bool{}
+	for _, alertID := range alertIDs {
+		child, ok := childrenByID[alertID]
+		if !ok {
+			continue
+		}
+		condition := &destinationsV2.CompositeConditionInput{Alert: child}
+
+		childStates := statesByAlert[alertID]
+		sort.SliceStable(childStates, func(i, j int) bool {
+			if isAlertingState(childStates[i].State) != isAlertingState(childStates[j].State) {
+				return isAlertingState(childStates[i].State)
+			}
+			return childStates[i].GroupByKey < childStates[j].GroupByKey
+		})
+		if len(childStates) > 0 {
+			condition.Alerting = isAlertingState(childStates[0].State)
+			condition.GroupValue = childStates[0].GroupByKey
+			condition.Value = childStates[0].Value
+		}
+
+		alerting[alertID] = condition.Alerting
+		conditions = append(conditions, condition)
+	}
+	return conditions, alerting
+}
+
+func isAlertingState(state modelInputs.AlertState) bool {
+	return state == modelInputs.AlertStateAlerting || state == modelInputs.AlertStateAlertingSilently
+}
@@ -0,0 +1,42 @@
+package metric_alerts
+
+import (
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCompositeConditions(t *testing.T) {
+	errorRate := &model.Alert{Model: model.Model{ID: 1}, Name: "Checkout error rate"}
+	latency := &model.Alert{Model: model.Model{ID: 2}, Name: "Checkout p95 latency"}
+	deploys := &model.Alert{Model: model.Model{ID: 3}, Name: "Deploys"}
+
+	states := []modelInputs.AlertStateChange{
+		{AlertID: 1, State: modelInputs.AlertStateAlerting, Value: pointy.Float64(0.03)},
+		{AlertID: 2, GroupByKey: "us-east", State: modelInputs.AlertStateNormal, Value: pointy.Float64(300)},
+		{AlertID: 2, GroupByKey: "us-west", State: modelInputs.AlertStateAlertingSilently, Value: pointy.Float64(912)},
+		{AlertID: 3, State: modelInputs.AlertStateNormal},
+	}
+
+	// alert 4 was deleted
+	conditions, alerting := getCompositeConditions([]int{2, 1, 3, 4}, []*model.Alert{errorRate, latency, deploys}, states)
+	assert.Equal(t, map[int]bool{1: true, 2: true, 3: false}, alerting)
+	if assert.Len(t, conditions, 3) {
+		assert.Equal(t, latency, conditions[0].Alert)
+		assert.Equal(t, "us-west", conditions[0].GroupValue)
+		assert.Equal(t, 912., *conditions[0].Value)
+		assert.Equal(t, errorRate, conditions[1].Alert)
+		assert.True(t, conditions[1].Alerting)
+		assert.Equal(t, deploys, conditions[2].Alert)
+		assert.False(t, conditions[2].Alerting)
+		assert.Nil(t, conditions[2].Value)
+	}
+
+	// children without recent states are normal
+	conditions, alerting = getCompositeConditions([]int{1, 2}, []*model.Alert{errorRate, latency}, nil)
+	assert.Equal(t, map[int]bool{1: false, 2: false}, alerting)
+	assert.Len(t, conditions, 2)
+}
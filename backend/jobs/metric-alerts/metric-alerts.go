@@ -8,9 +8,12 @@ import (
 
 	"github.com/highlight-run/highlight/backend/alerts/predictions"
 	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
 	"github.com/highlight-run/highlight/backend/clickhouse"
+	alert_evaluation "github.com/highlight-run/highlight/backend/jobs/alert-evaluation"
 	"github.com/highlight-run/highlight/backend/lambda"
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/highlight-run/highlight/backend/redis"
 	"github.com/highlight-run/highlight/backend/util"
 	"github.com/highlight-run/workerpool"
 	"github.com/openlyinc/pointy"
@@ -28,6 +31,9 @@ const maxWorkers = 40
 const anomalyBucketCount = 100
 const alertEvalFreq = time.Minute
 
+// how far back to look for the start of an alerting period when a group returns to normal
+const resolvedLookback = 7 * 24 * time.Hour
+
 func applyDefaultFilters(productType modelInputs.ProductType) string {
 	if productType == modelInputs.ProductTypeErrors {
 		now := time.Now().UTC()
@@ -37,12 +43,13 @@ func applyDefaultFilters(productType modelInputs.ProductType) string {
 	return ""
 }
 
-func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, ccClient *clickhouse.Client, lambdaClient *lambda.Client) {
+func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, redis *redis.Client, ccClient *clickhouse.Client, lambdaClient *lambda.Client) {
 	log.WithContext(ctx).Info("Starting to watch metric alerts")
 
 	alertWorkerpool := workerpool.New(maxWorkers)
 	alertWorkerpool.SetPanicHandler(util.Recover)
 
+	scheduler := alert_evaluation.NewScheduler(redis, "metric")
 	processAlertsImpl := func() {
 		alerts := getMetricAlerts(ctx, DB)
 		log.WithContext(ctx).Infof("processing %d metric alerts", len(alerts))
@@ -53,7 +60,9 @@ func WatchMetricAlerts(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Cl
 				func() {
 					ctx := context.Background()
 
-					err := processMetricAlert(ctx, DB, MailClient, alert, ccClient, lambdaClient)
+					err := scheduler.Evaluate(ctx, alert.ID, alertEvalFreq, func(ctx context.Context, tick time.Time) error {
+						return processMetricAlert(ctx, DB, MailClient, alert, ccClient, lambdaClient, tick)
+					})
 					if err != nil {
 						log.WithContext(ctx).Error(err)
 					}
@@ -78,7 +87,12 @@ func getMetricAlerts(ctx context.Context, DB *gorm.DB) []*model.Alert {
 
 const timeFormatSecondsNoTz = "2006-01-02T15:04:05"
 
-func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.Alert, ccClient *clickhouse.Client, lambdaClient *lambda.Client) error {
+// processMetricAlert evaluates the alert for the evaluation tick starting at `tick`.
+func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.Client, alert *model.Alert, ccClient *clickhouse.Client, lambdaClient *lambda.Client, tick time.Time) error {
+	if alert.CompositeCondition != nil {
+		return processCompositeAlert(ctx, DB, MailClient, alert, ccClient, lambdaClient, tick)
+	}
+
 	span, ctx := util.StartSpanFromContext(ctx, "WatchMetricAlerts.processMetricAlert")
 	span.SetAttribute("alert_id", alert.ID)
 	span.SetAttribute("project_id", alert.ProjectID)
@@ -91,17 +105,19 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 			"alertProductType": alert.ProductType,
 		}).Info("processing metric alert")
 
-	curDate := time.Now().Round(time.Minute).Add(-1 * time.Minute)
+	curDate := tick.Round(time.Minute).Add(-1 * time.Minute)
 
 	thresholdWindow := 1 * time.Hour
 	if alert.ThresholdWindow != nil {
 		thresholdWindow = time.Duration(*alert.ThresholdWindow) * time.Second
 	}
 
+	// the metric state is only saved since the alert was last updated, so change alerts read both windows
 	saveMetricState := alert.Sql == nil &&
 		alert.ProductType != modelInputs.ProductTypeErrors &&
 		alert.ProductType != modelInputs.ProductTypeSessions &&
-		alert.ProductType != modelInputs.ProductTypeEvents
+		alert.ProductType != modelInputs.ProductTypeEvents &&
+		alert.ThresholdType != modelInputs.ThresholdTypeChange
 
 	endDate := curDate
 	startDate := curDate.Add(-1 * thresholdWindow)
@@ -134,22 +150,194 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 		return alertingState.GroupByKey, alertingState.Timestamp
 	})
 
-	var config clickhouse.SampleableTableConfig
-	switch alert.ProductType {
+	var savedState *clickhouse.SavedMetricState
+	if saveMetricState {
+		blockInfo, err := ccClient.GetBlockNumbers(ctx, alert.MetricId, startDate, endDate)
+		if err != nil {
+			return err
+		}
+
+		savedState = &clickhouse.SavedMetricState{
+			MetricId:        alert.MetricId,
+			BlockNumberInfo: blockInfo,
+		}
+
+		// 1 bucket per minute
+		bucketCount = int((endDate.Sub(startDate)) / time.Minute)
+	}
+
+	readMetricsInput, err := getReadMetricsInput(alert, startDate, endDate, bucketCount)
+	if err != nil {
+		return err
+	}
+	readMetricsInput.SavedMetricState = savedState
+
+	buckets, err := ccClient.ReadMetrics(ctx, readMetricsInput)
+	if err != nil {
+		return err
+	}
+
+	var previousValues map[string]*float64
+	if alert.ThresholdType == modelInputs.ThresholdTypeChange {
+		previousValues, err = getPreviousValues(ctx, ccClient, alert, readMetricsInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	var thresholdValue float64
+	if alert.ThresholdValue != nil {
+		thresholdValue = *alert.ThresholdValue
+	}
+
+	groupByKey := ""
+	if alert.GroupByKey != nil {
+		groupByKey = *alert.GroupByKey
+	}
+
+	silences, err := alertsV2.GetAlertSilences(ctx, DB, alert, curDate)
+	if err != nil {
+		return err
+	}
+
+	var bucketsInner []*modelInputs.MetricBucket
+
+	stateChanges := []modelInputs.AlertStateChange{}
+	if saveMetricState {
+		var windowSeconds *int
+		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
+			windowSeconds = alert.ThresholdWindow
+		}
+
+		startDate := curDate.Add(-1 * thresholdWindow)
+		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
+			startDate = curDate.Add(-anomalyBucketCount * thresholdWindow)
+		}
+
+		bucketsInner, err = ccClient.AggregateMetricStates(ctx, alert.MetricId, startDate, curDate, thresholdWindow, alert.FunctionType, windowSeconds)
+		if err != nil {
+			return err
+		}
+
+		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly && alert.ThresholdWindow != nil {
+			bucketsInner, err = addAnomalyPredictions(ctx, alert, bucketsInner, thresholdValue)
+			if err != nil {
+				return err
+			}
+		}
+	} else if buckets != nil {
+		bucketsInner = buckets.Buckets
+	}
+
+	groupValues := map[string]*float64{}
+	evaluations := evaluateBuckets(alert, curDate, bucketsInner, previousValues, thresholdValue, lastAlerts, cooldown, silences)
+	for _, evaluation := range evaluations {
+		groupValues[evaluation.stateChange.GroupByKey] = evaluation.stateChange.Value
+		stateChanges = append(stateChanges, evaluation.stateChange)
+	}
+
+	firingStates, err := ccClient.GetFiringAlertStates(ctx, alert.ProjectID, alert.ID, curDate.Add(-resolvedLookback), curDate)
+	if err != nil {
+		log.WithContext(ctx).WithFields(
+			log.Fields{
+				"alertID":          alert.ID,
+				"alertProductType": alert.ProductType,
+			}).WithError(err).Error("failed to get firing alert states")
+	}
+
+	resolvedStates, missingStateChanges := getResolvedAlertStates(curDate, alert.ID, firingStates, stateChanges)
+	stateChanges = append(stateChanges, missingStateChanges...)
+	// the states are written before notifying, so that a failed write, which evaluates the tick again,
+	// does not notify twice. once written, the cooldown suppresses the alerting notifications of a later tick.
+	if err := ccClient.WriteAlertStateChanges(ctx, alert.ProjectID, stateChanges); err != nil {
+		return err
+	}
+
+	for _, evaluation := range evaluations {
+		alertStateChange := evaluation.stateChange
+		if alertStateChange.State != modelInputs.AlertStateAlerting {
+			continue
+		}
+		log.WithContext(ctx).WithFields(
+			log.Fields{
+				"alertID":          alert.ID,
+				"alertProductType": alert.ProductType,
+			}).Info("alerting metric alert")
+
+		var err error
+		if evaluation.change != nil {
+			err = alertsV2.SendChangeAlerts(ctx, DB, MailClient, lambdaClient, alert, groupByKey, alertStateChange.GroupByKey, *evaluation.change, destinationsV2.ChangeInput{
+				Value:         *alertStateChange.Value,
+				PreviousValue: previousValues[alertStateChange.GroupByKey],
+			})
+		} else {
+			err = alertsV2.SendAlerts(ctx, DB, MailClient, lambdaClient, alert, groupByKey, alertStateChange.GroupByKey, *alertStateChange.Value)
+		}
+		if err != nil {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID":          alert.ID,
+					"alertProductType": alert.ProductType,
+				}).Error(err)
+		}
+	}
+
+	// incident destinations are resolved even when resolved notifications are disabled
+	for _, resolvedState := range resolvedStates {
+		log.WithContext(ctx).WithFields(
+			log.Fields{
+				"alertID":          alert.ID,
+				"alertProductType": alert.ProductType,
+			}).Info("resolved metric alert")
+
+		err := alertsV2.SendResolvedAlerts(ctx, DB, MailClient, lambdaClient, alert, groupByKey, resolvedState.GroupByKey, destinationsV2.ResolvedInput{
+			FiringSince: resolvedState.FiringSince,
+			ResolvedAt:  curDate,
+			Value:       groupValues[resolvedState.GroupByKey],
+			Silenced:    alertsV2.IsSilenced(silences, alert, resolvedState.GroupByKey, curDate),
+		})
+		if err != nil {
+			log.WithContext(ctx).WithFields(
+				log.Fields{
+					"alertID":          alert.ID,
+					"alertProductType": alert.ProductType,
+				}).Error(err)
+		}
+	}
+
+	log.WithContext(ctx).WithFields(
+		log.Fields{
+			"alertID":          alert.ID,
+			"alertProductType": alert.ProductType,
+		}).Info("processed metric alert")
+
+	return nil
+}
+
+func getSampleableTableConfig(productType modelInputs.ProductType) (clickhouse.SampleableTableConfig, error) {
+	switch productType {
 	case modelInputs.ProductTypeErrors:
-		config = clickhouse.ErrorsSampleableTableConfig
+		return clickhouse.ErrorsSampleableTableConfig, nil
 	case modelInputs.ProductTypeLogs:
-		config = clickhouse.LogsSampleableTableConfig
+		return clickhouse.LogsSampleableTableConfig, nil
 	case modelInputs.ProductTypeSessions:
-		config = clickhouse.SessionsSampleableTableConfig
+		return clickhouse.SessionsSampleableTableConfig, nil
 	case modelInputs.ProductTypeMetrics:
-		config = clickhouse.MetricsSampleableTableConfig
+		return clickhouse.MetricsSampleableTableConfig, nil
 	case modelInputs.ProductTypeTraces:
-		config = clickhouse.TracesSampleableTableConfig
+		return clickhouse.TracesSampleableTableConfig, nil
 	case modelInputs.ProductTypeEvents:
-		config = clickhouse.EventsSampleableTableConfig
+		return clickhouse.EventsSampleableTableConfig, nil
 	default:
-		return errors.Errorf("Unknown product type: %s", alert.ProductType)
+		return clickhouse.SampleableTableConfig{}, errors.Errorf("Unknown product type: %s", productType)
+	}
+}
+
+// getReadMetricsInput returns the query of the alert metric between the dates, grouped by the alert group by key.
+func getReadMetricsInput(alert *model.Alert, startDate time.Time, endDate time.Time, bucketCount int) (clickhouse.ReadMetricsInput, error) {
+	config, err := getSampleableTableConfig(alert.ProductType)
+	if err != nil {
+		return clickhouse.ReadMetricsInput{}, err
 	}
 
 	query := applyDefaultFilters(alert.ProductType)
@@ -175,25 +363,9 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 		groupBy = append(groupBy, *alert.GroupByKey)
 	}
 
-	var savedState *clickhouse.SavedMetricState
-	if saveMetricState {
-		blockInfo, err := ccClient.GetBlockNumbers(ctx, alert.MetricId, startDate, endDate)
-		if err != nil {
-			return err
-		}
-
-		savedState = &clickhouse.SavedMetricState{
-			MetricId:        alert.MetricId,
-			BlockNumberInfo: blockInfo,
-		}
-
-		// 1 bucket per minute
-		bucketCount = int((endDate.Sub(startDate)) / time.Minute)
-	}
-
 	aggregatorCount := modelInputs.MetricAggregatorCount
 
-	buckets, err := ccClient.ReadMetrics(ctx, clickhouse.ReadMetricsInput{
+	return clickhouse.ReadMetricsInput{
 		SampleableConfig: config,
 		ProjectIDs:       []int{alert.ProjectID},
 		Params: modelInputs.QueryInput{
@@ -203,91 +375,94 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 				EndDate:   endDate,
 			},
 		},
-		GroupBy:          groupBy,
-		BucketCount:      &bucketCount,
-		BucketBy:         modelInputs.MetricBucketByTimestamp.String(),
-		Limit:            pointy.Int(20),
-		LimitAggregator:  &aggregatorCount,
-		SavedMetricState: savedState,
-		NoBucketMax:      true,
+		GroupBy:         groupBy,
+		BucketCount:     &bucketCount,
+		BucketBy:        modelInputs.MetricBucketByTimestamp.String(),
+		Limit:           pointy.Int(20),
+		LimitAggregator: &aggregatorCount,
+		NoBucketMax:     true,
 		Expressions: []*modelInputs.MetricExpressionInput{{
 			Aggregator: alert.FunctionType,
 			Column:     column,
 		}},
 		Sql: alert.Sql,
-	})
-	if err != nil {
-		return err
-	}
+	}, nil
+}
 
-	var thresholdValue float64
-	if alert.ThresholdValue != nil {
-		thresholdValue = *alert.ThresholdValue
+// getPreviousValues returns the values of the same window ThresholdChangeWindow earlier, by group.
+func getPreviousValues(ctx context.Context, ccClient *clickhouse.Client, alert *model.Alert, readMetricsInput clickhouse.ReadMetricsInput) (map[string]*float64, error) {
+	changeWindow := destinationsV2.GetChangeWindow(alert)
+	previousInput := readMetricsInput
+	previousInput.Params.DateRange = &modelInputs.DateRangeRequiredInput{
+		StartDate: readMetricsInput.Params.DateRange.StartDate.Add(-changeWindow),
+		EndDate:   readMetricsInput.Params.DateRange.EndDate.Add(-changeWindow),
 	}
 
-	groupByKey := ""
-	if len(groupBy) > 0 {
-		groupByKey = groupBy[0]
+	previousBuckets, err := ccClient.ReadMetrics(ctx, previousInput)
+	if err != nil {
+		return nil, err
 	}
 
-	var bucketsInner []*modelInputs.MetricBucket
-
-	stateChanges := []modelInputs.AlertStateChange{}
-	if saveMetricState {
-		var windowSeconds *int
-		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
-			windowSeconds = alert.ThresholdWindow
-		}
-
-		startDate := curDate.Add(-1 * thresholdWindow)
-		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
-			startDate = curDate.Add(-anomalyBucketCount * thresholdWindow)
-		}
+	previousValues := map[string]*float64{}
+	for _, bucket := range previousBuckets.Buckets {
+		previousValues[strings.Join(bucket.Group, "")] = bucket.MetricValue
+	}
+	return previousValues, nil
+}
 
-		bucketsInner, err = ccClient.AggregateMetricStates(ctx, alert.MetricId, startDate, curDate, thresholdWindow, alert.FunctionType, windowSeconds)
-		if err != nil {
-			return err
-		}
+// addAnomalyPredictions predicts the bounds of the alert metric from its history, returning the buckets of the last
+// window which is the one evaluated.
+func addAnomalyPredictions(ctx context.Context, alert *model.Alert, buckets []*modelInputs.MetricBucket, thresholdValue float64) ([]*modelInputs.MetricBucket, error) {
+	if len(buckets) == 0 {
+		return buckets, nil
+	}
 
-		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly && alert.ThresholdWindow != nil {
-			if err := predictions.AddPredictions(ctx, bucketsInner, modelInputs.PredictionSettings{
-				ChangepointPriorScale: .25,
-				IntervalWidth:         thresholdValue,
-				ThresholdCondition:    alert.ThresholdCondition,
-				IntervalSeconds:       *alert.ThresholdWindow,
-			}); err != nil {
-				return err
-			}
+	if err := predictions.AddPredictions(ctx, buckets, modelInputs.PredictionSettings{
+		ChangepointPriorScale: .25,
+		IntervalWidth:         thresholdValue,
+		ThresholdCondition:    alert.ThresholdCondition,
+		IntervalSeconds:       *alert.ThresholdWindow,
+	}); err != nil {
+		return nil, err
+	}
 
-			maxId := lo.Max(lo.Map(bucketsInner, func(bucket *modelInputs.MetricBucket, _ int) uint64 { return bucket.BucketID }))
+	maxId := lo.Max(lo.Map(buckets, func(bucket *modelInputs.MetricBucket, _ int) uint64 { return bucket.BucketID }))
 
-			// Only interested in the last bucket
-			newBuckets := []*modelInputs.MetricBucket{}
-			for _, bucket := range bucketsInner {
-				if bucket.BucketID == maxId {
-					newBuckets = append(newBuckets, bucket)
-				}
-			}
-			bucketsInner = newBuckets
+	// Only interested in the last bucket
+	newBuckets := []*modelInputs.MetricBucket{}
+	for _, bucket := range buckets {
+		if bucket.BucketID == maxId {
+			newBuckets = append(newBuckets, bucket)
 		}
-	} else if buckets != nil {
-		bucketsInner = buckets.Buckets
 	}
+	return newBuckets, nil
+}
 
-	if len(bucketsInner) == 0 {
+// metricEvaluation is the state of a group of the alert at an evaluation, with the relative change of its value
+// for change alerts.
+type metricEvaluation struct {
+	stateChange modelInputs.AlertStateChange
+	change      *float64
+}
+
+// evaluateBuckets compares the value of each group of the alert with its threshold at curDate. Alerting groups are
+// only notified once their cooldown since lastAlerts has passed and while they are not silenced.
+func evaluateBuckets(alert *model.Alert, curDate time.Time, buckets []*modelInputs.MetricBucket, previousValues map[string]*float64, thresholdValue float64, lastAlerts map[string]time.Time, cooldown time.Duration, silences []*model.AlertSilence) []metricEvaluation {
+	if len(buckets) == 0 {
 		// write a normal state with no group by key to avoid missing data
-		alertStateChange := getAlertStateChange(curDate, false, alert.ID, "", lastAlerts, cooldown)
-		stateChanges = append(stateChanges, alertStateChange)
+		return []metricEvaluation{{stateChange: getAlertStateChange(curDate, false, alert.ID, "", lastAlerts, cooldown)}}
 	}
 
-	for _, bucket := range bucketsInner {
+	var evaluations []metricEvaluation
+	for _, bucket := range buckets {
+		group := strings.Join(bucket.Group, "")
 		if bucket.MetricValue == nil {
-			alertStateChange := getAlertStateChange(curDate, false, alert.ID, strings.Join(bucket.Group, ""), lastAlerts, cooldown)
-			stateChanges = append(stateChanges, alertStateChange)
+			evaluations = append(evaluations, metricEvaluation{stateChange: getAlertStateChange(curDate, false, alert.ID, group, lastAlerts, cooldown)})
 			continue
 		}
 
 		alertCondition := false
+		var change *float64
 		if alert.ThresholdType == modelInputs.ThresholdTypeAnomaly {
 			if alert.ThresholdCondition == modelInputs.ThresholdConditionAbove && bucket.YhatUpper != nil {
 				alertCondition = *bucket.MetricValue >= *bucket.YhatUpper
@@ -296,6 +471,9 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 			} else if alert.ThresholdCondition == modelInputs.ThresholdConditionOutside && bucket.YhatUpper != nil && bucket.YhatLower != nil {
 				alertCondition = *bucket.MetricValue >= *bucket.YhatUpper || *bucket.MetricValue <= *bucket.YhatLower
 			}
+		} else if alert.ThresholdType == modelInputs.ThresholdTypeChange {
+			change = getChange(alert, bucket.MetricValue, previousValues[group])
+			alertCondition = change != nil && isChangeAlerting(alert.ThresholdCondition, *change, thresholdValue)
 		} else {
 			if alert.ThresholdCondition == modelInputs.ThresholdConditionBelow {
 				alertCondition = *bucket.MetricValue <= thresholdValue
@@ -304,39 +482,36 @@ func processMetricAlert(ctx context.Context, DB *gorm.DB, MailClient *sendgrid.C
 			}
 		}
 
-		alertStateChange := getAlertStateChange(curDate, alertCondition, alert.ID, strings.Join(bucket.Group, ""), lastAlerts, cooldown)
-
-		if alertStateChange.State == modelInputs.AlertStateAlerting {
-			log.WithContext(ctx).WithFields(
-				log.Fields{
-					"alertID":          alert.ID,
-					"alertProductType": alert.ProductType,
-				}).Info("alerting metric alert")
-
-			err := alertsV2.SendAlerts(ctx, DB, MailClient, lambdaClient, alert, groupByKey, strings.Join(bucket.Group, ""), *bucket.MetricValue)
-			if err != nil {
-				log.WithContext(ctx).WithFields(
-					log.Fields{
-						"alertID":          alert.ID,
-						"alertProductType": alert.ProductType,
-					}).Error(err)
-			}
+		alertStateChange := getAlertStateChange(curDate, alertCondition, alert.ID, group, lastAlerts, cooldown)
+		alertStateChange.Value = bucket.MetricValue
+		if alertStateChange.State == modelInputs.AlertStateAlerting && alertsV2.IsSilenced(silences, alert, alertStateChange.GroupByKey, curDate) {
+			alertStateChange.State = modelInputs.AlertStateAlertingSilently
 		}
-
-		stateChanges = append(stateChanges, alertStateChange)
+		evaluations = append(evaluations, metricEvaluation{stateChange: alertStateChange, change: change})
 	}
+	return evaluations
+}
 
-	if err := ccClient.WriteAlertStateChanges(ctx, alert.ProjectID, stateChanges); err != nil {
-		return err
+// getResolvedAlertStates returns the firing groups which are no longer alerting in this evaluation, along with
+// normal state changes for the firing groups that were not evaluated because they no longer have data.
+func getResolvedAlertStates(curDate time.Time, alertId int, firingStates []clickhouse.FiringAlertState, stateChanges []modelInputs.AlertStateChange) ([]clickhouse.FiringAlertState, []modelInputs.AlertStateChange) {
+	evaluated := map[string]bool{}
+	for _, stateChange := range stateChanges {
+		evaluated[stateChange.GroupByKey] = evaluated[stateChange.GroupByKey] || isAlertingState(stateChange.State)
 	}
 
-	log.WithContext(ctx).WithFields(
-		log.Fields{
-			"alertID":          alert.ID,
-			"alertProductType": alert.ProductType,
-		}).Info("processed metric alert")
-
-	return nil
+	var resolved []clickhouse.FiringAlertState
+	var missing []modelInputs.AlertStateChange
+	for _, firingState := range firingStates {
+		alerting, ok := evaluated[firingState.GroupByKey]
+		if !ok {
+			missing = append(missing, getAlertStateChange(curDate, false, alertId, firingState.GroupByKey, nil, 0))
+		}
+		if !alerting {
+			resolved = append(resolved, firingState)
+		}
+	}
+	return resolved, missing
 }
 
 func getAlertStateChange(curDate time.Time, alerting bool, alertId int, groupByKey string, lastAlerts map[string]time.Time, cooldown time.Duration) modelInputs.AlertStateChange {
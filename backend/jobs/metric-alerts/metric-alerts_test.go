@@ -0,0 +1,44 @@
+package metric_alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResolvedAlertStates(t *testing.T) {
+	curDate := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	firingSince := curDate.Add(-15 * time.Minute)
+	firingStates := []clickhouse.FiringAlertState{
+		{GroupByKey: "recovered", FiringSince: firingSince},
+		{GroupByKey: "still-alerting", FiringSince: firingSince},
+		{GroupByKey: "cooling-down", FiringSince: firingSince},
+		{GroupByKey: "no-data", FiringSince: firingSince},
+	}
+	stateChanges := []modelInputs.AlertStateChange{
+		{GroupByKey: "recovered", State: modelInputs.AlertStateNormal},
+		{GroupByKey: "still-alerting", State: modelInputs.AlertStateAlerting},
+		{GroupByKey: "cooling-down", State: modelInputs.AlertStateAlertingSilently},
+		{GroupByKey: "never-alerted", State: modelInputs.AlertStateNormal},
+	}
+
+	resolved, missing := getResolvedAlertStates(curDate, 1, firingStates, stateChanges)
+	if assert.Len(t, resolved, 2) {
+		assert.Equal(t, "recovered", resolved[0].GroupByKey)
+		assert.Equal(t, "no-data", resolved[1].GroupByKey)
+		assert.Equal(t, firingSince, resolved[1].FiringSince)
+	}
+	if assert.Len(t, missing, 1) {
+		assert.Equal(t, "no-data", missing[0].GroupByKey)
+		assert.Equal(t, modelInputs.AlertStateNormal, missing[0].State)
+		assert.Equal(t, curDate, missing[0].Timestamp)
+		assert.Equal(t, 1, missing[0].AlertID)
+	}
+
+	resolved, missing = getResolvedAlertStates(curDate, 1, nil, stateChanges)
+	assert.Empty(t, resolved)
+	assert.Empty(t, missing)
+}
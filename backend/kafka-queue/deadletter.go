@@ -0,0 +1,289 @@
+package kafka_queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+)
+
+const DeadLetterTopicSuffix = "_dead_letter"
+
+// Headers added to a message when it is routed to the dead letter topic.
+// The original message headers (ie. trace propagation) are preserved alongside these.
+const (
+	DeadLetterHeaderPrefix    = "highlight.dead_letter."
+	DeadLetterTopicHeader     = DeadLetterHeaderPrefix + "topic"
+	DeadLetterPartitionHeader = DeadLetterHeaderPrefix + "partition"
+	DeadLetterOffsetHeader    = DeadLetterHeaderPrefix + "offset"
+	DeadLetterFailuresHeader  = DeadLetterHeaderPrefix + "failures"
+	DeadLetterErrorHeader     = DeadLetterHeaderPrefix + "error"
+	DeadLetterTimeHeader      = DeadLetterHeaderPrefix + "time"
+)
+
+// maxDeadLetterErrorLength truncates the last error stored on a dead lettered message.
+const maxDeadLetterErrorLength = 4096
+
+func GetDeadLetterTopic(topic string) string {
+	return topic + DeadLetterTopicSuffix
+}
+
+// createDeadLetterTopic provisions the dead letter topic of a consumed topic with the broker default replication.
+func createDeadLetterTopic(ctx context.Context, client *kafka.Client, topic string) {
+	deadLetterTopic := GetDeadLetterTopic(topic)
+	resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{
+			Topic:             deadLetterTopic,
+			NumPartitions:     1,
+			ReplicationFactor: -1,
+		}},
+	})
+	if err == nil {
+		err = resp.Errors[deadLetterTopic]
+	}
+	if err != nil && !errors.Is(err, kafka.TopicAlreadyExists) {
+		log.WithContext(ctx).WithField("topic", deadLetterTopic).Error(errors.Wrap(err, "failed to create dead letter topic"))
+	}
+}
+
+// DeadLetter is a message that exhausted its retries and was written to a dead letter topic.
+type DeadLetter struct {
+	// Topic, Partition and Offset of the original message.
+	Topic     string
+	Partition int
+	Offset    int64
+	// Partition and Offset of the message in the dead letter topic.
+	DeadLetterPartition int
+	DeadLetterOffset    int64
+	Time                time.Time
+	Type                PayloadType
+	Failures            int
+	Error               string
+	Key                 []byte
+	Value               []byte
+	Headers             []kafka.Header
+}
+
+// ErrDeadLetterNotConfigured is returned when dead lettering a message of a queue without a dead letter topic, ie. in dev.
+var ErrDeadLetterNotConfigured = errors.New("dead letter producer is not configured for this queue")
+
+// ErrDeadLetterUndeliverable is returned when a message can never be written to the dead letter topic,
+// ie. when it was not received from a queue or the dead letter topic does not exist. Retrying will not help.
+var ErrDeadLetterUndeliverable = errors.New("message cannot be dead lettered")
+
+// SubmitDeadLetter writes a message that exhausted its retries to the dead letter topic of the queue,
+// keeping the original key, value and headers along with the failure count and the last error.
+func (p *Queue) SubmitDeadLetter(ctx context.Context, msg RetryableMessage, cause error) error {
+	if p.deadLetterP == nil {
+		return ErrDeadLetterNotConfigured
+	}
+	m, err := newDeadLetterMessage(p.Topic, msg, cause)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+	if err := p.deadLetterP.WriteMessages(ctx, m); err != nil {
+		if isUnknownTopicError(err) {
+			return errors.Wrapf(ErrDeadLetterUndeliverable, "dead letter topic %s does not exist", GetDeadLetterTopic(p.Topic))
+		}
+		return errors.Wrap(err, "failed to write dead letter message")
+	}
+	hmetric.Incr(ctx, p.metricPrefix()+"deadLetter.count", nil, 1)
+	return nil
+}
+
+func isUnknownTopicError(err error) bool {
+	var writeErrors kafka.WriteErrors
+	if errors.As(err, &writeErrors) {
+		return lo.ContainsBy(writeErrors, func(err error) bool {
+			return err != nil && errors.Is(err, kafka.UnknownTopicOrPartition)
+		})
+	}
+	return errors.Is(err, kafka.UnknownTopicOrPartition)
+}
+
+func newDeadLetterMessage(topic string, msg RetryableMessage, cause error) (kafka.Message, error) {
+	m := msg.GetKafkaMessage()
+	if m == nil {
+		return kafka.Message{}, errors.Wrap(ErrDeadLetterUndeliverable, "message was not received from a queue")
+	}
+
+	errMsg := ""
+	if cause != nil {
+		errMsg = cause.Error()
+		if len(errMsg) > maxDeadLetterErrorLength {
+			errMsg = errMsg[:maxDeadLetterErrorLength]
+		}
+	}
+
+	carrier := KafkaCarrier{Headers: lo.Filter(m.Headers, func(h kafka.Header, _ int) bool {
+		return !strings.HasPrefix(h.Key, DeadLetterHeaderPrefix)
+	})}
+	carrier.Set(DeadLetterTopicHeader, topic)
+	carrier.Set(DeadLetterPartitionHeader, strconv.Itoa(m.Partition))
+	carrier.Set(DeadLetterOffsetHeader, strconv.FormatInt(m.Offset, 10))
+	carrier.Set(DeadLetterFailuresHeader, strconv.Itoa(msg.GetFailures()))
+	carrier.Set(DeadLetterErrorHeader, errMsg)
+	carrier.Set(DeadLetterTimeHeader, time.Now().UTC().Format(time.RFC3339Nano))
+
+	return kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: carrier.Headers,
+	}, nil
+}
+
+// ReadDeadLetters calls fn for every message in the dead letter topic of the queue
+// that was dead lettered between start and end.
+func (p *Queue) ReadDeadLetters(ctx context.Context, start, end time.Time, fn func(*DeadLetter) error) error {
+	topic := GetDeadLetterTopic(p.Topic)
+	resp, err := p.Client.Metadata(ctx, &kafka.MetadataRequest{
+		Addr:   p.Client.Addr,
+		Topics: []string{topic},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to read dead letter topic partitions")
+	}
+	if len(resp.Topics) == 0 || resp.Topics[0].Error != nil {
+		return errors.Errorf("dead letter topic %s does not exist", topic)
+	}
+
+	var requests []kafka.OffsetRequest
+	for _, partition := range resp.Topics[0].Partitions {
+		requests = append(requests, kafka.TimeOffsetOf(partition.ID, start), kafka.LastOffsetOf(partition.ID))
+	}
+	offsets, err := p.Client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Addr:           p.Client.Addr,
+		Topics:         map[string][]kafka.OffsetRequest{topic: requests},
+		IsolationLevel: kafka.ReadCommitted,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list dead letter offsets")
+	}
+
+	for _, partition := range offsets.Topics[topic] {
+		if partition.Error != nil {
+			return errors.Wrapf(partition.Error, "failed to list offsets for partition %d", partition.Partition)
+		}
+		first, last := partition.LastOffset, partition.LastOffset
+		for offset := range partition.Offsets {
+			if offset != -1 && offset < first {
+				first = offset
+			}
+		}
+		if first >= last {
+			continue
+		}
+		if err := p.readDeadLetterPartition(ctx, topic, partition.Partition, first, last, end, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Queue) readDeadLetterPartition(ctx context.Context, topic string, partition int, first, last int64, end time.Time, fn func(*DeadLetter) error) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     p.brokers,
+		Dialer:      p.dialer,
+		Topic:       topic,
+		Partition:   partition,
+		MaxBytes:    int(p.MessageSizeBytes),
+		MaxWait:     time.Second,
+		Logger:      getLogger("dead-letter-consumer", topic, log.InfoLevel),
+		ErrorLogger: getLogger("dead-letter-consumer", topic, log.ErrorLevel),
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to close dead letter reader")
+		}
+	}()
+	if err := reader.SetOffset(first); err != nil {
+		return errors.Wrap(err, "failed to set dead letter offset")
+	}
+
+	for {
+		readCtx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+		m, err := reader.ReadMessage(readCtx)
+		cancel()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read dead letter partition %d", partition)
+		}
+		if m.Time.After(end) {
+			return nil
+		}
+		if err := fn(parseDeadLetter(m)); err != nil {
+			return err
+		}
+		if m.Offset >= last-1 {
+			return nil
+		}
+	}
+}
+
+func parseDeadLetter(m kafka.Message) *DeadLetter {
+	carrier := KafkaCarrier{Headers: m.Headers}
+	dl := &DeadLetter{
+		Topic:               carrier.Get(DeadLetterTopicHeader),
+		DeadLetterPartition: m.Partition,
+		DeadLetterOffset:    m.Offset,
+		Time:                m.Time,
+		Error:               carrier.Get(DeadLetterErrorHeader),
+		Key:                 m.Key,
+		Value:               m.Value,
+		Headers:             m.Headers,
+	}
+	dl.Partition, _ = strconv.Atoi(carrier.Get(DeadLetterPartitionHeader))
+	dl.Offset, _ = strconv.ParseInt(carrier.Get(DeadLetterOffsetHeader), 10, 64)
+	dl.Failures, _ = strconv.Atoi(carrier.Get(DeadLetterFailuresHeader))
+	if t, err := time.Parse(time.RFC3339Nano, carrier.Get(DeadLetterTimeHeader)); err == nil {
+		dl.Time = t
+	}
+
+	var msgType struct {
+		Type PayloadType
+	}
+	if err := json.Unmarshal(m.Value, &msgType); err == nil {
+		dl.Type = msgType.Type
+	}
+	return dl
+}
+
+// Redeliver re-submits a dead lettered message to the queue topic with its original key and headers.
+func (p *Queue) Redeliver(ctx context.Context, dl *DeadLetter) error {
+	if p.kafkaP == nil {
+		return errors.New("queue is not configured as a producer")
+	}
+	if dl.Topic != "" && dl.Topic != p.Topic {
+		return errors.Errorf("dead letter from topic %s cannot be redelivered to %s", dl.Topic, p.Topic)
+	}
+	headers := lo.Filter(dl.Headers, func(h kafka.Header, _ int) bool {
+		return !strings.HasPrefix(h.Key, DeadLetterHeaderPrefix)
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+	if err := p.kafkaP.WriteMessages(ctx, kafka.Message{
+		Key:     dl.Key,
+		Value:   dl.Value,
+		Headers: headers,
+	}); err != nil {
+		return errors.Wrap(err, "failed to redeliver dead letter message")
+	}
+	hmetric.Incr(ctx, p.metricPrefix()+"deadLetter.redeliver.count", nil, 1)
+	return nil
+}
+
+func (dl *DeadLetter) String() string {
+	return fmt.Sprintf("%s[%d]@%d type=%d failures=%d time=%s error=%q",
+		dl.Topic, dl.Partition, dl.Offset, dl.Type, dl.Failures, dl.Time.Format(time.RFC3339), dl.Error)
+}
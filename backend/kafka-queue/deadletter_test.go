@@ -0,0 +1,42 @@
+package kafka_queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeadLetter(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	dl := parseDeadLetter(kafka.Message{
+		Partition: 0,
+		Offset:    42,
+		Key:       []byte("session-key"),
+		Value:     []byte(`{"Type":5,"Failures":0}`),
+		Headers: []kafka.Header{
+			{Key: "traceparent", Value: []byte("00-abc-def-01")},
+			{Key: DeadLetterTopicHeader, Value: []byte("dev_topic")},
+			{Key: DeadLetterPartitionHeader, Value: []byte("3")},
+			{Key: DeadLetterOffsetHeader, Value: []byte("1234")},
+			{Key: DeadLetterFailuresHeader, Value: []byte("1")},
+			{Key: DeadLetterErrorHeader, Value: []byte("failed to process")},
+			{Key: DeadLetterTimeHeader, Value: []byte(ts.Format(time.RFC3339Nano))},
+		},
+	})
+
+	assert.Equal(t, "dev_topic", dl.Topic)
+	assert.Equal(t, 3, dl.Partition)
+	assert.Equal(t, int64(1234), dl.Offset)
+	assert.Equal(t, int64(42), dl.DeadLetterOffset)
+	assert.Equal(t, PushBackendPayload, dl.Type)
+	assert.Equal(t, 1, dl.Failures)
+	assert.Equal(t, "failed to process", dl.Error)
+	assert.Equal(t, ts, dl.Time)
+	assert.Equal(t, []byte("session-key"), dl.Key)
+}
+
+func TestGetDeadLetterTopic(t *testing.T) {
+	assert.Equal(t, "dev_topic_traces_dead_letter", GetDeadLetterTopic("dev_topic_traces"))
+}
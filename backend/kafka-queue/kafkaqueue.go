@@ -58,6 +58,9 @@ type Queue struct {
 	Client           *kafka.Client
 	kafkaP           *kafka.Writer
 	kafkaC           *kafka.Reader
+	deadLetterP      *kafka.Writer
+	brokers          []string
+	dialer           *kafka.Dialer
 }
 
 type MessageQueue interface {
@@ -67,6 +70,14 @@ type MessageQueue interface {
 	LogStats()
 }
 
+// ConsumerQueue is a MessageQueue that tracks the consumed offsets of received messages,
+// as used by the kafka workers.
+type ConsumerQueue interface {
+	MessageQueue
+	Commit(context.Context, *kafka.Message)
+	SubmitDeadLetter(context.Context, RetryableMessage, error) error
+}
+
 type TopicType string
 
 const (
@@ -200,14 +211,21 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 				Topic:             topic,
 				NumPartitions:     8,
 				ReplicationFactor: 1,
+			}, {
+				Topic:             GetDeadLetterTopic(topic),
+				NumPartitions:     1,
+				ReplicationFactor: 1,
 			}},
 		})
 		if err != nil {
 			log.WithContext(ctx).Error(errors.Wrap(err, "failed to create dev topic"))
 		}
+	} else if (mode>>1)&1 == 1 {
+		// consumers route failed messages to the dead letter topic
+		createDeadLetterTopic(ctx, client, topic)
 	}
 
-	pool := &Queue{Topic: topic, ConsumerGroup: groupID, Client: client, MessageSizeBytes: MaxMessageSizeBytes}
+	pool := &Queue{Topic: topic, ConsumerGroup: groupID, Client: client, MessageSizeBytes: MaxMessageSizeBytes, brokers: brokers, dialer: dialer}
 	if mode&1 == 1 {
 		pool.kafkaP = &kafka.Writer{
 			Addr:         kafka.TCP(brokers...),
@@ -302,6 +320,20 @@ func New(ctx context.Context, topic string, mode Mode, configOverride *ConfigOve
 		}
 
 		pool.kafkaC = kafka.NewReader(config)
+		pool.deadLetterP = &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Transport:    transport,
+			Topic:        GetDeadLetterTopic(pool.Topic),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			Compression:  kafka.Zstd,
+			BatchBytes:   MaxMessageSizeBytes,
+			BatchTimeout: 10 * time.Millisecond,
+			ReadTimeout:  KafkaOperationTimeout,
+			WriteTimeout: KafkaOperationTimeout,
+			Logger:       getLogger("dead-letter-producer", topic, log.InfoLevel),
+			ErrorLogger:  getLogger("dead-letter-producer", topic, log.ErrorLevel),
+		}
 	}
 
 	go func() {
@@ -331,6 +363,12 @@ func (p *Queue) Stop(ctx context.Context) {
 		}
 		p.kafkaP = nil
 	}
+	if p.deadLetterP != nil {
+		if err := p.deadLetterP.Close(); err != nil {
+			log.WithContext(ctx).Error(errors.Wrap(err, "failed to close dead letter writer"))
+		}
+		p.deadLetterP = nil
+	}
 }
 
 func (p *Queue) Submit(ctx context.Context, partitionKey string, messages ...RetryableMessage) error {
@@ -358,7 +396,7 @@ func (p *Queue) Submit(ctx context.Context, partitionKey string, messages ...Ret
 	var kMessages []kafka.Message
 	for _, msg := range messages {
 		msg.SetMaxRetries(TaskRetries)
-		msgBytes, err := p.serializeMessage(msg)
+		msgBytes, err := serializeMessage(msg)
 		if err != nil {
 			log.WithContext(ctx).Error(errors.Wrap(err, "failed to serialize message"))
 			return err
@@ -406,7 +444,7 @@ func (p *Queue) Receive(ctx context.Context) (context.Context, RetryableMessage)
 	propagator := otel.GetTextMapPropagator()
 	ctx = propagator.Extract(ctx, &carrier)
 
-	msg, err := p.deserializeMessage(m.Value)
+	msg, err := deserializeMessage(m.Value, p.MessageSizeBytes)
 	if err != nil {
 		log.WithContext(ctx).WithField("topic", p.Topic).WithField("partition", m.Partition).WithField("msgBytes", len(m.Value)).Error(errors.Wrap(err, "failed to deserialize message"))
 		return ctx, nil
@@ -505,7 +543,7 @@ func (p *Queue) LogStats() {
 	}
 }
 
-func (p *Queue) serializeMessage(msg RetryableMessage) (compressed []byte, err error) {
+func serializeMessage(msg RetryableMessage) (compressed []byte, err error) {
 	compressed, err = json.Marshal(&msg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshall json")
@@ -513,8 +551,8 @@ func (p *Queue) serializeMessage(msg RetryableMessage) (compressed []byte, err e
 	return
 }
 
-func (p *Queue) deserializeMessage(compressed []byte) (RetryableMessage, error) {
-	if int64(len(compressed)) >= p.MessageSizeBytes {
+func deserializeMessage(compressed []byte, maxSizeBytes int64) (RetryableMessage, error) {
+	if int64(len(compressed)) >= maxSizeBytes {
 		return nil, errors.New("message too large")
 	}
 	var msgType struct {
@@ -0,0 +1,325 @@
+package kafka_queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/util"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+const DefaultMemoryPartitions = 8
+
+// MemoryBroker is an in-process replacement for the kafka cluster so that the
+// public graph -> worker -> clickhouse ingest path can run in a single binary,
+// ie. for local development and integration tests.
+// Like kafka, messages are partitioned by key and each partition of a topic is consumed in order
+// by a single consumer of the (one) consumer group. Messages that are received but not committed
+// are redelivered when consumers join or leave the group. Committed messages are dropped.
+type MemoryBroker struct {
+	mu         sync.Mutex
+	partitions int
+	topics     map[string]*memoryTopic
+	// notify is closed and replaced whenever messages are produced or partitions are reassigned.
+	notify chan struct{}
+}
+
+type memoryTopic struct {
+	log [][]kafka.Message
+	// start is the offset of the first message of each partition log
+	start      []int64
+	committed  []int64
+	position   []int64
+	assignment []*MemoryQueue
+	consumers  []*MemoryQueue
+}
+
+func NewMemoryBroker(partitions int) *MemoryBroker {
+	if partitions <= 0 {
+		partitions = DefaultMemoryPartitions
+	}
+	return &MemoryBroker{
+		partitions: partitions,
+		topics:     map[string]*memoryTopic{},
+		notify:     make(chan struct{}),
+	}
+}
+
+// Queue returns a producer and consumer for the topic.
+// A queue only joins the consumer group once it receives a message.
+func (b *MemoryBroker) Queue(topic string) *MemoryQueue {
+	return &MemoryQueue{Topic: topic, broker: b}
+}
+
+// Messages returns the uncommitted messages of the topic, ie. to inspect a dead letter topic in tests.
+func (b *MemoryBroker) Messages(topic string) []kafka.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var messages []kafka.Message
+	for _, partition := range b.getTopic(topic).log {
+		messages = append(messages, partition...)
+	}
+	return messages
+}
+
+// Lag returns the number of produced messages of the topic that have not been committed.
+func (b *MemoryBroker) Lag(topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t := b.getTopic(topic)
+	var lag int64
+	for p := range t.log {
+		lag += t.end(p) - t.committed[p]
+	}
+	return lag
+}
+
+// Drain waits until every message produced to a consumed topic has been committed, ie. so that the process
+// can stop once the workers have processed the queued messages. Topics without consumers, such as
+// dead letter topics, are not waited on. Returns the context error if ctx is done first.
+func (b *MemoryBroker) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for b.consumedLag() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBroker) consumedLag() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var lag int64
+	for _, t := range b.topics {
+		if len(t.consumers) == 0 {
+			continue
+		}
+		for p := range t.log {
+			lag += t.end(p) - t.committed[p]
+		}
+	}
+	return lag
+}
+
+// getTopic must be called with b.mu held.
+func (b *MemoryBroker) getTopic(topic string) *memoryTopic {
+	t, ok := b.topics[topic]
+	if !ok {
+		t = &memoryTopic{
+			log:        make([][]kafka.Message, b.partitions),
+			start:      make([]int64, b.partitions),
+			committed:  make([]int64, b.partitions),
+			position:   make([]int64, b.partitions),
+			assignment: make([]*MemoryQueue, b.partitions),
+		}
+		b.topics[topic] = t
+	}
+	return t
+}
+
+// end returns the offset of the next message produced to a partition.
+func (t *memoryTopic) end(partition int) int64 {
+	return t.start[partition] + int64(len(t.log[partition]))
+}
+
+// broadcast must be called with b.mu held.
+func (b *MemoryBroker) broadcast() {
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// rebalance assigns partitions round-robin to the consumers of the topic and rewinds
+// every partition to its committed offset so that uncommitted messages are redelivered.
+// It must be called with b.mu held.
+func (b *MemoryBroker) rebalance(t *memoryTopic) {
+	for p := range t.assignment {
+		t.assignment[p] = nil
+		if len(t.consumers) > 0 {
+			t.assignment[p] = t.consumers[p%len(t.consumers)]
+		}
+		t.position[p] = t.committed[p]
+	}
+	b.broadcast()
+}
+
+func (b *MemoryBroker) produce(topic string, messages ...kafka.Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t := b.getTopic(topic)
+	partitions := make([]int, b.partitions)
+	for i := range partitions {
+		partitions[i] = i
+	}
+	balancer := &kafka.Hash{}
+	for _, m := range messages {
+		p := balancer.Balance(m, partitions...)
+		m.Topic = topic
+		m.Partition = p
+		m.Offset = t.end(p)
+		m.Time = time.Now()
+		t.log[p] = append(t.log[p], m)
+	}
+	b.broadcast()
+}
+
+// fetch returns the next message of a partition assigned to the consumer,
+// or a channel that is notified when a message may become available.
+func (b *MemoryBroker) fetch(q *MemoryQueue) (*kafka.Message, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t := b.getTopic(q.Topic)
+	if !q.joined {
+		q.joined = true
+		t.consumers = append(t.consumers, q)
+		b.rebalance(t)
+	}
+	for i := 0; i < b.partitions; i++ {
+		p := (q.nextPartition + i) % b.partitions
+		if t.assignment[p] != q || t.position[p] >= t.end(p) {
+			continue
+		}
+		m := t.log[p][t.position[p]-t.start[p]]
+		t.position[p]++
+		q.nextPartition = (p + 1) % b.partitions
+		return &m, nil
+	}
+	return nil, b.notify
+}
+
+func (b *MemoryBroker) commit(q *MemoryQueue, msg *kafka.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t := b.getTopic(q.Topic)
+	if msg.Partition < 0 || msg.Partition >= b.partitions {
+		return errors.Errorf("invalid partition %d", msg.Partition)
+	}
+	if t.assignment[msg.Partition] != q {
+		return errors.Errorf("partition %d is not assigned to this consumer", msg.Partition)
+	}
+	if msg.Offset+1 > t.committed[msg.Partition] {
+		t.committed[msg.Partition] = msg.Offset + 1
+		// committed messages are never redelivered
+		t.log[msg.Partition] = t.log[msg.Partition][t.committed[msg.Partition]-t.start[msg.Partition]:]
+		t.start[msg.Partition] = t.committed[msg.Partition]
+	}
+	return nil
+}
+
+func (b *MemoryBroker) leave(q *MemoryQueue) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !q.joined {
+		return
+	}
+	q.joined = false
+	t := b.getTopic(q.Topic)
+	for i, c := range t.consumers {
+		if c == q {
+			t.consumers = append(t.consumers[:i], t.consumers[i+1:]...)
+			break
+		}
+	}
+	b.rebalance(t)
+}
+
+// MemoryQueue is a ConsumerQueue backed by a MemoryBroker.
+type MemoryQueue struct {
+	Topic  string
+	broker *MemoryBroker
+	// joined and nextPartition are guarded by broker.mu
+	joined        bool
+	nextPartition int
+}
+
+func (q *MemoryQueue) Stop(_ context.Context) {
+	q.broker.leave(q)
+}
+
+func (q *MemoryQueue) Receive(ctx context.Context) (context.Context, RetryableMessage) {
+	rxCtx, cancel := context.WithTimeout(ctx, KafkaOperationTimeout)
+	defer cancel()
+
+	// clear timeout to return a context with no deadline
+	ctx = context.WithoutCancel(ctx)
+
+	for {
+		m, notify := q.broker.fetch(q)
+		if m == nil {
+			select {
+			case <-notify:
+				continue
+			case <-rxCtx.Done():
+				return ctx, nil
+			}
+		}
+
+		carrier := KafkaCarrier{Headers: m.Headers}
+		ctx = otel.GetTextMapPropagator().Extract(ctx, &carrier)
+
+		msg, err := deserializeMessage(m.Value, MaxMessageSizeBytes)
+		if err != nil {
+			log.WithContext(ctx).WithField("topic", q.Topic).WithField("partition", m.Partition).Error(errors.Wrap(err, "failed to deserialize message"))
+			return ctx, nil
+		}
+		msg.SetKafkaMessage(m)
+		return ctx, msg
+	}
+}
+
+func (q *MemoryQueue) Submit(ctx context.Context, partitionKey string, messages ...RetryableMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if partitionKey == "" {
+		partitionKey = util.GenerateRandomString(32)
+	}
+
+	carrier := KafkaCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, &carrier)
+
+	var kMessages []kafka.Message
+	for _, msg := range messages {
+		msg.SetMaxRetries(TaskRetries)
+		msgBytes, err := serializeMessage(msg)
+		if err != nil {
+			return err
+		}
+		kMessages = append(kMessages, kafka.Message{
+			Key:     []byte(partitionKey),
+			Value:   msgBytes,
+			Headers: carrier.Headers,
+		})
+	}
+	q.broker.produce(q.Topic, kMessages...)
+	return nil
+}
+
+func (q *MemoryQueue) Commit(ctx context.Context, msg *kafka.Message) {
+	if msg == nil {
+		return
+	}
+	if err := q.broker.commit(q, msg); err != nil {
+		log.WithContext(ctx).WithField("topic", q.Topic).Error(errors.Wrap(err, "failed to commit message"))
+	}
+}
+
+func (q *MemoryQueue) SubmitDeadLetter(_ context.Context, msg RetryableMessage, cause error) error {
+	m, err := newDeadLetterMessage(q.Topic, msg, cause)
+	if err != nil {
+		return err
+	}
+	q.broker.produce(GetDeadLetterTopic(q.Topic), m)
+	return nil
+}
+
+func (q *MemoryQueue) LogStats() {
+	log.WithContext(context.Background()).WithField("topic", q.Topic).WithField("lag", q.broker.Lag(q.Topic)).Debug("Memory Queue Stats")
+}
@@ -0,0 +1,122 @@
+package kafka_queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveAll(t *testing.T, ctx context.Context, q *MemoryQueue, n int) []RetryableMessage {
+	var messages []RetryableMessage
+	for i := 0; i < n; i++ {
+		_, msg := q.Receive(ctx)
+		if !assert.NotNil(t, msg) {
+			return messages
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func TestMemoryQueue_OrderedPerKey(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(4)
+	producer := broker.Queue("topic")
+	consumer := broker.Queue("topic")
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, producer.Submit(ctx, "session", &Message{Type: PushBackendPayload, Failures: i}))
+	}
+
+	for i, msg := range receiveAll(t, ctx, consumer, 10) {
+		assert.Equal(t, i, msg.GetFailures())
+		assert.Equal(t, TaskRetries, msg.GetMaxRetries())
+		consumer.Commit(ctx, msg.GetKafkaMessage())
+	}
+	assert.Equal(t, int64(0), broker.Lag("topic"))
+}
+
+func TestMemoryQueue_RedeliversUncommitted(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(1)
+	producer := broker.Queue("topic")
+	assert.NoError(t, producer.Submit(ctx, "key", &Message{Type: PushBackendPayload, Failures: 0}, &Message{Type: PushBackendPayload, Failures: 1}))
+
+	first := broker.Queue("topic")
+	messages := receiveAll(t, ctx, first, 2)
+	first.Commit(ctx, messages[0].GetKafkaMessage())
+	first.Stop(ctx)
+	assert.Equal(t, int64(1), broker.Lag("topic"))
+
+	second := broker.Queue("topic")
+	messages = receiveAll(t, ctx, second, 1)
+	assert.Equal(t, 1, messages[0].GetFailures())
+	second.Commit(ctx, messages[0].GetKafkaMessage())
+	assert.Equal(t, int64(0), broker.Lag("topic"))
+}
+
+func TestMemoryQueue_DropsCommitted(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(1)
+	q := broker.Queue("topic")
+	assert.NoError(t, q.Submit(ctx, "key", &Message{Type: PushBackendPayload, Failures: 0}, &Message{Type: PushBackendPayload, Failures: 1}))
+
+	messages := receiveAll(t, ctx, q, 2)
+	q.Commit(ctx, messages[0].GetKafkaMessage())
+	assert.Len(t, broker.Messages("topic"), 1)
+
+	assert.NoError(t, q.Submit(ctx, "key", &Message{Type: PushBackendPayload, Failures: 2}))
+	messages = receiveAll(t, ctx, q, 1)
+	assert.Equal(t, 2, messages[0].GetFailures())
+	assert.Equal(t, int64(2), messages[0].GetKafkaMessage().Offset)
+	q.Commit(ctx, messages[0].GetKafkaMessage())
+	assert.Empty(t, broker.Messages("topic"))
+	assert.Equal(t, int64(0), broker.Lag("topic"))
+}
+
+func TestMemoryQueue_ReceiveTimesOut(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q := NewMemoryBroker(1).Queue("topic")
+	_, msg := q.Receive(ctx)
+	assert.Nil(t, msg)
+}
+
+func TestMemoryQueue_SubmitDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(1)
+	q := broker.Queue("topic")
+	assert.NoError(t, q.Submit(ctx, "key", &Message{Type: PushBackendPayload}))
+	msg := receiveAll(t, ctx, q, 1)[0]
+
+	assert.NoError(t, q.SubmitDeadLetter(ctx, msg, errors.New("failed to process")))
+	deadLetters := broker.Messages(GetDeadLetterTopic("topic"))
+	if assert.Len(t, deadLetters, 1) {
+		dl := parseDeadLetter(deadLetters[0])
+		assert.Equal(t, "topic", dl.Topic)
+		assert.Equal(t, PushBackendPayload, dl.Type)
+		assert.Equal(t, "failed to process", dl.Error)
+		assert.Equal(t, []byte("key"), dl.Key)
+	}
+}
+
+func TestMemoryBroker_Drain(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(2)
+	producer := broker.Queue("topic")
+	consumer := broker.Queue("topic")
+	assert.NoError(t, producer.Submit(ctx, "key", &Message{Type: PushBackendPayload}))
+	_, msg := consumer.Receive(ctx)
+	// dead letter topics are not consumed and do not block draining
+	assert.NoError(t, consumer.SubmitDeadLetter(ctx, msg, errors.New("failed to process")))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, broker.Drain(timeoutCtx), context.DeadlineExceeded)
+
+	consumer.Commit(ctx, msg.GetKafkaMessage())
+	assert.NoError(t, broker.Drain(ctx))
+}
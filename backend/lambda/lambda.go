@@ -178,16 +178,19 @@ const (
 	ReactEmailTemplateTrackEventAlert ReactEmailTemplate = "track-event-properties-alert"
 	ReactEmailTemplateTrackUserAlert  ReactEmailTemplate = "track-user-properties-alert"
 	// new alert emails
-	ReactEmailTemplateSessionsAlert ReactEmailTemplate = "sessions-alert"
-	ReactEmailTemplateErrorsAlert   ReactEmailTemplate = "errors-alert"
-	ReactEmailTemplateLogsAlert     ReactEmailTemplate = "logs-alert"
-	ReactEmailTemplateTracesAlert   ReactEmailTemplate = "traces-alert"
-	ReactEmailTemplateMetricsAlert  ReactEmailTemplate = "metrics-alert"
-	ReactEmailTemplateEventsAlert   ReactEmailTemplate = "events-alert"
+	ReactEmailTemplateSessionsAlert  ReactEmailTemplate = "sessions-alert"
+	ReactEmailTemplateErrorsAlert    ReactEmailTemplate = "errors-alert"
+	ReactEmailTemplateLogsAlert      ReactEmailTemplate = "logs-alert"
+	ReactEmailTemplateTracesAlert    ReactEmailTemplate = "traces-alert"
+	ReactEmailTemplateMetricsAlert   ReactEmailTemplate = "metrics-alert"
+	ReactEmailTemplateEventsAlert    ReactEmailTemplate = "events-alert"
+	ReactEmailTemplateCompositeAlert ReactEmailTemplate = "composite-alert"
+	ReactEmailTemplateChangeAlert    ReactEmailTemplate = "change-alert"
 	// session insights
 	ReactEmailTemplateSessionInsights ReactEmailTemplate = "session-insights"
 	// notifications
-	ReactEmailTemplateAlertUpsert ReactEmailTemplate = "alert-upsert"
+	ReactEmailTemplateAlertUpsert   ReactEmailTemplate = "alert-upsert"
+	ReactEmailTemplateAlertResolved ReactEmailTemplate = "alert-resolved"
 )
 
 func (s *Client) GetSessionInsightEmailHtml(ctx context.Context, toEmail string, unsubscribeUrl string, data utils.SessionInsightsData) (string, error) {
@@ -0,0 +1,266 @@
+package livetail
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	hredis "github.com/highlight-run/highlight/backend/redis"
+	e "github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxRowsPerSecond caps the rows streamed to each connection. Matching rows above the cap
+// are counted and reported as dropped with the next payload.
+const MaxRowsPerSecond = 100
+
+// MaxTailsPerProject caps the concurrent tails of a project on each server, as each tail holds a redis connection.
+const MaxTailsPerProject = 10
+
+// ActiveExpiry is how long ingest keeps publishing the rows of a project after a tail last refreshed it.
+const ActiveExpiry = time.Minute
+
+// PublishLogs publishes flushed log rows of projects which are being tailed.
+func PublishLogs(ctx context.Context, redisClient *hredis.Client, rows []*clickhouse.LogRow) error {
+	return publish(ctx, redisClient, model.PricingProductTypeLogs, rows, func(row *clickhouse.LogRow) uint32 {
+		return row.ProjectId
+	})
+}
+
+// PublishTraces publishes flushed trace rows of projects which are being tailed.
+func PublishTraces(ctx context.Context, redisClient *hredis.Client, rows []*clickhouse.ClickhouseTraceRow) error {
+	return publish(ctx, redisClient, model.PricingProductTypeTraces, rows, func(row *clickhouse.ClickhouseTraceRow) uint32 {
+		return row.ProjectId
+	})
+}
+
+func publish[TRow any](ctx context.Context, redisClient *hredis.Client, productType model.PricingProductType, rows []*TRow, getProjectID func(*TRow) uint32) error {
+	for projectID, projectRows := range lo.GroupBy(rows, getProjectID) {
+		active, err := redisClient.IsLiveTailActive(ctx, productType, int(projectID))
+		if err != nil {
+			return err
+		} else if !active {
+			continue
+		}
+
+		payload, err := json.Marshal(projectRows)
+		if err != nil {
+			return e.Wrap(err, "error marshaling live tail rows")
+		}
+		if err := redisClient.PublishLiveTail(ctx, productType, int(projectID), payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tail streams the rows of a project matching a search query as they are ingested.
+type Tail[TRow any, TPayload any] struct {
+	messages  <-chan *redis.Message
+	close     func() error
+	keepAlive func(ctx context.Context) error
+	matches   func(*TRow) bool
+	payload   func(rows []*TRow, dropped int) TPayload
+	limiter   *limiter
+}
+
+// TailLogs subscribes to the logs of the project matching the query.
+func TailLogs(ctx context.Context, redisClient *hredis.Client, projectID int, query string) (*Tail[clickhouse.LogRow, *modelInputs.LogsTailPayload], error) {
+	if err := parser.ValidateQuery(query, clickhouse.LogsTableConfig); err != nil {
+		return nil, err
+	}
+	filters := parser.Parse(query, clickhouse.LogsTableConfig)
+	return subscribe(ctx, redisClient, model.PricingProductTypeLogs, projectID,
+		func(row *clickhouse.LogRow) bool {
+			return clickhouse.LogMatchesQuery(row, filters)
+		},
+		logsPayload)
+}
+
+func logsPayload(rows []*clickhouse.LogRow, dropped int) *modelInputs.LogsTailPayload {
+	return &modelInputs.LogsTailPayload{
+		Logs:    lo.Map(rows, func(row *clickhouse.LogRow, _ int) *modelInputs.Log { return row.AsLog() }),
+		Dropped: dropped,
+	}
+}
+
+// TailTraces subscribes to the trace spans of the project matching the query.
+func TailTraces(ctx context.Context, redisClient *hredis.Client, projectID int, query string) (*Tail[clickhouse.ClickhouseTraceRow, *modelInputs.TracesTailPayload], error) {
+	if err := parser.ValidateQuery(query, clickhouse.TracesTableConfig); err != nil {
+		return nil, err
+	}
+	filters := parser.Parse(query, clickhouse.TracesTableConfig)
+	return subscribe(ctx, redisClient, model.PricingProductTypeTraces, projectID,
+		func(row *clickhouse.ClickhouseTraceRow) bool {
+			return clickhouse.ClickhouseTraceMatchesQuery(row, filters)
+		},
+		tracesPayload)
+}
+
+func tracesPayload(rows []*clickhouse.ClickhouseTraceRow, dropped int) *modelInputs.TracesTailPayload {
+	return &modelInputs.TracesTailPayload{
+		Traces:  lo.Map(rows, func(row *clickhouse.ClickhouseTraceRow, _ int) *modelInputs.Trace { return row.AsTrace() }),
+		Dropped: dropped,
+	}
+}
+
+func subscribe[TRow any, TPayload any](ctx context.Context, redisClient *hredis.Client, productType model.PricingProductType, projectID int, matches func(*TRow) bool, payload func([]*TRow, int) TPayload) (*Tail[TRow, TPayload], error) {
+	if !projectTails.acquire(projectID) {
+		return nil, e.Errorf("project %d has too many live tails, the limit is %d", projectID, MaxTailsPerProject)
+	}
+
+	keepAlive := func(ctx context.Context) error {
+		return redisClient.SetLiveTailActive(ctx, productType, projectID, ActiveExpiry)
+	}
+	if err := keepAlive(ctx); err != nil {
+		projectTails.release(projectID)
+		return nil, err
+	}
+
+	pubsub, err := redisClient.SubscribeLiveTail(ctx, productType, projectID)
+	if err != nil {
+		projectTails.release(projectID)
+		return nil, err
+	}
+
+	return &Tail[TRow, TPayload]{
+		messages: pubsub.Channel(),
+		close: func() error {
+			defer projectTails.release(projectID)
+			return pubsub.Close()
+		},
+		keepAlive: keepAlive,
+		matches:   matches,
+		payload:   payload,
+		limiter:   newLimiter(MaxRowsPerSecond, time.Second),
+	}, nil
+}
+
+// Run streams payloads to the channel until the context is done or the subscription is closed.
+// Rows dropped by the rate cap are reported at least once a second, even if no further rows are admitted.
+func (t *Tail[TRow, TPayload]) Run(ctx context.Context, ch chan<- TPayload) {
+	defer func() {
+		if err := t.close(); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to close live tail subscription")
+		}
+	}()
+
+	keepAliveTicker := time.NewTicker(ActiveExpiry / 2)
+	defer keepAliveTicker.Stop()
+	droppedTicker := time.NewTicker(t.limiter.window)
+	defer droppedTicker.Stop()
+
+	send := func(payload TPayload) bool {
+		select {
+		case ch <- payload:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAliveTicker.C:
+			// the subscription context may be cancelled before the connection closes
+			if err := t.keepAlive(context.Background()); err != nil {
+				log.WithContext(ctx).WithError(err).Error("failed to refresh live tail")
+			}
+		case <-droppedTicker.C:
+			if dropped := t.limiter.takeDropped(); dropped > 0 && !send(t.payload(nil, dropped)) {
+				return
+			}
+		case msg, ok := <-t.messages:
+			if !ok {
+				return
+			}
+			var rows []*TRow
+			if err := json.Unmarshal([]byte(msg.Payload), &rows); err != nil {
+				log.WithContext(ctx).WithError(err).Error("failed to unmarshal live tail rows")
+				continue
+			}
+
+			matched := lo.Filter(rows, func(row *TRow, _ int) bool { return t.matches(row) })
+			admitted := t.limiter.admit(len(matched), time.Now())
+			if admitted == 0 {
+				continue
+			}
+			if !send(t.payload(matched[:admitted], t.limiter.takeDropped())) {
+				return
+			}
+		}
+	}
+}
+
+// tailCounter counts the open tails of each project.
+type tailCounter struct {
+	mu        sync.Mutex
+	max       int
+	byProject map[int]int
+}
+
+var projectTails = newTailCounter(MaxTailsPerProject)
+
+func newTailCounter(max int) *tailCounter {
+	return &tailCounter{max: max, byProject: map[int]int{}}
+}
+
+// acquire reserves a tail of the project, returning false if the project is at the limit.
+func (c *tailCounter) acquire(projectID int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byProject[projectID] >= c.max {
+		return false
+	}
+	c.byProject[projectID]++
+	return true
+}
+
+func (c *tailCounter) release(projectID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byProject[projectID]--; c.byProject[projectID] <= 0 {
+		delete(c.byProject, projectID)
+	}
+}
+
+// limiter admits up to max rows per window, counting the rows it drops.
+type limiter struct {
+	max         int
+	window      time.Duration
+	windowStart time.Time
+	admitted    int
+	dropped     int
+}
+
+func newLimiter(max int, window time.Duration) *limiter {
+	return &limiter{max: max, window: window}
+}
+
+// admit returns how many of n rows fit in the current window.
+func (l *limiter) admit(n int, now time.Time) int {
+	if now.Sub(l.windowStart) >= l.window {
+		l.windowStart = now
+		l.admitted = 0
+	}
+	admitted := min(n, l.max-l.admitted)
+	l.admitted += admitted
+	l.dropped += n - admitted
+	return admitted
+}
+
+// takeDropped returns the rows dropped since it was last called.
+func (l *limiter) takeDropped() int {
+	dropped := l.dropped
+	l.dropped = 0
+	return dropped
+}
@@ -0,0 +1,92 @@
+package livetail
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/parser"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(10, time.Second)
+
+	assert.Equal(t, 4, l.admit(4, now))
+	assert.Equal(t, 6, l.admit(8, now.Add(100*time.Millisecond)))
+	assert.Equal(t, 0, l.admit(3, now.Add(900*time.Millisecond)))
+	assert.Equal(t, 5, l.takeDropped())
+	assert.Equal(t, 0, l.takeDropped())
+
+	// a new window admits rows again
+	assert.Equal(t, 10, l.admit(12, now.Add(time.Second)))
+	assert.Equal(t, 2, l.takeDropped())
+}
+
+func TestTailCounter(t *testing.T) {
+	c := newTailCounter(2)
+	assert.True(t, c.acquire(1))
+	assert.True(t, c.acquire(1))
+	assert.False(t, c.acquire(1))
+	// other projects have their own limit
+	assert.True(t, c.acquire(2))
+
+	c.release(1)
+	assert.True(t, c.acquire(1))
+	c.release(1)
+	c.release(1)
+	c.release(2)
+	assert.Empty(t, c.byProject)
+}
+
+func TestTailRun(t *testing.T) {
+	messages := make(chan *redis.Message)
+	filters := parser.Parse("level=error service_name=api", clickhouse.LogsTableConfig)
+	tail := &Tail[clickhouse.LogRow, *modelInputs.LogsTailPayload]{
+		messages:  messages,
+		close:     func() error { return nil },
+		keepAlive: func(ctx context.Context) error { return nil },
+		matches: func(row *clickhouse.LogRow) bool {
+			return clickhouse.LogMatchesQuery(row, filters)
+		},
+		payload: logsPayload,
+		limiter: newLimiter(2, time.Hour),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *modelInputs.LogsTailPayload)
+	done := make(chan struct{})
+	go func() {
+		tail.Run(ctx, ch)
+		close(done)
+	}()
+
+	publish := func(rows ...*clickhouse.LogRow) {
+		payload, err := json.Marshal(rows)
+		assert.NoError(t, err)
+		messages <- &redis.Message{Payload: string(payload)}
+	}
+	row := func(level string, body string) *clickhouse.LogRow {
+		return clickhouse.NewLogRow(time.Now(), 1, clickhouse.WithSeverityText(level), clickhouse.WithServiceName("api"), clickhouse.WithBody(ctx, body))
+	}
+
+	publish(row("error", "first"), row("info", "skipped"))
+	payload := <-ch
+	assert.Equal(t, 1, len(payload.Logs))
+	assert.Equal(t, "first", payload.Logs[0].Message)
+	assert.Equal(t, 0, payload.Dropped)
+
+	publish(row("error", "second"), row("error", "third"), row("error", "fourth"))
+	payload = <-ch
+	assert.Equal(t, 1, len(payload.Logs))
+	assert.Equal(t, "second", payload.Logs[0].Message)
+	assert.Equal(t, 2, payload.Dropped)
+
+	cancel()
+	<-done
+}
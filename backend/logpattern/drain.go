@@ -0,0 +1,96 @@
+package logpattern
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parameters of the clustering, as in "Drain: An Online Log Parsing Approach with Fixed Depth Tree" (He et al., 2017).
+const (
+	// leadingTokens is the number of leading tokens routing a template to its candidate clusters.
+	leadingTokens = 1
+	// SimilarityThreshold is the fraction of its tokens a template must share with a cluster to join it.
+	SimilarityThreshold = 0.5
+)
+
+var placeholders = []string{Wildcard, String, UUID, IP, Hex, Number}
+
+// Cluster is a group of similar templates.
+type Cluster struct {
+	// Tokens of the template of the cluster, where the tokens differing between its members are wildcards.
+	Tokens []string
+}
+
+// Template returns the pattern shared by the members of the cluster.
+func (c *Cluster) Template() string {
+	return strings.Join(c.Tokens, " ")
+}
+
+// Miner clusters the templates of log bodies by similarity, as in Drain. Templates are routed to candidate
+// clusters with the same number of tokens and the same leading tokens, which is the fixed depth parse tree
+// of Drain, and join the most similar candidate if they share at least SimilarityThreshold of their tokens.
+// Otherwise they start a new cluster. Use NewMiner to create a Miner.
+type Miner struct {
+	groups map[string][]*Cluster
+}
+
+func NewMiner() *Miner {
+	return &Miner{groups: map[string][]*Cluster{}}
+}
+
+// Add assigns the tokens of a template, as returned by Tokenize, to a cluster and returns the cluster.
+// The tokens of the cluster which differ from the template are replaced by wildcards.
+func (m *Miner) Add(tokens []string) *Cluster {
+	key := groupKey(tokens)
+
+	var best *Cluster
+	bestSimilarity := -1.0
+	for _, cluster := range m.groups[key] {
+		if s := similarity(cluster.Tokens, tokens); s > bestSimilarity {
+			best, bestSimilarity = cluster, s
+		}
+	}
+
+	if best != nil && bestSimilarity >= SimilarityThreshold {
+		for idx, token := range tokens {
+			if best.Tokens[idx] != token {
+				best.Tokens[idx] = Wildcard
+			}
+		}
+		return best
+	}
+
+	cluster := &Cluster{Tokens: slices.Clone(tokens)}
+	m.groups[key] = append(m.groups[key], cluster)
+	return cluster
+}
+
+// groupKey is the path of a template in the parse tree: its number of tokens followed by its leading tokens,
+// where variable tokens are routed to a wildcard so that they do not split clusters.
+func groupKey(tokens []string) string {
+	key := []string{strconv.Itoa(len(tokens))}
+	for idx := 0; idx < leadingTokens && idx < len(tokens); idx++ {
+		token := tokens[idx]
+		if slices.Contains(placeholders, token) {
+			token = Wildcard
+		}
+		key = append(key, token)
+	}
+	return strings.Join(key, " ")
+}
+
+// similarity is the fraction of the tokens of a template equal to the tokens of a cluster of the same length.
+// Wildcards of the cluster are parameters and do not count as similar.
+func similarity(cluster []string, tokens []string) float64 {
+	if len(tokens) == 0 {
+		return 1
+	}
+	matches := 0
+	for idx, token := range tokens {
+		if cluster[idx] != Wildcard && cluster[idx] == token {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(tokens))
+}
@@ -0,0 +1,113 @@
+package logpattern
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Placeholders replacing the variable parts of a log body.
+const (
+	Wildcard = "<*>"
+	String   = "<str>"
+	UUID     = "<uuid>"
+	IP       = "<ip>"
+	Hex      = "<hex>"
+	Number   = "<num>"
+)
+
+// maxTokens caps the length of a template, the remaining tokens are replaced by a single truncation token.
+const maxTokens = 64
+const truncation = "..."
+
+// masks are applied to the whole body in order, so that quoted strings are
+// replaced before the numbers or ids they may contain. The first submatch of
+// a pattern is kept in front of the placeholder.
+var masks = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+	// variable optionally reports whether a match should be masked
+	variable func(string) bool
+}{
+	// a single quote after a letter or digit is an apostrophe, as in "couldn't"
+	{regexp.MustCompile(`"(?:[^"\\]|\\.)*"|(^|[^\pL\pN])'(?:[^'\\]|\\.)*'`), String, nil},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), UUID, nil},
+	{regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}(?::\d{1,5})?\b`), IP, nil},
+	{regexp.MustCompile(`(?i)\b(?:[0-9a-f]{1,4}:){7}[0-9a-f]{1,4}\b|\b(?:[0-9a-f]{1,4}:)+:(?:[0-9a-f]{1,4}:)*[0-9a-f]{1,4}\b`), IP, nil},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b|\b[0-9a-f]{8,}\b`), Hex, isHex},
+	{regexp.MustCompile(`[-+]?\b\d+(?:\.\d+)?(?:[eE][-+]?\d+)?\b`), Number, nil},
+}
+
+// Tokenize splits a log body into the tokens of its template, masking the variable parts.
+// As in Drain, any token which still contains a digit after masking is replaced by a wildcard.
+// Bodies longer than the maximum number of tokens are truncated before masking.
+func Tokenize(body string) []string {
+	body, truncated := truncate(body)
+	for _, mask := range masks {
+		body = mask.pattern.ReplaceAllStringFunc(body, func(match string) string {
+			if mask.variable != nil && !mask.variable(match) {
+				return match
+			}
+			if mask.pattern.NumSubexp() > 0 {
+				return mask.pattern.FindStringSubmatch(match)[1] + mask.placeholder
+			}
+			return mask.placeholder
+		})
+	}
+
+	tokens := strings.Fields(body)
+	if truncated {
+		tokens = append(tokens, truncation)
+	}
+	for idx, token := range tokens {
+		if hasDigit(token) {
+			tokens[idx] = Wildcard
+		}
+	}
+	return tokens
+}
+
+// Template returns the pattern of a log body with its variable parts masked.
+func Template(body string) string {
+	return strings.Join(Tokenize(body), " ")
+}
+
+// ID returns the pattern id of a log body. Bodies sharing a template share an id,
+// which is stable across processes so that it can be assigned at ingest.
+// Similar templates are clustered when patterns are read, see Miner.
+func ID(body string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(Template(body)))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// truncate returns the prefix of a body holding its first maxTokens fields and whether the body was cut.
+func truncate(body string) (string, bool) {
+	fields := 0
+	inField := false
+	for idx, r := range body {
+		if unicode.IsSpace(r) {
+			inField = false
+			continue
+		}
+		if !inField {
+			if fields == maxTokens {
+				return body[:idx], true
+			}
+			fields++
+			inField = true
+		}
+	}
+	return body, false
+}
+
+func hasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) != -1
+}
+
+// isHex reports whether a hex word is an id rather than text such as "deadbeef" or a plain number.
+func isHex(s string) bool {
+	return strings.HasPrefix(strings.ToLower(s), "0x") || (hasDigit(s) && strings.ContainsAny(strings.ToLower(s), "abcdef"))
+}
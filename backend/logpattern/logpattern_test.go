@@ -0,0 +1,63 @@
+package logpattern
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	for body, expected := range map[string]string{
+		"user 123 logged in after 45.6ms":                                          "user <num> logged in after <*>",
+		"request 5f0c5e2a-9b7d-4a43-8f3b-2d9c0e6b1a7f completed":                   "request <uuid> completed",
+		"connection from 10.0.12.4:5432 refused":                                   "connection from <ip> refused",
+		"dial tcp [2001:db8:85a3:0:0:8a2e:370:7334]:443 timeout":                   "dial tcp [<ip>]:<num> timeout",
+		"pointer 0xc000123abc and commit 9fceb02d0ae598e95dc970b74767f19372d61af8": "pointer <hex> and commit <hex>",
+		`failed to find key "user:42" in 'sessions'`:                               "failed to find key <str> in <str>",
+		"deadbeef cafe 12345678":                                                   "deadbeef cafe <num>",
+		"retry=3 worker-v2 status=ok":                                              "retry=<num> <*> status=ok",
+		"  spaced\tout \n message ":                                                "spaced out message",
+		"couldn't connect, won't retry":                                            "couldn't connect, won't retry",
+		"user's session 'abc' isn't valid":                                         "user's session <str> isn't valid",
+		"it's '42' and 'x' now":                                                    "it's <str> and <str> now",
+	} {
+		assert.Equal(t, expected, Template(body), body)
+	}
+}
+
+func TestTemplateTruncation(t *testing.T) {
+	tokens := Tokenize(strings.Repeat("word ", maxTokens+10))
+	assert.Len(t, tokens, maxTokens+1)
+	assert.Equal(t, truncation, tokens[maxTokens])
+
+	tokens = Tokenize(strings.Repeat("word ", maxTokens) + `"unterminated ` + strings.Repeat("x ", 1000))
+	assert.Len(t, tokens, maxTokens+1)
+	assert.Equal(t, "word", tokens[maxTokens-1])
+}
+
+func TestID(t *testing.T) {
+	id := ID("user 123 logged in from 10.0.0.1")
+	assert.Len(t, id, 16)
+	assert.Equal(t, id, ID("user 456 logged in from 192.168.1.20"))
+	assert.NotEqual(t, id, ID("user 456 logged out from 192.168.1.20"))
+}
+
+func TestMiner(t *testing.T) {
+	miner := NewMiner()
+	login := miner.Add(Tokenize("user alice logged in from web"))
+	assert.Same(t, login, miner.Add(Tokenize("user bob logged in from mobile")))
+	assert.Equal(t, "user <*> logged in from <*>", login.Template())
+
+	// templates with a different number of tokens or different leading tokens are not compared
+	assert.NotSame(t, login, miner.Add(Tokenize("user carol logged in")))
+	assert.NotSame(t, login, miner.Add(Tokenize("admin dave logged in from web")))
+	// leading variables do not split clusters
+	assert.Same(t, miner.Add(Tokenize("42 rows inserted into events")), miner.Add(Tokenize("7 rows inserted into sessions")))
+
+	// templates sharing less than the similarity threshold start a new cluster
+	cache := miner.Add(Tokenize("user eve cache miss for key"))
+	assert.NotSame(t, login, cache)
+	assert.Equal(t, "user eve cache miss for key", cache.Template())
+	assert.Equal(t, "user <*> logged in from <*>", login.Template())
+}
@@ -6,8 +6,10 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	publicModel "github.com/highlight-run/highlight/backend/public-graph/graph/model"
@@ -85,7 +87,7 @@ func init() {
 	runtimeParsed, handlerParsed = util.GetRuntime()
 }
 
-func healthRouter(runtimeFlag util.Runtime, db *gorm.DB, rClient *redis.Client, ccClient *clickhouse.Client, queue *kafkaqueue.Queue, batchedQueue *kafkaqueue.Queue) http.HandlerFunc {
+func healthRouter(runtimeFlag util.Runtime, db *gorm.DB, rClient *redis.Client, ccClient *clickhouse.Client, queue kafkaqueue.MessageQueue, batchedQueue kafkaqueue.MessageQueue) http.HandlerFunc {
 	// only checks kafka because kafka is the only critical infrastructure needed for public graph to be healthy.
 	topic := kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault})
 	batchedTopic := kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeBatched})
@@ -186,6 +188,10 @@ func validateOrigin(_ *http.Request, origin string) (bool, []string) {
 }
 
 var defaultPort = "8082"
+var defaultOTLPGRPCPort = "4317"
+
+// shutdownTimeout bounds the graceful stop of the HTTP listener and the draining of the in-memory queue.
+const shutdownTimeout = 30 * time.Second
 
 func main() {
 	rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -307,24 +313,41 @@ func main() {
 		}
 	}
 
+	// the in-memory queue replaces kafka when the public graph and workers run in the same process
+	var memoryBroker *kafkaqueue.MemoryBroker
+	if *env.InMemoryQueueFlag {
+		if runtimeParsed != util.All {
+			log.WithContext(ctx).Fatalf("the in-memory queue is not supported for runtime %s", runtimeParsed)
+		}
+		log.WithContext(ctx).Info("using in-memory message queue")
+		memoryBroker = kafkaqueue.NewMemoryBroker(kafkaqueue.DefaultMemoryPartitions)
+	}
+	newProducer := func(topicType kafkaqueue.TopicType, configOverride *kafkaqueue.ConfigOverride) kafkaqueue.MessageQueue {
+		topic := kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: topicType})
+		if memoryBroker != nil {
+			return memoryBroker.Queue(topic)
+		}
+		return kafkaqueue.New(ctx, topic, kafkaqueue.Producer, configOverride)
+	}
+
 	// sync writes with batching per-partition
-	kafkaProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}), kafkaqueue.Producer, nil)
+	kafkaProducer := newProducer(kafkaqueue.TopicTypeDefault, nil)
 	// sync writes without batching
-	kafkaDataSyncProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDataSync}), kafkaqueue.Producer, &kafkaqueue.ConfigOverride{BatchSize: ptr.Int(1)})
+	kafkaDataSyncProducer := newProducer(kafkaqueue.TopicTypeDataSync, &kafkaqueue.ConfigOverride{BatchSize: ptr.Int(1)})
 
 	// async writes for workers (where order of write between workers does not matter)
 	kCfg := &kafkaqueue.ConfigOverride{Async: ptr.Bool(true)}
-	kafkaAsyncProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}), kafkaqueue.Producer, kCfg)
+	kafkaAsyncProducer := newProducer(kafkaqueue.TopicTypeDefault, kCfg)
 	defer kafkaAsyncProducer.Stop(ctx)
-	kafkaBatchedProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeBatched}), kafkaqueue.Producer, kCfg)
+	kafkaBatchedProducer := newProducer(kafkaqueue.TopicTypeBatched, kCfg)
 	defer kafkaBatchedProducer.Stop(ctx)
-	kafkaTracesProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeTraces}), kafkaqueue.Producer, kCfg)
+	kafkaTracesProducer := newProducer(kafkaqueue.TopicTypeTraces, kCfg)
 	defer kafkaTracesProducer.Stop(ctx)
-	kafkaMetricSumProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricSum}), kafkaqueue.Producer, kCfg)
+	kafkaMetricSumProducer := newProducer(kafkaqueue.TopicTypeMetricSum, kCfg)
 	defer kafkaMetricSumProducer.Stop(ctx)
-	kafkaMetricHistogramProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricHistogram}), kafkaqueue.Producer, kCfg)
+	kafkaMetricHistogramProducer := newProducer(kafkaqueue.TopicTypeMetricHistogram, kCfg)
 	defer kafkaMetricHistogramProducer.Stop(ctx)
-	kafkaMetricSummaryProducer := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeMetricSummary}), kafkaqueue.Producer, kCfg)
+	kafkaMetricSummaryProducer := newProducer(kafkaqueue.TopicTypeMetricSummary, kCfg)
 	defer kafkaMetricSummaryProducer.Stop(ctx)
 
 	var lambdaClient *lambda.Client
@@ -431,6 +454,18 @@ func main() {
 	privateResolver.RH = &rh
 	defer rh.Close()
 
+	// SIGINT and SIGTERM stop the OTLP gRPC listener gracefully. Once the signal is captured the process no longer
+	// exits on its own, so the HTTP listener is stopped on the same signal and main returns after both listeners
+	// have stopped, running the deferred shutdown. The worker runtime has no listeners and keeps the default
+	// signal handling.
+	shutdownCtx := ctx
+	if runtimeParsed != util.Worker {
+		var stop context.CancelFunc
+		shutdownCtx, stop = signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+	}
+	var listeners sync.WaitGroup
+
 	/*
 		Selectively turn on backends depending on the input flag
 		If type is 'all', we run public-graph on /public and private-graph on /private
@@ -570,6 +605,17 @@ func main() {
 		})
 		otelHandler := otel.New(publicResolver)
 		otelHandler.Listen(r)
+		listeners.Add(1)
+		go func() {
+			defer listeners.Done()
+			otlpGRPCPort := defaultOTLPGRPCPort
+			if env.Config.OTLPGRPCPort != "" {
+				otlpGRPCPort = env.Config.OTLPGRPCPort
+			}
+			if err := otelHandler.ListenGRPC(shutdownCtx, ":"+otlpGRPCPort); err != nil {
+				log.WithContext(ctx).WithError(err).WithField("port", otlpGRPCPort).Error("failed to run OTLP gRPC listener")
+			}
+		}()
 		vercel.Listen(r, tracerNoResources)
 		highlightHttp.Listen(r, tracerNoResources)
 	}
@@ -608,7 +654,7 @@ func main() {
 			LambdaClient:         lambdaClient,
 			SessionCache:         sessionCache,
 		}
-		w := &worker.Worker{Resolver: privateResolver, PublicResolver: publicResolver, StorageClient: storageClient}
+		w := &worker.Worker{Resolver: privateResolver, PublicResolver: publicResolver, StorageClient: storageClient, MemoryBroker: memoryBroker}
 		if runtimeParsed == util.Worker {
 			w.GetHandler(ctx, handlerParsed)(ctx)
 		} else {
@@ -624,33 +670,56 @@ func main() {
 			go w.GetPublicWorker(kafkaqueue.TopicTypeMetricHistogram)(ctx)
 			go w.GetPublicWorker(kafkaqueue.TopicTypeMetricSummary)(ctx)
 			go w.ScheduledTasks(ctx)
-			if env.IsDevEnv() && env.UseSSL() {
-				log.WithContext(ctx).
-					WithField("runtime", runtimeParsed).
-					WithField("port", defaultPort).
-					Info("running HTTPS listener")
-				log.Fatal(http.ListenAndServeTLS(":"+defaultPort, localhostCertPath, localhostKeyPath, r))
-			} else {
-				log.WithContext(ctx).
-					WithField("runtime", runtimeParsed).
-					WithField("port", defaultPort).
-					Info("running HTTP listener")
-				log.Fatal(http.ListenAndServe(":"+defaultPort, r))
-			}
+			serveHTTP(shutdownCtx, r)
 		}
 	} else {
-		if env.IsDevEnv() && env.UseSSL() {
-			log.WithContext(ctx).
-				WithField("runtime", runtimeParsed).
-				WithField("port", defaultPort).
-				Info("running HTTPS listener")
-			log.Fatal(http.ListenAndServeTLS(":"+defaultPort, localhostCertPath, localhostKeyPath, r))
-		} else {
-			log.WithContext(ctx).
-				WithField("runtime", runtimeParsed).
-				WithField("port", defaultPort).
-				Info("running HTTP listener")
-			log.Fatal(http.ListenAndServe(":"+defaultPort, r))
+		serveHTTP(shutdownCtx, r)
+	}
+
+	listeners.Wait()
+	if memoryBroker != nil {
+		// the listeners no longer submit messages, so wait for the workers to process the queued ones
+		drainCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		if err := memoryBroker.Drain(drainCtx); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to drain the in-memory queue before shutting down")
 		}
 	}
+	log.WithContext(ctx).WithField("runtime", runtimeParsed).Info("shutting down")
+}
+
+// serveHTTP serves the router until shutdownCtx is cancelled. In-flight requests are then given
+// shutdownTimeout to complete before serveHTTP returns.
+func serveHTTP(shutdownCtx context.Context, handler http.Handler) {
+	server := &http.Server{Addr: ":" + defaultPort, Handler: handler}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-shutdownCtx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to shut down HTTP listener")
+		}
+	}()
+
+	var err error
+	if env.IsDevEnv() && env.UseSSL() {
+		log.WithContext(shutdownCtx).
+			WithField("runtime", runtimeParsed).
+			WithField("port", defaultPort).
+			Info("running HTTPS listener")
+		err = server.ListenAndServeTLS(localhostCertPath, localhostKeyPath)
+	} else {
+		log.WithContext(shutdownCtx).
+			WithField("runtime", runtimeParsed).
+			WithField("port", defaultPort).
+			Info("running HTTP listener")
+		err = server.ListenAndServe()
+	}
+	if !e.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	// the listener closes as soon as the shutdown starts, so wait for in-flight requests
+	<-stopped
 }
@@ -190,6 +190,7 @@ var Models = []interface{}{
 	&LogAdminsView{},
 	&ProjectFilterSettings{},
 	&ProjectClientSamplingSettings{},
+	&ProjectErrorGroupingSettings{},
 	&AllWorkspaceSettings{},
 	&ErrorGroupActivityLog{},
 	&UserJourneyStep{},
@@ -200,6 +201,8 @@ var Models = []interface{}{
 	&Visualization{},
 	&Alert{},
 	&AlertDestination{},
+	&AlertSilence{},
+	&WebhookDelivery{},
 	&SSOClient{},
 }
 
@@ -457,6 +460,15 @@ type ProjectFilterSettings struct {
 	LogExclusionQuery                 *string
 	TraceExclusionQuery               *string
 	MetricExclusionQuery              *string
+	// Tail sampling buffers the spans of a trace and keeps or drops the trace as a whole.
+	// When enabled, it replaces per-span sampling by TraceSamplingRate.
+	TraceTailSamplingEnabled            bool `gorm:"default:false"`
+	TraceTailSamplingWindowSeconds      int  `gorm:"default:30"`
+	TraceTailSamplingKeepErrors         bool `gorm:"default:true"`
+	TraceTailSamplingLatencyThresholdMs *int64
+	TraceTailSamplingKeepQuery          *string
+	// JSON object of service name to the rate of its traces to keep, defaulting to TraceSamplingRate
+	TraceTailSamplingServiceRates *string `gorm:"type:jsonb"`
 }
 
 type ProjectClientSamplingSettings struct {
@@ -467,6 +479,16 @@ type ProjectClientSamplingSettings struct {
 	LogSamplingConfigs  *string `gorm:"type:jsonb"`
 }
 
+type ProjectErrorGroupingSettings struct {
+	Model
+	Project   *Project
+	ProjectID int
+	// JSON array of errorgroups.Rule applied in order when grouping the errors of the project
+	Rules *string `gorm:"type:jsonb"`
+	// overrides the workspace ErrorEmbeddingsThreshold when set
+	EmbeddingsThreshold *float64
+}
+
 type AllWorkspaceSettings struct {
 	Model
 	WorkspaceID    int  `gorm:"uniqueIndex"`
@@ -1133,10 +1155,16 @@ var Fingerprint = struct {
 	StackFrameCode     FingerprintType
 	StackFrameMetadata FingerprintType
 	JsonResult         FingerprintType
+	Message            FingerprintType
+	Custom             FingerprintType
 }{
 	StackFrameCode:     "CODE",
 	StackFrameMetadata: "META",
 	JsonResult:         "JSON",
+	// the error event after grouping rules stripped parts of it
+	Message: "MESSAGE",
+	// a fingerprint override or grouping attribute, errors with the same value are always grouped together
+	Custom: "CUSTOM",
 }
 
 type ErrorFingerprint struct {
@@ -1945,6 +1973,10 @@ type Alert struct {
 	LastAdminToEditID int                 `gorm:"last_admin_to_edit_id"`
 	Destinations      []*AlertDestination `gorm:"foreignKey:AlertID"`
 	Default           bool                `gorm:"default:false"` // alert created during setup flow
+	// opts out of the notification sent when an alerting group returns to normal
+	DisableResolvedNotifications bool `gorm:"default:false"`
+	// incident severity sent to paging destinations: critical, error, warning or info
+	Severity *string
 
 	// fields for threshold alert
 	BelowThreshold     *bool
@@ -1954,6 +1986,15 @@ type Alert struct {
 	ThresholdType      modelInputs.ThresholdType
 	ThresholdCondition modelInputs.ThresholdCondition
 	Sql                *string
+
+	// fields for change alert, which compares the threshold window with the same window ThresholdChangeWindow seconds earlier
+	ThresholdChangeWindow *int
+	ThresholdChangeType   modelInputs.ChangeType
+
+	// fields for composite alert, which alerts on a combination of the latest states of its child alerts
+	CompositeCondition *string `gorm:"type:jsonb"`
+	// set on the child alerts created for the inline conditions of a composite alert
+	ParentAlertID *int `gorm:"index"`
 }
 
 type AlertDestination struct {
@@ -1962,7 +2003,55 @@ type AlertDestination struct {
 	DestinationType modelInputs.AlertDestinationType
 	TypeID          string
 	TypeName        string
-	Authorization   *string // webhooks may have this
+	Authorization   *string // webhooks may have this; the routing or api key of paging destinations
+	// optional text/template templates evaluated against the alert input, replacing the default message
+	// for chat and webhook destinations. Webhooks only use the body, sent as the JSON payload.
+	TitleTemplate *string
+	BodyTemplate  *string
+	// webhook payloads are signed with the secret. After a rotation, the previous secret keeps signing
+	// payloads for a grace period so that receivers can switch over.
+	SigningSecret          *string
+	PreviousSigningSecret  *string
+	SigningSecretRotatedAt *time.Time
+}
+
+// WebhookDelivery is an attempt to deliver a notification to a webhook destination. The attempts of a
+// notification, including its retries and redeliveries, share the DeliveryID sent to the webhook.
+type WebhookDelivery struct {
+	Model
+	ProjectID       int `gorm:"index"`
+	AlertID         int `gorm:"index"`
+	DestinationID   int
+	DeliveryID      string `gorm:"index"`
+	URL             string
+	Payload         string
+	Attempt         int
+	StatusCode      *int
+	LatencyMs       int
+	ResponseExcerpt string
+	Error           *string
+	Success         bool
+	Redelivery      bool
+}
+
+// AlertSilence stops the notifications of the matching alerts while it is active. Silenced alerts
+// are still evaluated and record AlertingSilently state changes. A silence without matchers applies
+// to every alert of the project.
+type AlertSilence struct {
+	Model
+	ProjectID   int `gorm:"index"`
+	AlertID     *int
+	ProductType *modelInputs.ProductType
+	// matches the alert groups with the value for the group by key, ie. service_name=checkout
+	GroupByKey   *string
+	GroupByValue *string
+	StartsAt     *time.Time
+	EndsAt       *time.Time
+	// recurring maintenance windows as a cron expression in UTC, each lasting DurationMinutes
+	Cron            *string
+	DurationMinutes *int
+	CreatorID       int
+	Reason          string
 }
 
 type AlertDeprecated struct {
@@ -2502,6 +2591,10 @@ type TableConfig struct {
 	SelectColumns  []string
 	DefaultFilter  string
 	IgnoredFilters map[string]bool
+	// keys of numeric and time columns, which support the range operators. Operators aren't validated when nil.
+	NumericKeys map[string]bool
+	// keys of time columns, whose range operators resolve relative time values like now-15m to unix seconds.
+	TimeKeys map[string]bool
 }
 
 type ColumnMapping struct {
@@ -0,0 +1,77 @@
+package otel
+
+import (
+	hlog "github.com/highlight/highlight/sdk/highlight-go/log"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// BodyArrayKey is the attribute key prefix used when flattening an array log body.
+const BodyArrayKey = "body"
+
+// BodyMessageKeys are the structured body keys, in order of precedence, used as the log message.
+var BodyMessageKeys = []string{"message", "msg"}
+
+// extractLogBody sets the log body from a non-string OTLP log body.
+// Structured (map / array) bodies are flattened into attributes with the same dotted-key scheme
+// as record attributes, without overriding them, so that each key is searchable.
+// The message is taken from a `message` / `msg` key when present, otherwise the JSON rendering of the body.
+func extractLogBody(fields *extractedFields, body pcommon.Value) {
+	switch body.Type() {
+	case pcommon.ValueTypeEmpty, pcommon.ValueTypeStr:
+		return
+	case pcommon.ValueTypeMap:
+		m := body.Map().AsRaw()
+		if len(m) == 0 {
+			return
+		}
+		if isSystemdBody(m) {
+			extractSystemd(fields, m)
+			return
+		}
+
+		var messageKey string
+		for _, key := range BodyMessageKeys {
+			if msg, ok := m[key].(string); ok && msg != "" {
+				fields.logBody = msg
+				messageKey = key
+				break
+			}
+		}
+		if messageKey == "" {
+			fields.logBody = body.AsString()
+		}
+
+		for k, v := range m {
+			if k == messageKey {
+				continue
+			}
+			setBodyAttributes(fields, k, v)
+		}
+	case pcommon.ValueTypeSlice:
+		if body.Slice().Len() == 0 {
+			return
+		}
+		fields.logBody = body.AsString()
+		setBodyAttributes(fields, BodyArrayKey, body.Slice().AsRaw())
+	default:
+		fields.logBody = body.AsString()
+	}
+}
+
+func setBodyAttributes(fields *extractedFields, k string, v any) {
+	for key, value := range hlog.FormatAttributes(k, v) {
+		if _, ok := fields.attrs[key]; ok {
+			continue
+		}
+		fields.attrs[key] = value
+	}
+}
+
+// isSystemdBody returns whether the map body was produced by the collector journald receiver.
+func isSystemdBody(m map[string]any) bool {
+	if _, ok := m[Message]; ok {
+		return true
+	}
+	_, ok := m["__CURSOR"]
+	return ok
+}
@@ -0,0 +1,110 @@
+package otel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_extractLogBody(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body          func(v pcommon.Value)
+		attrs         map[string]string
+		expectedBody  string
+		expectedAttrs map[string]string
+	}{
+		"string": {
+			body:          func(v pcommon.Value) { v.SetStr("hello") },
+			expectedBody:  "",
+			expectedAttrs: map[string]string{},
+		},
+		"int": {
+			body:          func(v pcommon.Value) { v.SetInt(42) },
+			expectedBody:  "42",
+			expectedAttrs: map[string]string{},
+		},
+		"map with msg": {
+			body: func(v pcommon.Value) {
+				m := v.SetEmptyMap()
+				m.PutStr("msg", "user logged in")
+				m.PutInt("user_id", 123)
+				m.PutEmptyMap("request").PutStr("path", "/login")
+			},
+			expectedBody: "user logged in",
+			expectedAttrs: map[string]string{
+				"user_id":      "123",
+				"request.path": "/login",
+			},
+		},
+		"map with message preferred over msg": {
+			body: func(v pcommon.Value) {
+				m := v.SetEmptyMap()
+				m.PutStr("message", "structlog event")
+				m.PutStr("msg", "other")
+			},
+			expectedBody: "structlog event",
+			expectedAttrs: map[string]string{
+				"msg": "other",
+			},
+		},
+		"map without message": {
+			body: func(v pcommon.Value) {
+				m := v.SetEmptyMap()
+				m.PutStr("event", "checkout")
+			},
+			expectedBody: `{"event":"checkout"}`,
+			expectedAttrs: map[string]string{
+				"event": "checkout",
+			},
+		},
+		"map does not override record attributes": {
+			body: func(v pcommon.Value) {
+				m := v.SetEmptyMap()
+				m.PutStr("msg", "hello")
+				m.PutStr("service.name", "from-body")
+			},
+			attrs:        map[string]string{"service.name": "from-attributes"},
+			expectedBody: "hello",
+			expectedAttrs: map[string]string{
+				"service.name": "from-attributes",
+			},
+		},
+		"array": {
+			body: func(v pcommon.Value) {
+				s := v.SetEmptySlice()
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetInt(2)
+			},
+			expectedBody: `["a",2]`,
+			expectedAttrs: map[string]string{
+				"body.0": "a",
+				"body.1": "2",
+			},
+		},
+		"systemd": {
+			body: func(v pcommon.Value) {
+				m := v.SetEmptyMap()
+				m.PutStr("MESSAGE", "started unit")
+				m.PutStr("_SYSTEMD_UNIT", "nginx.service")
+			},
+			expectedBody: "started unit",
+			expectedAttrs: map[string]string{
+				"MESSAGE":       "started unit",
+				"_SYSTEMD_UNIT": "nginx.service",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fields := newExtractedFields()
+			for k, v := range tc.attrs {
+				fields.attrs[k] = v
+			}
+			body := pcommon.NewValueEmpty()
+			tc.body(body)
+			extractLogBody(fields, body)
+			assert.Equal(t, tc.expectedBody, fields.logBody)
+			assert.Equal(t, tc.expectedAttrs, fields.attrs)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package otel
+
+import (
+	"mime"
+	"net/http"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+)
+
+// otlpRequest is implemented by the plogotlp, pmetricotlp and ptraceotlp export requests.
+type otlpRequest interface {
+	UnmarshalJSON(data []byte) error
+	UnmarshalProto(data []byte) error
+}
+
+// otlpResponse is implemented by the plogotlp, pmetricotlp and ptraceotlp export responses.
+type otlpResponse interface {
+	MarshalJSON() ([]byte, error)
+	MarshalProto() ([]byte, error)
+}
+
+// isJSONRequest returns whether the OTLP/HTTP request body uses the JSON encoding.
+// Per the OTLP spec, anything other than `application/json` is treated as binary protobuf.
+func isJSONRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == contentTypeJSON
+}
+
+// unmarshalRequest decodes the body into the export request using the encoding of the http request.
+// The OTLP JSON mapping (hex trace / span ids, string int64s, enum names) is handled by pdata.
+func unmarshalRequest(r *http.Request, body []byte, req otlpRequest) error {
+	if isJSONRequest(r) {
+		return req.UnmarshalJSON(body)
+	}
+	return req.UnmarshalProto(body)
+}
+
+// writeResponse writes a successful export response using the same encoding as the http request.
+func writeResponse(w http.ResponseWriter, r *http.Request, resp otlpResponse) {
+	var body []byte
+	var err error
+	if isJSONRequest(r) {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		body, err = resp.MarshalJSON()
+	} else {
+		w.Header().Set("Content-Type", contentTypeProtobuf)
+		body, err = resp.MarshalProto()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
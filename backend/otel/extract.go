@@ -214,11 +214,9 @@ func extractFields(ctx context.Context, params extractFieldsParams) (*extractedF
 		}
 	}
 
-	// process potential systemd message
-	if params.logRecord != nil && params.logRecord.Body().Type().String() == "Map" {
-		if m := params.logRecord.Body().Map().AsRaw(); len(m) > 0 {
-			extractSystemd(fields, m)
-		}
+	// process potential structured (systemd, slog, structlog, etc.) message
+	if params.logRecord != nil {
+		extractLogBody(fields, params.logRecord.Body())
 	}
 
 	if val, ok := fields.attrs[highlight.DeprecatedProjectIDAttribute]; ok {
@@ -0,0 +1,147 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/highlight/highlight/sdk/highlight-go"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCMaxRecvMsgSize matches the default max request size of the OpenTelemetry collector OTLP receiver.
+const GRPCMaxRecvMsgSize = 64 * 1024 * 1024
+
+// GRPCShutdownTimeout bounds the graceful stop of the gRPC server, after which in-flight exports are cancelled.
+const GRPCShutdownTimeout = 30 * time.Second
+
+type grpcTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	handler *Handler
+}
+
+func (s *grpcTraceServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	span, ctx := highlight.StartTrace(ctx, "otel.grpc.traces")
+	defer highlight.EndTrace(span)
+
+	err := s.handler.processTraces(ctx, getGRPCHeaders(ctx), req)
+	return ptraceotlp.NewExportResponse(), toGRPCStatus(ctx, err)
+}
+
+type grpcLogServer struct {
+	plogotlp.UnimplementedGRPCServer
+	handler *Handler
+}
+
+func (s *grpcLogServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	span, ctx := highlight.StartTrace(ctx, "otel.grpc.logs")
+	defer highlight.EndTrace(span)
+
+	err := s.handler.processLogs(ctx, getGRPCHeaders(ctx), req)
+	return plogotlp.NewExportResponse(), toGRPCStatus(ctx, err)
+}
+
+type grpcMetricServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	handler *Handler
+}
+
+func (s *grpcMetricServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	span, ctx := highlight.StartTrace(ctx, "otel.grpc.metrics")
+	defer highlight.EndTrace(span)
+
+	err := s.handler.processMetrics(ctx, getGRPCHeaders(ctx), req)
+	return pmetricotlp.NewExportResponse(), toGRPCStatus(ctx, err)
+}
+
+// getGRPCHeaders converts the incoming gRPC metadata to http headers
+// so that project ids can be extracted the same way as for OTLP/HTTP requests.
+func getGRPCHeaders(ctx context.Context) http.Header {
+	headers := http.Header{}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return headers
+	}
+	for key, values := range md {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+	return headers
+}
+
+// toGRPCStatus maps ingest errors to the gRPC status codes that OTLP exporters use to decide whether to retry.
+// UNAVAILABLE is always retried, while RESOURCE_EXHAUSTED without retry info is not.
+func toGRPCStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	log.WithContext(ctx).WithError(err).Error("failed to process otel grpc export")
+	if errors.Is(err, ErrQueueUnavailable) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// NewGRPCServer creates a gRPC server implementing the OTLP trace, logs and metrics collector services.
+// gzip compressed requests are supported through the registered grpc gzip encoding.
+func (o *Handler) NewGRPCServer() *grpc.Server {
+	s := grpc.NewServer(grpc.MaxRecvMsgSize(GRPCMaxRecvMsgSize))
+	ptraceotlp.RegisterGRPCServer(s, &grpcTraceServer{handler: o})
+	plogotlp.RegisterGRPCServer(s, &grpcLogServer{handler: o})
+	pmetricotlp.RegisterGRPCServer(s, &grpcMetricServer{handler: o})
+	return s
+}
+
+// ListenGRPC serves the OTLP gRPC receiver on the provided address, blocking until the server stops.
+// The server stops gracefully once ctx is cancelled.
+func (o *Handler) ListenGRPC(ctx context.Context, addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.WithContext(ctx).WithField("addr", addr).Info("running OTLP gRPC listener")
+	return serveGRPC(ctx, o.NewGRPCServer(), lis)
+}
+
+// serveGRPC serves until the listener fails or ctx is cancelled. On cancellation, in-flight exports are given
+// GRPCShutdownTimeout to complete before the server is stopped.
+func serveGRPC(ctx context.Context, s *grpc.Server, lis net.Listener) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(GRPCShutdownTimeout):
+			s.Stop()
+		}
+	}()
+
+	if err := s.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package otel
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/integrations"
+	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	public "github.com/highlight-run/highlight/backend/public-graph/graph"
+	"github.com/highlight-run/highlight/backend/storage"
+	"github.com/highlight-run/highlight/backend/store"
+	e "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestHandler_GRPCTrace(t *testing.T) {
+	inputBytes, err := os.ReadFile("./samples/traces.json")
+	if err != nil {
+		t.Fatalf("error reading: %v", err)
+	}
+
+	req := ptraceotlp.NewExportRequest()
+	if err := req.UnmarshalJSON(inputBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	producer := MockKafkaProducer{}
+	resolver := &public.Resolver{
+		Redis:                red,
+		Store:                store.NewStore(db, red, integrations.NewIntegrationsClient(db), &storage.FilesystemClient{}, &producer, nil),
+		AsyncProducerQueue:   &producer,
+		ProducerQueue:        &producer,
+		BatchedQueue:         &producer,
+		TracesQueue:          &producer,
+		MetricSumQueue:       &producer,
+		MetricSummaryQueue:   &producer,
+		MetricHistogramQueue: &producer,
+		DB:                   db,
+		Clickhouse:           chClient,
+	}
+	h := Handler{
+		resolver: resolver,
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := h.NewGRPCServer()
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = ptraceotlp.NewGRPCClient(conn).Export(context.Background(), req, grpc.UseCompressor(gzip.Name))
+	assert.NoError(t, err)
+
+	messageCountsByType := map[kafkaqueue.PayloadType]int{}
+	for _, message := range producer.messages {
+		messageCountsByType[message.GetType()]++
+	}
+	assert.Equal(t, 512, messageCountsByType[kafkaqueue.PushTracesFlattened])
+	assert.Equal(t, 15, messageCountsByType[kafkaqueue.PushLogsFlattened])
+}
+
+func TestToGRPCStatus(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		expected codes.Code
+	}{
+		{nil, codes.OK},
+		{ErrQuotaExceeded, codes.ResourceExhausted},
+		{e.Wrap(ErrQueueUnavailable, "failed to submit"), codes.Unavailable},
+		{e.New("unexpected"), codes.Internal},
+	} {
+		assert.Equal(t, tc.expected, status.Code(toGRPCStatus(context.Background(), tc.err)))
+	}
+}
+
+func TestCombineSubmitResults(t *testing.T) {
+	queueErr := e.Wrap(ErrQueueUnavailable, "failed to submit")
+	assert.NoError(t, combineSubmitResults())
+	assert.NoError(t, combineSubmitResults(nil, ErrQuotaExceeded))
+	assert.ErrorIs(t, combineSubmitResults(ErrQuotaExceeded, ErrQuotaExceeded), ErrQuotaExceeded)
+	assert.ErrorIs(t, combineSubmitResults(nil, queueErr), ErrQueueUnavailable)
+}
+
+func TestServeGRPC_StopsOnContextCancel(t *testing.T) {
+	h := Handler{}
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveGRPC(ctx, h.NewGRPCServer(), lis)
+	}()
+	cancel()
+
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("gRPC server did not stop after context cancellation")
+	}
+}
@@ -39,6 +39,34 @@ type Handler struct {
 	resolver *graph.Resolver
 }
 
+var (
+	// ErrQueueUnavailable is returned when ingested data could not be written to the worker queues.
+	// Clients should retry the export.
+	ErrQueueUnavailable = e.New("otel worker queue unavailable")
+	// ErrQuotaExceeded is returned when all ingested data was dropped because
+	// the projects exceeded their billing quota. Clients should not retry the export.
+	ErrQuotaExceeded = e.New("otel project billing quota exceeded")
+)
+
+// combineSubmitResults merges the results of submitting each product of an export request.
+// The request is only reported as over quota if none of its products were accepted.
+func combineSubmitResults(results ...error) error {
+	var accepted, quotaExceeded bool
+	for _, err := range results {
+		if errors.Is(err, ErrQuotaExceeded) {
+			quotaExceeded = true
+		} else if err != nil {
+			return err
+		} else {
+			accepted = true
+		}
+	}
+	if quotaExceeded && !accepted {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
 var IgnoredSpanNamePrefixes = []string{"fs "}
 
 func lg(ctx context.Context, fields *extractedFields) *log.Entry {
@@ -136,15 +164,28 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 
 	span, _ := highlight.StartTrace(ctx, "otel.proto")
 	req := ptraceotlp.NewExportRequest()
-	err = req.UnmarshalProto(output)
+	err = unmarshalRequest(r, output, req)
 	span.RecordError(err)
 	highlight.EndTrace(span)
 	if err != nil {
-		log.WithContext(ctx).WithError(err).Error("invalid trace protobuf")
+		log.WithContext(ctx).WithError(err).Error("invalid trace payload")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	if err := o.processTraces(ctx, r.Header, req); err != nil && !errors.Is(err, ErrQuotaExceeded) {
+		log.WithContext(ctx).WithError(err).Error("failed to process otel traces")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	writeResponse(w, r, ptraceotlp.NewExportResponse())
+}
+
+// processTraces extracts the spans, span events, logs and errors from the export request
+// and submits them to the worker queues. Like processLogs and processMetrics, it is shared by the OTLP/HTTP
+// and OTLP/gRPC receivers.
+func (o *Handler) processTraces(ctx context.Context, headers http.Header, req ptraceotlp.ExportRequest) error {
 	var projectSessionErrors = make(map[string]map[string][]*model.BackendErrorObjectInput)
 	var projectLogs = make(map[string][]*clickhouse.LogRow)
 
@@ -174,7 +215,7 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 				}
 
 				fields, err := extractFields(ctx, extractFieldsParams{
-					headers:  r.Header,
+					headers:  headers,
 					resource: &resource,
 					span:     &span,
 					curTime:  curTime,
@@ -196,7 +237,7 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 					}
 					event := events.At(l)
 					fields, err := extractFields(ctx, extractFieldsParams{
-						headers:  r.Header,
+						headers:  headers,
 						resource: &resource,
 						scope:    &scope,
 						span:     &span,
@@ -344,34 +385,28 @@ func (o *Handler) HandleTrace(w http.ResponseWriter, r *http.Request) {
 					MetricSumRow: metric,
 				})
 			}
-			err = o.resolver.MetricSumQueue.Submit(ctx, sessionID, messages...)
-			if err != nil {
-				log.WithContext(ctx).WithError(err).Error("failed to submit otel project metrics to public worker queue")
-				w.WriteHeader(http.StatusServiceUnavailable)
-				return
+			if err := o.resolver.MetricSumQueue.Submit(ctx, sessionID, messages...); err != nil {
+				return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project metrics to public worker queue"))
 			}
 		}
 	}
 
 	if err := o.submitProjectSessionErrors(ctx, projectSessionErrors); err != nil {
-		log.WithContext(ctx).WithError(err).Error("failed to submit otel project session errors")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project session errors"))
 	}
 
-	if err := o.submitTraceSpans(ctx, traceSpans); err != nil {
-		log.WithContext(ctx).WithError(err).Error("failed to submit otel project spans")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+	var results []error
+	if len(traceSpans) > 0 {
+		if err := o.submitTraceSpans(ctx, traceSpans); err != nil && !errors.Is(err, ErrQuotaExceeded) {
+			return err
+		} else {
+			results = append(results, err)
+		}
 	}
-
-	if err := o.submitProjectLogs(ctx, projectLogs); err != nil {
-		log.WithContext(ctx).WithError(err).Error("failed to submit otel project logs")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+	if len(projectLogs) > 0 {
+		results = append(results, o.submitProjectLogs(ctx, projectLogs))
 	}
-
-	w.WriteHeader(http.StatusOK)
+	return combineSubmitResults(results...)
 }
 
 func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
@@ -386,15 +421,26 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 
 	span, _ := highlight.StartTrace(ctx, "otel.proto")
 	req := plogotlp.NewExportRequest()
-	err = req.UnmarshalProto(output)
+	err = unmarshalRequest(r, output, req)
 	span.RecordError(err)
 	highlight.EndTrace(span)
 	if err != nil {
-		log.WithContext(ctx).WithError(err).Error("invalid log protobuf")
+		log.WithContext(ctx).WithError(err).Error("invalid log payload")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	if err := o.processLogs(ctx, r.Header, req); err != nil && !errors.Is(err, ErrQuotaExceeded) {
+		log.WithContext(ctx).WithError(err).Error("failed to process otel logs")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	writeResponse(w, r, plogotlp.NewExportResponse())
+}
+
+// processLogs extracts the log records and errors from the export request and submits them to the worker queues.
+func (o *Handler) processLogs(ctx context.Context, headers http.Header, req plogotlp.ExportRequest) error {
 	var projectLogs = make(map[string][]*clickhouse.LogRow)
 	var projectSessionErrors = make(map[string]map[string][]*model.BackendErrorObjectInput)
 
@@ -412,7 +458,7 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 				logRecord := logRecords.At(k)
 
 				fields, err := extractFields(ctx, extractFieldsParams{
-					headers:                r.Header,
+					headers:                headers,
 					resource:               &resource,
 					scope:                  &scope,
 					logRecord:              &logRecord,
@@ -463,19 +509,19 @@ func (o *Handler) HandleLog(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := o.submitProjectLogs(ctx, projectLogs); err != nil {
-		log.WithContext(ctx).WithError(err).Error("failed to submit otel project logs")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+	var logsErr error
+	if len(projectLogs) > 0 {
+		logsErr = o.submitProjectLogs(ctx, projectLogs)
+		if logsErr != nil && !errors.Is(logsErr, ErrQuotaExceeded) {
+			return logsErr
+		}
 	}
 
 	if err := o.submitProjectSessionErrors(ctx, projectSessionErrors); err != nil {
-		log.WithContext(ctx).WithError(err).Error("failed to submit otel log project session errors")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+		return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel log project session errors"))
 	}
 
-	w.WriteHeader(http.StatusOK)
+	return logsErr
 }
 
 func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
@@ -490,15 +536,26 @@ func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
 
 	span, _ := highlight.StartTrace(ctx, "otel.proto")
 	req := pmetricotlp.NewExportRequest()
-	err = req.UnmarshalProto(output)
+	err = unmarshalRequest(r, output, req)
 	span.RecordError(err)
 	highlight.EndTrace(span)
 	if err != nil {
-		log.WithContext(ctx).WithError(err).Error("invalid metric protobuf")
+		log.WithContext(ctx).WithError(err).Error("invalid metric payload")
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	if err := o.processMetrics(ctx, r.Header, req); err != nil && !errors.Is(err, ErrQuotaExceeded) {
+		log.WithContext(ctx).WithError(err).Error("failed to process otel metrics")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	writeResponse(w, r, pmetricotlp.NewExportResponse())
+}
+
+// processMetrics extracts the metric data points from the export request and submits them to the worker queues.
+func (o *Handler) processMetrics(ctx context.Context, headers http.Header, req pmetricotlp.ExportRequest) error {
 	var projectMetrics = make(map[int][]clickhouse.MetricRow)
 	var projectRetentions = make(map[int]uint8)
 
@@ -536,8 +593,8 @@ func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
 					}
 				}
 				for _, dp := range dps {
-					fields, err := extractFields(r.Context(), extractFieldsParams{
-						headers:          r.Header,
+					fields, err := extractFields(ctx, extractFieldsParams{
+						headers:          headers,
 						resource:         &resource,
 						scope:            &scope,
 						metric:           &metric,
@@ -563,13 +620,10 @@ func (o *Handler) HandleMetric(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := o.submitProjectMetrics(ctx, projectMetrics); err != nil {
-		log.WithContext(ctx).WithError(err).Error("failed to submit otel project metrics")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return
+	if len(projectMetrics) == 0 {
+		return nil
 	}
-
-	w.WriteHeader(http.StatusOK)
+	return combineSubmitResults(o.submitProjectMetrics(ctx, projectMetrics))
 }
 
 func (o *Handler) getQuotaExceededByProject(ctx context.Context, projectIds map[uint32]struct{}, productType model2.PricingProductType) (map[uint32]bool, error) {
@@ -649,10 +703,12 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 	sp, c := highlight.StartTrace(ctx, "otel.upsertServices")
 	var markBackendSetupProjectIds []uint32
 	var filteredRows []*clickhouse.LogRow
+	var quotaExceeded bool
 	for _, logRows := range projectLogs {
 		for _, logRow := range logRows {
 			// Filter out any log rows for projects where the log quota has been exceeded
 			if quotaExceededByProject[logRow.ProjectId] {
+				quotaExceeded = true
 				continue
 			}
 
@@ -672,6 +728,10 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 	}
 	highlight.EndTrace(sp)
 
+	if quotaExceeded && len(filteredRows) == 0 {
+		return ErrQuotaExceeded
+	}
+
 	sp, c = highlight.StartTrace(ctx, "otel.markBackendSetupImpl")
 	for _, projectId := range markBackendSetupProjectIds {
 		err := o.resolver.MarkBackendSetupImpl(c, int(projectId), model2.MarkBackendSetupTypeLogs)
@@ -683,10 +743,7 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 
 	sp, c = highlight.StartTrace(ctx, "otel.prepareMessages")
 	var messages []kafkaqueue.RetryableMessage
-	for _, logRow := range filteredRows {
-		if !o.resolver.IsLogIngested(c, logRow) {
-			continue
-		}
+	for _, logRow := range o.resolver.FilterLogsIngested(c, filteredRows) {
 		messages = append(messages, &kafkaqueue.LogRowMessage{
 			Type:   kafkaqueue.PushLogsFlattened,
 			LogRow: logRow,
@@ -696,7 +753,7 @@ func (o *Handler) submitProjectLogs(ctx context.Context, projectLogs map[string]
 
 	err = o.resolver.BatchedQueue.Submit(ctx, "", messages...)
 	if err != nil {
-		return e.Wrap(err, "failed to submit otel project logs to public worker queue")
+		return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project logs to public worker queue"))
 	}
 	return nil
 }
@@ -752,13 +809,40 @@ func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*
 		quotaExceededByProject = map[uint32]bool{}
 	}
 
+	var accepted, quotaExceeded bool
+	var candidateRows []*clickhouse.TraceRow
+	tailSampledRows := map[string][]*clickhouse.TraceRow{}
 	for traceID, traceRows := range traceRows {
-		var messages []kafkaqueue.RetryableMessage
 		for _, traceRow := range traceRows {
 			if quotaExceededByProject[traceRow.ProjectId] {
+				quotaExceeded = true
 				continue
 			}
-			if !o.resolver.IsTraceIngested(ctx, traceRow) {
+			accepted = true
+			if o.resolver.IsTraceTailSampled(ctx, int(traceRow.ProjectId)) {
+				tailSampledRows[traceID] = append(tailSampledRows[traceID], traceRow)
+				continue
+			}
+			candidateRows = append(candidateRows, traceRow)
+		}
+	}
+
+	// tail sampled spans are submitted by the worker once the whole trace is decided. a failure to buffer
+	// a trace is only returned once the other spans are submitted, as buffering is idempotent on a retry.
+	var bufferErr error
+	for traceID, traceRows := range tailSampledRows {
+		if err := o.resolver.BufferTraceSpans(ctx, traceID, traceRows); err != nil {
+			bufferErr = errors.Join(bufferErr, e.Wrap(err, "failed to buffer otel trace spans for tail sampling"))
+		}
+	}
+	ingestedRows := lo.SliceToMap(o.resolver.FilterTracesIngested(ctx, candidateRows), func(traceRow *clickhouse.TraceRow) (*clickhouse.TraceRow, struct{}) {
+		return traceRow, struct{}{}
+	})
+
+	for traceID, traceRows := range traceRows {
+		var messages []kafkaqueue.RetryableMessage
+		for _, traceRow := range traceRows {
+			if _, ok := ingestedRows[traceRow]; !ok {
 				continue
 			}
 
@@ -776,9 +860,16 @@ func (o *Handler) submitTraceSpans(ctx context.Context, traceRows map[string][]*
 
 		err := o.resolver.TracesQueue.Submit(ctx, traceID, messages...)
 		if err != nil {
-			return e.Wrap(err, "failed to submit otel project traces to public worker queue")
+			return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project traces to public worker queue"))
 		}
 	}
+	if bufferErr != nil {
+		return errors.Join(ErrQueueUnavailable, bufferErr)
+	}
+
+	if quotaExceeded && !accepted {
+		return ErrQuotaExceeded
+	}
 
 	for projectId := range markBackendSetupProjectIds {
 		err := o.resolver.MarkBackendSetupImpl(ctx, int(projectId), model2.MarkBackendSetupTypeTraces)
@@ -800,69 +891,78 @@ func (o *Handler) submitProjectMetrics(ctx context.Context, projectMetricRows ma
 		quotaExceededByProject = map[uint32]bool{}
 	}
 
-	var sumMessages, histogramMessages, summaryMessages []kafkaqueue.RetryableMessage
+	var accepted, quotaExceeded bool
+	var candidateRows []clickhouse.MetricRow
 	for projectID, metricRows := range projectMetricRows {
 		for _, metricRow := range metricRows {
 			if metricRow == nil {
 				continue
 			}
 			if quotaExceededByProject[uint32(projectID)] {
+				quotaExceeded = true
 				continue
 			}
-			if !o.resolver.IsMetricIngested(ctx, metricRow) {
-				continue
-			}
-			if metricSumRow, ok := metricRow.(*clickhouse.MetricSumRow); ok {
-				// create service record for any services found in ingested traces
-				if metricSumRow.ServiceName != "" {
-					if _, err = o.resolver.Store.UpsertService(ctx, int(metricSumRow.ProjectId), metricSumRow.ServiceName, metricSumRow.Attributes); err != nil {
-						log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from metric sum"))
-					}
+			accepted = true
+			candidateRows = append(candidateRows, metricRow)
+		}
+	}
+
+	var sumMessages, histogramMessages, summaryMessages []kafkaqueue.RetryableMessage
+	for _, metricRow := range o.resolver.FilterMetricsIngested(ctx, candidateRows) {
+		if metricSumRow, ok := metricRow.(*clickhouse.MetricSumRow); ok {
+			// create service record for any services found in ingested traces
+			if metricSumRow.ServiceName != "" {
+				if _, err = o.resolver.Store.UpsertService(ctx, int(metricSumRow.ProjectId), metricSumRow.ServiceName, metricSumRow.Attributes); err != nil {
+					log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from metric sum"))
 				}
-				sumMessages = append(sumMessages, &kafkaqueue.OTeLMetricSumRow{
-					Type:         kafkaqueue.PushOTeLMetricSum,
-					MetricSumRow: metricSumRow,
-				})
 			}
-			if metricHistogramRow, ok := metricRow.(*clickhouse.MetricHistogramRow); ok {
-				// create service record for any services found in ingested traces
-				if metricHistogramRow.ServiceName != "" {
-					if _, err = o.resolver.Store.UpsertService(ctx, int(metricHistogramRow.ProjectId), metricHistogramRow.ServiceName, metricHistogramRow.Attributes); err != nil {
-						log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from metric histogram"))
-					}
+			sumMessages = append(sumMessages, &kafkaqueue.OTeLMetricSumRow{
+				Type:         kafkaqueue.PushOTeLMetricSum,
+				MetricSumRow: metricSumRow,
+			})
+		}
+		if metricHistogramRow, ok := metricRow.(*clickhouse.MetricHistogramRow); ok {
+			// create service record for any services found in ingested traces
+			if metricHistogramRow.ServiceName != "" {
+				if _, err = o.resolver.Store.UpsertService(ctx, int(metricHistogramRow.ProjectId), metricHistogramRow.ServiceName, metricHistogramRow.Attributes); err != nil {
+					log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from metric histogram"))
 				}
-				histogramMessages = append(histogramMessages, &kafkaqueue.OTeLMetricHistogramRow{
-					Type:               kafkaqueue.PushOTeLMetricHistogram,
-					MetricHistogramRow: metricHistogramRow,
-				})
 			}
-			if metricSummaryRow, ok := metricRow.(*clickhouse.MetricSummaryRow); ok {
-				// create service record for any services found in ingested traces
-				if metricSummaryRow.ServiceName != "" {
-					if _, err = o.resolver.Store.UpsertService(ctx, int(metricSummaryRow.ProjectId), metricSummaryRow.ServiceName, metricSummaryRow.Attributes); err != nil {
-						log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from metric summary"))
-					}
+			histogramMessages = append(histogramMessages, &kafkaqueue.OTeLMetricHistogramRow{
+				Type:               kafkaqueue.PushOTeLMetricHistogram,
+				MetricHistogramRow: metricHistogramRow,
+			})
+		}
+		if metricSummaryRow, ok := metricRow.(*clickhouse.MetricSummaryRow); ok {
+			// create service record for any services found in ingested traces
+			if metricSummaryRow.ServiceName != "" {
+				if _, err = o.resolver.Store.UpsertService(ctx, int(metricSummaryRow.ProjectId), metricSummaryRow.ServiceName, metricSummaryRow.Attributes); err != nil {
+					log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from metric summary"))
 				}
-				summaryMessages = append(summaryMessages, &kafkaqueue.OTeLMetricSummaryRow{
-					Type:             kafkaqueue.PushOTeLMetricSummary,
-					MetricSummaryRow: metricSummaryRow,
-				})
 			}
+			summaryMessages = append(summaryMessages, &kafkaqueue.OTeLMetricSummaryRow{
+				Type:             kafkaqueue.PushOTeLMetricSummary,
+				MetricSummaryRow: metricSummaryRow,
+			})
 		}
+	}
 
-		// no ordering for metrics data
-		err := o.resolver.MetricSumQueue.Submit(ctx, "", sumMessages...)
-		if err != nil {
-			return e.Wrap(err, "failed to submit otel project sum metrics to public worker queue")
-		}
-		err = o.resolver.MetricHistogramQueue.Submit(ctx, "", histogramMessages...)
-		if err != nil {
-			return e.Wrap(err, "failed to submit otel project histogram metrics to public worker queue")
-		}
-		err = o.resolver.MetricSummaryQueue.Submit(ctx, "", summaryMessages...)
-		if err != nil {
-			return e.Wrap(err, "failed to submit otel project summary metrics to public worker queue")
-		}
+	// no ordering for metrics data
+	err = o.resolver.MetricSumQueue.Submit(ctx, "", sumMessages...)
+	if err != nil {
+		return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project sum metrics to public worker queue"))
+	}
+	err = o.resolver.MetricHistogramQueue.Submit(ctx, "", histogramMessages...)
+	if err != nil {
+		return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project histogram metrics to public worker queue"))
+	}
+	err = o.resolver.MetricSummaryQueue.Submit(ctx, "", summaryMessages...)
+	if err != nil {
+		return errors.Join(ErrQueueUnavailable, e.Wrap(err, "failed to submit otel project summary metrics to public worker queue"))
+	}
+
+	if quotaExceeded && !accepted {
+		return ErrQuotaExceeded
 	}
 
 	for projectId := range projectIds {
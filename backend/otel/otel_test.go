@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -282,6 +283,47 @@ func TestHandler_HandleTrace(t *testing.T) {
 
 }
 
+func TestHandler_HandleTraceJSON(t *testing.T) {
+	inputBytes, err := os.ReadFile("./samples/traces.json")
+	if err != nil {
+		t.Fatalf("error reading: %v", err)
+	}
+
+	producer := MockKafkaProducer{}
+	resolver := &public.Resolver{
+		Redis:                red,
+		Store:                store.NewStore(db, red, integrations.NewIntegrationsClient(db), &storage.FilesystemClient{}, &producer, nil),
+		AsyncProducerQueue:   &producer,
+		ProducerQueue:        &producer,
+		BatchedQueue:         &producer,
+		TracesQueue:          &producer,
+		MetricSumQueue:       &producer,
+		MetricSummaryQueue:   &producer,
+		MetricHistogramQueue: &producer,
+		DB:                   db,
+		Clickhouse:           chClient,
+	}
+	h := Handler{
+		resolver: resolver,
+	}
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("POST", "", bytes.NewReader(inputBytes))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	h.HandleTrace(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.NoError(t, ptraceotlp.NewExportResponse().UnmarshalJSON(w.Body.Bytes()))
+
+	messageCountsByType := map[kafkaqueue.PayloadType]int{}
+	for _, message := range producer.messages {
+		messageCountsByType[message.GetType()]++
+	}
+	assert.Equal(t, 512, messageCountsByType[kafkaqueue.PushTracesFlattened])
+	assert.Equal(t, 15, messageCountsByType[kafkaqueue.PushLogsFlattened])
+}
+
 func TestHandler_HandleMetric(t *testing.T) {
 	for file, tc := range map[string]struct {
 		expectedMetrics *int
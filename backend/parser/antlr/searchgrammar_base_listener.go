@@ -113,9 +113,15 @@ func (s *BaseSearchGrammarListener) EnterExists_search_expr(ctx *Exists_search_e
 // ExitExists_search_expr is called when production exists_search_expr is exited.
 func (s *BaseSearchGrammarListener) ExitExists_search_expr(ctx *Exists_search_exprContext) {}
 
+// EnterIn_search_expr is called when production in_search_expr is entered.
+func (s *BaseSearchGrammarListener) EnterIn_search_expr(ctx *In_search_exprContext) {}
+
 // EnterKey_val_search_expr is called when production key_val_search_expr is entered.
 func (s *BaseSearchGrammarListener) EnterKey_val_search_expr(ctx *Key_val_search_exprContext) {}
 
+// ExitIn_search_expr is called when production in_search_expr is exited.
+func (s *BaseSearchGrammarListener) ExitIn_search_expr(ctx *In_search_exprContext) {}
+
 // ExitKey_val_search_expr is called when production key_val_search_expr is exited.
 func (s *BaseSearchGrammarListener) ExitKey_val_search_expr(ctx *Key_val_search_exprContext) {}
 
@@ -172,3 +178,39 @@ func (s *BaseSearchGrammarListener) EnterSearch_value(ctx *Search_valueContext)
 
 // ExitSearch_value is called when production search_value is exited.
 func (s *BaseSearchGrammarListener) ExitSearch_value(ctx *Search_valueContext) {}
+
+// EnterIn_op is called when production in_op is entered.
+func (s *BaseSearchGrammarListener) EnterIn_op(ctx *In_opContext) {}
+
+// ExitIn_op is called when production in_op is exited.
+func (s *BaseSearchGrammarListener) ExitIn_op(ctx *In_opContext) {}
+
+// EnterIn_list is called when production in_list is entered.
+func (s *BaseSearchGrammarListener) EnterIn_list(ctx *In_listContext) {}
+
+// ExitIn_list is called when production in_list is exited.
+func (s *BaseSearchGrammarListener) ExitIn_list(ctx *In_listContext) {}
+
+// EnterPipeline is called when production pipeline is entered.
+func (s *BaseSearchGrammarListener) EnterPipeline(ctx *PipelineContext) {}
+
+// ExitPipeline is called when production pipeline is exited.
+func (s *BaseSearchGrammarListener) ExitPipeline(ctx *PipelineContext) {}
+
+// EnterPipe_stage is called when production pipe_stage is entered.
+func (s *BaseSearchGrammarListener) EnterPipe_stage(ctx *Pipe_stageContext) {}
+
+// ExitPipe_stage is called when production pipe_stage is exited.
+func (s *BaseSearchGrammarListener) ExitPipe_stage(ctx *Pipe_stageContext) {}
+
+// EnterPipe_command is called when production pipe_command is entered.
+func (s *BaseSearchGrammarListener) EnterPipe_command(ctx *Pipe_commandContext) {}
+
+// ExitPipe_command is called when production pipe_command is exited.
+func (s *BaseSearchGrammarListener) ExitPipe_command(ctx *Pipe_commandContext) {}
+
+// EnterPipe_arg is called when production pipe_arg is entered.
+func (s *BaseSearchGrammarListener) EnterPipe_arg(ctx *Pipe_argContext) {}
+
+// ExitPipe_arg is called when production pipe_arg is exited.
+func (s *BaseSearchGrammarListener) ExitPipe_arg(ctx *Pipe_argContext) {}
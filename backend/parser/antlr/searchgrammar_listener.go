@@ -53,6 +53,9 @@ type SearchGrammarListener interface {
 	// EnterExists_search_expr is called when entering the exists_search_expr production.
 	EnterExists_search_expr(c *Exists_search_exprContext)
 
+	// EnterIn_search_expr is called when entering the in_search_expr production.
+	EnterIn_search_expr(c *In_search_exprContext)
+
 	// EnterKey_val_search_expr is called when entering the key_val_search_expr production.
 	EnterKey_val_search_expr(c *Key_val_search_exprContext)
 
@@ -83,6 +86,24 @@ type SearchGrammarListener interface {
 	// EnterSearch_value is called when entering the search_value production.
 	EnterSearch_value(c *Search_valueContext)
 
+	// EnterIn_op is called when entering the in_op production.
+	EnterIn_op(c *In_opContext)
+
+	// EnterIn_list is called when entering the in_list production.
+	EnterIn_list(c *In_listContext)
+
+	// EnterPipeline is called when entering the pipeline production.
+	EnterPipeline(c *PipelineContext)
+
+	// EnterPipe_stage is called when entering the pipe_stage production.
+	EnterPipe_stage(c *Pipe_stageContext)
+
+	// EnterPipe_command is called when entering the pipe_command production.
+	EnterPipe_command(c *Pipe_commandContext)
+
+	// EnterPipe_arg is called when entering the pipe_arg production.
+	EnterPipe_arg(c *Pipe_argContext)
+
 	// ExitSearch_query is called when exiting the search_query production.
 	ExitSearch_query(c *Search_queryContext)
 
@@ -128,6 +149,9 @@ type SearchGrammarListener interface {
 	// ExitExists_search_expr is called when exiting the exists_search_expr production.
 	ExitExists_search_expr(c *Exists_search_exprContext)
 
+	// ExitIn_search_expr is called when exiting the in_search_expr production.
+	ExitIn_search_expr(c *In_search_exprContext)
+
 	// ExitKey_val_search_expr is called when exiting the key_val_search_expr production.
 	ExitKey_val_search_expr(c *Key_val_search_exprContext)
 
@@ -157,4 +181,22 @@ type SearchGrammarListener interface {
 
 	// ExitSearch_value is called when exiting the search_value production.
 	ExitSearch_value(c *Search_valueContext)
+
+	// ExitIn_op is called when exiting the in_op production.
+	ExitIn_op(c *In_opContext)
+
+	// ExitIn_list is called when exiting the in_list production.
+	ExitIn_list(c *In_listContext)
+
+	// ExitPipeline is called when exiting the pipeline production.
+	ExitPipeline(c *PipelineContext)
+
+	// ExitPipe_stage is called when exiting the pipe_stage production.
+	ExitPipe_stage(c *Pipe_stageContext)
+
+	// ExitPipe_command is called when exiting the pipe_command production.
+	ExitPipe_command(c *Pipe_commandContext)
+
+	// ExitPipe_arg is called when exiting the pipe_arg production.
+	ExitPipe_arg(c *Pipe_argContext)
 }
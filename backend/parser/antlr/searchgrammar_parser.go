@@ -33,22 +33,23 @@ var SearchGrammarParserStaticData struct {
 func searchgrammarParserInit() {
 	staticData := &SearchGrammarParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'AND'", "'OR'", "'NOT'", "'EXISTS'", "'!'", "'='", "'!='", "'<'",
-		"'<='", "'>'", "'>='", "'('", "')'", "':'",
+		"", "'AND'", "'OR'", "'NOT'", "'EXISTS'", "'IN'", "'!'", "'='", "'!='",
+		"'<'", "'<='", "'>'", "'>='", "'('", "')'", "':'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "AND", "OR", "NOT", "EXISTS", "BANG", "EQ", "NEQ", "LT", "LTE",
-		"GT", "GTE", "LPAREN", "RPAREN", "COLON", "ID", "STRING", "VALUE", "WS",
-		"ERROR_CHARACTERS",
+		"", "AND", "OR", "NOT", "EXISTS", "IN", "BANG", "EQ", "NEQ", "LT", "LTE",
+		"GT", "GTE", "LPAREN", "RPAREN", "COLON", "PIPE", "ID", "STRING", "VALUE",
+		"WS", "ERROR_CHARACTERS",
 	}
 	staticData.RuleNames = []string{
 		"search_query", "top_col_expr", "col_expr", "search_expr", "search_key",
 		"and_op", "implicit_and_op", "or_op", "exists_op", "negation_op", "bin_op",
-		"search_value",
+		"search_value", "in_op", "in_list", "pipeline", "pipe_stage", "pipe_command",
+		"pipe_arg",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 19, 129, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 21, 177, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 29, 8, 0, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 39, 8, 1, 1, 2, 1, 2, 1, 2, 1, 2,
@@ -60,47 +61,69 @@ func searchgrammarParserInit() {
 		4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 110, 8,
 		8, 1, 9, 1, 9, 1, 10, 5, 10, 115, 8, 10, 10, 10, 12, 10, 118, 9, 10, 1,
 		10, 1, 10, 5, 10, 122, 8, 10, 10, 10, 12, 10, 125, 9, 10, 1, 11, 1, 11,
-		1, 11, 0, 2, 4, 6, 12, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 0, 2,
-		2, 0, 5, 11, 14, 14, 1, 0, 15, 17, 134, 0, 28, 1, 0, 0, 0, 2, 38, 1, 0,
-		0, 0, 4, 49, 1, 0, 0, 0, 6, 79, 1, 0, 0, 0, 8, 98, 1, 0, 0, 0, 10, 100,
-		1, 0, 0, 0, 12, 102, 1, 0, 0, 0, 14, 104, 1, 0, 0, 0, 16, 109, 1, 0, 0,
-		0, 18, 111, 1, 0, 0, 0, 20, 116, 1, 0, 0, 0, 22, 126, 1, 0, 0, 0, 24, 29,
-		5, 0, 0, 1, 25, 26, 3, 6, 3, 0, 26, 27, 5, 0, 0, 1, 27, 29, 1, 0, 0, 0,
-		28, 24, 1, 0, 0, 0, 28, 25, 1, 0, 0, 0, 29, 1, 1, 0, 0, 0, 30, 31, 5, 12,
-		0, 0, 31, 32, 3, 4, 2, 0, 32, 33, 5, 13, 0, 0, 33, 39, 1, 0, 0, 0, 34,
-		35, 3, 18, 9, 0, 35, 36, 3, 2, 1, 0, 36, 39, 1, 0, 0, 0, 37, 39, 3, 22,
-		11, 0, 38, 30, 1, 0, 0, 0, 38, 34, 1, 0, 0, 0, 38, 37, 1, 0, 0, 0, 39,
-		3, 1, 0, 0, 0, 40, 41, 6, 2, -1, 0, 41, 42, 5, 12, 0, 0, 42, 43, 3, 4,
-		2, 0, 43, 44, 5, 13, 0, 0, 44, 50, 1, 0, 0, 0, 45, 46, 3, 18, 9, 0, 46,
-		47, 3, 4, 2, 4, 47, 50, 1, 0, 0, 0, 48, 50, 3, 22, 11, 0, 49, 40, 1, 0,
-		0, 0, 49, 45, 1, 0, 0, 0, 49, 48, 1, 0, 0, 0, 50, 59, 1, 0, 0, 0, 51, 52,
-		10, 3, 0, 0, 52, 53, 5, 1, 0, 0, 53, 58, 3, 4, 2, 4, 54, 55, 10, 2, 0,
-		0, 55, 56, 5, 2, 0, 0, 56, 58, 3, 4, 2, 3, 57, 51, 1, 0, 0, 0, 57, 54,
-		1, 0, 0, 0, 58, 61, 1, 0, 0, 0, 59, 57, 1, 0, 0, 0, 59, 60, 1, 0, 0, 0,
-		60, 5, 1, 0, 0, 0, 61, 59, 1, 0, 0, 0, 62, 63, 6, 3, -1, 0, 63, 64, 5,
-		12, 0, 0, 64, 65, 3, 6, 3, 0, 65, 66, 5, 13, 0, 0, 66, 80, 1, 0, 0, 0,
-		67, 68, 3, 18, 9, 0, 68, 69, 3, 6, 3, 7, 69, 80, 1, 0, 0, 0, 70, 71, 3,
-		8, 4, 0, 71, 73, 3, 20, 10, 0, 72, 74, 3, 2, 1, 0, 73, 72, 1, 0, 0, 0,
-		73, 74, 1, 0, 0, 0, 74, 80, 1, 0, 0, 0, 75, 76, 3, 8, 4, 0, 76, 77, 3,
-		16, 8, 0, 77, 80, 1, 0, 0, 0, 78, 80, 3, 2, 1, 0, 79, 62, 1, 0, 0, 0, 79,
-		67, 1, 0, 0, 0, 79, 70, 1, 0, 0, 0, 79, 75, 1, 0, 0, 0, 79, 78, 1, 0, 0,
-		0, 80, 95, 1, 0, 0, 0, 81, 82, 10, 6, 0, 0, 82, 83, 3, 10, 5, 0, 83, 84,
-		3, 6, 3, 7, 84, 94, 1, 0, 0, 0, 85, 86, 10, 5, 0, 0, 86, 87, 3, 14, 7,
-		0, 87, 88, 3, 6, 3, 6, 88, 94, 1, 0, 0, 0, 89, 90, 10, 4, 0, 0, 90, 91,
-		3, 12, 6, 0, 91, 92, 3, 6, 3, 5, 92, 94, 1, 0, 0, 0, 93, 81, 1, 0, 0, 0,
-		93, 85, 1, 0, 0, 0, 93, 89, 1, 0, 0, 0, 94, 97, 1, 0, 0, 0, 95, 93, 1,
-		0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 7, 1, 0, 0, 0, 97, 95, 1, 0, 0, 0, 98,
-		99, 5, 15, 0, 0, 99, 9, 1, 0, 0, 0, 100, 101, 5, 1, 0, 0, 101, 11, 1, 0,
-		0, 0, 102, 103, 1, 0, 0, 0, 103, 13, 1, 0, 0, 0, 104, 105, 5, 2, 0, 0,
-		105, 15, 1, 0, 0, 0, 106, 110, 5, 4, 0, 0, 107, 108, 5, 3, 0, 0, 108, 110,
-		5, 4, 0, 0, 109, 106, 1, 0, 0, 0, 109, 107, 1, 0, 0, 0, 110, 17, 1, 0,
-		0, 0, 111, 112, 5, 3, 0, 0, 112, 19, 1, 0, 0, 0, 113, 115, 5, 18, 0, 0,
-		114, 113, 1, 0, 0, 0, 115, 118, 1, 0, 0, 0, 116, 114, 1, 0, 0, 0, 116,
-		117, 1, 0, 0, 0, 117, 119, 1, 0, 0, 0, 118, 116, 1, 0, 0, 0, 119, 123,
-		7, 0, 0, 0, 120, 122, 5, 18, 0, 0, 121, 120, 1, 0, 0, 0, 122, 125, 1, 0,
-		0, 0, 123, 121, 1, 0, 0, 0, 123, 124, 1, 0, 0, 0, 124, 21, 1, 0, 0, 0,
-		125, 123, 1, 0, 0, 0, 126, 127, 7, 1, 0, 0, 127, 23, 1, 0, 0, 0, 12, 28,
-		38, 49, 57, 59, 73, 79, 93, 95, 109, 116, 123,
+		1, 11, 1, 3, 1, 3, 1, 3, 1, 3, 2, 12, 7, 12, 2, 13, 7, 13, 1, 12, 1, 12,
+		1, 13, 1, 13, 4, 13, 142, 8, 13, 11, 13, 12, 13, 143, 1, 13, 1, 13, 3,
+		0, 149, 1, 0, 8, 0, 1, 0, 1, 0, 1, 0, 2, 14, 7, 14, 2, 15, 7, 15, 2, 16,
+		7, 16, 2, 17, 7, 17, 1, 14, 4, 14, 163, 8, 14, 11, 14, 12, 14, 164, 1,
+		15, 1, 15, 5, 15, 169, 8, 15, 10, 15, 12, 15, 172, 9, 15, 1, 16, 1, 16,
+		1, 17, 1, 17, 0, 2, 4, 6, 18, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
+		133, 135, 153, 155, 157, 159, 0, 3, 2, 0, 6, 12, 15, 15, 2, 0, 5, 5, 17,
+		19, 1, 0, 16, 16, 182, 0, 28, 1, 0, 0, 0, 2, 38, 1, 0, 0, 0, 4, 49, 1,
+		0, 0, 0, 6, 79, 1, 0, 0, 0, 8, 98, 1, 0, 0, 0, 10, 100, 1, 0, 0, 0, 12,
+		102, 1, 0, 0, 0, 14, 104, 1, 0, 0, 0, 16, 109, 1, 0, 0, 0, 18, 111, 1,
+		0, 0, 0, 20, 116, 1, 0, 0, 0, 22, 126, 1, 0, 0, 0, 24, 29, 5, 0, 0, 1,
+		25, 147, 3, 6, 3, 0, 26, 27, 5, 0, 0, 1, 27, 29, 1, 0, 0, 0, 28, 24, 1,
+		0, 0, 0, 28, 25, 1, 0, 0, 0, 28, 150, 1, 0, 0, 0, 29, 1, 1, 0, 0, 0, 30,
+		31, 5, 13, 0, 0, 31, 32, 3, 4, 2, 0, 32, 33, 5, 14, 0, 0, 33, 39, 1, 0,
+		0, 0, 34, 35, 3, 18, 9, 0, 35, 36, 3, 2, 1, 0, 36, 39, 1, 0, 0, 0, 37,
+		39, 3, 22, 11, 0, 38, 30, 1, 0, 0, 0, 38, 34, 1, 0, 0, 0, 38, 37, 1, 0,
+		0, 0, 39, 3, 1, 0, 0, 0, 40, 41, 6, 2, -1, 0, 41, 42, 5, 13, 0, 0, 42,
+		43, 3, 4, 2, 0, 43, 44, 5, 14, 0, 0, 44, 50, 1, 0, 0, 0, 45, 46, 3, 18,
+		9, 0, 46, 47, 3, 4, 2, 4, 47, 50, 1, 0, 0, 0, 48, 50, 3, 22, 11, 0, 49,
+		40, 1, 0, 0, 0, 49, 45, 1, 0, 0, 0, 49, 48, 1, 0, 0, 0, 50, 59, 1, 0, 0,
+		0, 51, 52, 10, 3, 0, 0, 52, 53, 5, 1, 0, 0, 53, 58, 3, 4, 2, 4, 54, 55,
+		10, 2, 0, 0, 55, 56, 5, 2, 0, 0, 56, 58, 3, 4, 2, 3, 57, 51, 1, 0, 0, 0,
+		57, 54, 1, 0, 0, 0, 58, 61, 1, 0, 0, 0, 59, 57, 1, 0, 0, 0, 59, 60, 1,
+		0, 0, 0, 60, 5, 1, 0, 0, 0, 61, 59, 1, 0, 0, 0, 62, 63, 6, 3, -1, 0, 63,
+		64, 5, 13, 0, 0, 64, 65, 3, 6, 3, 0, 65, 66, 5, 14, 0, 0, 66, 80, 1, 0,
+		0, 0, 67, 68, 3, 18, 9, 0, 68, 69, 3, 6, 3, 7, 69, 80, 1, 0, 0, 0, 70,
+		71, 3, 8, 4, 0, 71, 73, 3, 20, 10, 0, 72, 74, 3, 2, 1, 0, 73, 72, 1, 0,
+		0, 0, 73, 74, 1, 0, 0, 0, 74, 80, 1, 0, 0, 0, 75, 76, 3, 8, 4, 0, 76,
+		77, 3, 16, 8, 0, 77, 80, 1, 0, 0, 0, 78, 80, 3, 2, 1, 0, 79, 62, 1, 0,
+		0, 0, 79, 67, 1, 0, 0, 0, 79, 70, 1, 0, 0, 0, 79, 129, 1, 0, 0, 0, 79,
+		75, 1, 0, 0, 0, 79, 78, 1, 0, 0, 0, 80, 95, 1, 0, 0, 0, 81, 82, 10, 6,
+		0, 0, 82, 83, 3, 10, 5, 0, 83, 84, 3, 6, 3, 7, 84, 94, 1, 0, 0, 0, 85,
+		86, 10, 5, 0, 0, 86, 87, 3, 14, 7, 0, 87, 88, 3, 6, 3, 6, 88, 94, 1, 0,
+		0, 0, 89, 90, 10, 4, 0, 0, 90, 91, 3, 12, 6, 0, 91, 92, 3, 6, 3, 5, 92,
+		94, 1, 0, 0, 0, 93, 81, 1, 0, 0, 0, 93, 85, 1, 0, 0, 0, 93, 89, 1, 0, 0,
+		0, 94, 97, 1, 0, 0, 0, 95, 93, 1, 0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 7, 1,
+		0, 0, 0, 97, 95, 1, 0, 0, 0, 98, 99, 5, 17, 0, 0, 99, 9, 1, 0, 0, 0,
+		100, 101, 5, 1, 0, 0, 101, 11, 1, 0, 0, 0, 102, 103, 1, 0, 0, 0, 103,
+		13, 1, 0, 0, 0, 104, 105, 5, 2, 0, 0, 105, 15, 1, 0, 0, 0, 106, 110, 5,
+		4, 0, 0, 107, 108, 5, 3, 0, 0, 108, 110, 5, 4, 0, 0, 109, 106, 1, 0, 0,
+		0, 109, 107, 1, 0, 0, 0, 110, 17, 1, 0, 0, 0, 111, 112, 5, 3, 0, 0, 112,
+		19, 1, 0, 0, 0, 113, 115, 5, 20, 0, 0, 114, 113, 1, 0, 0, 0, 115, 118,
+		1, 0, 0, 0, 116, 114, 1, 0, 0, 0, 116, 117, 1, 0, 0, 0, 117, 119, 1, 0,
+		0, 0, 118, 116, 1, 0, 0, 0, 119, 123, 7, 0, 0, 0, 120, 122, 5, 20, 0, 0,
+		121, 120, 1, 0, 0, 0, 122, 125, 1, 0, 0, 0, 123, 121, 1, 0, 0, 0, 123,
+		124, 1, 0, 0, 0, 124, 21, 1, 0, 0, 0, 125, 123, 1, 0, 0, 0, 126, 127, 7,
+		1, 0, 0, 127, 23, 1, 0, 0, 0, 129, 130, 3, 8, 4, 0, 130, 131, 3, 133,
+		12, 0, 131, 132, 3, 135, 13, 0, 132, 80, 1, 0, 0, 0, 133, 137, 1, 0, 0,
+		0, 137, 138, 5, 5, 0, 0, 138, 134, 1, 0, 0, 0, 135, 139, 1, 0, 0, 0,
+		139, 141, 5, 13, 0, 0, 140, 142, 3, 22, 11, 0, 141, 140, 1, 0, 0, 0,
+		142, 143, 1, 0, 0, 0, 143, 141, 1, 0, 0, 0, 143, 144, 1, 0, 0, 0, 144,
+		145, 1, 0, 0, 0, 145, 146, 5, 14, 0, 0, 146, 136, 1, 0, 0, 0, 147, 148,
+		1, 0, 0, 0, 147, 149, 1, 0, 0, 0, 148, 149, 3, 153, 14, 0, 149, 26, 1,
+		0, 0, 0, 150, 151, 3, 153, 14, 0, 151, 152, 5, 0, 0, 1, 152, 29, 1, 0,
+		0, 0, 153, 162, 1, 0, 0, 0, 162, 161, 1, 0, 0, 0, 161, 163, 3, 155, 15,
+		0, 163, 164, 1, 0, 0, 0, 164, 162, 1, 0, 0, 0, 164, 165, 1, 0, 0, 0,
+		165, 154, 1, 0, 0, 0, 155, 166, 1, 0, 0, 0, 166, 170, 3, 157, 16, 0,
+		170, 168, 1, 0, 0, 0, 170, 171, 1, 0, 0, 0, 168, 167, 1, 0, 0, 0, 167,
+		169, 3, 159, 17, 0, 169, 172, 1, 0, 0, 0, 172, 170, 1, 0, 0, 0, 171,
+		156, 1, 0, 0, 0, 157, 173, 1, 0, 0, 0, 173, 174, 5, 16, 0, 0, 174, 158,
+		1, 0, 0, 0, 159, 175, 1, 0, 0, 0, 175, 176, 8, 2, 0, 0, 176, 160, 1, 0,
+		0, 0, 16, 28, 38, 49, 57, 59, 73, 79, 93, 95, 109, 116, 123, 143, 147,
+		164, 170,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -143,21 +166,23 @@ const (
 	SearchGrammarParserOR               = 2
 	SearchGrammarParserNOT              = 3
 	SearchGrammarParserEXISTS           = 4
-	SearchGrammarParserBANG             = 5
-	SearchGrammarParserEQ               = 6
-	SearchGrammarParserNEQ              = 7
-	SearchGrammarParserLT               = 8
-	SearchGrammarParserLTE              = 9
-	SearchGrammarParserGT               = 10
-	SearchGrammarParserGTE              = 11
-	SearchGrammarParserLPAREN           = 12
-	SearchGrammarParserRPAREN           = 13
-	SearchGrammarParserCOLON            = 14
-	SearchGrammarParserID               = 15
-	SearchGrammarParserSTRING           = 16
-	SearchGrammarParserVALUE            = 17
-	SearchGrammarParserWS               = 18
-	SearchGrammarParserERROR_CHARACTERS = 19
+	SearchGrammarParserIN               = 5
+	SearchGrammarParserBANG             = 6
+	SearchGrammarParserEQ               = 7
+	SearchGrammarParserNEQ              = 8
+	SearchGrammarParserLT               = 9
+	SearchGrammarParserLTE              = 10
+	SearchGrammarParserGT               = 11
+	SearchGrammarParserGTE              = 12
+	SearchGrammarParserLPAREN           = 13
+	SearchGrammarParserRPAREN           = 14
+	SearchGrammarParserCOLON            = 15
+	SearchGrammarParserPIPE             = 16
+	SearchGrammarParserID               = 17
+	SearchGrammarParserSTRING           = 18
+	SearchGrammarParserVALUE            = 19
+	SearchGrammarParserWS               = 20
+	SearchGrammarParserERROR_CHARACTERS = 21
 )
 
 // SearchGrammarParser rules.
@@ -174,6 +199,12 @@ const (
 	SearchGrammarParserRULE_negation_op     = 9
 	SearchGrammarParserRULE_bin_op          = 10
 	SearchGrammarParserRULE_search_value    = 11
+	SearchGrammarParserRULE_in_op           = 12
+	SearchGrammarParserRULE_in_list         = 13
+	SearchGrammarParserRULE_pipeline        = 14
+	SearchGrammarParserRULE_pipe_stage      = 15
+	SearchGrammarParserRULE_pipe_command    = 16
+	SearchGrammarParserRULE_pipe_arg        = 17
 )
 
 // ISearch_queryContext is an interface to support dynamic dispatch.
@@ -186,6 +217,7 @@ type ISearch_queryContext interface {
 	// Getter signatures
 	EOF() antlr.TerminalNode
 	Search_expr() ISearch_exprContext
+	Pipeline() IPipelineContext
 
 	// IsSearch_queryContext differentiates from other interfaces.
 	IsSearch_queryContext()
@@ -243,6 +275,22 @@ func (s *Search_queryContext) Search_expr() ISearch_exprContext {
 	return t.(ISearch_exprContext)
 }
 
+func (s *Search_queryContext) Pipeline() IPipelineContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPipelineContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPipelineContext)
+}
+
 func (s *Search_queryContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -266,6 +314,8 @@ func (s *Search_queryContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SearchGrammarParser) Search_query() (localctx ISearch_queryContext) {
 	localctx = NewSearch_queryContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 0, SearchGrammarParserRULE_search_query)
+	var _la int
+
 	p.SetState(28)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
@@ -284,12 +334,26 @@ func (p *SearchGrammarParser) Search_query() (localctx ISearch_queryContext) {
 			}
 		}
 
-	case SearchGrammarParserNOT, SearchGrammarParserLPAREN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
+	case SearchGrammarParserNOT, SearchGrammarParserIN, SearchGrammarParserLPAREN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
 		p.EnterOuterAlt(localctx, 2)
 		{
 			p.SetState(25)
 			p.search_expr(0)
 		}
+		p.SetState(147)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SearchGrammarParserPIPE {
+			{
+				p.SetState(148)
+				p.Pipeline()
+			}
+
+		}
 		{
 			p.SetState(26)
 			p.Match(SearchGrammarParserEOF)
@@ -299,6 +363,21 @@ func (p *SearchGrammarParser) Search_query() (localctx ISearch_queryContext) {
 			}
 		}
 
+	case SearchGrammarParserPIPE:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(150)
+			p.Pipeline()
+		}
+		{
+			p.SetState(151)
+			p.Match(SearchGrammarParserEOF)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
 	default:
 		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		goto errorExit
@@ -579,7 +658,7 @@ func (p *SearchGrammarParser) Top_col_expr() (localctx ITop_col_exprContext) {
 			p.Top_col_expr()
 		}
 
-	case SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
+	case SearchGrammarParserIN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
 		localctx = NewTop_col_search_valueContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
@@ -1033,7 +1112,7 @@ func (p *SearchGrammarParser) col_expr(_p int) (localctx ICol_exprContext) {
 			p.col_expr(4)
 		}
 
-	case SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
+	case SearchGrammarParserIN, SearchGrammarParserID, SearchGrammarParserSTRING, SearchGrammarParserVALUE:
 		localctx = NewCol_search_valueContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1627,6 +1706,84 @@ func (s *Exists_search_exprContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
+type In_search_exprContext struct {
+	Search_exprContext
+}
+
+func NewIn_search_exprContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *In_search_exprContext {
+	var p = new(In_search_exprContext)
+
+	InitEmptySearch_exprContext(&p.Search_exprContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*Search_exprContext))
+
+	return p
+}
+
+func (s *In_search_exprContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *In_search_exprContext) Search_key() ISearch_keyContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISearch_keyContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISearch_keyContext)
+}
+
+func (s *In_search_exprContext) In_op() IIn_opContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIn_opContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIn_opContext)
+}
+
+func (s *In_search_exprContext) In_list() IIn_listContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIn_listContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIn_listContext)
+}
+
+func (s *In_search_exprContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterIn_search_expr(s)
+	}
+}
+
+func (s *In_search_exprContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitIn_search_expr(s)
+	}
+}
+
 type Key_val_search_exprContext struct {
 	Search_exprContext
 }
@@ -1847,6 +2004,23 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 		}
 
 	case 4:
+		localctx = NewIn_search_exprContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(129)
+			p.Search_key()
+		}
+		{
+			p.SetState(130)
+			p.In_op()
+		}
+		{
+			p.SetState(131)
+			p.In_list()
+		}
+
+	case 5:
 		localctx = NewExists_search_exprContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -1859,7 +2033,7 @@ func (p *SearchGrammarParser) search_expr(_p int) (localctx ISearch_exprContext)
 			p.Exists_op()
 		}
 
-	case 5:
+	case 6:
 		localctx = NewBody_search_exprContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
@@ -2724,7 +2898,7 @@ func (p *SearchGrammarParser) Bin_op() (localctx IBin_opContext) {
 		p.SetState(119)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&20448) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&40896) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -2787,6 +2961,7 @@ type ISearch_valueContext interface {
 	STRING() antlr.TerminalNode
 	ID() antlr.TerminalNode
 	VALUE() antlr.TerminalNode
+	IN() antlr.TerminalNode
 
 	// IsSearch_valueContext differentiates from other interfaces.
 	IsSearch_valueContext()
@@ -2836,6 +3011,10 @@ func (s *Search_valueContext) VALUE() antlr.TerminalNode {
 	return s.GetToken(SearchGrammarParserVALUE, 0)
 }
 
+func (s *Search_valueContext) IN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserIN, 0)
+}
+
 func (s *Search_valueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2866,7 +3045,791 @@ func (p *SearchGrammarParser) Search_value() (localctx ISearch_valueContext) {
 		p.SetState(126)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&229376) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&917536) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IIn_opContext is an interface to support dynamic dispatch.
+type IIn_opContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	IN() antlr.TerminalNode
+
+	// IsIn_opContext differentiates from other interfaces.
+	IsIn_opContext()
+}
+
+type In_opContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIn_opContext() *In_opContext {
+	var p = new(In_opContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_in_op
+	return p
+}
+
+func InitEmptyIn_opContext(p *In_opContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_in_op
+}
+
+func (*In_opContext) IsIn_opContext() {}
+
+func NewIn_opContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *In_opContext {
+	var p = new(In_opContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_in_op
+
+	return p
+}
+
+func (s *In_opContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *In_opContext) IN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserIN, 0)
+}
+
+func (s *In_opContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *In_opContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *In_opContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterIn_op(s)
+	}
+}
+
+func (s *In_opContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitIn_op(s)
+	}
+}
+
+func (p *SearchGrammarParser) In_op() (localctx IIn_opContext) {
+	localctx = NewIn_opContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 133, SearchGrammarParserRULE_in_op)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(137)
+		p.Match(SearchGrammarParserIN)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IIn_listContext is an interface to support dynamic dispatch.
+type IIn_listContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	LPAREN() antlr.TerminalNode
+	AllSearch_value() []ISearch_valueContext
+	Search_value(i int) ISearch_valueContext
+	RPAREN() antlr.TerminalNode
+
+	// IsIn_listContext differentiates from other interfaces.
+	IsIn_listContext()
+}
+
+type In_listContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIn_listContext() *In_listContext {
+	var p = new(In_listContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_in_list
+	return p
+}
+
+func InitEmptyIn_listContext(p *In_listContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_in_list
+}
+
+func (*In_listContext) IsIn_listContext() {}
+
+func NewIn_listContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *In_listContext {
+	var p = new(In_listContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_in_list
+
+	return p
+}
+
+func (s *In_listContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *In_listContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserLPAREN, 0)
+}
+
+func (s *In_listContext) AllSearch_value() []ISearch_valueContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISearch_valueContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISearch_valueContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISearch_valueContext); ok {
+			tst[i] = t.(ISearch_valueContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *In_listContext) Search_value(i int) ISearch_valueContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISearch_valueContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISearch_valueContext)
+}
+
+func (s *In_listContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserRPAREN, 0)
+}
+
+func (s *In_listContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *In_listContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *In_listContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterIn_list(s)
+	}
+}
+
+func (s *In_listContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitIn_list(s)
+	}
+}
+
+func (p *SearchGrammarParser) In_list() (localctx IIn_listContext) {
+	localctx = NewIn_listContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 135, SearchGrammarParserRULE_in_list)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(139)
+		p.Match(SearchGrammarParserLPAREN)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(141)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&917536) != 0) {
+		{
+			p.SetState(140)
+			p.Search_value()
+		}
+
+		p.SetState(143)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(145)
+		p.Match(SearchGrammarParserRPAREN)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IPipelineContext is an interface to support dynamic dispatch.
+type IPipelineContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllPipe_stage() []IPipe_stageContext
+	Pipe_stage(i int) IPipe_stageContext
+
+	// IsPipelineContext differentiates from other interfaces.
+	IsPipelineContext()
+}
+
+type PipelineContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPipelineContext() *PipelineContext {
+	var p = new(PipelineContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipeline
+	return p
+}
+
+func InitEmptyPipelineContext(p *PipelineContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipeline
+}
+
+func (*PipelineContext) IsPipelineContext() {}
+
+func NewPipelineContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PipelineContext {
+	var p = new(PipelineContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_pipeline
+
+	return p
+}
+
+func (s *PipelineContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PipelineContext) AllPipe_stage() []IPipe_stageContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IPipe_stageContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IPipe_stageContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IPipe_stageContext); ok {
+			tst[i] = t.(IPipe_stageContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *PipelineContext) Pipe_stage(i int) IPipe_stageContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPipe_stageContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPipe_stageContext)
+}
+
+func (s *PipelineContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PipelineContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PipelineContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterPipeline(s)
+	}
+}
+
+func (s *PipelineContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitPipeline(s)
+	}
+}
+
+func (p *SearchGrammarParser) Pipeline() (localctx IPipelineContext) {
+	localctx = NewPipelineContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 153, SearchGrammarParserRULE_pipeline)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(162)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = _la == SearchGrammarParserPIPE {
+		{
+			p.SetState(161)
+			p.Pipe_stage()
+		}
+
+		p.SetState(164)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IPipe_stageContext is an interface to support dynamic dispatch.
+type IPipe_stageContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	Pipe_command() IPipe_commandContext
+	AllPipe_arg() []IPipe_argContext
+	Pipe_arg(i int) IPipe_argContext
+
+	// IsPipe_stageContext differentiates from other interfaces.
+	IsPipe_stageContext()
+}
+
+type Pipe_stageContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPipe_stageContext() *Pipe_stageContext {
+	var p = new(Pipe_stageContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipe_stage
+	return p
+}
+
+func InitEmptyPipe_stageContext(p *Pipe_stageContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipe_stage
+}
+
+func (*Pipe_stageContext) IsPipe_stageContext() {}
+
+func NewPipe_stageContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Pipe_stageContext {
+	var p = new(Pipe_stageContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_pipe_stage
+
+	return p
+}
+
+func (s *Pipe_stageContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *Pipe_stageContext) Pipe_command() IPipe_commandContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPipe_commandContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPipe_commandContext)
+}
+
+func (s *Pipe_stageContext) AllPipe_arg() []IPipe_argContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IPipe_argContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IPipe_argContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IPipe_argContext); ok {
+			tst[i] = t.(IPipe_argContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *Pipe_stageContext) Pipe_arg(i int) IPipe_argContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPipe_argContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPipe_argContext)
+}
+
+func (s *Pipe_stageContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Pipe_stageContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *Pipe_stageContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterPipe_stage(s)
+	}
+}
+
+func (s *Pipe_stageContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitPipe_stage(s)
+	}
+}
+
+func (p *SearchGrammarParser) Pipe_stage() (localctx IPipe_stageContext) {
+	localctx = NewPipe_stageContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 155, SearchGrammarParserRULE_pipe_stage)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(166)
+		p.Pipe_command()
+	}
+	p.SetState(170)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&4128766) != 0 {
+		{
+			p.SetState(167)
+			p.Pipe_arg()
+		}
+
+		p.SetState(172)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IPipe_commandContext is an interface to support dynamic dispatch.
+type IPipe_commandContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	PIPE() antlr.TerminalNode
+
+	// IsPipe_commandContext differentiates from other interfaces.
+	IsPipe_commandContext()
+}
+
+type Pipe_commandContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPipe_commandContext() *Pipe_commandContext {
+	var p = new(Pipe_commandContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipe_command
+	return p
+}
+
+func InitEmptyPipe_commandContext(p *Pipe_commandContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipe_command
+}
+
+func (*Pipe_commandContext) IsPipe_commandContext() {}
+
+func NewPipe_commandContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Pipe_commandContext {
+	var p = new(Pipe_commandContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_pipe_command
+
+	return p
+}
+
+func (s *Pipe_commandContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *Pipe_commandContext) PIPE() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserPIPE, 0)
+}
+
+func (s *Pipe_commandContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Pipe_commandContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *Pipe_commandContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterPipe_command(s)
+	}
+}
+
+func (s *Pipe_commandContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitPipe_command(s)
+	}
+}
+
+func (p *SearchGrammarParser) Pipe_command() (localctx IPipe_commandContext) {
+	localctx = NewPipe_commandContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 157, SearchGrammarParserRULE_pipe_command)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(173)
+		p.Match(SearchGrammarParserPIPE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IPipe_argContext is an interface to support dynamic dispatch.
+type IPipe_argContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	PIPE() antlr.TerminalNode
+
+	// IsPipe_argContext differentiates from other interfaces.
+	IsPipe_argContext()
+}
+
+type Pipe_argContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPipe_argContext() *Pipe_argContext {
+	var p = new(Pipe_argContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipe_arg
+	return p
+}
+
+func InitEmptyPipe_argContext(p *Pipe_argContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SearchGrammarParserRULE_pipe_arg
+}
+
+func (*Pipe_argContext) IsPipe_argContext() {}
+
+func NewPipe_argContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Pipe_argContext {
+	var p = new(Pipe_argContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SearchGrammarParserRULE_pipe_arg
+
+	return p
+}
+
+func (s *Pipe_argContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *Pipe_argContext) PIPE() antlr.TerminalNode {
+	return s.GetToken(SearchGrammarParserPIPE, 0)
+}
+
+func (s *Pipe_argContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Pipe_argContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *Pipe_argContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.EnterPipe_arg(s)
+	}
+}
+
+func (s *Pipe_argContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SearchGrammarListener); ok {
+		listenerT.ExitPipe_arg(s)
+	}
+}
+
+func (p *SearchGrammarParser) Pipe_arg() (localctx IPipe_argContext) {
+	localctx = NewPipe_argContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 159, SearchGrammarParserRULE_pipe_arg)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(175)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la <= 0 || _la == SearchGrammarParserPIPE {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -2,9 +2,11 @@ package listener
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/antlr4-go/antlr/v4"
 	"github.com/huandu/go-sqlbuilder"
@@ -28,6 +30,7 @@ var OperatorLessThan Operator = "<"
 var OperatorLessThanOrEqualTo Operator = "<="
 var OperatorAnd Operator = "AND"
 var OperatorOr Operator = "OR"
+var OperatorIPInRange Operator = "isIPAddressInRange"
 
 type FilterOperation struct {
 	Column   string
@@ -51,6 +54,10 @@ type SearchListener struct {
 	attributesList    bool
 	tableConfig       model.TableConfig
 	IgnoredFilters    map[string]string
+	// relative time values like now-1h are resolved against the time the listener was created
+	now time.Time
+	// index of the first rule of the IN list being walked, or -1 outside of a list
+	inListStart int
 }
 
 func (s *SearchListener) GetFilters() Filters {
@@ -68,6 +75,8 @@ func NewSearchListener(sqlBuilder *sqlbuilder.SelectBuilder, tableConfig model.T
 		attributesList:    tableConfig.AttributesTable != "",
 		tableConfig:       tableConfig,
 		IgnoredFilters:    map[string]string{},
+		now:               time.Now(),
+		inListStart:       -1,
 	}
 }
 
@@ -84,10 +93,18 @@ func getAttributeFilterExpr(key string, filterType attributeFilterType, col stri
 		prefix = "toFloat64OrNull("
 		postfix = ")"
 	}
+	if t, ok := value.(time.Time); ok {
+		// attribute values are parsed as times in any format, to compare them with a relative time
+		prefix = "parseDateTime64BestEffortOrNull("
+		postfix = ", 3, 'UTC')"
+		value = t.UTC().Format(time.DateTime)
+	}
 	if filterType == attributeFilterTypeMap {
 		// For NOT EXISTS queries, return true if there is no matching key in the array.
 		if value == "" {
 			return sqlbuilder.Buildf(fmt.Sprintf("empty(arrayFilter((k, v) -> k = %%s, %s))", col), key)
+		} else if op == OperatorIPInRange {
+			return sqlbuilder.Buildf(fmt.Sprintf("notEmpty(arrayFilter((k, v) -> k = %%s AND %s, %s))", ipInRangeExpr("v"), col), key, value)
 		} else {
 			return sqlbuilder.Buildf(fmt.Sprintf("notEmpty(arrayFilter((k, v) -> k = %%s AND %sv%s %s %%s, %s))", prefix, postfix, op, col), key, value)
 		}
@@ -95,13 +112,24 @@ func getAttributeFilterExpr(key string, filterType attributeFilterType, col stri
 		// For NOT EXISTS queries, return true if there is no matching key in the array.
 		if value == "" {
 			return sqlbuilder.Buildf(fmt.Sprintf("empty(arrayFilter((v) -> v != '', %s))", col))
+		} else if op == OperatorIPInRange {
+			return sqlbuilder.Buildf(fmt.Sprintf("notEmpty(arrayFilter((v) -> %s, %s))", ipInRangeExpr("v"), col), value)
 		} else {
 			return sqlbuilder.Buildf(fmt.Sprintf("notEmpty(arrayFilter((v) -> %sv%s %s %%s, %s))", prefix, postfix, op, col), value)
 		}
 	}
+	if op == OperatorIPInRange {
+		return sqlbuilder.Buildf(ipInRangeExpr(col+"[%s]"), key, key, key, value)
+	}
 	return sqlbuilder.Buildf(prefix+col+fmt.Sprintf("[%%s]%s %s %%s", postfix, op), key, value)
 }
 
+// ipInRangeExpr returns a format string matching expr against a CIDR argument.
+// isIPAddressInRange throws on values which are not IP addresses, so those are filtered out first.
+func ipInRangeExpr(expr string) string {
+	return fmt.Sprintf("(isIPv4String(%[1]s) OR isIPv6String(%[1]s)) AND isIPAddressInRange(%[1]s, %%s)", expr)
+}
+
 func (s *SearchListener) EnterSearch_query(ctx *parser.Search_queryContext) {}
 func (s *SearchListener) ExitSearch_query(ctx *parser.Search_queryContext) {
 	s.sb.Where(s.rules...)
@@ -236,6 +264,32 @@ func (s *SearchListener) ExitKey_val_search_expr(ctx *parser.Key_val_search_expr
 	}
 }
 
+func (s *SearchListener) EnterIn_search_expr(ctx *parser.In_search_exprContext) {}
+func (s *SearchListener) ExitIn_search_expr(ctx *parser.In_search_exprContext) {
+	start := s.inListStart
+	s.inListStart = -1
+	if start < 0 || (s.tableConfig.IgnoredFilters != nil && s.tableConfig.IgnoredFilters[s.currentKey]) {
+		return
+	}
+
+	// copy the values, the slices are reused below
+	rules := append([]string{}, s.rules[start:]...)
+	ops := append(Filters{}, s.ops[start:]...)
+	s.rules = s.rules[:start]
+	s.ops = s.ops[:start]
+
+	// a list of only commas has no values and matches nothing
+	if len(rules) == 0 {
+		s.rules = append(s.rules, "false")
+	} else {
+		s.rules = append(s.rules, s.sb.Or(rules...))
+	}
+	s.ops = append(s.ops, &FilterOperation{
+		Operator: OperatorOr,
+		Filters:  ops,
+	})
+}
+
 func (s *SearchListener) EnterParen_search_expr(ctx *parser.Paren_search_exprContext) {}
 func (s *SearchListener) ExitParen_search_expr(ctx *parser.Paren_search_exprContext)  {}
 
@@ -256,6 +310,26 @@ func (s *SearchListener) ExitImplicit_and_op(ctx *parser.Implicit_and_opContext)
 func (s *SearchListener) EnterNegation_op(ctx *parser.Negation_opContext) {}
 func (s *SearchListener) ExitNegation_op(ctx *parser.Negation_opContext)  {}
 
+func (s *SearchListener) EnterIn_op(ctx *parser.In_opContext) {
+	s.currentOp = "="
+}
+func (s *SearchListener) ExitIn_op(ctx *parser.In_opContext) {}
+
+func (s *SearchListener) EnterIn_list(ctx *parser.In_listContext) {
+	s.inListStart = len(s.rules)
+}
+func (s *SearchListener) ExitIn_list(ctx *parser.In_listContext) {}
+
+// aggregation stages are compiled by parser.ParsePipeline and don't filter rows
+func (s *SearchListener) EnterPipeline(ctx *parser.PipelineContext)         {}
+func (s *SearchListener) ExitPipeline(ctx *parser.PipelineContext)          {}
+func (s *SearchListener) EnterPipe_stage(ctx *parser.Pipe_stageContext)     {}
+func (s *SearchListener) ExitPipe_stage(ctx *parser.Pipe_stageContext)      {}
+func (s *SearchListener) EnterPipe_command(ctx *parser.Pipe_commandContext) {}
+func (s *SearchListener) ExitPipe_command(ctx *parser.Pipe_commandContext)  {}
+func (s *SearchListener) EnterPipe_arg(ctx *parser.Pipe_argContext)         {}
+func (s *SearchListener) ExitPipe_arg(ctx *parser.Pipe_argContext)          {}
+
 func (s *SearchListener) EnterBin_op(ctx *parser.Bin_opContext) {
 	s.currentOp = ctx.GetText()
 }
@@ -290,7 +364,15 @@ func (s *SearchListener) ExitExists_op(ctx *parser.Exists_opContext) {
 }
 
 func (s *SearchListener) EnterSearch_value(ctx *parser.Search_valueContext) {
-	s.appendRules(ctx.GetText())
+	value := ctx.GetText()
+	// commas are lexed as part of the values, so split them into the list values
+	if s.inListStart >= 0 && ctx.STRING() == nil {
+		for _, v := range splitListValue(value) {
+			s.appendRules(v)
+		}
+		return
+	}
+	s.appendRules(value)
 }
 func (s *SearchListener) ExitSearch_value(ctx *parser.Search_valueContext) {}
 
@@ -353,6 +435,24 @@ func (s *SearchListener) appendRules(value string) {
 		filterType = attributeFilterTypeMap
 	}
 
+	if s.currentOp == ">" || s.currentOp == ">=" || s.currentOp == "<" || s.currentOp == "<=" {
+		if t, ok := relativeTime(value, s.now); ok {
+			if extendedAttributeKey {
+				op := Operator(s.currentOp)
+				s.rules = append(s.rules, s.sb.Var(getAttributeFilterExpr(s.currentKey, filterType, attributesColumn, op, t)))
+				s.ops = append(s.ops, &FilterOperation{
+					Key:      s.currentKey,
+					Column:   attributesColumn,
+					Operator: op,
+					Values:   []string{t.UTC().Format(time.RFC3339)},
+				})
+				return
+			} else if s.tableConfig.TimeKeys[s.currentKey] {
+				value = strconv.FormatInt(t.Unix(), 10)
+			}
+		}
+	}
+
 	if s.currentOp == ":" || s.currentOp == "=" || s.currentOp == "!=" {
 		if strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
 			value = strings.Trim(value, "/")
@@ -391,6 +491,23 @@ func (s *SearchListener) appendRules(value string) {
 					Values:   []string{value},
 				})
 			}
+		} else if _, _, err := net.ParseCIDR(value); err == nil && ipKeyRegex.MatchString(s.currentKey) {
+			if extendedAttributeKey {
+				s.rules = append(s.rules, s.sb.Var(getAttributeFilterExpr(s.currentKey, filterType, attributesColumn, OperatorIPInRange, value)))
+				s.ops = append(s.ops, &FilterOperation{
+					Key:      s.currentKey,
+					Column:   attributesColumn,
+					Operator: OperatorIPInRange,
+					Values:   []string{value},
+				})
+			} else {
+				s.rules = append(s.rules, s.sb.Var(sqlbuilder.Buildf(ipInRangeExpr(fmt.Sprintf("toString(%s)", filterKey)), value)))
+				s.ops = append(s.ops, &FilterOperation{
+					Key:      filterKey,
+					Operator: OperatorIPInRange,
+					Values:   []string{value},
+				})
+			}
 		} else {
 			if extendedAttributeKey {
 				s.rules = append(s.rules, s.sb.Var(getAttributeFilterExpr(s.currentKey, filterType, attributesColumn, OperatorEqual, value)))
@@ -486,6 +603,37 @@ func (s *SearchListener) appendRules(value string) {
 	}
 }
 
+// splitListValue splits a value of an IN list on commas outside of quotes, dropping empty values
+func splitListValue(value string) []string {
+	var values []string
+	var quote rune
+	var escaped bool
+	start := 0
+	for i, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == ',':
+			if i > start {
+				values = append(values, value[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(value) {
+		values = append(values, value[start:])
+	}
+	return values
+}
+
 func wildcardValue(value string) string {
 	value = strings.ReplaceAll(strings.ReplaceAll(value, "_", "\\_"), "*", "%")
 
@@ -559,6 +707,41 @@ func NumericValue(value string, tableKey string) string {
 	return strconv.FormatInt(num*nanoMultiplier/keyDivisor, 10)
 }
 
+// ipKeyRegex matches keys holding IP addresses, such as ip, client_ip, http.client_ip or client.address,
+// whose CIDR values match the addresses in the range rather than the value itself
+var ipKeyRegex = regexp.MustCompile(`(?i)(?:^|[._])ip(?:_?addr(?:ess)?)?$|\.addr(?:ess)?$`)
+
+var relativeTimeRegex = regexp.MustCompile(`(?i)^now(?:([+-])(\d+)([smhdw]))?$`)
+
+var relativeTimeUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// relativeTime resolves values like now, now-15m or now+1d against the given time
+func relativeTime(value string, now time.Time) (time.Time, bool) {
+	matches := relativeTimeRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return time.Time{}, false
+	}
+	if matches[1] == "" {
+		return now, true
+	}
+
+	num, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	offset := time.Duration(num) * relativeTimeUnits[strings.ToLower(matches[3])]
+	if matches[1] == "-" {
+		offset = -offset
+	}
+	return now.Add(offset), true
+}
+
 func baseUnit(tableKey string) string {
 	unit := timeMetrics[tableKey]
 	if unit == "" {
@@ -1,9 +1,16 @@
 package listener
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/huandu/go-sqlbuilder"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/highlight-run/highlight/backend/model"
+	parser "github.com/highlight-run/highlight/backend/parser/antlr"
 )
 
 type UnquoteTest struct {
@@ -99,3 +106,77 @@ func TestNumericValue(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, output)
 	}
 }
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	var testCases = map[string]time.Time{
+		"now":     now,
+		"NOW":     now,
+		"now-15m": now.Add(-15 * time.Minute),
+		"now-1h":  now.Add(-time.Hour),
+		"now-7d":  now.AddDate(0, 0, -7),
+		"now+1w":  now.AddDate(0, 0, 7),
+		"now-30s": now.Add(-30 * time.Second),
+	}
+
+	for value, expected := range testCases {
+		output, ok := relativeTime(value, now)
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, output, value)
+	}
+
+	for _, value := range []string{"nowhere", "now-1", "now-1y", "1h", ""} {
+		_, ok := relativeTime(value, now)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestRelativeTimeSearch(t *testing.T) {
+	now := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	sb := sqlbuilder.NewSelectBuilder().Select("*").From("t")
+	l := NewSearchListener(sb, model.TableConfig{
+		KeysToColumns: map[string]string{"timestamp": "Timestamp", "level": "Level"},
+		TimeKeys:      map[string]bool{"timestamp": true},
+		BodyColumn:    "Body",
+	})
+	l.now = now
+
+	p := parser.NewSearchGrammarParser(antlr.NewCommonTokenStream(parser.NewSearchGrammarLexer(antlr.NewInputStream("timestamp>=now-1h timestamp<now level=now")), antlr.TokenDefaultChannel))
+	antlr.ParseTreeWalkerDefault.Walk(l, p.Search_query())
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	sql, _ = sqlbuilder.ClickHouse.Interpolate(sql, args)
+	assert.Equal(t, fmt.Sprintf("SELECT * FROM t WHERE Timestamp >= '%d' AND Timestamp < '%d' AND toString(Level) = 'now'", now.Add(-time.Hour).Unix(), now.Unix()), sql)
+}
+
+func TestRelativeTimeAttributeSearch(t *testing.T) {
+	now := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	sb := sqlbuilder.NewSelectBuilder().Select("*").From("t")
+	l := NewSearchListener(sb, model.TableConfig{
+		KeysToColumns:     map[string]string{"timestamp": "Timestamp", "duration": "Duration"},
+		TimeKeys:          map[string]bool{"timestamp": true},
+		AttributesColumns: []model.ColumnMapping{{Column: "LogAttributes"}},
+		BodyColumn:        "Body",
+	})
+	l.now = now
+
+	p := parser.NewSearchGrammarParser(antlr.NewCommonTokenStream(parser.NewSearchGrammarLexer(antlr.NewInputStream("updated_at>now-15m duration>now")), antlr.TokenDefaultChannel))
+	antlr.ParseTreeWalkerDefault.Walk(l, p.Search_query())
+
+	sql, args := sb.BuildWithFlavor(sqlbuilder.ClickHouse)
+	sql, _ = sqlbuilder.ClickHouse.Interpolate(sql, args)
+	assert.Equal(t, "SELECT * FROM t WHERE parseDateTime64BestEffortOrNull(LogAttributes['updated_at'], 3, 'UTC') > '2024-01-08 11:45:00' AND Duration > 'now'", sql)
+	assert.Equal(t, Filters{
+		{Key: "updated_at", Column: "LogAttributes", Operator: OperatorGreaterThan, Values: []string{"2024-01-08T11:45:00Z"}},
+		{Key: "Duration", Operator: OperatorGreaterThan, Values: []string{"now"}},
+	}, l.GetFilters())
+}
+
+func TestSplitListValue(t *testing.T) {
+	assert.Equal(t, []string{"200"}, splitListValue("200,"))
+	assert.Equal(t, []string{"200", "404"}, splitListValue("200,404"))
+	assert.Equal(t, []string{"404"}, splitListValue(",404"))
+	assert.Equal(t, []string{`"a,b"`}, splitListValue(`"a,b",`))
+	assert.Equal(t, []string{`'a\',b'`, "c"}, splitListValue(`'a\',b',c`))
+	assert.Nil(t, splitListValue(","))
+}
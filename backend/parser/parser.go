@@ -20,6 +20,8 @@ func GetSearchFilters(query string, tableConfig model.TableConfig, listener *lis
 	s := util.StartSpan("GetSearchFilters", util.Tag("query", query))
 	defer s.Finish()
 
+	// aggregation stages don't filter rows and must stay last, so only the filter is searched
+	query, _ = SplitPipeline(query)
 	if !strings.Contains(query, string(modelInputs.ReservedTraceKeyMetricName)) {
 		query = query + " " + tableConfig.DefaultFilter
 	}
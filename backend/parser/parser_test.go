@@ -58,6 +58,23 @@ func TestSpacesInSearch(t *testing.T) {
 	assert.Equal(t, "SELECT * FROM t WHERE NOT (toString(SpanName) = 'KafkaWorkersOnStrike')", sql)
 }
 
+func TestInListSearch(t *testing.T) {
+	sql, _ := buildSqlForQuery("level IN (info, warn,error) custom in (\"a, b\" c)")
+	assert.Equal(t, "SELECT * FROM t WHERE (toString(Level) = 'info' OR toString(Level) = 'warn' OR toString(Level) = 'error') AND (TraceAttributes['custom'] = 'a, b' OR TraceAttributes['custom'] = 'c')", sql)
+
+	sql, _ = buildSqlForQuery("NOT service_name IN (private-graph, *-worker) logged in")
+	assert.Equal(t, "SELECT * FROM t WHERE NOT ((toString(ServiceName) = 'private-graph' OR ServiceName ILIKE '%-worker%')) AND hasTokenCaseInsensitive(SpanName, 'logged') AND hasTokenCaseInsensitive(SpanName, 'in')", sql)
+}
+
+func TestCIDRSearch(t *testing.T) {
+	sql, _ := buildSqlForQuery("ip=10.0.0.0/8 http.client_ip!=192.168.0.0/16")
+	assert.Equal(t, "SELECT * FROM t WHERE (isIPv4String(TraceAttributes['ip']) OR isIPv6String(TraceAttributes['ip'])) AND isIPAddressInRange(TraceAttributes['ip'], '10.0.0.0/8') AND NOT ((isIPv4String(HttpAttributes['http.client_ip']) OR isIPv6String(HttpAttributes['http.client_ip'])) AND isIPAddressInRange(HttpAttributes['http.client_ip'], '192.168.0.0/16'))", sql)
+
+	// only keys holding IP addresses match CIDR ranges
+	sql, _ = buildSqlForQuery("source=10.0.0.0/8 version=1.2.3.0/24")
+	assert.Equal(t, "SELECT * FROM t WHERE toString(Source) = '10.0.0.0/8' AND TraceAttributes['version'] = '1.2.3.0/24'", sql)
+}
+
 func buildSqlForQuery(query string) (string, error) {
 	sqlBuilder := sqlbuilder.NewSelectBuilder()
 	sb := sqlBuilder.Select("*").From("t")
@@ -0,0 +1,581 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/highlight-run/highlight/backend/parser/antlr"
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/samber/lo"
+)
+
+// PipelineCommand is a stage of the aggregation pipeline following the filter of a search query,
+// e.g. `level=error | stats count(), p95(duration) by service_name | sort -count | head 10`.
+type PipelineCommand string
+
+const (
+	PipelineCommandStats     PipelineCommand = "stats"
+	PipelineCommandTimechart PipelineCommand = "timechart"
+	PipelineCommandWhere     PipelineCommand = "where"
+	PipelineCommandSort      PipelineCommand = "sort"
+	PipelineCommandHead      PipelineCommand = "head"
+)
+
+var pipelineAggregators = map[string]modelInputs.MetricAggregator{
+	"count":          modelInputs.MetricAggregatorCount,
+	"count_distinct": modelInputs.MetricAggregatorCountDistinct,
+	"dc":             modelInputs.MetricAggregatorCountDistinct,
+	"min":            modelInputs.MetricAggregatorMin,
+	"max":            modelInputs.MetricAggregatorMax,
+	"avg":            modelInputs.MetricAggregatorAvg,
+	"sum":            modelInputs.MetricAggregatorSum,
+	"p50":            modelInputs.MetricAggregatorP50,
+	"p90":            modelInputs.MetricAggregatorP90,
+	"p95":            modelInputs.MetricAggregatorP95,
+	"p99":            modelInputs.MetricAggregatorP99,
+}
+
+var pipelineOperators = map[string]listener.Operator{
+	"=":  listener.OperatorEqual,
+	"!=": listener.OperatorNotEqual,
+	">":  listener.OperatorGreaterThan,
+	">=": listener.OperatorGreaterThanOrEqualTo,
+	"<":  listener.OperatorLessThan,
+	"<=": listener.OperatorLessThanOrEqualTo,
+}
+
+var spanRegex = regexp.MustCompile(`^(\d+)([smhdw])$`)
+
+var spanUnits = map[string]int{"s": 1, "m": 60, "h": 60 * 60, "d": 24 * 60 * 60, "w": 7 * 24 * 60 * 60}
+
+const defaultPipelineHead = 10
+
+// PipelineAggregation is an aggregated field of a stats or timechart stage.
+type PipelineAggregation struct {
+	Aggregator modelInputs.MetricAggregator
+	Column     string
+	// Name refers to the field in later stages: `count` for `count()`, `p95(duration)` for
+	// `p95(duration)`, or the alias given with `as`.
+	Name string
+}
+
+// PipelineCondition keeps the aggregated rows of a where stage whose field compares to the value.
+type PipelineCondition struct {
+	Field string
+	Op    listener.Operator
+	Value float64
+}
+
+// PipelineSort orders aggregated rows by an aggregated field or a group key.
+type PipelineSort struct {
+	Field      string
+	Descending bool
+}
+
+// PipelineStep is a where, sort or head stage applied to the aggregated rows in query order.
+type PipelineStep struct {
+	Command   PipelineCommand
+	Condition *PipelineCondition
+	Sort      []PipelineSort
+	Limit     int
+}
+
+// Pipeline is the compiled aggregation pipeline of a search query.
+type Pipeline struct {
+	Aggregations []PipelineAggregation
+	GroupBy      []string
+	// Timechart buckets the aggregations by time rather than aggregating the whole date range.
+	Timechart bool
+	// Span is the timechart bucket width in seconds, nil to use the requested bucket count.
+	Span  *int
+	Steps []PipelineStep
+}
+
+// IsGroupKey returns whether the field refers to a group key rather than an aggregated field.
+func (p *Pipeline) IsGroupKey(field string) bool {
+	return lo.Contains(p.GroupBy, field)
+}
+
+// AggregationIndex returns the index of the aggregated field, or -1 if there is no such field.
+func (p *Pipeline) AggregationIndex(field string) int {
+	_, idx, _ := lo.FindIndexOf(p.Aggregations, func(a PipelineAggregation) bool {
+		return strings.EqualFold(a.Name, field)
+	})
+	return idx
+}
+
+// PipelineError is a problem with a pipeline stage, positioned at the offending part of the query.
+type PipelineError struct {
+	Message string
+	Line    int
+	Column  int
+	Length  int
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("invalid search query pipeline: %d:%d %s", e.Line, e.Column, e.Message)
+}
+
+// HasPipeline returns whether the search query has aggregation stages after its filter.
+func HasPipeline(query string) bool {
+	_, pipeline := SplitPipeline(query)
+	return pipeline != ""
+}
+
+// SplitPipeline splits a search query into its filter and the aggregation pipeline starting at the first pipe.
+func SplitPipeline(query string) (filter string, pipeline string) {
+	lexer := parser.NewSearchGrammarLexer(antlr.NewInputStream(query))
+	lexer.RemoveErrorListeners()
+	for token := lexer.NextToken(); token.GetTokenType() != antlr.TokenEOF; token = lexer.NextToken() {
+		if token.GetTokenType() == parser.SearchGrammarLexerPIPE {
+			runes := []rune(query)
+			return string(runes[:token.GetStart()]), string(runes[token.GetStart():])
+		}
+	}
+	return query, ""
+}
+
+// ParsePipeline compiles the aggregation pipeline of a search query, returning nil if the query has none.
+// Syntax errors of the filter are left to ValidateSearchQuery.
+func ParsePipeline(query string) (*Pipeline, error) {
+	if !HasPipeline(query) {
+		return nil, nil
+	}
+
+	lexer := parser.NewSearchGrammarLexer(antlr.NewInputStream(query))
+	lexer.RemoveErrorListeners()
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewSearchGrammarParser(stream)
+	p.RemoveErrorListeners()
+
+	l := &pipelineListener{}
+	antlr.ParseTreeWalkerDefault.Walk(l, p.Search_query())
+	if len(l.stages) == 0 {
+		return nil, nil
+	}
+
+	pipeline, err := compilePipeline(l.stages)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+type pipelineListener struct {
+	parser.BaseSearchGrammarListener
+
+	stages []*pipelineStage
+}
+
+func (l *pipelineListener) EnterPipe_stage(ctx *parser.Pipe_stageContext) {
+	l.stages = append(l.stages, newPipelineStage(ctx))
+}
+
+// pipelineStage is the command and raw argument text of a pipe stage, tokenized separately from the
+// search grammar as the arguments use their own syntax, e.g. `p95(duration) by service_name`.
+type pipelineStage struct {
+	command string
+	// position of the command
+	commandLine   int
+	commandColumn int
+	args          string
+	// position of the first argument, or of the end of the command without arguments
+	line   int
+	column int
+}
+
+func newPipelineStage(ctx *parser.Pipe_stageContext) *pipelineStage {
+	token := ctx.GetStart()
+	stage := &pipelineStage{}
+	// the pipe token includes the command, e.g. `| stats`
+	text := token.GetText()
+	stage.command = strings.TrimLeft(text, "| \t\n\r\f")
+	stage.commandLine, stage.commandColumn = advance(token.GetLine(), token.GetColumn(), strings.TrimSuffix(text, stage.command))
+	stage.line, stage.column = advance(stage.commandLine, stage.commandColumn, stage.command)
+
+	args := ctx.AllPipe_arg()
+	if len(args) > 0 {
+		start, stop := args[0].GetStart(), args[len(args)-1].GetStop()
+		stage.args = start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+		stage.line = start.GetLine()
+		stage.column = start.GetColumn()
+	}
+	return stage
+}
+
+// errorAt positions an error at an offset of the stage arguments.
+func (s *pipelineStage) errorAt(token pipelineToken, message string) *PipelineError {
+	line, column := advance(s.line, s.column, string([]rune(s.args)[:token.offset]))
+	return &PipelineError{Message: message, Line: line, Column: column, Length: token.length}
+}
+
+// advance returns the position after the text starting at the line and column.
+func advance(line int, column int, text string) (int, int) {
+	for _, r := range text {
+		if r == '\n' {
+			line++
+			column = 0
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func (s *pipelineStage) commandError(message string) *PipelineError {
+	return &PipelineError{
+		Message: message,
+		Line:    s.commandLine,
+		Column:  s.commandColumn,
+		Length:  len([]rune(s.command)),
+	}
+}
+
+func compilePipeline(stages []*pipelineStage) (*Pipeline, *PipelineError) {
+	pipeline := &Pipeline{}
+	for idx, stage := range stages {
+		// the lexer only reads a pipe followed by one of the commands as a stage
+		command := PipelineCommand(strings.ToLower(stage.command))
+
+		isAggregation := command == PipelineCommandStats || command == PipelineCommandTimechart
+		if idx == 0 && !isAggregation {
+			return nil, stage.commandError(fmt.Sprintf("\"%s\" must follow a stats or timechart stage", command))
+		} else if idx > 0 && isAggregation {
+			return nil, stage.commandError(fmt.Sprintf("Only one stats or timechart stage is supported, found \"%s\"", command))
+		}
+
+		args := &pipelineArgs{stage: stage, tokens: tokenizePipelineArgs(stage.args)}
+		var err *PipelineError
+		switch command {
+		case PipelineCommandStats, PipelineCommandTimechart:
+			err = args.parseAggregation(pipeline, command == PipelineCommandTimechart)
+		case PipelineCommandWhere:
+			err = args.parseWhere(pipeline)
+		case PipelineCommandSort:
+			err = args.parseSort(pipeline)
+		case PipelineCommandHead:
+			err = args.parseHead(pipeline)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pipeline, nil
+}
+
+type pipelineToken struct {
+	text   string
+	quoted bool
+	// rune offset and length within the stage arguments
+	offset int
+	length int
+}
+
+func (t pipelineToken) is(text string) bool {
+	return !t.quoted && strings.EqualFold(t.text, text)
+}
+
+// tokenizePipelineArgs splits stage arguments into words, quoted strings, parentheses, commas and operators.
+func tokenizePipelineArgs(args string) []pipelineToken {
+	runes := []rune(args)
+	var tokens []pipelineToken
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, pipelineToken{text: string(r), offset: i, length: 1})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			length := 1
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				length = 2
+			}
+			tokens = append(tokens, pipelineToken{text: string(runes[i : i+length]), offset: i, length: length})
+			i += length
+		case r == '"' || r == '\'' || r == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end, len(runes)-1)
+			tokens = append(tokens, pipelineToken{text: string(runes[i+1 : end]), quoted: true, offset: i, length: end - i + 1})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("(),=!<>\"'`", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, pipelineToken{text: string(runes[i:end]), offset: i, length: end - i})
+			i = end
+		}
+	}
+	return tokens
+}
+
+type pipelineArgs struct {
+	stage  *pipelineStage
+	tokens []pipelineToken
+	pos    int
+}
+
+func (a *pipelineArgs) done() bool {
+	return a.pos >= len(a.tokens)
+}
+
+func (a *pipelineArgs) peek() pipelineToken {
+	if a.done() {
+		return pipelineToken{offset: len([]rune(a.stage.args))}
+	}
+	return a.tokens[a.pos]
+}
+
+func (a *pipelineArgs) next() pipelineToken {
+	token := a.peek()
+	a.pos++
+	return token
+}
+
+func (a *pipelineArgs) accept(text string) bool {
+	if !a.done() && a.peek().is(text) {
+		a.pos++
+		return true
+	}
+	return false
+}
+
+func (a *pipelineArgs) errorAt(token pipelineToken, format string, args ...interface{}) *PipelineError {
+	return a.stage.errorAt(token, fmt.Sprintf(format, args...))
+}
+
+func (a *pipelineArgs) expectEnd() *PipelineError {
+	if !a.done() {
+		return a.errorAt(a.peek(), "Unexpected \"%s\" in %s stage", a.peek().text, strings.ToLower(a.stage.command))
+	}
+	return nil
+}
+
+// word reads a key, field name or function name.
+func (a *pipelineArgs) word(expected string) (pipelineToken, *PipelineError) {
+	token := a.next()
+	if token.text == "" || (!token.quoted && strings.ContainsAny(token.text, "(),=!<>")) {
+		return token, a.errorAt(token, "Expected %s", expected)
+	}
+	return token, nil
+}
+
+// field reads a reference to an aggregated field or group key, e.g. `count`, `count()` or `p95(duration)`.
+func (a *pipelineArgs) field() (string, pipelineToken, *PipelineError) {
+	token, err := a.word("a field")
+	if err != nil {
+		return "", token, err
+	}
+	name := token.text
+	if a.accept("(") {
+		column := ""
+		if !a.peek().is(")") {
+			c, err := a.word("a column")
+			if err != nil {
+				return "", token, err
+			}
+			column = c.text
+		}
+		end := a.next()
+		if !end.is(")") {
+			return "", token, a.errorAt(end, "Expected \")\"")
+		}
+		name = aggregationName(name, column)
+		token.length = end.offset + end.length - token.offset
+	}
+	return name, token, nil
+}
+
+func aggregatorNames() []string {
+	names := lo.Keys(pipelineAggregators)
+	sort.Strings(names)
+	return names
+}
+
+func aggregationName(function string, column string) string {
+	if column == "" {
+		return strings.ToLower(function)
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToLower(function), column)
+}
+
+// parseAggregation reads `[span=1h] agg(column) [as name], ... [by key, ...]`, where span is only valid for timechart.
+func (a *pipelineArgs) parseAggregation(pipeline *Pipeline, timechart bool) *PipelineError {
+	pipeline.Timechart = timechart
+	if timechart && a.peek().is("span") {
+		a.next()
+		if eq := a.next(); !eq.is("=") {
+			return a.errorAt(eq, "Expected \"=\" after span")
+		}
+		token := a.next()
+		match := spanRegex.FindStringSubmatch(strings.ToLower(token.text))
+		if match == nil {
+			return a.errorAt(token, "Invalid span \"%s\", expected a duration like 30s, 5m, 1h or 1d", token.text)
+		}
+		value, _ := strconv.Atoi(match[1])
+		if value <= 0 {
+			return a.errorAt(token, "Span must be positive")
+		}
+		pipeline.Span = lo.ToPtr(value * spanUnits[match[2]])
+	}
+
+	for {
+		token, err := a.word("an aggregation like count() or p95(duration)")
+		if err != nil {
+			return err
+		}
+		aggregator, ok := pipelineAggregators[strings.ToLower(token.text)]
+		if !ok {
+			return a.errorAt(token, "Unknown aggregation \"%s\", expected one of %s", token.text,
+				strings.Join(aggregatorNames(), ", "))
+		}
+		column := ""
+		if a.accept("(") {
+			if !a.peek().is(")") {
+				c, err := a.word("a column")
+				if err != nil {
+					return err
+				}
+				column = c.text
+			}
+			if end := a.next(); !end.is(")") {
+				return a.errorAt(end, "Expected \")\"")
+			}
+		}
+		if column == "" && aggregator != modelInputs.MetricAggregatorCount {
+			return a.errorAt(token, "Aggregation \"%s\" requires a column, e.g. %s(duration)", token.text, strings.ToLower(token.text))
+		}
+
+		name := aggregationName(token.text, column)
+		if a.accept("as") {
+			alias, err := a.word("a name after \"as\"")
+			if err != nil {
+				return err
+			}
+			name = alias.text
+		}
+		if pipeline.AggregationIndex(name) != -1 {
+			return a.errorAt(token, "Duplicate aggregation \"%s\", rename it with \"as\"", name)
+		}
+		pipeline.Aggregations = append(pipeline.Aggregations, PipelineAggregation{
+			Aggregator: aggregator,
+			Column:     column,
+			Name:       name,
+		})
+
+		if !a.accept(",") {
+			break
+		}
+	}
+
+	if a.accept("by") {
+		for {
+			key, err := a.word("a key to group by")
+			if err != nil {
+				return err
+			}
+			pipeline.GroupBy = append(pipeline.GroupBy, key.text)
+			if !a.accept(",") {
+				break
+			}
+		}
+	}
+	return a.expectEnd()
+}
+
+// parseWhere reads `field op number`, comparing an aggregated field.
+func (a *pipelineArgs) parseWhere(pipeline *Pipeline) *PipelineError {
+	field, token, err := a.field()
+	if err != nil {
+		return err
+	}
+	if pipeline.AggregationIndex(field) == -1 {
+		if pipeline.IsGroupKey(field) {
+			return a.errorAt(token, "where compares aggregated fields, \"%s\" is a group key", field)
+		}
+		return a.errorAt(token, "Unknown field \"%s\", expected one of %s", field, strings.Join(pipeline.fields(), ", "))
+	}
+
+	opToken := a.next()
+	op, ok := pipelineOperators[opToken.text]
+	if !ok || opToken.quoted {
+		return a.errorAt(opToken, "Expected a comparison operator (=, !=, >, >=, <, <=)")
+	}
+
+	valueToken := a.next()
+	value, parseErr := strconv.ParseFloat(valueToken.text, 64)
+	if parseErr != nil || valueToken.quoted {
+		return a.errorAt(valueToken, "Expected a number to compare \"%s\" with", field)
+	}
+
+	pipeline.Steps = append(pipeline.Steps, PipelineStep{
+		Command:   PipelineCommandWhere,
+		Condition: &PipelineCondition{Field: field, Op: op, Value: value},
+	})
+	return a.expectEnd()
+}
+
+// parseSort reads `[-]field, ...`, where a leading `-` sorts descending.
+func (a *pipelineArgs) parseSort(pipeline *Pipeline) *PipelineError {
+	var sorts []PipelineSort
+	for {
+		descending := false
+		if token := a.peek(); !token.quoted && (strings.HasPrefix(token.text, "-") || strings.HasPrefix(token.text, "+")) {
+			descending = strings.HasPrefix(token.text, "-")
+			a.tokens[a.pos].text = token.text[1:]
+			a.tokens[a.pos].offset++
+			a.tokens[a.pos].length--
+			if a.tokens[a.pos].text == "" {
+				a.pos++
+			}
+		}
+		field, token, err := a.field()
+		if err != nil {
+			return err
+		}
+		if pipeline.AggregationIndex(field) == -1 && !pipeline.IsGroupKey(field) {
+			return a.errorAt(token, "Unknown field \"%s\", expected one of %s", field, strings.Join(pipeline.fields(), ", "))
+		}
+		sorts = append(sorts, PipelineSort{Field: field, Descending: descending})
+		if !a.accept(",") {
+			break
+		}
+	}
+
+	pipeline.Steps = append(pipeline.Steps, PipelineStep{Command: PipelineCommandSort, Sort: sorts})
+	return a.expectEnd()
+}
+
+// parseHead reads an optional positive row limit.
+func (a *pipelineArgs) parseHead(pipeline *Pipeline) *PipelineError {
+	limit := defaultPipelineHead
+	if !a.done() {
+		token := a.next()
+		n, err := strconv.Atoi(token.text)
+		if err != nil || n <= 0 || token.quoted {
+			return a.errorAt(token, "Expected a positive number of rows")
+		}
+		limit = n
+	}
+
+	pipeline.Steps = append(pipeline.Steps, PipelineStep{Command: PipelineCommandHead, Limit: limit})
+	return a.expectEnd()
+}
+
+func (p *Pipeline) fields() []string {
+	return append(lo.Map(p.Aggregations, func(a PipelineAggregation, _ int) string { return a.Name }), p.GroupBy...)
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/huandu/go-assert"
+)
+
+func TestParsePipeline(t *testing.T) {
+	pipeline, err := ParsePipeline("level=error | stats count(), p95(duration) by service_name | where count > 5 | sort -count | head 10")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []PipelineAggregation{
+		{Aggregator: modelInputs.MetricAggregatorCount, Name: "count"},
+		{Aggregator: modelInputs.MetricAggregatorP95, Column: "duration", Name: "p95(duration)"},
+	}, pipeline.Aggregations)
+	assert.Equal(t, []string{"service_name"}, pipeline.GroupBy)
+	assert.Assert(t, !pipeline.Timechart)
+	assert.Equal(t, []PipelineStep{
+		{Command: PipelineCommandWhere, Condition: &PipelineCondition{Field: "count", Op: listener.OperatorGreaterThan, Value: 5}},
+		{Command: PipelineCommandSort, Sort: []PipelineSort{{Field: "count", Descending: true}}},
+		{Command: PipelineCommandHead, Limit: 10},
+	}, pipeline.Steps)
+
+	pipeline, err = ParsePipeline("| timechart span=5m avg(duration) as latency, dc(trace_id) by service_name, span_name | sort latency, span_name | head")
+	assert.Equal(t, nil, err)
+	assert.Assert(t, pipeline.Timechart)
+	assert.Equal(t, 300, *pipeline.Span)
+	assert.Equal(t, "latency", pipeline.Aggregations[0].Name)
+	assert.Equal(t, modelInputs.MetricAggregatorCountDistinct, pipeline.Aggregations[1].Aggregator)
+	assert.Equal(t, []string{"service_name", "span_name"}, pipeline.GroupBy)
+	assert.Equal(t, []PipelineSort{{Field: "latency"}, {Field: "span_name"}}, pipeline.Steps[0].Sort)
+	assert.Equal(t, defaultPipelineHead, pipeline.Steps[1].Limit)
+
+	pipeline, err = ParsePipeline("level=error service_name=private-graph")
+	assert.Equal(t, nil, err)
+	assert.Assert(t, pipeline == nil)
+}
+
+func TestParsePipelineErrors(t *testing.T) {
+	for query, expected := range map[string]PipelineError{
+		"| head 5":                                        {Message: `"head" must follow a stats or timechart stage`, Column: 2, Length: 4},
+		"| stats count | stats count":                     {Message: `Only one stats or timechart stage is supported, found "stats"`, Column: 16, Length: 5},
+		"| stats median(duration)":                        {Message: `Unknown aggregation "median", expected one of avg, count, count_distinct, dc, max, min, p50, p90, p95, p99, sum`, Column: 8, Length: 6},
+		"| stats p95()":                                   {Message: `Aggregation "p95" requires a column, e.g. p95(duration)`, Column: 8, Length: 3},
+		"| stats count by":                                {Message: "Expected a key to group by", Column: 16, Length: 0},
+		"| stats count by a | where a > 1":                {Message: `where compares aggregated fields, "a" is a group key`, Column: 27, Length: 1},
+		"| stats count | where count > high":              {Message: `Expected a number to compare "count" with`, Column: 30, Length: 4},
+		"| stats count | sort -p95(duration)":             {Message: `Unknown field "p95(duration)", expected one of count`, Column: 22, Length: 13},
+		"| stats count | head 0":                          {Message: "Expected a positive number of rows", Column: 21, Length: 1},
+		"| timechart span=1y count":                       {Message: `Invalid span "1y", expected a duration like 30s, 5m, 1h or 1d`, Column: 17, Length: 2},
+		"level=error\n| stats count() foo":                {Message: `Unexpected "foo" in stats stage`, Line: 2, Column: 16, Length: 3},
+		"level=error |\n  STATS count() |  STATS count()": {Message: `Only one stats or timechart stage is supported, found "stats"`, Line: 2, Column: 19, Length: 5},
+	} {
+		_, err := ParsePipeline(query)
+		pipelineErr, ok := err.(*PipelineError)
+		assert.Assert(t, ok)
+		if expected.Line == 0 {
+			expected.Line = 1
+		}
+		assert.Equal(t, expected, *pipelineErr)
+	}
+}
+
+func TestPipeOutsidePipeline(t *testing.T) {
+	// a pipe is only a stage when it is followed by a command and is not part of a value
+	for _, query := range []string{
+		"service_name=/api|worker/",
+		`message=/(GET|POST) \/api/`,
+		"GET | 200",
+		"a|b",
+		"| count",
+		"level=error | headers sent",
+		"level=error |stats/",
+		`message="a | stats count()"`,
+	} {
+		filter, pipeline := SplitPipeline(query)
+		assert.Equal(t, query, filter)
+		assert.Equal(t, "", pipeline)
+		assert.Equal(t, false, HasPipeline(query))
+	}
+
+	filter, pipeline := SplitPipeline("service_name=/api|worker/ GET | 200 | stats count()")
+	assert.Equal(t, "service_name=/api|worker/ GET | 200 ", filter)
+	assert.Equal(t, "| stats count()", pipeline)
+}
+
+func TestPipelineFilter(t *testing.T) {
+	filter, pipeline := SplitPipeline(`span_name="a | b" level=error | stats count()`)
+	assert.Equal(t, `span_name="a | b" level=error `, filter)
+	assert.Equal(t, "| stats count()", pipeline)
+
+	sql, _ := buildSqlForQuery("level=error | stats count() by service_name | head 5")
+	assert.Equal(t, "SELECT * FROM t WHERE toString(Level) = 'error'", sql)
+}
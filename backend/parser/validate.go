@@ -0,0 +1,194 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/highlight-run/highlight/backend/model"
+	parser "github.com/highlight-run/highlight/backend/parser/antlr"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/samber/lo"
+)
+
+// InvalidQueryError is returned when searching with a query that has errors.
+type InvalidQueryError struct {
+	Errors []*modelInputs.SearchQueryError
+}
+
+func (e *InvalidQueryError) Error() string {
+	messages := lo.Map(e.Errors, func(err *modelInputs.SearchQueryError, _ int) string {
+		return fmt.Sprintf("%d:%d %s", err.Line, err.Column, err.Message)
+	})
+	return fmt.Sprintf("invalid search query: %s", strings.Join(messages, "; "))
+}
+
+// ValidateSearchQuery returns the problems of a search query against the table, positioned at the offending token.
+// Syntax errors make the query ambiguous, while unknown keys are only warnings for tables with attributes as they
+// are searched as attributes.
+func ValidateSearchQuery(query string, tableConfig model.TableConfig) []*modelInputs.SearchQueryError {
+	errorListener := &syntaxErrorListener{
+		DefaultErrorListener: antlr.NewDefaultErrorListener(),
+		errors:               []*modelInputs.SearchQueryError{},
+	}
+
+	is := antlr.NewInputStream(query)
+	lexer := parser.NewSearchGrammarLexer(is)
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorListener)
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewSearchGrammarParser(stream)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errorListener)
+
+	tree := p.Search_query()
+
+	validationListener := &validationListener{
+		tableConfig: tableConfig,
+		errors:      errorListener.errors,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(validationListener, tree)
+	return validationListener.errors
+}
+
+// ValidateQuery returns an InvalidQueryError if the search query has errors.
+func ValidateQuery(query string, tableConfig model.TableConfig) error {
+	errors := lo.Filter(ValidateSearchQuery(query, tableConfig), func(err *modelInputs.SearchQueryError, _ int) bool {
+		return err.Severity == modelInputs.SearchQueryErrorSeverityError
+	})
+	if len(errors) > 0 {
+		return &InvalidQueryError{Errors: errors}
+	}
+	return nil
+}
+
+type syntaxErrorListener struct {
+	*antlr.DefaultErrorListener
+	errors []*modelInputs.SearchQueryError
+}
+
+func (l *syntaxErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	length := 1
+	if token, ok := offendingSymbol.(antlr.Token); ok {
+		length = len([]rune(token.GetText()))
+		if token.GetTokenType() == antlr.TokenEOF {
+			length = 0
+		}
+	}
+	// error recovery can report several errors at the same position, only the first is useful
+	for _, err := range l.errors {
+		if err.Line == line && err.Column == column {
+			return
+		}
+	}
+	l.errors = append(l.errors, &modelInputs.SearchQueryError{
+		Type:     modelInputs.SearchQueryErrorTypeSyntax,
+		Severity: modelInputs.SearchQueryErrorSeverityError,
+		Message:  msg,
+		Line:     line,
+		Column:   column,
+		Length:   length,
+	})
+}
+
+type validationListener struct {
+	parser.BaseSearchGrammarListener
+
+	tableConfig model.TableConfig
+	errors      []*modelInputs.SearchQueryError
+	stages      []*pipelineStage
+}
+
+func (v *validationListener) EnterPipe_stage(ctx *parser.Pipe_stageContext) {
+	v.stages = append(v.stages, newPipelineStage(ctx))
+}
+
+// ExitPipeline reports the first problem of the aggregation stages, unless a stage is missing its command
+// which is already a syntax error.
+func (v *validationListener) ExitPipeline(ctx *parser.PipelineContext) {
+	if lo.SomeBy(v.stages, func(s *pipelineStage) bool { return s.command == "" }) {
+		return
+	}
+	if _, err := compilePipeline(v.stages); err != nil {
+		v.errors = append(v.errors, &modelInputs.SearchQueryError{
+			Type:     modelInputs.SearchQueryErrorTypeInvalidPipeline,
+			Severity: modelInputs.SearchQueryErrorSeverityError,
+			Message:  err.Message,
+			Line:     err.Line,
+			Column:   err.Column,
+			Length:   err.Length,
+		})
+	}
+}
+
+func (v *validationListener) EnterKey_val_search_expr(ctx *parser.Key_val_search_exprContext) {
+	if ctx.Search_key() == nil || ctx.Bin_op() == nil {
+		return
+	}
+	key := ctx.Search_key().GetText()
+	known := v.validateKey(ctx.Search_key().GetStart(), key)
+
+	op := ctx.Bin_op()
+	switch {
+	case op.BANG() != nil:
+		v.addError(op.GetStart(), modelInputs.SearchQueryErrorTypeInvalidOperator, modelInputs.SearchQueryErrorSeverityError,
+			fmt.Sprintf("Unknown operator \"!\" for key \"%s\", use \"!=\" to exclude values", key))
+	case op.GT() != nil || op.GTE() != nil || op.LT() != nil || op.LTE() != nil:
+		if known && v.tableConfig.NumericKeys != nil && !v.tableConfig.NumericKeys[key] {
+			v.addError(op.GetStart(), modelInputs.SearchQueryErrorTypeInvalidOperator, modelInputs.SearchQueryErrorSeverityError,
+				fmt.Sprintf("Operator \"%s\" is only supported for numeric keys, \"%s\" is not numeric", op.GetText(), key))
+		}
+	}
+}
+
+func (v *validationListener) EnterIn_search_expr(ctx *parser.In_search_exprContext) {
+	if ctx.Search_key() == nil {
+		return
+	}
+	v.validateKey(ctx.Search_key().GetStart(), ctx.Search_key().GetText())
+}
+
+func (v *validationListener) EnterExists_search_expr(ctx *parser.Exists_search_exprContext) {
+	if ctx.Search_key() == nil {
+		return
+	}
+	v.validateKey(ctx.Search_key().GetStart(), ctx.Search_key().GetText())
+}
+
+// validateKey reports keys which are neither a column nor a reserved key of the table, returning whether the key is known.
+func (v *validationListener) validateKey(token antlr.Token, key string) bool {
+	if _, ok := v.tableConfig.KeysToColumns[key]; ok || lo.Contains(v.tableConfig.ReservedKeys, key) {
+		return true
+	}
+
+	knownKeys := lo.Uniq(append(lo.Keys(v.tableConfig.KeysToColumns), v.tableConfig.ReservedKeys...))
+	sort.Strings(knownKeys)
+	suggestion, hasSuggestion := lo.Find(knownKeys, func(k string) bool {
+		return strings.EqualFold(k, key)
+	})
+
+	hasAttributes := len(v.tableConfig.AttributesColumns) > 0 || v.tableConfig.AttributesTable != ""
+	if hasAttributes && hasSuggestion {
+		v.addError(token, modelInputs.SearchQueryErrorTypeUnknownKey, modelInputs.SearchQueryErrorSeverityWarning,
+			fmt.Sprintf("Unknown key \"%s\" is searched as an attribute, did you mean \"%s\"?", key, suggestion))
+	} else if !hasAttributes {
+		message := fmt.Sprintf("Unknown key \"%s\"", key)
+		if hasSuggestion {
+			message += fmt.Sprintf(", did you mean \"%s\"?", suggestion)
+		}
+		v.addError(token, modelInputs.SearchQueryErrorTypeUnknownKey, modelInputs.SearchQueryErrorSeverityError, message)
+	}
+	return false
+}
+
+func (v *validationListener) addError(token antlr.Token, errorType modelInputs.SearchQueryErrorType, severity modelInputs.SearchQueryErrorSeverity, message string) {
+	v.errors = append(v.errors, &modelInputs.SearchQueryError{
+		Type:     errorType,
+		Severity: severity,
+		Message:  message,
+		Line:     token.GetLine(),
+		Column:   token.GetColumn(),
+		Length:   len([]rune(token.GetText())),
+	})
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/model"
+	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	"github.com/huandu/go-assert"
+)
+
+func TestValidateSyntaxErrors(t *testing.T) {
+	errors := ValidateSearchQuery("service_name=(foo OR", tableConfig)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, modelInputs.SearchQueryErrorTypeSyntax, errors[0].Type)
+	assert.Equal(t, modelInputs.SearchQueryErrorSeverityError, errors[0].Severity)
+	assert.Equal(t, 1, errors[0].Line)
+	assert.Equal(t, 20, errors[0].Column)
+	assert.Equal(t, 0, errors[0].Length)
+
+	errors = ValidateSearchQuery("span_name=foo AND\n(level=info", tableConfig)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, 2, errors[0].Line)
+
+	assert.Equal(t, 0, len(ValidateSearchQuery(`span_name="Chris Schmitz" duration>1us level:info source=(backend OR frontend) OR (service_name!=private-graph) "body query" http.custom=attribute2`, tableConfig)))
+	assert.Equal(t, 0, len(ValidateSearchQuery("", tableConfig)))
+}
+
+func TestValidateUnknownKeys(t *testing.T) {
+	// unknown keys are searched as attributes
+	assert.Equal(t, 0, len(ValidateSearchQuery("custom=attribute3 custom EXISTS", tableConfig)))
+
+	errors := ValidateSearchQuery("level=info Service_Name=foo", tableConfig)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, modelInputs.SearchQueryErrorTypeUnknownKey, errors[0].Type)
+	assert.Equal(t, modelInputs.SearchQueryErrorSeverityWarning, errors[0].Severity)
+	assert.Equal(t, `Unknown key "Service_Name" is searched as an attribute, did you mean "service_name"?`, errors[0].Message)
+	assert.Equal(t, 11, errors[0].Column)
+	assert.Equal(t, 12, errors[0].Length)
+	assert.Assert(t, ValidateQuery("level=info Service_Name=foo", tableConfig) == nil)
+
+	noAttributesConfig := model.TableConfig{
+		KeysToColumns: map[string]string{"status": "Status"},
+		ReservedKeys:  []string{"status", "tag"},
+	}
+	errors = ValidateSearchQuery("status=OPEN tag=foo custom=bar Status EXISTS", noAttributesConfig)
+	assert.Equal(t, 2, len(errors))
+	assert.Equal(t, `Unknown key "custom"`, errors[0].Message)
+	assert.Equal(t, modelInputs.SearchQueryErrorSeverityError, errors[0].Severity)
+	assert.Equal(t, `Unknown key "Status", did you mean "status"?`, errors[1].Message)
+	assert.Assert(t, ValidateQuery("custom=bar", noAttributesConfig) != nil)
+}
+
+func TestValidateOperators(t *testing.T) {
+	numericConfig := tableConfig
+	numericConfig.NumericKeys = map[string]bool{"duration": true}
+
+	assert.Equal(t, 0, len(ValidateSearchQuery("duration>=1ms duration<1s custom>5", numericConfig)))
+
+	errors := ValidateSearchQuery("level>info", numericConfig)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, modelInputs.SearchQueryErrorTypeInvalidOperator, errors[0].Type)
+	assert.Equal(t, 5, errors[0].Column)
+	assert.Equal(t, 1, errors[0].Length)
+
+	// types aren't known without numeric keys
+	assert.Equal(t, 0, len(ValidateSearchQuery("level>info", tableConfig)))
+
+	errors = ValidateSearchQuery("level!info", tableConfig)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, modelInputs.SearchQueryErrorTypeInvalidOperator, errors[0].Type)
+
+	err := ValidateQuery("level!info service_name=(foo", tableConfig)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, 2, len(err.(*InvalidQueryError).Errors))
+}
+
+func TestValidatePipeline(t *testing.T) {
+	assert.Equal(t, 0, len(ValidateSearchQuery("level=error | stats count(), p95(duration) by service_name | sort -count | head 10", tableConfig)))
+
+	errors := ValidateSearchQuery("level=error | stats count() | sort -p95(duration)", tableConfig)
+	assert.Equal(t, 1, len(errors))
+	assert.Equal(t, modelInputs.SearchQueryErrorTypeInvalidPipeline, errors[0].Type)
+	assert.Equal(t, modelInputs.SearchQueryErrorSeverityError, errors[0].Severity)
+	assert.Equal(t, 36, errors[0].Column)
+	assert.Equal(t, 13, errors[0].Length)
+
+	// pipes which are not followed by a command are part of the filter
+	for _, query := range []string{"service_name=/api|worker/", "GET | 200", "level=error | headers sent"} {
+		assert.Equal(t, 0, len(ValidateSearchQuery(query, tableConfig)))
+	}
+}
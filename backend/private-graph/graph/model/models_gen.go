@@ -96,10 +96,56 @@ type AdminAndWorkspaceDetails struct {
 	PromoCode                   *string `json:"promo_code,omitempty"`
 }
 
+type AlertBacktest struct {
+	Evaluations        int                          `json:"evaluations"`
+	EvaluationInterval int                          `json:"evaluation_interval"`
+	StateChanges       []*AlertStateChange          `json:"state_changes"`
+	Notifications      []*AlertBacktestNotification `json:"notifications"`
+}
+
+type AlertBacktestNotification struct {
+	Timestamp   time.Time                     `json:"timestamp"`
+	Type        AlertBacktestNotificationType `json:"type"`
+	GroupByKey  string                        `json:"group_by_key"`
+	Value       *float64                      `json:"value,omitempty"`
+	FiringSince *time.Time                    `json:"firing_since,omitempty"`
+}
+
+type AlertDefinitionInput struct {
+	ProductType           ProductType         `json:"product_type"`
+	FunctionType          MetricAggregator    `json:"function_type"`
+	FunctionColumn        *string             `json:"function_column,omitempty"`
+	Query                 *string             `json:"query,omitempty"`
+	GroupByKey            *string             `json:"group_by_key,omitempty"`
+	ThresholdValue        *float64            `json:"threshold_value,omitempty"`
+	ThresholdWindow       *int                `json:"threshold_window,omitempty"`
+	ThresholdCooldown     *int                `json:"threshold_cooldown,omitempty"`
+	ThresholdType         *ThresholdType      `json:"threshold_type,omitempty"`
+	ThresholdCondition    *ThresholdCondition `json:"threshold_condition,omitempty"`
+	ThresholdChangeWindow *int                `json:"threshold_change_window,omitempty"`
+	ThresholdChangeType   *ChangeType         `json:"threshold_change_type,omitempty"`
+	SQL                   *string             `json:"sql,omitempty"`
+}
+
 type AlertDestinationInput struct {
 	DestinationType AlertDestinationType `json:"destination_type"`
 	TypeID          string               `json:"type_id"`
 	TypeName        string               `json:"type_name"`
+	Authorization   *string              `json:"authorization,omitempty"`
+	TitleTemplate   *string              `json:"title_template,omitempty"`
+	BodyTemplate    *string              `json:"body_template,omitempty"`
+}
+
+type AlertSilenceInput struct {
+	AlertID         *int         `json:"alert_id,omitempty"`
+	ProductType     *ProductType `json:"product_type,omitempty"`
+	GroupByKey      *string      `json:"group_by_key,omitempty"`
+	GroupByValue    *string      `json:"group_by_value,omitempty"`
+	StartsAt        *time.Time   `json:"starts_at,omitempty"`
+	EndsAt          *time.Time   `json:"ends_at,omitempty"`
+	Cron            *string      `json:"cron,omitempty"`
+	DurationMinutes *int         `json:"duration_minutes,omitempty"`
+	Reason          string       `json:"reason"`
 }
 
 type AlertStateChange struct {
@@ -109,6 +155,7 @@ type AlertStateChange struct {
 	AlertID    int        `json:"alertID"`
 	State      AlertState `json:"state"`
 	GroupByKey string     `json:"groupByKey"`
+	Value      *float64   `json:"value,omitempty"`
 }
 
 type AlertStateChangeResults struct {
@@ -116,6 +163,11 @@ type AlertStateChangeResults struct {
 	TotalCount        int64               `json:"totalCount"`
 }
 
+type AlertTemplatePreview struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+}
+
 type AllProjectSettings struct {
 	ID                                int            `json:"id"`
 	VerboseID                         string         `json:"verbose_id"`
@@ -210,6 +262,31 @@ type ClickhouseQuery struct {
 	DateRange *DateRangeRequiredInput `json:"dateRange"`
 }
 
+type CompositeCondition struct {
+	Operator   *CompositeOperator    `json:"operator,omitempty"`
+	AlertID    *int                  `json:"alert_id,omitempty"`
+	Conditions []*CompositeCondition `json:"conditions,omitempty"`
+}
+
+type CompositeConditionInput struct {
+	Operator   *CompositeOperator             `json:"operator,omitempty"`
+	AlertID    *int                           `json:"alert_id,omitempty"`
+	Inline     *CompositeInlineConditionInput `json:"inline,omitempty"`
+	Conditions []*CompositeConditionInput     `json:"conditions,omitempty"`
+}
+
+type CompositeInlineConditionInput struct {
+	Name               *string             `json:"name,omitempty"`
+	ProductType        ProductType         `json:"product_type"`
+	FunctionType       MetricAggregator    `json:"function_type"`
+	FunctionColumn     *string             `json:"function_column,omitempty"`
+	Query              *string             `json:"query,omitempty"`
+	GroupByKey         *string             `json:"group_by_key,omitempty"`
+	ThresholdValue     float64             `json:"threshold_value"`
+	ThresholdWindow    *int                `json:"threshold_window,omitempty"`
+	ThresholdCondition *ThresholdCondition `json:"threshold_condition,omitempty"`
+}
+
 type DashboardDefinition struct {
 	ID                int                      `json:"id"`
 	UpdatedAt         time.Time                `json:"updated_at"`
@@ -339,6 +416,25 @@ type ErrorGroupTagAggregationBucket struct {
 	Percent  float64 `json:"percent"`
 }
 
+type ErrorGroupingRule struct {
+	Type        ErrorGroupingRuleType `json:"type"`
+	Pattern     *string               `json:"pattern,omitempty"`
+	Attribute   *string               `json:"attribute,omitempty"`
+	Fingerprint *string               `json:"fingerprint,omitempty"`
+}
+
+type ErrorGroupingRuleInput struct {
+	Type        ErrorGroupingRuleType `json:"type"`
+	Pattern     *string               `json:"pattern,omitempty"`
+	Attribute   *string               `json:"attribute,omitempty"`
+	Fingerprint *string               `json:"fingerprint,omitempty"`
+}
+
+type ErrorGroupingSettings struct {
+	Rules               []*ErrorGroupingRule `json:"rules"`
+	EmbeddingsThreshold *float64             `json:"embeddings_threshold,omitempty"`
+}
+
 type ErrorMetadata struct {
 	ErrorID         int        `json:"error_id"`
 	SessionID       int        `json:"session_id"`
@@ -592,6 +688,16 @@ type LogLine struct {
 	Labels    string    `json:"labels"`
 }
 
+type LogPattern struct {
+	PatternID  string    `json:"patternID"`
+	PatternIDs []string  `json:"patternIDs"`
+	Pattern    string    `json:"pattern"`
+	Count      uint64    `json:"count"`
+	Sample     string    `json:"sample"`
+	FirstSeen  time.Time `json:"firstSeen"`
+	LastSeen   time.Time `json:"lastSeen"`
+}
+
 type LogsHistogram struct {
 	Buckets      []*LogsHistogramBucket `json:"buckets"`
 	TotalCount   uint64                 `json:"totalCount"`
@@ -609,6 +715,11 @@ type LogsHistogramBucketCount struct {
 	Level LogLevel `json:"level"`
 }
 
+type LogsTailPayload struct {
+	Logs    []*Log `json:"logs"`
+	Dropped int    `json:"dropped"`
+}
+
 type MatchedErrorTag struct {
 	ID          int     `json:"id"`
 	Title       string  `json:"title"`
@@ -803,39 +914,41 @@ type SSOLogin struct {
 }
 
 type Sampling struct {
-	SessionSamplingRate    float64 `json:"session_sampling_rate"`
-	ErrorSamplingRate      float64 `json:"error_sampling_rate"`
-	LogSamplingRate        float64 `json:"log_sampling_rate"`
-	TraceSamplingRate      float64 `json:"trace_sampling_rate"`
-	MetricSamplingRate     float64 `json:"metric_sampling_rate"`
-	SessionMinuteRateLimit *int64  `json:"session_minute_rate_limit,omitempty"`
-	ErrorMinuteRateLimit   *int64  `json:"error_minute_rate_limit,omitempty"`
-	LogMinuteRateLimit     *int64  `json:"log_minute_rate_limit,omitempty"`
-	TraceMinuteRateLimit   *int64  `json:"trace_minute_rate_limit,omitempty"`
-	MetricMinuteRateLimit  *int64  `json:"metric_minute_rate_limit,omitempty"`
-	SessionExclusionQuery  *string `json:"session_exclusion_query,omitempty"`
-	ErrorExclusionQuery    *string `json:"error_exclusion_query,omitempty"`
-	LogExclusionQuery      *string `json:"log_exclusion_query,omitempty"`
-	TraceExclusionQuery    *string `json:"trace_exclusion_query,omitempty"`
-	MetricExclusionQuery   *string `json:"metric_exclusion_query,omitempty"`
+	SessionSamplingRate    float64            `json:"session_sampling_rate"`
+	ErrorSamplingRate      float64            `json:"error_sampling_rate"`
+	LogSamplingRate        float64            `json:"log_sampling_rate"`
+	TraceSamplingRate      float64            `json:"trace_sampling_rate"`
+	MetricSamplingRate     float64            `json:"metric_sampling_rate"`
+	SessionMinuteRateLimit *int64             `json:"session_minute_rate_limit,omitempty"`
+	ErrorMinuteRateLimit   *int64             `json:"error_minute_rate_limit,omitempty"`
+	LogMinuteRateLimit     *int64             `json:"log_minute_rate_limit,omitempty"`
+	TraceMinuteRateLimit   *int64             `json:"trace_minute_rate_limit,omitempty"`
+	MetricMinuteRateLimit  *int64             `json:"metric_minute_rate_limit,omitempty"`
+	SessionExclusionQuery  *string            `json:"session_exclusion_query,omitempty"`
+	ErrorExclusionQuery    *string            `json:"error_exclusion_query,omitempty"`
+	LogExclusionQuery      *string            `json:"log_exclusion_query,omitempty"`
+	TraceExclusionQuery    *string            `json:"trace_exclusion_query,omitempty"`
+	MetricExclusionQuery   *string            `json:"metric_exclusion_query,omitempty"`
+	TraceTailSampling      *TraceTailSampling `json:"trace_tail_sampling,omitempty"`
 }
 
 type SamplingInput struct {
-	SessionSamplingRate    *float64 `json:"session_sampling_rate,omitempty"`
-	ErrorSamplingRate      *float64 `json:"error_sampling_rate,omitempty"`
-	LogSamplingRate        *float64 `json:"log_sampling_rate,omitempty"`
-	TraceSamplingRate      *float64 `json:"trace_sampling_rate,omitempty"`
-	MetricSamplingRate     *float64 `json:"metric_sampling_rate,omitempty"`
-	SessionMinuteRateLimit *int64   `json:"session_minute_rate_limit,omitempty"`
-	ErrorMinuteRateLimit   *int64   `json:"error_minute_rate_limit,omitempty"`
-	LogMinuteRateLimit     *int64   `json:"log_minute_rate_limit,omitempty"`
-	TraceMinuteRateLimit   *int64   `json:"trace_minute_rate_limit,omitempty"`
-	MetricMinuteRateLimit  *int64   `json:"metric_minute_rate_limit,omitempty"`
-	SessionExclusionQuery  *string  `json:"session_exclusion_query,omitempty"`
-	ErrorExclusionQuery    *string  `json:"error_exclusion_query,omitempty"`
-	LogExclusionQuery      *string  `json:"log_exclusion_query,omitempty"`
-	TraceExclusionQuery    *string  `json:"trace_exclusion_query,omitempty"`
-	MetricExclusionQuery   *string  `json:"metric_exclusion_query,omitempty"`
+	SessionSamplingRate    *float64                `json:"session_sampling_rate,omitempty"`
+	ErrorSamplingRate      *float64                `json:"error_sampling_rate,omitempty"`
+	LogSamplingRate        *float64                `json:"log_sampling_rate,omitempty"`
+	TraceSamplingRate      *float64                `json:"trace_sampling_rate,omitempty"`
+	MetricSamplingRate     *float64                `json:"metric_sampling_rate,omitempty"`
+	SessionMinuteRateLimit *int64                  `json:"session_minute_rate_limit,omitempty"`
+	ErrorMinuteRateLimit   *int64                  `json:"error_minute_rate_limit,omitempty"`
+	LogMinuteRateLimit     *int64                  `json:"log_minute_rate_limit,omitempty"`
+	TraceMinuteRateLimit   *int64                  `json:"trace_minute_rate_limit,omitempty"`
+	MetricMinuteRateLimit  *int64                  `json:"metric_minute_rate_limit,omitempty"`
+	SessionExclusionQuery  *string                 `json:"session_exclusion_query,omitempty"`
+	ErrorExclusionQuery    *string                 `json:"error_exclusion_query,omitempty"`
+	LogExclusionQuery      *string                 `json:"log_exclusion_query,omitempty"`
+	TraceExclusionQuery    *string                 `json:"trace_exclusion_query,omitempty"`
+	MetricExclusionQuery   *string                 `json:"metric_exclusion_query,omitempty"`
+	TraceTailSampling      *TraceTailSamplingInput `json:"trace_tail_sampling,omitempty"`
 }
 
 type SanitizedAdmin struct {
@@ -861,6 +974,15 @@ type SanitizedSlackChannelInput struct {
 	WebhookChannelID   *string `json:"webhook_channel_id,omitempty"`
 }
 
+type SearchQueryError struct {
+	Type     SearchQueryErrorType     `json:"type"`
+	Severity SearchQueryErrorSeverity `json:"severity"`
+	Message  string                   `json:"message"`
+	Line     int                      `json:"line"`
+	Column   int                      `json:"column"`
+	Length   int                      `json:"length"`
+}
+
 type ServiceConnection struct {
 	Edges    []*ServiceEdge `json:"edges"`
 	PageInfo *PageInfo      `json:"pageInfo"`
@@ -888,6 +1010,16 @@ type ServiceNode struct {
 	ErrorDetails   []string      `json:"errorDetails,omitempty"`
 }
 
+type ServiceSamplingRate struct {
+	ServiceName string  `json:"service_name"`
+	Rate        float64 `json:"rate"`
+}
+
+type ServiceSamplingRateInput struct {
+	ServiceName string  `json:"service_name"`
+	Rate        float64 `json:"rate"`
+}
+
 type SessionAlertInput struct {
 	ProjectID              int                           `json:"project_id"`
 	Name                   string                        `json:"name"`
@@ -1077,6 +1209,29 @@ type TracePayload struct {
 	Errors []*TraceError `json:"errors"`
 }
 
+type TraceTailSampling struct {
+	Enabled            bool                   `json:"enabled"`
+	WindowSeconds      int                    `json:"window_seconds"`
+	KeepErrors         bool                   `json:"keep_errors"`
+	LatencyThresholdMs *int64                 `json:"latency_threshold_ms,omitempty"`
+	KeepQuery          *string                `json:"keep_query,omitempty"`
+	ServiceRates       []*ServiceSamplingRate `json:"service_rates"`
+}
+
+type TraceTailSamplingInput struct {
+	Enabled            *bool                       `json:"enabled,omitempty"`
+	WindowSeconds      *int                        `json:"window_seconds,omitempty"`
+	KeepErrors         *bool                       `json:"keep_errors,omitempty"`
+	LatencyThresholdMs *int64                      `json:"latency_threshold_ms,omitempty"`
+	KeepQuery          *string                     `json:"keep_query,omitempty"`
+	ServiceRates       []*ServiceSamplingRateInput `json:"service_rates,omitempty"`
+}
+
+type TracesTailPayload struct {
+	Traces  []*Trace `json:"traces"`
+	Dropped int      `json:"dropped"`
+}
+
 type TrackPropertyInput struct {
 	ID    *int   `json:"id,omitempty"`
 	Name  string `json:"name"`
@@ -1168,6 +1323,11 @@ type WebhookDestinationInput struct {
 	Authorization *string `json:"authorization,omitempty"`
 }
 
+type WebhookSigningSecret struct {
+	DestinationID int    `json:"destination_id"`
+	SigningSecret string `json:"signing_secret"`
+}
+
 type WorkspaceForInviteLink struct {
 	ExpirationDate  *time.Time `json:"expiration_date,omitempty"`
 	InviteeEmail    *string    `json:"invitee_email,omitempty"`
@@ -1178,6 +1338,47 @@ type WorkspaceForInviteLink struct {
 	ProjectID       int        `json:"project_id"`
 }
 
+type AlertBacktestNotificationType string
+
+const (
+	AlertBacktestNotificationTypeAlert    AlertBacktestNotificationType = "Alert"
+	AlertBacktestNotificationTypeResolved AlertBacktestNotificationType = "Resolved"
+)
+
+var AllAlertBacktestNotificationType = []AlertBacktestNotificationType{
+	AlertBacktestNotificationTypeAlert,
+	AlertBacktestNotificationTypeResolved,
+}
+
+func (e AlertBacktestNotificationType) IsValid() bool {
+	switch e {
+	case AlertBacktestNotificationTypeAlert, AlertBacktestNotificationTypeResolved:
+		return true
+	}
+	return false
+}
+
+func (e AlertBacktestNotificationType) String() string {
+	return string(e)
+}
+
+func (e *AlertBacktestNotificationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertBacktestNotificationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertBacktestNotificationType", str)
+	}
+	return nil
+}
+
+func (e AlertBacktestNotificationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type AlertDestinationType string
 
 const (
@@ -1186,6 +1387,8 @@ const (
 	AlertDestinationTypeMicrosoftTeams AlertDestinationType = "MicrosoftTeams"
 	AlertDestinationTypeWebhook        AlertDestinationType = "Webhook"
 	AlertDestinationTypeEmail          AlertDestinationType = "Email"
+	AlertDestinationTypePagerDuty      AlertDestinationType = "PagerDuty"
+	AlertDestinationTypeOpsgenie       AlertDestinationType = "Opsgenie"
 )
 
 var AllAlertDestinationType = []AlertDestinationType{
@@ -1194,11 +1397,13 @@ var AllAlertDestinationType = []AlertDestinationType{
 	AlertDestinationTypeMicrosoftTeams,
 	AlertDestinationTypeWebhook,
 	AlertDestinationTypeEmail,
+	AlertDestinationTypePagerDuty,
+	AlertDestinationTypeOpsgenie,
 }
 
 func (e AlertDestinationType) IsValid() bool {
 	switch e {
-	case AlertDestinationTypeSlack, AlertDestinationTypeDiscord, AlertDestinationTypeMicrosoftTeams, AlertDestinationTypeWebhook, AlertDestinationTypeEmail:
+	case AlertDestinationTypeSlack, AlertDestinationTypeDiscord, AlertDestinationTypeMicrosoftTeams, AlertDestinationTypeWebhook, AlertDestinationTypeEmail, AlertDestinationTypePagerDuty, AlertDestinationTypeOpsgenie:
 		return true
 	}
 	return false
@@ -1225,6 +1430,51 @@ func (e AlertDestinationType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type AlertSeverity string
+
+const (
+	AlertSeverityCritical AlertSeverity = "critical"
+	AlertSeverityError    AlertSeverity = "error"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityInfo     AlertSeverity = "info"
+)
+
+var AllAlertSeverity = []AlertSeverity{
+	AlertSeverityCritical,
+	AlertSeverityError,
+	AlertSeverityWarning,
+	AlertSeverityInfo,
+}
+
+func (e AlertSeverity) IsValid() bool {
+	switch e {
+	case AlertSeverityCritical, AlertSeverityError, AlertSeverityWarning, AlertSeverityInfo:
+		return true
+	}
+	return false
+}
+
+func (e AlertSeverity) String() string {
+	return string(e)
+}
+
+func (e *AlertSeverity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertSeverity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertSeverity", str)
+	}
+	return nil
+}
+
+func (e AlertSeverity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type AlertState string
 
 const (
@@ -1274,6 +1524,90 @@ func (e AlertState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ChangeType string
+
+const (
+	ChangeTypePercent ChangeType = "Percent"
+	ChangeTypeRate    ChangeType = "Rate"
+)
+
+var AllChangeType = []ChangeType{
+	ChangeTypePercent,
+	ChangeTypeRate,
+}
+
+func (e ChangeType) IsValid() bool {
+	switch e {
+	case ChangeTypePercent, ChangeTypeRate:
+		return true
+	}
+	return false
+}
+
+func (e ChangeType) String() string {
+	return string(e)
+}
+
+func (e *ChangeType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChangeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChangeType", str)
+	}
+	return nil
+}
+
+func (e ChangeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CompositeOperator string
+
+const (
+	CompositeOperatorAnd CompositeOperator = "And"
+	CompositeOperatorOr  CompositeOperator = "Or"
+	CompositeOperatorNot CompositeOperator = "Not"
+)
+
+var AllCompositeOperator = []CompositeOperator{
+	CompositeOperatorAnd,
+	CompositeOperatorOr,
+	CompositeOperatorNot,
+}
+
+func (e CompositeOperator) IsValid() bool {
+	switch e {
+	case CompositeOperatorAnd, CompositeOperatorOr, CompositeOperatorNot:
+		return true
+	}
+	return false
+}
+
+func (e CompositeOperator) String() string {
+	return string(e)
+}
+
+func (e *CompositeOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CompositeOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CompositeOperator", str)
+	}
+	return nil
+}
+
+func (e CompositeOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type DashboardChartType string
 
 const (
@@ -1446,6 +1780,51 @@ func (e EnhancementSource) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ErrorGroupingRuleType string
+
+const (
+	ErrorGroupingRuleTypeStripMessage     ErrorGroupingRuleType = "strip_message"
+	ErrorGroupingRuleTypeIgnoreFrames     ErrorGroupingRuleType = "ignore_frames"
+	ErrorGroupingRuleTypeGroupByAttribute ErrorGroupingRuleType = "group_by_attribute"
+	ErrorGroupingRuleTypeFingerprint      ErrorGroupingRuleType = "fingerprint"
+)
+
+var AllErrorGroupingRuleType = []ErrorGroupingRuleType{
+	ErrorGroupingRuleTypeStripMessage,
+	ErrorGroupingRuleTypeIgnoreFrames,
+	ErrorGroupingRuleTypeGroupByAttribute,
+	ErrorGroupingRuleTypeFingerprint,
+}
+
+func (e ErrorGroupingRuleType) IsValid() bool {
+	switch e {
+	case ErrorGroupingRuleTypeStripMessage, ErrorGroupingRuleTypeIgnoreFrames, ErrorGroupingRuleTypeGroupByAttribute, ErrorGroupingRuleTypeFingerprint:
+		return true
+	}
+	return false
+}
+
+func (e ErrorGroupingRuleType) String() string {
+	return string(e)
+}
+
+func (e *ErrorGroupingRuleType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ErrorGroupingRuleType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ErrorGroupingRuleType", str)
+	}
+	return nil
+}
+
+func (e ErrorGroupingRuleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ErrorState string
 
 const (
@@ -2456,6 +2835,7 @@ const (
 	ReservedLogKeyEnvironment     ReservedLogKey = "environment"
 	ReservedLogKeyLevel           ReservedLogKey = "level"
 	ReservedLogKeyMessage         ReservedLogKey = "message"
+	ReservedLogKeyPatternID       ReservedLogKey = "pattern_id"
 	ReservedLogKeySecureSessionID ReservedLogKey = "secure_session_id"
 	ReservedLogKeySpanID          ReservedLogKey = "span_id"
 	ReservedLogKeyTraceID         ReservedLogKey = "trace_id"
@@ -2469,6 +2849,7 @@ var AllReservedLogKey = []ReservedLogKey{
 	ReservedLogKeyEnvironment,
 	ReservedLogKeyLevel,
 	ReservedLogKeyMessage,
+	ReservedLogKeyPatternID,
 	ReservedLogKeySecureSessionID,
 	ReservedLogKeySpanID,
 	ReservedLogKeyTraceID,
@@ -2480,7 +2861,7 @@ var AllReservedLogKey = []ReservedLogKey{
 
 func (e ReservedLogKey) IsValid() bool {
 	switch e {
-	case ReservedLogKeyEnvironment, ReservedLogKeyLevel, ReservedLogKeyMessage, ReservedLogKeySecureSessionID, ReservedLogKeySpanID, ReservedLogKeyTraceID, ReservedLogKeySource, ReservedLogKeyServiceName, ReservedLogKeyServiceVersion, ReservedLogKeyTimestamp:
+	case ReservedLogKeyEnvironment, ReservedLogKeyLevel, ReservedLogKeyMessage, ReservedLogKeyPatternID, ReservedLogKeySecureSessionID, ReservedLogKeySpanID, ReservedLogKeyTraceID, ReservedLogKeySource, ReservedLogKeyServiceName, ReservedLogKeyServiceVersion, ReservedLogKeyTimestamp:
 		return true
 	}
 	return false
@@ -2848,6 +3229,92 @@ func (e SavedSegmentEntityType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type SearchQueryErrorSeverity string
+
+const (
+	SearchQueryErrorSeverityError   SearchQueryErrorSeverity = "Error"
+	SearchQueryErrorSeverityWarning SearchQueryErrorSeverity = "Warning"
+)
+
+var AllSearchQueryErrorSeverity = []SearchQueryErrorSeverity{
+	SearchQueryErrorSeverityError,
+	SearchQueryErrorSeverityWarning,
+}
+
+func (e SearchQueryErrorSeverity) IsValid() bool {
+	switch e {
+	case SearchQueryErrorSeverityError, SearchQueryErrorSeverityWarning:
+		return true
+	}
+	return false
+}
+
+func (e SearchQueryErrorSeverity) String() string {
+	return string(e)
+}
+
+func (e *SearchQueryErrorSeverity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchQueryErrorSeverity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchQueryErrorSeverity", str)
+	}
+	return nil
+}
+
+func (e SearchQueryErrorSeverity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SearchQueryErrorType string
+
+const (
+	SearchQueryErrorTypeSyntax          SearchQueryErrorType = "Syntax"
+	SearchQueryErrorTypeUnknownKey      SearchQueryErrorType = "UnknownKey"
+	SearchQueryErrorTypeInvalidOperator SearchQueryErrorType = "InvalidOperator"
+	SearchQueryErrorTypeInvalidPipeline SearchQueryErrorType = "InvalidPipeline"
+)
+
+var AllSearchQueryErrorType = []SearchQueryErrorType{
+	SearchQueryErrorTypeSyntax,
+	SearchQueryErrorTypeUnknownKey,
+	SearchQueryErrorTypeInvalidOperator,
+	SearchQueryErrorTypeInvalidPipeline,
+}
+
+func (e SearchQueryErrorType) IsValid() bool {
+	switch e {
+	case SearchQueryErrorTypeSyntax, SearchQueryErrorTypeUnknownKey, SearchQueryErrorTypeInvalidOperator, SearchQueryErrorTypeInvalidPipeline:
+		return true
+	}
+	return false
+}
+
+func (e SearchQueryErrorType) String() string {
+	return string(e)
+}
+
+func (e *SearchQueryErrorType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchQueryErrorType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchQueryErrorType", str)
+	}
+	return nil
+}
+
+func (e SearchQueryErrorType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ServiceStatus string
 
 const (
@@ -3364,16 +3831,18 @@ type ThresholdType string
 const (
 	ThresholdTypeConstant ThresholdType = "Constant"
 	ThresholdTypeAnomaly  ThresholdType = "Anomaly"
+	ThresholdTypeChange   ThresholdType = "Change"
 )
 
 var AllThresholdType = []ThresholdType{
 	ThresholdTypeConstant,
 	ThresholdTypeAnomaly,
+	ThresholdTypeChange,
 }
 
 func (e ThresholdType) IsValid() bool {
 	switch e {
-	case ThresholdTypeConstant, ThresholdTypeAnomaly:
+	case ThresholdTypeConstant, ThresholdTypeAnomaly, ThresholdTypeChange:
 		return true
 	}
 	return false
@@ -11,11 +11,13 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/highlight-run/highlight/backend/env"
+	"github.com/highlight-run/highlight/backend/errorgroups"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/marketplacemetering"
@@ -24,6 +26,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	github2 "github.com/google/go-github/v50/github"
+	"github.com/google/uuid"
 	"github.com/sashabaranov/go-openai"
 
 	parse "github.com/highlight-run/highlight/backend/event-parse"
@@ -41,6 +44,9 @@ import (
 
 	"github.com/highlight-run/highlight/backend/alerts/integrations/discord"
 	microsoft_teams "github.com/highlight-run/highlight/backend/alerts/integrations/microsoft-teams"
+	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
+	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	webhookV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/webhook"
 	"github.com/highlight-run/highlight/backend/clickhouse"
 	"github.com/highlight-run/highlight/backend/clickup"
 	"github.com/highlight-run/highlight/backend/integrations"
@@ -48,6 +54,7 @@ import (
 	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
 	"github.com/highlight-run/highlight/backend/lambda"
 	"github.com/highlight-run/highlight/backend/oauth"
+	"github.com/highlight-run/highlight/backend/parser"
 	"github.com/highlight-run/highlight/backend/redis"
 	"github.com/highlight-run/highlight/backend/stepfunctions"
 	"github.com/highlight-run/highlight/backend/store"
@@ -68,6 +75,7 @@ import (
 	"github.com/slack-go/slack/slackevents"
 	"github.com/stripe/stripe-go/v78"
 	"github.com/stripe/stripe-go/v78/webhook"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 
 	"github.com/highlight-run/workerpool"
 
@@ -90,6 +98,9 @@ const SessionActiveMetricName = "sessions.active_length"
 const SessionProcessedMetricName = "sessions.processed"
 const MaxDownloadSize = 32 * 1024 * 1024 // 32MB
 
+// signingSecretHintLength is the number of trailing characters of a webhook signing secret returned to the client.
+const signingSecretHintLength = 4
+
 var AuthenticationError = errors.New("401 - AuthenticationError")
 var AuthorizationError = errors.New("403 - AuthorizationError")
 
@@ -3661,7 +3672,12 @@ func (r *Resolver) UpsertDiscordChannel(workspaceId int, name string) (*model.Di
 	}, nil
 }
 
-func normalizeExpressions(column *string, metricTypes []modelInputs.MetricAggregator, expressions []*modelInputs.MetricExpressionInput) ([]*modelInputs.MetricExpressionInput, error) {
+func normalizeExpressions(query string, column *string, metricTypes []modelInputs.MetricAggregator, expressions []*modelInputs.MetricExpressionInput) ([]*modelInputs.MetricExpressionInput, error) {
+	// a query pipeline such as `| stats count() by service_name` provides its own expressions
+	if len(metricTypes) == 0 && len(expressions) == 0 && parser.HasPipeline(query) {
+		return nil, nil
+	}
+
 	if len(metricTypes) == 0 && len(expressions) == 0 {
 		return nil, errors.New("No expressions provided")
 	}
@@ -4437,7 +4453,7 @@ func (r *Resolver) MetricsAggregated(ctx context.Context, projectID int, params
 		return nil, err
 	}
 
-	expressions, err = normalizeExpressions(column, metricTypes, expressions)
+	expressions, err = normalizeExpressions(params.Query, column, metricTypes, expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -4486,6 +4502,23 @@ func reorderGraphs(viz *model.Visualization) {
 	viz.Graphs = orderedGraphs
 }
 
+// searchQueryError exposes the errors of an invalid search query in the extensions of the graphql error, so that
+// the search bar can show where the query is invalid.
+func searchQueryError(err error) error {
+	var invalidQueryError *parser.InvalidQueryError
+	if errors.As(err, &invalidQueryError) {
+		return &gqlerror.Error{
+			Err:     err,
+			Message: err.Error(),
+			Extensions: map[string]interface{}{
+				"code":   "INVALID_SEARCH_QUERY",
+				"errors": invalidQueryError.Errors,
+			},
+		}
+	}
+	return err
+}
+
 func backfillAlertFields(alert *model.Alert) {
 	if alert == nil {
 		return
@@ -4495,6 +4528,10 @@ func backfillAlertFields(alert *model.Alert) {
 		alert.ThresholdType = modelInputs.ThresholdTypeConstant
 	}
 
+	if !alert.ThresholdChangeType.IsValid() {
+		alert.ThresholdChangeType = modelInputs.ChangeTypePercent
+	}
+
 	if !alert.ThresholdCondition.IsValid() {
 		belowThreshold := false
 		if alert.BelowThreshold != nil {
@@ -4509,6 +4546,277 @@ func backfillAlertFields(alert *model.Alert) {
 	}
 }
 
+// traceTailSamplingParams returns the trace tail sampling updates of the sampling settings input.
+func traceTailSamplingParams(sampling *modelInputs.SamplingInput) *store.TraceTailSamplingParams {
+	if sampling == nil || sampling.TraceTailSampling == nil {
+		return nil
+	}
+	input := sampling.TraceTailSampling
+	params := &store.TraceTailSamplingParams{
+		Enabled:            input.Enabled,
+		WindowSeconds:      input.WindowSeconds,
+		KeepErrors:         input.KeepErrors,
+		LatencyThresholdMs: input.LatencyThresholdMs,
+		KeepQuery:          input.KeepQuery,
+	}
+	if input.ServiceRates != nil {
+		params.ServiceRates = lo.SliceToMap(input.ServiceRates, func(rate *modelInputs.ServiceSamplingRateInput) (string, float64) {
+			return rate.ServiceName, rate.Rate
+		})
+	}
+	return params
+}
+
+// getTraceTailSampling returns the trace tail sampling settings of a project, with service rates ordered by service.
+func getTraceTailSampling(ctx context.Context, settings *model.ProjectFilterSettings) *modelInputs.TraceTailSampling {
+	serviceRates := map[string]float64{}
+	if settings.TraceTailSamplingServiceRates != nil {
+		if err := json.Unmarshal([]byte(*settings.TraceTailSamplingServiceRates), &serviceRates); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("project_id", settings.ProjectID).Error("failed to parse trace tail sampling service rates")
+		}
+	}
+	services := lo.Keys(serviceRates)
+	sort.Strings(services)
+
+	return &modelInputs.TraceTailSampling{
+		Enabled:            settings.TraceTailSamplingEnabled,
+		WindowSeconds:      settings.TraceTailSamplingWindowSeconds,
+		KeepErrors:         settings.TraceTailSamplingKeepErrors,
+		LatencyThresholdMs: settings.TraceTailSamplingLatencyThresholdMs,
+		KeepQuery:          settings.TraceTailSamplingKeepQuery,
+		ServiceRates: lo.Map(services, func(service string, _ int) *modelInputs.ServiceSamplingRate {
+			return &modelInputs.ServiceSamplingRate{ServiceName: service, Rate: serviceRates[service]}
+		}),
+	}
+}
+
+// getErrorGroupingSettings returns the error grouping rules and embeddings threshold of a project.
+func getErrorGroupingSettings(ctx context.Context, settings *model.ProjectErrorGroupingSettings) *modelInputs.ErrorGroupingSettings {
+	var rules []errorgroups.Rule
+	if settings.Rules != nil && *settings.Rules != "" {
+		if err := json.Unmarshal([]byte(*settings.Rules), &rules); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("project_id", settings.ProjectID).Error("failed to parse error grouping rules")
+		}
+	}
+
+	return &modelInputs.ErrorGroupingSettings{
+		Rules: lo.Map(rules, func(rule errorgroups.Rule, _ int) *modelInputs.ErrorGroupingRule {
+			return &modelInputs.ErrorGroupingRule{
+				Type:        modelInputs.ErrorGroupingRuleType(rule.Type),
+				Pattern:     lo.EmptyableToPtr(rule.Pattern),
+				Attribute:   lo.EmptyableToPtr(rule.Attribute),
+				Fingerprint: lo.EmptyableToPtr(rule.Fingerprint),
+			}
+		}),
+		EmbeddingsThreshold: settings.EmbeddingsThreshold,
+	}
+}
+
+func applyAlertSilenceInput(silence *model.AlertSilence, input modelInputs.AlertSilenceInput) {
+	silence.AlertID = input.AlertID
+	silence.ProductType = input.ProductType
+	silence.GroupByKey = input.GroupByKey
+	silence.GroupByValue = input.GroupByValue
+	silence.StartsAt = input.StartsAt
+	silence.EndsAt = input.EndsAt
+	silence.Cron = input.Cron
+	silence.DurationMinutes = input.DurationMinutes
+	silence.Reason = input.Reason
+}
+
+// alertDestinationFromInput builds the destination of an alert, with the templates customizing its notifications.
+func alertDestinationFromInput(alertID int, input *modelInputs.AlertDestinationInput) *model.AlertDestination {
+	return &model.AlertDestination{
+		AlertID:         alertID,
+		DestinationType: input.DestinationType,
+		TypeID:          input.TypeID,
+		TypeName:        input.TypeName,
+		Authorization:   input.Authorization,
+		TitleTemplate:   input.TitleTemplate,
+		BodyTemplate:    input.BodyTemplate,
+	}
+}
+
+// validateAlertDestinationTemplates renders the templates of each destination against sample data for the type of the alert.
+func validateAlertDestinationTemplates(productType modelInputs.ProductType, thresholdType modelInputs.ThresholdType, destinations []*modelInputs.AlertDestinationInput) error {
+	for _, d := range destinations {
+		if err := destinationsV2.ValidateTemplates(*alertDestinationFromInput(0, d), productType, thresholdType); err != nil {
+			return e.Wrapf(err, "invalid templates for %s destination %s", d.DestinationType, d.TypeName)
+		}
+	}
+	return nil
+}
+
+// setWebhookSigningSecrets keeps the signing secrets of the previous webhook destinations of the alert with the same url,
+// since the secrets are not returned to the client and the destinations are recreated when the alert is updated,
+// and generates a secret for new webhooks.
+func setWebhookSigningSecrets(previous []*model.AlertDestination, destinations []*model.AlertDestination) error {
+	previousByURL := map[string]*model.AlertDestination{}
+	for _, d := range previous {
+		if d.DestinationType == modelInputs.AlertDestinationTypeWebhook && d.SigningSecret != nil {
+			previousByURL[d.TypeID] = d
+		}
+	}
+
+	for _, d := range destinations {
+		if d.DestinationType != modelInputs.AlertDestinationTypeWebhook {
+			continue
+		}
+		if p, ok := previousByURL[d.TypeID]; ok {
+			d.SigningSecret = p.SigningSecret
+			d.PreviousSigningSecret = p.PreviousSigningSecret
+			d.SigningSecretRotatedAt = p.SigningSecretRotatedAt
+			continue
+		}
+		secret, err := webhookV2.NewSigningSecret()
+		if err != nil {
+			return err
+		}
+		d.SigningSecret = &secret
+	}
+	return nil
+}
+
+// getSigningSecretHint returns the last characters of the signing secret of a webhook destination, so that the
+// secret can be told apart without being returned to the client.
+func getSigningSecretHint(destination *model.AlertDestination) *string {
+	if destination.SigningSecret == nil || len(*destination.SigningSecret) < signingSecretHintLength {
+		return nil
+	}
+	secret := *destination.SigningSecret
+	return pointy.String(secret[len(secret)-signingSecretHintLength:])
+}
+
+// setPagingAuthorizations keeps the routing or api key of the previous paging destinations of the alert with the same type
+// and id, since the keys are not returned to the client and the destinations are recreated when the alert is updated.
+func setPagingAuthorizations(previous []*model.AlertDestination, destinations []*model.AlertDestination) {
+	type pagingDestination struct {
+		destinationType modelInputs.AlertDestinationType
+		typeID          string
+	}
+	previousByID := map[pagingDestination]*model.AlertDestination{}
+	for _, d := range previous {
+		if d.Authorization != nil && *d.Authorization != "" {
+			previousByID[pagingDestination{d.DestinationType, d.TypeID}] = d
+		}
+	}
+
+	for _, d := range destinations {
+		if d.DestinationType != modelInputs.AlertDestinationTypePagerDuty && d.DestinationType != modelInputs.AlertDestinationTypeOpsgenie {
+			continue
+		}
+		if d.Authorization != nil && *d.Authorization != "" {
+			continue
+		}
+		if p, ok := previousByID[pagingDestination{d.DestinationType, d.TypeID}]; ok {
+			d.Authorization = p.Authorization
+		}
+	}
+}
+
+// saveCompositeCondition stores the condition of the composite alert, creating a child alert for each inline condition.
+// Inline children of a previous condition of the alert which are no longer referenced are deleted.
+func saveCompositeCondition(ctx context.Context, tx *gorm.DB, alert *model.Alert, input *modelInputs.CompositeConditionInput) error {
+	inlineCount := 0
+	condition, err := buildCompositeCondition(ctx, tx, alert, input, &inlineCount)
+	if err != nil {
+		return err
+	}
+	if err := alertsV2.ValidateCompositeCondition(condition); err != nil {
+		return err
+	}
+
+	var children []*model.Alert
+	alertIDs := alertsV2.CompositeAlertIDs(condition)
+	if err := tx.WithContext(ctx).Where("id IN ?", alertIDs).Find(&children).Error; err != nil {
+		return err
+	}
+	childrenByID := lo.KeyBy(children, func(child *model.Alert) int { return child.ID })
+	for _, alertID := range alertIDs {
+		child, ok := childrenByID[alertID]
+		if !ok || child.ProjectID != alert.ProjectID {
+			return e.Errorf("alert %d not found", alertID)
+		}
+		if child.ID == alert.ID || child.CompositeCondition != nil {
+			return e.New("a composite alert cannot reference composite alerts")
+		}
+		if child.ParentAlertID != nil && *child.ParentAlertID != alert.ID {
+			return e.Errorf("alert %d is a condition of another composite alert", alertID)
+		}
+	}
+
+	if err := tx.WithContext(ctx).Where("parent_alert_id = ? AND id NOT IN ?", alert.ID, alertIDs).Delete(&model.Alert{}).Error; err != nil {
+		return err
+	}
+
+	conditionStr, err := json.Marshal(condition)
+	if err != nil {
+		return err
+	}
+
+	// displayed like its first child
+	first := childrenByID[alertIDs[0]]
+	alert.CompositeCondition = pointy.String(string(conditionStr))
+	alert.ProductType = first.ProductType
+	alert.FunctionType = first.FunctionType
+	return tx.WithContext(ctx).Model(alert).Select("CompositeCondition", "ProductType", "FunctionType").Updates(alert).Error
+}
+
+func buildCompositeCondition(ctx context.Context, tx *gorm.DB, parent *model.Alert, input *modelInputs.CompositeConditionInput, inlineCount *int) (*modelInputs.CompositeCondition, error) {
+	if input == nil {
+		return nil, e.New("a composite condition is required")
+	}
+
+	condition := &modelInputs.CompositeCondition{
+		Operator: input.Operator,
+		AlertID:  input.AlertID,
+	}
+	if input.Inline != nil {
+		if input.AlertID != nil {
+			return nil, e.New("a composite condition cannot reference an alert and an inline condition")
+		}
+		*inlineCount += 1
+		inline := input.Inline
+		name := fmt.Sprintf("%s condition %d", parent.Name, *inlineCount)
+		if inline.Name != nil {
+			name = *inline.Name
+		}
+		thresholdCondition := modelInputs.ThresholdConditionAbove
+		if inline.ThresholdCondition != nil {
+			thresholdCondition = *inline.ThresholdCondition
+		}
+		child := &model.Alert{
+			ProjectID:          parent.ProjectID,
+			MetricId:           uuid.New().String(),
+			Name:               name,
+			ProductType:        inline.ProductType,
+			FunctionType:       inline.FunctionType,
+			FunctionColumn:     inline.FunctionColumn,
+			Query:              inline.Query,
+			GroupByKey:         inline.GroupByKey,
+			ThresholdValue:     &inline.ThresholdValue,
+			ThresholdWindow:    inline.ThresholdWindow,
+			ThresholdType:      modelInputs.ThresholdTypeConstant,
+			ThresholdCondition: thresholdCondition,
+			LastAdminToEditID:  parent.LastAdminToEditID,
+			ParentAlertID:      &parent.ID,
+		}
+		if err := tx.WithContext(ctx).Create(child).Error; err != nil {
+			return nil, err
+		}
+		condition.AlertID = &child.ID
+	}
+
+	for _, childInput := range input.Conditions {
+		child, err := buildCompositeCondition(ctx, tx, parent, childInput, inlineCount)
+		if err != nil {
+			return nil, err
+		}
+		condition.Conditions = append(condition.Conditions, child)
+	}
+	return condition, nil
+}
+
 func (r *Resolver) GetSessionFields(ctx context.Context, session *model.Session) ([]*model.Field, error) {
 	fields, err := r.ClickhouseClient.GetSessionFields(ctx, session.ProjectID, session.ID)
 	if err != nil {
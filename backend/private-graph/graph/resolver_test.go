@@ -1203,3 +1203,55 @@ func TestQueryResolver_updateBillingDetails(t *testing.T) {
 		assert.True(t, hs.Active)
 	})
 }
+
+func TestUpdateAlertKeepsPagingAuthorization(t *testing.T) {
+	util.RunTestWithDBWipe(t, DB, func(t *testing.T) {
+		admin := model.Admin{UID: ptr.String("a1b2c3")}
+		if err := DB.Create(&admin).Error; err != nil {
+			t.Fatal(e.Wrap(err, "error inserting admin"))
+		}
+
+		workspace := model.Workspace{Name: ptr.String("test1")}
+		if err := DB.Create(&workspace).Error; err != nil {
+			t.Fatal(e.Wrap(err, "error inserting workspace"))
+		}
+
+		if err := DB.Create(&model.WorkspaceAdmin{
+			WorkspaceID: workspace.ID, AdminID: admin.ID,
+		}).Error; err != nil {
+			t.Fatal(e.Wrap(err, "error inserting workspace admin"))
+		}
+
+		project := model.Project{Name: ptr.String("p1"), WorkspaceID: workspace.ID}
+		if err := DB.Create(&project).Error; err != nil {
+			t.Fatal(e.Wrap(err, "error inserting project"))
+		}
+
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, model.ContextKeys.UID, *admin.UID)
+		r := &mutationResolver{Resolver: &Resolver{DB: DB, Store: Store}}
+		_ = r.Store.Redis.FlushDB(ctx)
+
+		alert, err := r.CreateAlert(ctx, project.ID, "errors", modelInputs.ProductTypeErrors, modelInputs.MetricAggregatorCount, nil, nil, nil, nil, pointy.Float64(1), pointy.Int(60), nil, nil, nil, []*modelInputs.AlertDestinationInput{
+			{DestinationType: modelInputs.AlertDestinationTypePagerDuty, TypeID: "service", TypeName: "Service", Authorization: pointy.String("routing-key")},
+			{DestinationType: modelInputs.AlertDestinationTypeOpsgenie, TypeID: "team", TypeName: "Team", Authorization: pointy.String("api-key")},
+		}, nil, nil, nil, nil, nil)
+		assert.NoError(t, err)
+
+		// the client cannot send back the keys, which are not returned by the api
+		_, err = r.UpdateAlert(ctx, project.ID, alert.ID, pointy.String("renamed"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, []*modelInputs.AlertDestinationInput{
+			{DestinationType: modelInputs.AlertDestinationTypePagerDuty, TypeID: "service", TypeName: "Service"},
+			{DestinationType: modelInputs.AlertDestinationTypeOpsgenie, TypeID: "other-team", TypeName: "Other Team"},
+		}, nil, nil, nil, nil, nil)
+		assert.NoError(t, err)
+
+		var destinations []*model.AlertDestination
+		if err := DB.Where(&model.AlertDestination{AlertID: alert.ID}).Order("id").Find(&destinations).Error; err != nil {
+			t.Fatal(e.Wrap(err, "error reading alert destinations"))
+		}
+		assert.Len(t, destinations, 2)
+		assert.Equal(t, "routing-key", lo.FromPtr(destinations[0].Authorization))
+		// a new paging destination does not inherit the key of another destination
+		assert.Empty(t, lo.FromPtr(destinations[1].Authorization))
+	})
+}
@@ -34,19 +34,24 @@ import (
 	"github.com/highlight-run/highlight/backend/alerts/predictions"
 	alertsV2 "github.com/highlight-run/highlight/backend/alerts/v2"
 	destinationsV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations"
+	webhookV2 "github.com/highlight-run/highlight/backend/alerts/v2/destinations/webhook"
 	"github.com/highlight-run/highlight/backend/apolloio"
 	"github.com/highlight-run/highlight/backend/clickhouse"
 	"github.com/highlight-run/highlight/backend/clickup"
 	Email "github.com/highlight-run/highlight/backend/email"
 	"github.com/highlight-run/highlight/backend/env"
+	"github.com/highlight-run/highlight/backend/errorgroups"
 	"github.com/highlight-run/highlight/backend/integrations/cloudflare"
 	"github.com/highlight-run/highlight/backend/integrations/height"
+	metric_alerts "github.com/highlight-run/highlight/backend/jobs/metric-alerts"
 	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
 	delete_handlers "github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/handlers"
 	"github.com/highlight-run/highlight/backend/lambda-functions/deleteSessions/utils"
 	utils2 "github.com/highlight-run/highlight/backend/lambda-functions/sessionExport/utils"
+	"github.com/highlight-run/highlight/backend/livetail"
 	"github.com/highlight-run/highlight/backend/model"
 	"github.com/highlight-run/highlight/backend/openai_client"
+	"github.com/highlight-run/highlight/backend/parser"
 	"github.com/highlight-run/highlight/backend/phonehome"
 	"github.com/highlight-run/highlight/backend/pricing"
 	"github.com/highlight-run/highlight/backend/private-graph/graph/generated"
@@ -74,6 +79,28 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// Severity is the resolver for the severity field.
+func (r *alertResolver) Severity(ctx context.Context, obj *model.Alert) (*modelInputs.AlertSeverity, error) {
+	if obj.Severity == nil {
+		return nil, nil
+	}
+	severity := modelInputs.AlertSeverity(*obj.Severity)
+	if !severity.IsValid() {
+		return nil, nil
+	}
+	return &severity, nil
+}
+
+// CompositeCondition is the resolver for the composite_condition field.
+func (r *alertResolver) CompositeCondition(ctx context.Context, obj *model.Alert) (*modelInputs.CompositeCondition, error) {
+	return alertsV2.GetCompositeCondition(obj)
+}
+
+// SigningSecretHint is the resolver for the signing_secret_hint field.
+func (r *alertDestinationResolver) SigningSecretHint(ctx context.Context, obj *model.AlertDestination) (*string, error) {
+	return getSigningSecretHint(obj), nil
+}
+
 // EnableBusinessDashboards is the resolver for the enable_business_dashboards field.
 func (r *allWorkspaceSettingsResolver) EnableBusinessDashboards(ctx context.Context, obj *model.AllWorkspaceSettings) (bool, error) {
 	w, err := r.isUserInWorkspaceReadOnly(ctx, obj.WorkspaceID)
@@ -661,6 +688,7 @@ func (r *mutationResolver) EditProjectSettings(ctx context.Context, projectID in
 		FilterSessionsWithoutError:        filterSessionsWithoutError,
 		AutoResolveStaleErrorsDayInterval: autoResolveStaleErrorsDayInterval,
 		Sampling:                          sampling,
+		TraceTailSampling:                 traceTailSamplingParams(sampling),
 	})
 	if err != nil {
 		return nil, err
@@ -680,6 +708,7 @@ func (r *mutationResolver) EditProjectSettings(ctx context.Context, projectID in
 		ErrorExclusionQuery:    projectFilterSettings.ErrorExclusionQuery,
 		LogExclusionQuery:      projectFilterSettings.LogExclusionQuery,
 		TraceExclusionQuery:    projectFilterSettings.TraceExclusionQuery,
+		TraceTailSampling:      getTraceTailSampling(ctx, projectFilterSettings),
 	}
 
 	return &allProjectSettings, nil
@@ -702,6 +731,36 @@ func (r *mutationResolver) EditProjectPlatforms(ctx context.Context, projectID i
 	return true, nil
 }
 
+// EditErrorGroupingSettings is the resolver for the editErrorGroupingSettings field.
+func (r *mutationResolver) EditErrorGroupingSettings(ctx context.Context, projectID int, rules []*modelInputs.ErrorGroupingRuleInput, embeddingsThreshold *float64) (*modelInputs.ErrorGroupingSettings, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupingRules []errorgroups.Rule
+	if rules != nil {
+		groupingRules = lo.Map(rules, func(rule *modelInputs.ErrorGroupingRuleInput, _ int) errorgroups.Rule {
+			return errorgroups.Rule{
+				Type:        errorgroups.RuleType(rule.Type),
+				Pattern:     lo.FromPtr(rule.Pattern),
+				Attribute:   lo.FromPtr(rule.Attribute),
+				Fingerprint: lo.FromPtr(rule.Fingerprint),
+			}
+		})
+	}
+
+	settings, err := r.Store.UpdateProjectErrorGroupingSettings(ctx, project.ID, store.UpdateProjectErrorGroupingSettingsParams{
+		Rules:               groupingRules,
+		EmbeddingsThreshold: embeddingsThreshold,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getErrorGroupingSettings(ctx, settings), nil
+}
+
 // EditWorkspace is the resolver for the editWorkspace field.
 func (r *mutationResolver) EditWorkspace(ctx context.Context, id int, name *string) (*model.Workspace, error) {
 	workspace, err := r.isUserInWorkspace(ctx, id)
@@ -3411,7 +3470,7 @@ func (r *mutationResolver) UpdateMetricMonitor(ctx context.Context, metricMonito
 }
 
 // CreateAlert is the resolver for the createAlert field.
-func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name string, productType modelInputs.ProductType, functionType modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, defaultArg *bool, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string) (*model.Alert, error) {
+func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name string, productType modelInputs.ProductType, functionType modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, defaultArg *bool, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string, thresholdChangeWindow *int, thresholdChangeType *modelInputs.ChangeType, disableResolvedNotifications *bool, severity *modelInputs.AlertSeverity) (*model.Alert, error) {
 	project, err := r.isUserInProject(ctx, projectID)
 	admin, _ := r.getCurrentAdmin(ctx)
 	if err != nil {
@@ -3433,6 +3492,19 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 		thresholdConditionDeref = *thresholdCondition
 	}
 
+	thresholdChangeTypeDeref := modelInputs.ChangeTypePercent
+	if thresholdChangeType != nil {
+		thresholdChangeTypeDeref = *thresholdChangeType
+	}
+
+	if thresholdChangeWindow != nil && *thresholdChangeWindow <= 0 {
+		return nil, e.New("threshold change window must be positive")
+	}
+
+	if err := validateAlertDestinationTemplates(productType, thresholdTypeDeref, destinations); err != nil {
+		return nil, err
+	}
+
 	newAlert := &model.Alert{
 		ProjectID:          projectID,
 		MetricId:           uuid.New().String(),
@@ -3450,6 +3522,14 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 		ThresholdCondition: thresholdConditionDeref,
 		LastAdminToEditID:  admin.ID,
 		Sql:                sql,
+
+		ThresholdChangeWindow: thresholdChangeWindow,
+		ThresholdChangeType:   thresholdChangeTypeDeref,
+
+		DisableResolvedNotifications: lo.FromPtr(disableResolvedNotifications),
+	}
+	if severity != nil {
+		newAlert.Severity = pointy.String(severity.String())
 	}
 
 	createdAlert := &model.Alert{}
@@ -3459,12 +3539,10 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 
 	alertDestinations := []*model.AlertDestination{}
 	for _, d := range destinations {
-		alertDestinations = append(alertDestinations, &model.AlertDestination{
-			AlertID:         createdAlert.ID,
-			DestinationType: d.DestinationType,
-			TypeID:          d.TypeID,
-			TypeName:        d.TypeName,
-		})
+		alertDestinations = append(alertDestinations, alertDestinationFromInput(createdAlert.ID, d))
+	}
+	if err := setWebhookSigningSecrets(nil, alertDestinations); err != nil {
+		return nil, err
 	}
 
 	if err := r.DB.WithContext(ctx).Create(alertDestinations).Error; err != nil {
@@ -3488,13 +3566,33 @@ func (r *mutationResolver) CreateAlert(ctx context.Context, projectID int, name
 }
 
 // UpdateAlert is the resolver for the updateAlert field.
-func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alertID int, name *string, productType *modelInputs.ProductType, functionType *modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string) (*model.Alert, error) {
+func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alertID int, name *string, productType *modelInputs.ProductType, functionType *modelInputs.MetricAggregator, functionColumn *string, query *string, groupByKey *string, thresholdValue *float64, thresholdWindow *int, thresholdCooldown *int, thresholdType *modelInputs.ThresholdType, thresholdCondition *modelInputs.ThresholdCondition, destinations []*modelInputs.AlertDestinationInput, sql *string, thresholdChangeWindow *int, thresholdChangeType *modelInputs.ChangeType, disableResolvedNotifications *bool, severity *modelInputs.AlertSeverity) (*model.Alert, error) {
 	project, err := r.isUserInProject(ctx, projectID)
 	admin, _ := r.getCurrentAdmin(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if thresholdChangeWindow != nil && *thresholdChangeWindow <= 0 {
+		return nil, e.New("threshold change window must be positive")
+	}
+
+	if len(destinations) > 0 {
+		existingAlert := &model.Alert{}
+		if err := r.DB.WithContext(ctx).Where(&model.Alert{Model: model.Model{ID: alertID}, ProjectID: project.ID}).Take(&existingAlert).Error; err != nil {
+			return nil, err
+		}
+		if productType != nil {
+			existingAlert.ProductType = *productType
+		}
+		if thresholdType != nil {
+			existingAlert.ThresholdType = *thresholdType
+		}
+		if err := validateAlertDestinationTemplates(existingAlert.ProductType, existingAlert.ThresholdType, destinations); err != nil {
+			return nil, err
+		}
+	}
+
 	alertUpdates := map[string]interface{}{
 		"MetricId":           uuid.New().String(),
 		"LastAdminToEditID":  admin.ID,
@@ -3510,6 +3608,15 @@ func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alert
 		"ThresholdType":      thresholdType,
 		"ThresholdCondition": thresholdCondition,
 		"Sql":                sql,
+
+		"ThresholdChangeWindow": thresholdChangeWindow,
+		"ThresholdChangeType":   thresholdChangeType,
+	}
+	if disableResolvedNotifications != nil {
+		alertUpdates["DisableResolvedNotifications"] = *disableResolvedNotifications
+	}
+	if severity != nil {
+		alertUpdates["Severity"] = severity.String()
 	}
 
 	alert := &model.Alert{}
@@ -3519,18 +3626,22 @@ func (r *mutationResolver) UpdateAlert(ctx context.Context, projectID int, alert
 	}
 	alertDestinations := []*model.AlertDestination{}
 	if err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		previousDestinations := []*model.AlertDestination{}
+		if err := tx.Where(&model.AlertDestination{AlertID: alert.ID}).Find(&previousDestinations).Error; err != nil {
+			return err
+		}
+
 		if err := tx.Where(&model.AlertDestination{AlertID: alert.ID}).Delete(&model.AlertDestination{}).Error; err != nil {
 			return err
 		}
 
 		for _, d := range destinations {
-			alertDestinations = append(alertDestinations, &model.AlertDestination{
-				AlertID:         alert.ID,
-				DestinationType: d.DestinationType,
-				TypeID:          d.TypeID,
-				TypeName:        d.TypeName,
-			})
+			alertDestinations = append(alertDestinations, alertDestinationFromInput(alert.ID, d))
+		}
+		if err := setWebhookSigningSecrets(previousDestinations, alertDestinations); err != nil {
+			return err
 		}
+		setPagingAuthorizations(previousDestinations, alertDestinations)
 
 		if err := r.DB.WithContext(ctx).Create(alertDestinations).Error; err != nil {
 			return err
@@ -3588,6 +3699,12 @@ func (r *mutationResolver) DeleteAlert(ctx context.Context, projectID int, alert
 
 	// TODO(spenny): send deletion message to destinations?
 
+	if err := r.DB.Where(
+		&model.Alert{ParentAlertID: &alertID, ProjectID: project.ID},
+	).Delete(&model.Alert{}).Error; err != nil {
+		return false, err
+	}
+
 	if err := r.DB.Where(
 		&model.AlertDestination{AlertID: alertID},
 	).Delete(&model.AlertDestination{}).Error; err != nil {
@@ -3597,6 +3714,317 @@ func (r *mutationResolver) DeleteAlert(ctx context.Context, projectID int, alert
 	return true, nil
 }
 
+// CreateCompositeAlert is the resolver for the createCompositeAlert field.
+func (r *mutationResolver) CreateCompositeAlert(ctx context.Context, projectID int, name string, condition modelInputs.CompositeConditionInput, thresholdCooldown *int, destinations []*modelInputs.AlertDestinationInput) (*model.Alert, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	admin, _ := r.getCurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newAlert := &model.Alert{
+		ProjectID:          projectID,
+		MetricId:           uuid.New().String(),
+		Name:               name,
+		ThresholdCooldown:  thresholdCooldown,
+		ThresholdType:      modelInputs.ThresholdTypeConstant,
+		ThresholdCondition: modelInputs.ThresholdConditionAbove,
+		LastAdminToEditID:  admin.ID,
+	}
+
+	if err := validateAlertDestinationTemplates(newAlert.ProductType, newAlert.ThresholdType, destinations); err != nil {
+		return nil, err
+	}
+
+	alertDestinations := []*model.AlertDestination{}
+	if err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newAlert).Error; err != nil {
+			return err
+		}
+
+		if err := saveCompositeCondition(ctx, tx, newAlert, &condition); err != nil {
+			return err
+		}
+
+		for _, d := range destinations {
+			alertDestinations = append(alertDestinations, alertDestinationFromInput(newAlert.ID, d))
+		}
+		if err := setWebhookSigningSecrets(nil, alertDestinations); err != nil {
+			return err
+		}
+
+		if len(alertDestinations) > 0 {
+			if err := tx.Create(alertDestinations).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(alertDestinations) > 0 {
+		notificationInput := destinationsV2.NotificationInput{
+			NotificationType: destinationsV2.NotificationTypeAlertCreated,
+			WorkspaceID:      project.WorkspaceID,
+			AlertUpsertInput: &destinationsV2.AlertUpsertInput{
+				Alert: newAlert,
+				Admin: admin,
+			},
+		}
+
+		alertsV2.SendNotifications(ctx, r.DB, r.MailClient, r.LambdaClient, notificationInput, alertDestinations)
+	}
+
+	return newAlert, nil
+}
+
+// UpdateCompositeAlert is the resolver for the updateCompositeAlert field.
+func (r *mutationResolver) UpdateCompositeAlert(ctx context.Context, projectID int, alertID int, name *string, condition *modelInputs.CompositeConditionInput, thresholdCooldown *int, destinations []*modelInputs.AlertDestinationInput) (*model.Alert, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	admin, _ := r.getCurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := &model.Alert{}
+	if err := r.DB.WithContext(ctx).Where(&model.Alert{Model: model.Model{ID: alertID}, ProjectID: project.ID}).Where("composite_condition IS NOT NULL").Take(&alert).Error; err != nil {
+		return nil, err
+	}
+
+	alertUpdates := map[string]interface{}{
+		"MetricId":          uuid.New().String(),
+		"LastAdminToEditID": admin.ID,
+	}
+	if name != nil {
+		alertUpdates["Name"] = *name
+	}
+	if thresholdCooldown != nil {
+		alertUpdates["ThresholdCooldown"] = *thresholdCooldown
+	}
+
+	if err := validateAlertDestinationTemplates(alert.ProductType, alert.ThresholdType, destinations); err != nil {
+		return nil, err
+	}
+
+	alertDestinations := []*model.AlertDestination{}
+	if err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&alert).Clauses(clause.Returning{}).Updates(&alertUpdates).Error; err != nil {
+			return err
+		}
+
+		if condition != nil {
+			if err := saveCompositeCondition(ctx, tx, alert, condition); err != nil {
+				return err
+			}
+		}
+
+		if destinations == nil {
+			return nil
+		}
+
+		previousDestinations := []*model.AlertDestination{}
+		if err := tx.Where(&model.AlertDestination{AlertID: alert.ID}).Find(&previousDestinations).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where(&model.AlertDestination{AlertID: alert.ID}).Delete(&model.AlertDestination{}).Error; err != nil {
+			return err
+		}
+
+		for _, d := range destinations {
+			alertDestinations = append(alertDestinations, alertDestinationFromInput(alert.ID, d))
+		}
+		if err := setWebhookSigningSecrets(previousDestinations, alertDestinations); err != nil {
+			return err
+		}
+		setPagingAuthorizations(previousDestinations, alertDestinations)
+
+		if len(alertDestinations) > 0 {
+			if err := tx.Create(alertDestinations).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(alertDestinations) > 0 {
+		notificationInput := destinationsV2.NotificationInput{
+			NotificationType: destinationsV2.NotificationTypeAlertUpdated,
+			WorkspaceID:      project.WorkspaceID,
+			AlertUpsertInput: &destinationsV2.AlertUpsertInput{
+				Alert: alert,
+				Admin: admin,
+			},
+		}
+
+		alertsV2.SendNotifications(ctx, r.DB, r.MailClient, r.LambdaClient, notificationInput, alertDestinations)
+	}
+
+	return alert, nil
+}
+
+// PreviewAlertTemplate is the resolver for the previewAlertTemplate field.
+func (r *mutationResolver) PreviewAlertTemplate(ctx context.Context, projectID int, productType *modelInputs.ProductType, thresholdType *modelInputs.ThresholdType, destination modelInputs.AlertDestinationInput, resolved *bool) (*modelInputs.AlertTemplatePreview, error) {
+	if _, err := r.isUserInProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	alertDestination := alertDestinationFromInput(0, &destination)
+	if !destinationsV2.SupportsTemplates(alertDestination.DestinationType) {
+		return nil, e.Errorf("%s destinations do not support templates", alertDestination.DestinationType)
+	}
+
+	alertInput := destinationsV2.SampleAlertInput(lo.FromPtr(productType), lo.FromPtr(thresholdType), lo.FromPtr(resolved))
+	title, body, err := destinationsV2.RenderTemplates(*alertDestination, alertInput)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &modelInputs.AlertTemplatePreview{}
+	if body != "" {
+		preview.Body = &body
+	}
+	if alertDestination.DestinationType != modelInputs.AlertDestinationTypeWebhook {
+		if title == "" {
+			title = destinationsV2.DefaultTitle(alertInput)
+		}
+		preview.Title = &title
+	}
+	return preview, nil
+}
+
+// CreateAlertSilence is the resolver for the createAlertSilence field.
+func (r *mutationResolver) CreateAlertSilence(ctx context.Context, projectID int, silence modelInputs.AlertSilenceInput) (*model.AlertSilence, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := r.getCurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alertSilence := &model.AlertSilence{
+		ProjectID: project.ID,
+		CreatorID: admin.ID,
+	}
+	applyAlertSilenceInput(alertSilence, silence)
+	if err := alertsV2.ValidateSilence(alertSilence); err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.WithContext(ctx).Create(alertSilence).Error; err != nil {
+		return nil, err
+	}
+
+	return alertSilence, nil
+}
+
+// UpdateAlertSilence is the resolver for the updateAlertSilence field.
+func (r *mutationResolver) UpdateAlertSilence(ctx context.Context, projectID int, id int, silence modelInputs.AlertSilenceInput) (*model.AlertSilence, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var alertSilence model.AlertSilence
+	if err := r.DB.WithContext(ctx).Where(&model.AlertSilence{Model: model.Model{ID: id}, ProjectID: project.ID}).Take(&alertSilence).Error; err != nil {
+		return nil, err
+	}
+
+	applyAlertSilenceInput(&alertSilence, silence)
+	if err := alertsV2.ValidateSilence(&alertSilence); err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.WithContext(ctx).Save(&alertSilence).Error; err != nil {
+		return nil, err
+	}
+
+	return &alertSilence, nil
+}
+
+// DeleteAlertSilence is the resolver for the deleteAlertSilence field.
+func (r *mutationResolver) DeleteAlertSilence(ctx context.Context, projectID int, id int) (bool, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.DB.WithContext(ctx).Where(
+		&model.AlertSilence{Model: model.Model{ID: id}, ProjectID: project.ID},
+	).Delete(&model.AlertSilence{}).Error; err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RotateWebhookSigningSecret is the resolver for the rotateWebhookSigningSecret field.
+func (r *mutationResolver) RotateWebhookSigningSecret(ctx context.Context, projectID int, destinationID int) (*modelInputs.WebhookSigningSecret, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := &model.AlertDestination{}
+	if err := r.DB.WithContext(ctx).Where(&model.AlertDestination{Model: model.Model{ID: destinationID}}).Take(&destination).Error; err != nil {
+		return nil, err
+	}
+	if err := r.DB.WithContext(ctx).Where(&model.Alert{Model: model.Model{ID: destination.AlertID}, ProjectID: project.ID}).Take(&model.Alert{}).Error; err != nil {
+		return nil, err
+	}
+	if destination.DestinationType != modelInputs.AlertDestinationTypeWebhook {
+		return nil, e.New("only webhook destinations have a signing secret")
+	}
+
+	secret, err := webhookV2.NewSigningSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// the previous secret keeps signing payloads for a grace period, so receivers can switch over
+	now := time.Now()
+	destination.PreviousSigningSecret = destination.SigningSecret
+	destination.SigningSecret = &secret
+	destination.SigningSecretRotatedAt = &now
+	if err := r.DB.WithContext(ctx).Model(&destination).Select("SigningSecret", "PreviousSigningSecret", "SigningSecretRotatedAt").Updates(destination).Error; err != nil {
+		return nil, err
+	}
+
+	return &modelInputs.WebhookSigningSecret{
+		DestinationID: destination.ID,
+		SigningSecret: secret,
+	}, nil
+}
+
+// RedeliverWebhook is the resolver for the redeliverWebhook field.
+func (r *mutationResolver) RedeliverWebhook(ctx context.Context, projectID int, id int) (*model.WebhookDelivery, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &model.WebhookDelivery{}
+	if err := r.DB.WithContext(ctx).Where(&model.WebhookDelivery{Model: model.Model{ID: id}, ProjectID: project.ID}).Take(&delivery).Error; err != nil {
+		return nil, err
+	}
+
+	// the destinations are recreated when the alert is updated, so the delivery is sent to the current destination of the url
+	destination := &model.AlertDestination{}
+	if err := r.DB.WithContext(ctx).Where(&model.AlertDestination{AlertID: delivery.AlertID, DestinationType: modelInputs.AlertDestinationTypeWebhook, TypeID: delivery.URL}).Take(&destination).Error; err != nil {
+		return nil, e.Wrap(err, "webhook destination no longer exists")
+	}
+
+	return webhookV2.Redeliver(ctx, r.DB, delivery, *destination)
+}
+
 // UpdateErrorAlert is the resolver for the updateErrorAlert field.
 func (r *mutationResolver) UpdateErrorAlert(ctx context.Context, projectID int, name *string, errorAlertID int, countThreshold *int, thresholdWindow *int, slackChannels []*modelInputs.SanitizedSlackChannelInput, discordChannels []*modelInputs.DiscordChannelInput, microsoftTeamsChannels []*modelInputs.MicrosoftTeamsChannelInput, webhookDestinations []*modelInputs.WebhookDestinationInput, emails []*string, query string, regexGroups []*string, frequency *int, disabled *bool) (*model.ErrorAlert, error) {
 	project, err := r.isUserInProject(ctx, projectID)
@@ -5953,6 +6381,21 @@ func (r *queryResolver) ErrorCommentsForProject(ctx context.Context, projectID i
 	return errorComments, nil
 }
 
+// ErrorGroupingSettings is the resolver for the error_grouping_settings field.
+func (r *queryResolver) ErrorGroupingSettings(ctx context.Context, projectID int) (*modelInputs.ErrorGroupingSettings, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := r.Store.GetProjectErrorGroupingSettings(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return getErrorGroupingSettings(ctx, settings), nil
+}
+
 // WorkspaceAdmins is the resolver for the workspace_admins field.
 func (r *queryResolver) WorkspaceAdmins(ctx context.Context, workspaceID int) ([]*model.WorkspaceAdminRole, error) {
 	workspace, err := r.isUserInWorkspace(ctx, workspaceID)
@@ -7173,7 +7616,8 @@ func (r *queryResolver) Alerts(ctx context.Context, projectID int) ([]*model.Ale
 		return nil, err
 	}
 	alerts := []*model.Alert{}
-	if err := r.DB.Order("created_at asc").Model(&model.Alert{}).Preload("Destinations").Where("project_id = ?", projectID).Find(&alerts).Error; err != nil {
+	// the child alerts of inline composite conditions are managed through their composite alert
+	if err := r.DB.Order("created_at asc").Model(&model.Alert{}).Preload("Destinations").Where("project_id = ?", projectID).Where("parent_alert_id IS NULL").Find(&alerts).Error; err != nil {
 		return nil, err
 	}
 
@@ -7236,6 +7680,94 @@ func (r *queryResolver) LastAlertStateChanges(ctx context.Context, alertID int)
 	return r.ClickhouseClient.GetLastAlertStateChanges(ctx, alert.ProjectID, alertID)
 }
 
+// AlertBacktest is the resolver for the alert_backtest field.
+func (r *queryResolver) AlertBacktest(ctx context.Context, projectID int, alert modelInputs.AlertDefinitionInput, dateRange modelInputs.DateRangeRequiredInput) (*modelInputs.AlertBacktest, error) {
+	if _, err := r.isUserInProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	thresholdType := modelInputs.ThresholdTypeConstant
+	if alert.ThresholdType != nil {
+		thresholdType = *alert.ThresholdType
+	}
+
+	thresholdCondition := modelInputs.ThresholdConditionAbove
+	if alert.ThresholdCondition != nil {
+		thresholdCondition = *alert.ThresholdCondition
+	}
+
+	thresholdChangeType := modelInputs.ChangeTypePercent
+	if alert.ThresholdChangeType != nil {
+		thresholdChangeType = *alert.ThresholdChangeType
+	}
+
+	if alert.ThresholdWindow != nil && *alert.ThresholdWindow <= 0 {
+		return nil, e.New("threshold window must be positive")
+	}
+
+	if alert.ThresholdChangeWindow != nil && *alert.ThresholdChangeWindow <= 0 {
+		return nil, e.New("threshold change window must be positive")
+	}
+
+	return metric_alerts.Backtest(ctx, r.DB, r.ClickhouseClient, &model.Alert{
+		ProjectID:          projectID,
+		ProductType:        alert.ProductType,
+		FunctionType:       alert.FunctionType,
+		FunctionColumn:     alert.FunctionColumn,
+		Query:              alert.Query,
+		GroupByKey:         alert.GroupByKey,
+		ThresholdValue:     alert.ThresholdValue,
+		ThresholdWindow:    alert.ThresholdWindow,
+		ThresholdCooldown:  alert.ThresholdCooldown,
+		ThresholdType:      thresholdType,
+		ThresholdCondition: thresholdCondition,
+		Sql:                alert.SQL,
+
+		ThresholdChangeWindow: alert.ThresholdChangeWindow,
+		ThresholdChangeType:   thresholdChangeType,
+	}, dateRange.StartDate, dateRange.EndDate)
+}
+
+// AlertSilences is the resolver for the alert_silences field.
+func (r *queryResolver) AlertSilences(ctx context.Context, projectID int) ([]*model.AlertSilence, error) {
+	_, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	silences := []*model.AlertSilence{}
+	if err := r.DB.WithContext(ctx).Order("created_at asc").Where("project_id = ?", projectID).Find(&silences).Error; err != nil {
+		return nil, err
+	}
+
+	return silences, nil
+}
+
+// WebhookDeliveries is the resolver for the webhook_deliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, projectID int, alertID *int, count *int) ([]*model.WebhookDelivery, error) {
+	project, err := r.isUserInProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 50
+	if count != nil && *count > 0 {
+		limit = min(*count, 500)
+	}
+
+	query := r.DB.WithContext(ctx).Where(&model.WebhookDelivery{ProjectID: project.ID})
+	if alertID != nil {
+		query = query.Where(&model.WebhookDelivery{AlertID: *alertID})
+	}
+
+	deliveries := []*model.WebhookDelivery{}
+	if err := query.Order("created_at desc").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
 // ErrorAlerts is the resolver for the error_alerts field.
 func (r *queryResolver) ErrorAlerts(ctx context.Context, projectID int) ([]*model.ErrorAlert, error) {
 	_, err := r.isUserInProjectOrDemoProject(ctx, projectID)
@@ -8084,6 +8616,7 @@ func (r *queryResolver) ProjectSettings(ctx context.Context, projectID int) (*mo
 			ErrorExclusionQuery:    projectFilterSettings.ErrorExclusionQuery,
 			LogExclusionQuery:      projectFilterSettings.LogExclusionQuery,
 			TraceExclusionQuery:    projectFilterSettings.TraceExclusionQuery,
+			TraceTailSampling:      getTraceTailSampling(ctx, projectFilterSettings),
 		},
 	}
 
@@ -9111,13 +9644,17 @@ func (r *queryResolver) Logs(ctx context.Context, projectID int, params modelInp
 		}
 	})
 
-	return r.ClickhouseClient.ReadLogs(ctx, project.ID, params, clickhouse.Pagination{
+	logs, err := r.ClickhouseClient.ReadLogs(ctx, project.ID, params, clickhouse.Pagination{
 		After:     after,
 		Before:    before,
 		At:        at,
 		Direction: direction,
 		Limit:     limit,
 	})
+	if err != nil {
+		return nil, searchQueryError(err)
+	}
+	return logs, nil
 }
 
 // LogsHistogram is the resolver for the logs_histogram field.
@@ -9130,6 +9667,16 @@ func (r *queryResolver) LogsHistogram(ctx context.Context, projectID int, params
 	return r.ClickhouseClient.ReadLogsHistogram(ctx, project.ID, params, 48)
 }
 
+// LogPatterns is the resolver for the log_patterns field.
+func (r *queryResolver) LogPatterns(ctx context.Context, projectID int, params modelInputs.QueryInput, limit *int) ([]*modelInputs.LogPattern, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ClickhouseClient.ReadLogPatterns(ctx, project.ID, params, limit)
+}
+
 // LogsMetrics is the resolver for the logs_metrics field.
 func (r *queryResolver) LogsMetrics(ctx context.Context, projectID int, params modelInputs.QueryInput, sql *string, column *string, metricTypes []modelInputs.MetricAggregator, groupBy []string, bucketBy string, bucketCount *int, bucketWindow *int, limit *int, limitAggregator *modelInputs.MetricAggregator, limitColumn *string, expressions []*modelInputs.MetricExpressionInput) (*modelInputs.MetricsBuckets, error) {
 	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
@@ -9137,7 +9684,7 @@ func (r *queryResolver) LogsMetrics(ctx context.Context, projectID int, params m
 		return nil, err
 	}
 
-	expressions, err = normalizeExpressions(column, metricTypes, expressions)
+	expressions, err = normalizeExpressions(params.Query, column, metricTypes, expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -9395,13 +9942,17 @@ func (r *queryResolver) Traces(ctx context.Context, projectID int, params modelI
 		return nil, err
 	}
 
-	return r.ClickhouseClient.ReadTraces(ctx, project.ID, params, clickhouse.Pagination{
+	traces, err := r.ClickhouseClient.ReadTraces(ctx, project.ID, params, clickhouse.Pagination{
 		After:     after,
 		Before:    before,
 		At:        at,
 		Direction: direction,
 		Limit:     limit,
 	})
+	if err != nil {
+		return nil, searchQueryError(err)
+	}
+	return traces, nil
 }
 
 // TracesMetrics is the resolver for the traces_metrics field.
@@ -9416,7 +9967,7 @@ func (r *queryResolver) TracesMetrics(ctx context.Context, projectID int, params
 		bucketByDeref = *bucketBy
 	}
 
-	expressions, err = normalizeExpressions(column, metricTypes, expressions)
+	expressions, err = normalizeExpressions(params.Query, column, metricTypes, expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -9482,7 +10033,7 @@ func (r *queryResolver) ErrorsMetrics(ctx context.Context, projectID int, params
 		return nil, err
 	}
 
-	expressions, err = normalizeExpressions(column, metricTypes, expressions)
+	expressions, err = normalizeExpressions(params.Query, column, metricTypes, expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -9517,7 +10068,7 @@ func (r *queryResolver) SessionsMetrics(ctx context.Context, projectID int, para
 		return nil, err
 	}
 
-	expressions, err = normalizeExpressions(column, metricTypes, expressions)
+	expressions, err = normalizeExpressions(params.Query, column, metricTypes, expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -9552,7 +10103,7 @@ func (r *queryResolver) EventsMetrics(ctx context.Context, projectID int, params
 		return nil, err
 	}
 
-	expressions, err = normalizeExpressions(column, metricTypes, expressions)
+	expressions, err = normalizeExpressions(params.Query, column, metricTypes, expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -9662,6 +10213,20 @@ func (r *queryResolver) Metrics(ctx context.Context, productType modelInputs.Pro
 	return results, nil
 }
 
+// ValidateSearchQuery is the resolver for the validate_search_query field.
+func (r *queryResolver) ValidateSearchQuery(ctx context.Context, projectID int, productType modelInputs.ProductType, query string) ([]*modelInputs.SearchQueryError, error) {
+	if _, err := r.isUserInProjectOrDemoProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	tableConfig, err := clickhouse.GetSearchTableConfig(productType)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.ValidateSearchQuery(query, tableConfig), nil
+}
+
 // Keys is the resolver for the keys field.
 func (r *queryResolver) Keys(ctx context.Context, productType *modelInputs.ProductType, projectID int, dateRange modelInputs.DateRangeRequiredInput, query *string, typeArg *modelInputs.KeyType, event *string) ([]*modelInputs.QueryKey, error) {
 	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
@@ -10157,6 +10722,49 @@ func (r *subscriptionResolver) SessionPayloadAppended(ctx context.Context, sessi
 	return ch, nil
 }
 
+// LogsTail is the resolver for the logs_tail field.
+func (r *subscriptionResolver) LogsTail(ctx context.Context, projectID int, query string) (<-chan *modelInputs.LogsTailPayload, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tail, err := livetail.TailLogs(ctx, r.Redis, project.ID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *modelInputs.LogsTailPayload)
+	// tails run for as long as the client is connected, so they don't hold a subscription worker
+	go func() {
+		defer util.Recover()
+		defer close(ch)
+		tail.Run(ctx, ch)
+	}()
+	return ch, nil
+}
+
+// TracesTail is the resolver for the traces_tail field.
+func (r *subscriptionResolver) TracesTail(ctx context.Context, projectID int, query string) (<-chan *modelInputs.TracesTailPayload, error) {
+	project, err := r.isUserInProjectOrDemoProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tail, err := livetail.TailTraces(ctx, r.Redis, project.ID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *modelInputs.TracesTailPayload)
+	go func() {
+		defer util.Recover()
+		defer close(ch)
+		tail.Run(ctx, ch)
+	}()
+	return ch, nil
+}
+
 // Data is the resolver for the data field.
 func (r *timelineIndicatorEventResolver) Data(ctx context.Context, obj *model.TimelineIndicatorEvent) (interface{}, error) {
 	return obj.Data, nil
@@ -10214,6 +10822,14 @@ func (r *visualizationResolver) Variables(ctx context.Context, obj *model.Visual
 	return variables, nil
 }
 
+// Alert returns generated.AlertResolver implementation.
+func (r *Resolver) Alert() generated.AlertResolver { return &alertResolver{r} }
+
+// AlertDestination returns generated.AlertDestinationResolver implementation.
+func (r *Resolver) AlertDestination() generated.AlertDestinationResolver {
+	return &alertDestinationResolver{r}
+}
+
 // AllWorkspaceSettings returns generated.AllWorkspaceSettingsResolver implementation.
 func (r *Resolver) AllWorkspaceSettings() generated.AllWorkspaceSettingsResolver {
 	return &allWorkspaceSettingsResolver{r}
@@ -10282,6 +10898,8 @@ func (r *Resolver) TimelineIndicatorEvent() generated.TimelineIndicatorEventReso
 // Visualization returns generated.VisualizationResolver implementation.
 func (r *Resolver) Visualization() generated.VisualizationResolver { return &visualizationResolver{r} }
 
+type alertResolver struct{ *Resolver }
+type alertDestinationResolver struct{ *Resolver }
 type allWorkspaceSettingsResolver struct{ *Resolver }
 type commentReplyResolver struct{ *Resolver }
 type errorAlertResolver struct{ *Resolver }
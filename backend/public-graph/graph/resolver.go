@@ -565,6 +565,23 @@ func (r *Resolver) GetTopErrorGroupMatchByEmbedding(ctx context.Context, project
 	return nil, nil
 }
 
+// GetErrorGroupMatchByFingerprint returns the error group currently holding the custom fingerprint set by a grouping rule.
+func (r *Resolver) GetErrorGroupMatchByFingerprint(ctx context.Context, projectID int, fingerprint string) (*int, error) {
+	var errorGroupIDs []int
+	if err := r.DB.WithContext(ctx).Model(&model.ErrorFingerprint{}).
+		Where(&model.ErrorFingerprint{ProjectID: projectID, Type: model.Fingerprint.Custom, Value: fingerprint}).
+		Where("error_group_id IS NOT NULL").
+		Order("id DESC").
+		Limit(1).
+		Pluck("error_group_id", &errorGroupIDs).Error; err != nil {
+		return nil, e.Wrap(err, "error querying error group by fingerprint")
+	}
+	if len(errorGroupIDs) == 0 {
+		return nil, nil
+	}
+	return &errorGroupIDs[0], nil
+}
+
 func (r *Resolver) GetTopErrorGroupMatch(ctx context.Context, event string, projectID int, fingerprints []*model.ErrorFingerprint) (*int, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "resolver.GetTopErrorGroupMatch", util.Tag("projectID", projectID), util.Tag("event", event), util.Tag("num_fingerprints", len(fingerprints)))
 	defer span.Finish()
@@ -625,6 +642,13 @@ func (r *Resolver) GetTopErrorGroupMatch(ctx context.Context, event string, proj
 			AND id IS NOT NULL
 			AND project_id = @projectID
 			UNION ALL
+			(SELECT DISTINCT error_group_id, 100 AS score, 0
+			FROM error_fingerprints
+			WHERE type = 'MESSAGE'
+			AND value = @event
+			AND project_id = @projectID
+			AND error_group_id IS NOT NULL)
+			UNION ALL
 			(SELECT DISTINCT ef.error_group_id, jr.score, 0
 			FROM error_fingerprints ef
 			INNER JOIN json_results jr
@@ -744,11 +768,21 @@ func (r *Resolver) HandleErrorAndGroup(ctx context.Context, errorObj *model.Erro
 		}
 	}
 
-	key := errorgroups.GetKey(projectID, errorObj, structuredStackTrace)
+	groupingSettings, err := r.Store.GetProjectErrorGroupingSettings(ctx, projectID)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("failed to query error grouping settings; grouping without rules")
+		groupingSettings = nil
+	}
+	groupingRules, err := errorgroups.ParseRules(groupingSettings)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("invalid error grouping rules; grouping without rules")
+	}
+
+	key := errorgroups.GetKey(projectID, errorObj, structuredStackTrace, groupingRules)
 	var cacheMiss bool
 	eg, err := redis.CachedEval(ctx, r.Redis, key, 10*time.Second, time.Hour, func() (*model.ErrorGroup, error) {
 		cacheMiss = true
-		return r.handleErrorAndGroup(ctx, project, errorObj, structuredStackTrace, projectID, workspace)
+		return r.handleErrorAndGroup(ctx, project, errorObj, structuredStackTrace, projectID, workspace, groupingSettings, groupingRules)
 	})
 	if eg == nil || err != nil {
 		log.WithContext(ctx).WithError(err).WithField("project_id", projectID).Error("failed to group error")
@@ -793,12 +827,14 @@ func (r *Resolver) HandleErrorAndGroup(ctx context.Context, errorObj *model.Erro
 }
 
 // Matches the ErrorObject with an existing ErrorGroup, or creates a new one if the group does not exist
-func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Project, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace, projectID int, workspace *model.Workspace) (*model.ErrorGroup, error) {
+// The project grouping rules strip the event and ignore frames used for matching, or force the group with a custom fingerprint.
+func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Project, errorObj *model.ErrorObject, structuredStackTrace []*privateModel.ErrorTrace, projectID int, workspace *model.Workspace, groupingSettings *model.ProjectErrorGroupingSettings, groupingRules errorgroups.Rules) (*model.ErrorGroup, error) {
 	span, ctx := util.StartSpanFromContext(ctx, "handleErrorAndGroup", util.Tag("projectID", projectID))
 	defer span.Finish()
 
 	var fingerprints []*model.ErrorFingerprint
-	fingerprints = append(fingerprints, errorgroups.GetFingerprints(projectID, structuredStackTrace)...)
+	fingerprints = append(fingerprints, errorgroups.GetGroupingFingerprints(projectID, errorObj, structuredStackTrace, groupingRules)...)
+	event := groupingRules.NormalizeEvent(errorObj.Event)
 
 	// Try unmarshalling the Event to JSON.
 	// If this works, create an error fingerprint for each of the project's JSON paths.
@@ -833,11 +869,26 @@ func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Proje
 	}
 
 	var embedding *model.ErrorObjectEmbeddings
-	if settings != nil && settings.ErrorEmbeddingsGroup {
+	if customFingerprint, ok := groupingRules.GetFingerprint(errorObj); ok {
+		errorObj.ErrorGroupingMethod = model.ErrorGroupingMethodClassic
+		errorGroup, err = r.GetOrCreateErrorGroup(ctx, errorObj, func() (*int, error) {
+			return r.GetErrorGroupMatchByFingerprint(ctx, projectID, customFingerprint)
+		}, nil, settings != nil && settings.ErrorEmbeddingsTagGroup)
+		if err != nil {
+			return nil, e.Wrap(err, "Error getting or creating error group by fingerprint")
+		}
+	} else if settings != nil && settings.ErrorEmbeddingsGroup {
+		threshold := settings.ErrorEmbeddingsThreshold
+		if groupingSettings != nil && groupingSettings.EmbeddingsThreshold != nil {
+			threshold = *groupingSettings.EmbeddingsThreshold
+		}
+		// embed the normalized event so that stripped parts do not split groups
+		embeddingObj := *errorObj
+		embeddingObj.Event = event
 		eCtx, cancel := context.WithTimeout(ctx, embeddings.InferenceTimeout)
 		defer cancel()
 		var emb []*model.ErrorObjectEmbeddings
-		emb, err = r.EmbeddingsClient.GetEmbeddings(eCtx, []*model.ErrorObject{errorObj})
+		emb, err = r.EmbeddingsClient.GetEmbeddings(eCtx, []*model.ErrorObject{&embeddingObj})
 		if err != nil || len(emb) == 0 {
 			log.WithContext(ctx).WithError(err).Error("failed to get embeddings")
 			errorObj.ErrorGroupingMethod = model.ErrorGroupingMethodClassic
@@ -845,7 +896,7 @@ func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Proje
 			embedding = emb[0]
 			embeddingType := model.ErrorGroupingMethodGteLargeEmbeddingV3
 			errorGroup, err = r.GetOrCreateErrorGroup(ctx, errorObj, func() (*int, error) {
-				match, err := r.GetTopErrorGroupMatchByEmbedding(ctx, errorObj.ProjectID, embeddingType, embedding.GteLargeEmbedding, settings.ErrorEmbeddingsThreshold)
+				match, err := r.GetTopErrorGroupMatchByEmbedding(ctx, errorObj.ProjectID, embeddingType, embedding.GteLargeEmbedding, threshold)
 				if err != nil {
 					log.WithContext(ctx).WithError(err).Error("failed to group error using embeddings")
 				}
@@ -871,7 +922,7 @@ func (r *Resolver) handleErrorAndGroup(ctx context.Context, project *model.Proje
 	if errorGroup == nil {
 		log.WithContext(ctx).WithError(err).WithField("error_object_id", errorObj.ID).Error("failed to create error group by embedding; using classic match")
 		errorGroup, err = r.GetOrCreateErrorGroup(ctx, errorObj, func() (*int, error) {
-			match, err := r.GetTopErrorGroupMatch(ctx, errorObj.Event, errorObj.ProjectID, fingerprints)
+			match, err := r.GetTopErrorGroupMatch(ctx, event, errorObj.ProjectID, fingerprints)
 			if err != nil {
 				return nil, e.Wrap(err, "Error getting top error group match")
 			}
@@ -1949,7 +2000,7 @@ func (r *Resolver) PushMetricsImpl(ctx context.Context, projectVerboseID *string
 	}
 
 	curTime := time.Now()
-	var metricRows []*clickhouse.MetricSumRow
+	var metricRows []clickhouse.MetricRow
 	for _, m := range metrics {
 		var spanID, traceID = ptr.ToString(m.SpanID), ptr.ToString(m.TraceID)
 		var serviceName, serviceVersion = session.ServiceName, ptr.ToString(session.AppVersion)
@@ -1994,13 +2045,10 @@ func (r *Resolver) PushMetricsImpl(ctx context.Context, projectVerboseID *string
 	}
 
 	var messages []kafka_queue.RetryableMessage
-	for _, metricRow := range metricRows {
-		if !r.IsMetricIngested(ctx, metricRow) {
-			continue
-		}
+	for _, metricRow := range r.FilterMetricsIngested(ctx, metricRows) {
 		messages = append(messages, &kafka_queue.OTeLMetricSumRow{
 			Type:         kafka_queue.PushOTeLMetricSum,
-			MetricSumRow: metricRow,
+			MetricSumRow: metricRow.(*clickhouse.MetricSumRow),
 		})
 	}
 	return r.MetricSumQueue.Submit(ctx, "", messages...)
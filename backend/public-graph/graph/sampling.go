@@ -16,95 +16,62 @@ import (
 	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	modelInputs "github.com/highlight-run/highlight/backend/public-graph/graph/model"
 	"github.com/highlight/highlight/sdk/highlight-go"
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
 	e "github.com/pkg/errors"
+	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func (r *Resolver) IsMetricIngested(ctx context.Context, metric clickhouse.MetricRow) bool {
-	if !r.IsMetricIngestedByFilter(ctx, metric) {
-		return false
-	}
-	if !r.IsMetricIngestedBySample(ctx, metric) {
-		return false
-	}
-	if !r.IsMetricIngestedByRateLimit(ctx, metric) {
-		return false
-	}
-	return true
-}
+// rateLimitWindow is the sliding window of the ProjectFilterSettings *MinuteRateLimit settings.
+const rateLimitWindow = time.Minute
 
-func (r *Resolver) IsMetricIngestedBySample(_ context.Context, _ clickhouse.MetricRow) bool {
-	// metrics cannot be sampled on ingest
-	return true
+// FilterMetricsIngested returns the metric rows that should be ingested.
+func (r *Resolver) FilterMetricsIngested(ctx context.Context, metrics []clickhouse.MetricRow) []clickhouse.MetricRow {
+	return filterIngested(ctx, r, privateModel.ProductTypeMetrics, metrics, func(metric clickhouse.MetricRow) int {
+		return int(metric.GetProjectId())
+	}, ingestCheck[clickhouse.MetricRow]{privateModel.IngestReasonFilter, r.IsMetricIngestedByFilter},
+		ingestCheck[clickhouse.MetricRow]{privateModel.IngestReasonSample, r.IsMetricIngestedBySample})
 }
 
-func (r *Resolver) IsMetricIngestedByRateLimit(ctx context.Context, metric clickhouse.MetricRow) bool {
-	return r.isItemIngestedByRate(ctx, metric.GetTimestamp(), privateModel.ProductTypeMetrics, int(metric.GetProjectId()))
+// IsMetricIngestedBySample samples metrics by series rather than by data point
+// so that the data points of a series that is ingested are all kept.
+func (r *Resolver) IsMetricIngestedBySample(ctx context.Context, metric clickhouse.MetricRow) bool {
+	return r.isItemIngestedBySample(ctx, privateModel.ProductTypeMetrics, int(metric.GetProjectId()), clickhouse.MetricSeriesKey(metric))
 }
 
 func (r *Resolver) IsMetricIngestedByFilter(ctx context.Context, metric clickhouse.MetricRow) bool {
 	return r.isItemIngestedByFilter(ctx, privateModel.ProductTypeMetrics, int(metric.GetProjectId()), metric)
 }
 
-func (r *Resolver) IsTraceIngested(ctx context.Context, trace *clickhouse.TraceRow) bool {
-	if !r.IsTraceIngestedByFilter(ctx, trace) {
-		return false
-	}
-	if !r.IsTraceIngestedBySample(ctx, trace) {
-		return false
-	}
-	if !r.IsTraceIngestedByRateLimit(ctx, trace) {
-		return false
-	}
-	return true
+// FilterTracesIngested returns the trace rows that should be ingested.
+func (r *Resolver) FilterTracesIngested(ctx context.Context, traces []*clickhouse.TraceRow) []*clickhouse.TraceRow {
+	return filterIngested(ctx, r, privateModel.ProductTypeTraces, traces, func(trace *clickhouse.TraceRow) int {
+		return int(trace.ProjectId)
+	}, ingestCheck[*clickhouse.TraceRow]{privateModel.IngestReasonFilter, r.IsTraceIngestedByFilter},
+		ingestCheck[*clickhouse.TraceRow]{privateModel.IngestReasonSample, r.IsTraceIngestedBySample})
 }
 
 func (r *Resolver) IsTraceIngestedBySample(ctx context.Context, trace *clickhouse.TraceRow) bool {
 	return r.isItemIngestedBySample(ctx, privateModel.ProductTypeTraces, int(trace.ProjectId), trace.TraceId)
 }
 
-func (r *Resolver) IsTraceIngestedByRateLimit(ctx context.Context, trace *clickhouse.TraceRow) bool {
-	return r.isItemIngestedByRate(ctx, trace.Timestamp, privateModel.ProductTypeTraces, int(trace.ProjectId))
-}
-
 func (r *Resolver) IsTraceIngestedByFilter(ctx context.Context, trace *clickhouse.TraceRow) bool {
 	return r.isItemIngestedByFilter(ctx, privateModel.ProductTypeTraces, int(trace.ProjectId), trace)
 }
 
-func (r *Resolver) IsLogIngested(ctx context.Context, logRow *clickhouse.LogRow) bool {
-	span, ctx := highlight.StartTrace(ctx,
-		"sampling.IsIngestedBy",
-		attribute.Int("project", int(logRow.ProjectId)),
-		attribute.String(highlight.TraceKeyAttribute, logRow.UUID),
-		attribute.String("product", string(privateModel.ProductTypeLogs)),
-		attribute.Bool("ingested", true),
-	)
-	defer span.End()
-
-	if !r.IsLogIngestedBySample(ctx, logRow) {
-		span.SetAttributes(attribute.Bool("ingested", false), attribute.String("reason", string(privateModel.IngestReasonSample)))
-		return false
-	}
-	if !r.IsLogIngestedByFilter(ctx, logRow) {
-		span.SetAttributes(attribute.Bool("ingested", false), attribute.String("reason", string(privateModel.IngestReasonFilter)))
-		return false
-	}
-	if !r.IsLogIngestedByRateLimit(ctx, logRow) {
-		span.SetAttributes(attribute.Bool("ingested", false), attribute.String("reason", string(privateModel.IngestReasonRate)))
-		return false
-	}
-	return true
+// FilterLogsIngested returns the log rows that should be ingested.
+func (r *Resolver) FilterLogsIngested(ctx context.Context, logRows []*clickhouse.LogRow) []*clickhouse.LogRow {
+	return filterIngested(ctx, r, privateModel.ProductTypeLogs, logRows, func(logRow *clickhouse.LogRow) int {
+		return int(logRow.ProjectId)
+	}, ingestCheck[*clickhouse.LogRow]{privateModel.IngestReasonSample, r.IsLogIngestedBySample},
+		ingestCheck[*clickhouse.LogRow]{privateModel.IngestReasonFilter, r.IsLogIngestedByFilter})
 }
 
 func (r *Resolver) IsLogIngestedBySample(ctx context.Context, logRow *clickhouse.LogRow) bool {
 	return r.isItemIngestedBySample(ctx, privateModel.ProductTypeLogs, int(logRow.ProjectId), logRow.UUID)
 }
 
-func (r *Resolver) IsLogIngestedByRateLimit(ctx context.Context, logRow *clickhouse.LogRow) bool {
-	return r.isItemIngestedByRate(ctx, logRow.Timestamp, privateModel.ProductTypeLogs, int(logRow.ProjectId))
-}
-
 func (r *Resolver) IsLogIngestedByFilter(ctx context.Context, logRow *clickhouse.LogRow) bool {
 	return r.isItemIngestedByFilter(ctx, privateModel.ProductTypeLogs, int(logRow.ProjectId), logRow)
 }
@@ -166,7 +133,7 @@ func (r *Resolver) IsErrorIngestedByRateLimit(ctx context.Context, projectID int
 		return true
 	}
 
-	return r.isItemIngestedByRate(ctx, errorObject.Timestamp, privateModel.ProductTypeErrors, settings.ProjectID)
+	return r.isItemIngestedByRate(ctx, privateModel.ProductTypeErrors, settings.ProjectID)
 }
 
 func (r *Resolver) IsErrorIngestedByFilter(ctx context.Context, projectID int, errorObject *modelInputs.BackendErrorObjectInput) bool {
@@ -236,7 +203,7 @@ func (r *Resolver) isSessionExcludedBySample(ctx context.Context, session *model
 }
 
 func (r *Resolver) isSessionExcludedByRateLimit(ctx context.Context, session *model.Session) bool {
-	return !r.isItemIngestedByRate(ctx, session.CreatedAt, privateModel.ProductTypeSessions, session.ProjectID)
+	return !r.isItemIngestedByRate(ctx, privateModel.ProductTypeSessions, session.ProjectID)
 }
 
 func (r *Resolver) IsSessionExcludedByFilter(ctx context.Context, session *model.Session) bool {
@@ -314,13 +281,115 @@ func (r *Resolver) isItemIngestedBySample(ctx context.Context, product privateMo
 	return ingested
 }
 
-func (r *Resolver) isItemIngestedByRate(ctx context.Context, when time.Time, product privateModel.ProductType, projectID int) bool {
+// ingestCheck is a sampling or exclusion check of filterIngested, with the reason recorded for the items it drops.
+type ingestCheck[T any] struct {
+	reason privateModel.IngestReason
+	check  func(context.Context, T) bool
+}
+
+// filterIngested applies the sampling and exclusion checks to each item, in order,
+// then admits the remaining items by the rate limit of their project. The rate limit is applied
+// once per project for the whole batch rather than per item. The number of items dropped for
+// each reason is recorded on the span of the batch.
+func filterIngested[T any](ctx context.Context, r *Resolver, product privateModel.ProductType, items []T, projectID func(T) int, checks ...ingestCheck[T]) []T {
+	span, ctx := highlight.StartTrace(ctx,
+		"sampling.FilterIngested",
+		attribute.String("product", string(product)),
+		attribute.Int("count", len(items)),
+	)
+	defer span.End()
+
+	var candidates []T
+	dropped := map[privateModel.IngestReason]int{}
+	for _, item := range items {
+		if check, failed := lo.Find(checks, func(c ingestCheck[T]) bool {
+			return !c.check(ctx, item)
+		}); failed {
+			dropped[check.reason]++
+		} else {
+			candidates = append(candidates, item)
+		}
+	}
+
+	admitted := r.admitByRate(ctx, product, lo.Map(candidates, func(item T, _ int) int {
+		return projectID(item)
+	}))
+	ingested := lo.Filter(candidates, func(_ T, i int) bool {
+		return admitted[i]
+	})
+	dropped[privateModel.IngestReasonRate] = len(candidates) - len(ingested)
+
+	span.SetAttributes(attribute.Int("ingested", len(ingested)))
+	for _, reason := range privateModel.AllIngestReason {
+		span.SetAttributes(attribute.Int(string(reason), dropped[reason]))
+	}
+	return ingested
+}
+
+func (r *Resolver) isItemIngestedByRate(ctx context.Context, product privateModel.ProductType, projectID int) bool {
+	return r.admitByRate(ctx, product, []int{projectID})[0]
+}
+
+// admitByRate applies the per-minute rate limits of the projects to a batch of items,
+// where projectIDs holds the project of each item. Items of a project are admitted
+// in order with a single redis call per project. Returns whether each item was admitted.
+func (r *Resolver) admitByRate(ctx context.Context, product privateModel.ProductType, projectIDs []int) []bool {
+	span, ctx := highlight.StartTrace(ctx,
+		"sampling.AdmitByRate",
+		attribute.String("product", string(product)),
+		attribute.Int("count", len(projectIDs)),
+	)
+	defer span.End()
+
+	admitted := make([]bool, len(projectIDs))
+	counts := lo.CountValues(projectIDs)
+	now := time.Now()
+	var dropped, limitedProjects int
+	for projectID, count := range counts {
+		n := int64(count)
+		max := r.getRateLimit(ctx, product, projectID)
+		if max != nil {
+			var err error
+			n, err = r.Redis.AdmitByRateLimit(ctx, fmt.Sprintf("sampling-%d-%s", projectID, product.String()), *max, n, rateLimitWindow, now)
+			if err != nil {
+				log.WithContext(ctx).WithError(err).WithField("project_id", projectID).WithField("product", product).Error("failed to apply ingest rate limit")
+				n = int64(count)
+			}
+		}
+		if projectDropped := int64(count) - n; projectDropped > 0 {
+			dropped += int(projectDropped)
+			limitedProjects++
+			hmetric.Histogram(ctx, "sampling.rate_limit.dropped", float64(projectDropped), []attribute.KeyValue{
+				attribute.Int("project_id", projectID),
+				attribute.String("product", string(product)),
+			}, 1)
+		}
+		for i, p := range projectIDs {
+			if n <= 0 {
+				break
+			}
+			if p == projectID {
+				admitted[i] = true
+				n--
+			}
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("projects", len(counts)),
+		attribute.Int("dropped", dropped),
+		attribute.Int("rate_limited_projects", limitedProjects),
+	)
+	return admitted
+}
+
+func (r *Resolver) getRateLimit(ctx context.Context, product privateModel.ProductType, projectID int) *int64 {
 	settings, err := r.getSettings(ctx, projectID, nil)
 	if err != nil {
-		return true
+		return nil
 	}
 
-	max := func() *int64 {
+	return func() *int64 {
 		switch product {
 		case privateModel.ProductTypeSessions:
 			return settings.SessionMinuteRateLimit
@@ -335,11 +404,6 @@ func (r *Resolver) isItemIngestedByRate(ctx context.Context, when time.Time, pro
 		}
 		return nil
 	}()
-	if max == nil {
-		return true
-	}
-	ingested := r.isIngestedByRateLimit(ctx, fmt.Sprintf("sampling-%d-%s", projectID, product.String()), *max, when.Minute())
-	return ingested
 }
 
 func (r *Resolver) isItemIngestedByFilter(ctx context.Context, product privateModel.ProductType, projectID int, object interface{}) bool {
@@ -382,8 +446,8 @@ func (r *Resolver) isItemIngestedByFilter(ctx context.Context, product privateMo
 			filters := parser.Parse(query, clickhouse.TracesTableNoDefaultConfig)
 			return clickhouse.TraceMatchesQuery(object.(*clickhouse.TraceRow), filters)
 		case privateModel.ProductTypeMetrics:
-			// TODO(vkorolik) implement metrics querying
-			return false
+			filters := parser.Parse(query, clickhouse.MetricsTableConfig)
+			return clickhouse.MetricMatchesQuery(object.(clickhouse.MetricRow), filters)
 		}
 		return false
 	}()
@@ -409,23 +473,6 @@ func IsIngestedBySample(ctx context.Context, key string, rate float64) bool {
 	return sum < threshold
 }
 
-// isIngestedByRateLimit limits ingestion for a key at a max items per minute
-func (r *Resolver) isIngestedByRateLimit(ctx context.Context, key string, max int64, minute int) bool {
-	key = fmt.Sprintf("%s-%d", key, minute)
-
-	// based on https://redis.com/glossary/rate-limiting/
-	count, _ := r.Redis.Client.Get(ctx, key).Int64()
-
-	if count >= max {
-		return false
-	}
-
-	r.Redis.Client.Incr(ctx, key)
-	r.Redis.Client.Expire(ctx, key, 59*time.Second)
-
-	return true
-}
-
 func (r *Resolver) getSettings(ctx context.Context, projectID int, sessionSecureID *string) (*model.ProjectFilterSettings, error) {
 	if projectID == 0 {
 		if sessionSecureID == nil {
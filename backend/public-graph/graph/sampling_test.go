@@ -79,24 +79,43 @@ func Test_isIngestedBySample(t *testing.T) {
 	}
 }
 
-func Test_isIngestedByRate(t *testing.T) {
+func Test_AdmitByRateLimit(t *testing.T) {
 	r := Resolver{Redis: redis.NewClient()}
 	const N = 10_000
 	ctx := context.TODO()
+	// pin a consistent time throughout the test to avoid sliding the window
+	now := time.Now()
 	for max := int64(0); max < N; max += N / 10 {
-		// pin a consistent minute throughout the test to avoid hitting multiple different minute buckets
-		minute := time.Now().Minute()
 		_ = r.Redis.FlushDB(ctx)
 		var ingested int64 = 0
 		for i := 0; i < N; i++ {
-			if r.isIngestedByRateLimit(ctx, "test-project-1", max, minute) {
-				ingested += 1
-			}
+			admitted, err := r.Redis.AdmitByRateLimit(ctx, "test-project-1", max, 1, rateLimitWindow, now)
+			assert.NoError(t, err)
+			ingested += admitted
 		}
 		assert.LessOrEqualf(t, ingested, max, "expected ingested lte max, max %+v", max)
 	}
 }
 
+func Test_AdmitByRateLimit_Batch(t *testing.T) {
+	r := Resolver{Redis: redis.NewClient()}
+	ctx := context.TODO()
+	_ = r.Redis.FlushDB(ctx)
+
+	now := time.Now().Truncate(rateLimitWindow)
+	admitted, err := r.Redis.AdmitByRateLimit(ctx, "test-project-1", 100, 70, rateLimitWindow, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(70), admitted)
+	admitted, err = r.Redis.AdmitByRateLimit(ctx, "test-project-1", 100, 70, rateLimitWindow, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), admitted)
+
+	// halfway through the next window, half of the previous window still counts towards the limit
+	admitted, err = r.Redis.AdmitByRateLimit(ctx, "test-project-1", 100, 100, rateLimitWindow, now.Add(rateLimitWindow*3/2))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), admitted)
+}
+
 func Test_IsSessionExcluded(t *testing.T) {
 	ctx := context.TODO()
 
@@ -0,0 +1,282 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/parser"
+	"github.com/highlight-run/highlight/backend/parser/listener"
+	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
+	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
+	e "github.com/pkg/errors"
+	"github.com/samber/lo"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TailSamplingFlushLimit is the max number of traces decided per redis shard in one flush.
+const TailSamplingFlushLimit = 1000
+
+// TailSamplingRetryDelay delays the next flush of a trace whose spans failed to flush.
+const TailSamplingRetryDelay = 30 * time.Second
+
+const defaultTailSamplingWindow = 30 * time.Second
+
+type TailSamplingReason string
+
+const (
+	TailSamplingReasonError   TailSamplingReason = "error"
+	TailSamplingReasonLatency TailSamplingReason = "latency"
+	TailSamplingReasonQuery   TailSamplingReason = "query"
+	TailSamplingReasonRate    TailSamplingReason = "rate"
+)
+
+// TailSamplingRules decide whether a trace is kept once all of its spans are buffered.
+type TailSamplingRules struct {
+	KeepErrors       bool
+	LatencyThreshold *time.Duration
+	KeepFilters      listener.Filters
+	// ServiceRates is the rate of traces to keep by the service of the root span.
+	ServiceRates map[string]float64
+	DefaultRate  float64
+}
+
+func NewTailSamplingRules(ctx context.Context, settings *model.ProjectFilterSettings) TailSamplingRules {
+	rules := TailSamplingRules{
+		KeepErrors:  settings.TraceTailSamplingKeepErrors,
+		DefaultRate: settings.TraceSamplingRate,
+	}
+	if settings.TraceTailSamplingLatencyThresholdMs != nil {
+		threshold := time.Duration(*settings.TraceTailSamplingLatencyThresholdMs) * time.Millisecond
+		rules.LatencyThreshold = &threshold
+	}
+	if query := settings.TraceTailSamplingKeepQuery; query != nil && *query != "" {
+		rules.KeepFilters = parser.Parse(*query, clickhouse.TracesTableNoDefaultConfig)
+	}
+	if serviceRates := settings.TraceTailSamplingServiceRates; serviceRates != nil {
+		if err := json.Unmarshal([]byte(*serviceRates), &rules.ServiceRates); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("project_id", settings.ProjectID).Error("failed to parse trace tail sampling service rates")
+		}
+	}
+	return rules
+}
+
+// Keep returns whether the trace should be ingested and the rule that decided it.
+func (rules TailSamplingRules) Keep(ctx context.Context, traceKey string, traceRows []*clickhouse.TraceRow) (bool, TailSamplingReason) {
+	if len(traceRows) == 0 {
+		return false, TailSamplingReasonRate
+	}
+	if rules.KeepErrors && lo.SomeBy(traceRows, func(t *clickhouse.TraceRow) bool {
+		return t.HasErrors || t.StatusCode == "Error"
+	}) {
+		return true, TailSamplingReasonError
+	}
+	if rules.LatencyThreshold != nil && lo.SomeBy(traceRows, func(t *clickhouse.TraceRow) bool {
+		return time.Duration(t.Duration) >= *rules.LatencyThreshold
+	}) {
+		return true, TailSamplingReasonLatency
+	}
+	if len(rules.KeepFilters) > 0 && lo.SomeBy(traceRows, func(t *clickhouse.TraceRow) bool {
+		return clickhouse.TraceMatchesQuery(t, rules.KeepFilters)
+	}) {
+		return true, TailSamplingReasonQuery
+	}
+
+	root, found := lo.Find(traceRows, func(t *clickhouse.TraceRow) bool {
+		return t.ParentSpanId == ""
+	})
+	if !found {
+		root = traceRows[0]
+	}
+	rate, ok := rules.ServiceRates[root.ServiceName]
+	if !ok {
+		rate = rules.DefaultRate
+	}
+	return IsIngestedBySample(ctx, traceKey, rate), TailSamplingReasonRate
+}
+
+// IsTraceTailSampled returns whether the spans of the project are buffered for a tail sampling decision.
+func (r *Resolver) IsTraceTailSampled(ctx context.Context, projectID int) bool {
+	settings, err := r.getSettings(ctx, projectID, nil)
+	if err != nil {
+		return false
+	}
+	return settings.TraceTailSamplingEnabled
+}
+
+// BufferTraceSpans holds the spans of a trace in redis until the tail sampling window of the trace elapses.
+// Spans arriving after the trace is decided follow the cached decision instead.
+func (r *Resolver) BufferTraceSpans(ctx context.Context, traceKey string, traceRows []*clickhouse.TraceRow) error {
+	if len(traceRows) == 0 {
+		return nil
+	}
+	decisions, err := r.Redis.GetTailSamplingDecisions(ctx, traceKey)
+	if err != nil {
+		return e.Wrap(err, "failed to read tail sampling decisions")
+	}
+
+	var kept, buffered []*clickhouse.TraceRow
+	for _, traceRow := range traceRows {
+		if keep, decided := decisions[int(traceRow.ProjectId)]; !decided {
+			buffered = append(buffered, traceRow)
+		} else if keep {
+			kept = append(kept, traceRow)
+		}
+	}
+	if err := r.submitTailSampledSpans(ctx, traceKey, kept); err != nil {
+		return err
+	}
+	if len(buffered) == 0 {
+		return nil
+	}
+
+	// the spans of each project are decided once the tail sampling window of the project elapses
+	for projectID, projectRows := range lo.GroupBy(buffered, func(t *clickhouse.TraceRow) uint32 {
+		return t.ProjectId
+	}) {
+		// spans are keyed by span id so that a retried export does not buffer them twice
+		spans := map[string][]byte{}
+		for _, traceRow := range projectRows {
+			span, err := json.Marshal(traceRow)
+			if err != nil {
+				return e.Wrap(err, "failed to serialize trace span")
+			}
+			spans[traceRow.SpanId] = span
+		}
+		if err := r.Redis.AddTailSampledSpans(ctx, tailSamplingBufferKey(int(projectID), traceKey), r.tailSamplingWindow(ctx, int(projectID)), spans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tailSamplingBufferKey identifies the buffered spans of a project in a trace.
+func tailSamplingBufferKey(projectID int, traceKey string) string {
+	return fmt.Sprintf("%d:%s", projectID, traceKey)
+}
+
+// tailSamplingTraceKey returns the trace of a buffer key. Spans buffered by trace only are keyed by the trace.
+func tailSamplingTraceKey(bufferKey string) string {
+	project, traceKey, found := strings.Cut(bufferKey, ":")
+	if _, err := strconv.Atoi(project); !found || err != nil {
+		return bufferKey
+	}
+	return traceKey
+}
+
+func (r *Resolver) tailSamplingWindow(ctx context.Context, projectID int) time.Duration {
+	if settings, err := r.getSettings(ctx, projectID, nil); err == nil && settings.TraceTailSamplingWindowSeconds > 0 {
+		return time.Duration(settings.TraceTailSamplingWindowSeconds) * time.Second
+	}
+	return defaultTailSamplingWindow
+}
+
+// FlushTailSampledTraces decides the traces whose tail sampling window has elapsed, by project,
+// and submits the spans of kept traces to the traces queue. Returns the number of traces decided.
+func (r *Resolver) FlushTailSampledTraces(ctx context.Context) (int, error) {
+	bufferKeys, err := r.Redis.GetTailSampledTracesToProcess(ctx, TailSamplingFlushLimit)
+	for _, bufferKey := range bufferKeys {
+		if err := r.flushTailSampledTrace(ctx, bufferKey); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("trace_key", bufferKey).Error("failed to flush tail sampled trace")
+			// the spans are still buffered, so the trace is decided again later
+			if err := r.Redis.RetryTailSampledTrace(ctx, bufferKey, TailSamplingRetryDelay); err != nil {
+				log.WithContext(ctx).WithError(err).WithField("trace_key", bufferKey).Error("failed to retry tail sampled trace")
+			}
+		}
+	}
+	return len(bufferKeys), err
+}
+
+// flushTailSampledTrace decides the buffered spans of a trace and submits the kept ones. The spans are only
+// removed from the buffer once they are submitted.
+func (r *Resolver) flushTailSampledTrace(ctx context.Context, bufferKey string) error {
+	spans, err := r.Redis.GetTailSampledSpans(ctx, bufferKey)
+	if err != nil {
+		return err
+	}
+	traceKey := tailSamplingTraceKey(bufferKey)
+
+	var traceRows []*clickhouse.TraceRow
+	for _, span := range spans {
+		var traceRow clickhouse.TraceRow
+		if err := json.Unmarshal([]byte(span), &traceRow); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("trace_key", traceKey).Error("failed to deserialize tail sampled span")
+			continue
+		}
+		traceRows = append(traceRows, &traceRow)
+	}
+
+	// spans buffered while the trace was being decided are flushed again with the same decision
+	decisions, err := r.Redis.GetTailSamplingDecisions(ctx, traceKey)
+	if err != nil {
+		log.WithContext(ctx).WithError(err).WithField("trace_key", traceKey).Error("failed to read tail sampling decisions")
+		decisions = map[int]bool{}
+	}
+
+	var kept []*clickhouse.TraceRow
+	for projectID, projectRows := range lo.GroupBy(traceRows, func(t *clickhouse.TraceRow) uint32 {
+		return t.ProjectId
+	}) {
+		keep, decided := decisions[int(projectID)]
+		if !decided {
+			settings, err := r.getSettings(ctx, int(projectID), nil)
+			if err != nil {
+				kept = append(kept, projectRows...)
+				continue
+			}
+			var reason TailSamplingReason
+			keep, reason = NewTailSamplingRules(ctx, settings).Keep(ctx, traceKey, projectRows)
+			hmetric.Incr(ctx, "sampling.tail.decision", []attribute.KeyValue{
+				attribute.Int("project_id", int(projectID)),
+				attribute.Bool("kept", keep),
+				attribute.String("reason", string(reason)),
+			}, 1)
+			if err := r.Redis.SetTailSamplingDecision(ctx, traceKey, int(projectID), r.tailSamplingWindow(ctx, int(projectID)), keep); err != nil {
+				log.WithContext(ctx).WithError(err).WithField("trace_key", traceKey).Error("failed to cache tail sampling decision")
+			}
+		}
+		if keep {
+			kept = append(kept, projectRows...)
+		}
+	}
+	if err := r.submitTailSampledSpans(ctx, traceKey, kept); err != nil {
+		return err
+	}
+	// the spans are submitted, so a failed removal is not retried as that would submit them again
+	if err := r.Redis.RemoveTailSampledSpans(ctx, bufferKey, lo.Keys(spans)); err != nil {
+		log.WithContext(ctx).WithError(err).WithField("trace_key", bufferKey).Error("failed to remove flushed tail sampled spans")
+	}
+	return nil
+}
+
+// submitTailSampledSpans submits the spans of a kept trace to the traces queue.
+func (r *Resolver) submitTailSampledSpans(ctx context.Context, traceKey string, kept []*clickhouse.TraceRow) error {
+	kept = filterIngested(ctx, r, privateModel.ProductTypeTraces, kept, func(trace *clickhouse.TraceRow) int {
+		return int(trace.ProjectId)
+	}, ingestCheck[*clickhouse.TraceRow]{privateModel.IngestReasonFilter, r.IsTraceIngestedByFilter})
+	if len(kept) == 0 {
+		return nil
+	}
+
+	var messages []kafka_queue.RetryableMessage
+	for _, traceRow := range kept {
+		// create service record for any services found in ingested traces
+		if traceRow.ServiceName != "" {
+			if _, err := r.Store.UpsertService(ctx, int(traceRow.ProjectId), traceRow.ServiceName, traceRow.TraceAttributes); err != nil {
+				log.WithContext(ctx).Error(e.Wrap(err, "failed to upsert service from trace"))
+			}
+		}
+		messages = append(messages, &kafka_queue.TraceRowMessage{
+			Type:               kafka_queue.PushTracesFlattened,
+			ClickhouseTraceRow: clickhouse.ConvertTraceRow(traceRow),
+		})
+	}
+	return r.TracesQueue.Submit(ctx, traceKey, messages...)
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/highlight-run/highlight/backend/clickhouse"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTailSamplingRules_Keep(t *testing.T) {
+	ctx := context.TODO()
+	settings := &model.ProjectFilterSettings{
+		TraceSamplingRate:                   0,
+		TraceTailSamplingKeepErrors:         true,
+		TraceTailSamplingLatencyThresholdMs: pointy.Int64(500),
+		TraceTailSamplingKeepQuery:          pointy.String("http.route:/checkout"),
+		TraceTailSamplingServiceRates:       pointy.String(`{"frontend": 1}`),
+	}
+	rules := NewTailSamplingRules(ctx, settings)
+
+	root := func(service string) *clickhouse.TraceRow {
+		return &clickhouse.TraceRow{SpanId: "root", ServiceName: service, Duration: int64(10 * time.Millisecond)}
+	}
+	child := func() *clickhouse.TraceRow {
+		return &clickhouse.TraceRow{SpanId: "child", ParentSpanId: "root", ServiceName: "db", Duration: int64(time.Millisecond)}
+	}
+
+	for name, tc := range map[string]struct {
+		rows     []*clickhouse.TraceRow
+		expected bool
+		reason   TailSamplingReason
+	}{
+		"healthy trace dropped by default rate": {
+			rows:     []*clickhouse.TraceRow{root("api"), child()},
+			expected: false,
+			reason:   TailSamplingReasonRate,
+		},
+		"error in child span": {
+			rows: []*clickhouse.TraceRow{root("api"), func() *clickhouse.TraceRow {
+				c := child()
+				c.StatusCode = "Error"
+				return c
+			}()},
+			expected: true,
+			reason:   TailSamplingReasonError,
+		},
+		"slow span": {
+			rows: []*clickhouse.TraceRow{root("api"), func() *clickhouse.TraceRow {
+				c := child()
+				c.Duration = int64(time.Second)
+				return c
+			}()},
+			expected: true,
+			reason:   TailSamplingReasonLatency,
+		},
+		"matches keep query": {
+			rows: []*clickhouse.TraceRow{func() *clickhouse.TraceRow {
+				r := root("api")
+				r.TraceAttributes = map[string]string{"http.route": "/checkout"}
+				return r
+			}(), child()},
+			expected: true,
+			reason:   TailSamplingReasonQuery,
+		},
+		"service rate of the root span": {
+			rows:     []*clickhouse.TraceRow{child(), root("frontend")},
+			expected: true,
+			reason:   TailSamplingReasonRate,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			keep, reason := rules.Keep(ctx, "trace-key", tc.rows)
+			assert.Equal(t, tc.expected, keep)
+			assert.Equal(t, tc.reason, reason)
+		})
+	}
+}
+
+func TestTailSamplingRules_KeepErrorsDisabled(t *testing.T) {
+	ctx := context.TODO()
+	rules := NewTailSamplingRules(ctx, &model.ProjectFilterSettings{TraceSamplingRate: 0})
+	keep, reason := rules.Keep(ctx, "trace-key", []*clickhouse.TraceRow{{HasErrors: true}})
+	assert.False(t, keep)
+	assert.Equal(t, TailSamplingReasonRate, reason)
+}
+
+func TestTailSamplingBufferKey(t *testing.T) {
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tailSamplingTraceKey(tailSamplingBufferKey(1, "4bf92f3577b34da6a3ce929d0e0e4736")))
+	// spans buffered before they were keyed by project
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tailSamplingTraceKey("4bf92f3577b34da6a3ce929d0e0e4736"))
+	assert.NotEqual(t, tailSamplingBufferKey(1, "trace"), tailSamplingBufferKey(2, "trace"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"sort"
 	"strconv"
 	"time"
@@ -74,6 +75,51 @@ func SessionFieldsKey(sessionSecureId string) string {
 	return fmt.Sprintf("session-fields-%s", sessionSecureId)
 }
 
+// TailSamplingShards spreads the traces awaiting a tail sampling decision over several sorted sets
+// to avoid a single hot key.
+const TailSamplingShards = 16
+
+func TailSamplingSpansKey(traceKey string) string {
+	return fmt.Sprintf("tail-sampling-spans-%s", traceKey)
+}
+
+func TailSamplingDecisionKey(traceKey string) string {
+	return fmt.Sprintf("tail-sampling-decision-%s", traceKey)
+}
+
+func TailSamplingTracesKey(shard int) string {
+	return fmt.Sprintf("tail-sampling-traces-%d", shard)
+}
+
+func tailSamplingShard(traceKey string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(traceKey))
+	return int(h.Sum32() % TailSamplingShards)
+}
+
+// RateLimitKey is the counter of the rate limit window starting at windowStart.
+// The key is hash tagged so that the current and previous windows live in the same cluster slot.
+func RateLimitKey(key string, windowStart time.Time) string {
+	return fmt.Sprintf("{%s}-%d", key, windowStart.Unix())
+}
+
+func AlertEvaluationClaimKey(kind string, alertID int, tick time.Time) string {
+	return fmt.Sprintf("alert-evaluation-claim-%s-%d-%d", kind, alertID, tick.Unix())
+}
+
+func AlertEvaluationLastTickKey(kind string, alertID int) string {
+	return fmt.Sprintf("alert-evaluation-last-tick-%s-%d", kind, alertID)
+}
+
+// LiveTailChannel is the pub/sub channel of the rows of a project ingested while it is being tailed.
+func LiveTailChannel(productType model.PricingProductType, projectID int) string {
+	return fmt.Sprintf("live-tail-%s-%d", productType, projectID)
+}
+
+func LiveTailActiveKey(productType model.PricingProductType, projectID int) string {
+	return fmt.Sprintf("live-tail-active-%s-%d", productType, projectID)
+}
+
 func NewClient() *Client {
 	var lfu cache.LocalCache
 	// disable lfu cache locally to allow flushing cache between test-cases
@@ -696,6 +742,248 @@ func (r *Client) AcquireLock(_ context.Context, key string, timeout time.Duratio
 	return mutex, mutex.Lock()
 }
 
+// Admits up to n items using a sliding window counter: the count of the previous window
+// is weighted by how much of it still overlaps the sliding window ending now.
+var slidingWindowAdmit = redis.NewScript(`
+	local current = KEYS[1]
+	local previous = KEYS[2]
+	local max = tonumber(ARGV[1])
+	local n = tonumber(ARGV[2])
+	local previousWeight = tonumber(ARGV[3])
+	local ttl = tonumber(ARGV[4])
+
+	local count = tonumber(redis.call("GET", current) or "0")
+	local previousCount = tonumber(redis.call("GET", previous) or "0")
+	local available = max - count - math.floor(previousCount * previousWeight)
+
+	local admitted = math.max(0, math.min(n, available))
+	if admitted > 0 then
+		redis.call("INCRBY", current, admitted)
+		redis.call("EXPIRE", current, ttl)
+	end
+
+	return admitted
+`)
+
+// AdmitByRateLimit atomically admits up to n items for the key so that at most max items
+// are admitted in any sliding window of the given duration. Returns the number of items admitted.
+func (r *Client) AdmitByRateLimit(ctx context.Context, key string, max int64, n int64, window time.Duration, now time.Time) (int64, error) {
+	if n <= 0 {
+		return 0, nil
+	}
+	windowStart := now.Truncate(window)
+	previousWeight := 1 - float64(now.Sub(windowStart))/float64(window)
+
+	keys := []string{RateLimitKey(key, windowStart), RateLimitKey(key, windowStart.Add(-window))}
+	// the counter must outlive the following window, which weighs it as its previous window
+	values := []interface{}{max, n, strconv.FormatFloat(previousWeight, 'f', -1, 64), int64((2 * window).Seconds())}
+	return slidingWindowAdmit.Run(ctx, r.Client, keys, values...).Int64()
+}
+
+// AddTailSampledSpans buffers spans of a trace, keyed by span id, until its tail sampling decision.
+// Buffering a span again, ie. when an export is retried, replaces it rather than duplicating it.
+// The decision is due one window after the first span of the trace is buffered.
+func (r *Client) AddTailSampledSpans(ctx context.Context, traceKey string, window time.Duration, spans map[string][]byte) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	spansKey := TailSamplingSpansKey(traceKey)
+	var values []interface{}
+	for spanID, span := range spans {
+		values = append(values, spanID, span)
+	}
+	_, err := r.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, spansKey, values...)
+		// keep the spans around long enough for a delayed decision, but not forever
+		p.Expire(ctx, spansKey, 10*window)
+		p.ZAddNX(ctx, TailSamplingTracesKey(tailSamplingShard(traceKey)), redis.Z{
+			Score:  float64(time.Now().Add(window).Unix()),
+			Member: traceKey,
+		})
+		return nil
+	})
+	return err
+}
+
+// Removes and returns up to `limit` traces of a shard whose tail sampling decision is due.
+var zPopRangeByScore = redis.NewScript(`
+	local key = KEYS[1]
+	local now = ARGV[1]
+	local limit = ARGV[2]
+	local range = redis.call("ZRANGEBYSCORE", key, 0, now, "LIMIT", 0, limit)
+	if #range > 0 then
+		redis.call("ZREM", key, unpack(range))
+	end
+	return range
+`)
+
+// GetTailSampledTracesToProcess returns up to `limit` traces per shard whose tail sampling decision is due.
+// Each trace is returned once, so the decisions may be made by several workers concurrently.
+func (r *Client) GetTailSampledTracesToProcess(ctx context.Context, limit int) ([]string, error) {
+	var traceKeys []string
+	for shard := 0; shard < TailSamplingShards; shard++ {
+		keys, err := zPopRangeByScore.Run(ctx, r.Client, []string{TailSamplingTracesKey(shard)}, time.Now().Unix(), limit).StringSlice()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return traceKeys, err
+		}
+		traceKeys = append(traceKeys, keys...)
+	}
+	return traceKeys, nil
+}
+
+// SetTailSamplingDecision caches whether a project keeps a trace, so that spans arriving after the decision
+// share it. The decision is kept as long as the buffered spans of the trace.
+func (r *Client) SetTailSamplingDecision(ctx context.Context, traceKey string, projectID int, window time.Duration, keep bool) error {
+	decisionKey := TailSamplingDecisionKey(traceKey)
+	_, err := r.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, decisionKey, strconv.Itoa(projectID), keep)
+		p.Expire(ctx, decisionKey, 10*window)
+		return nil
+	})
+	return err
+}
+
+// GetTailSamplingDecisions returns the cached tail sampling decisions of a trace by project.
+func (r *Client) GetTailSamplingDecisions(ctx context.Context, traceKey string) (map[int]bool, error) {
+	values, err := r.Client.HGetAll(ctx, TailSamplingDecisionKey(traceKey)).Result()
+	if err != nil {
+		return nil, err
+	}
+	decisions := map[int]bool{}
+	for field, value := range values {
+		projectID, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		decisions[projectID] = value == "1"
+	}
+	return decisions, nil
+}
+
+// GetTailSampledSpans returns the buffered spans of a trace by span id. The spans are kept until
+// RemoveTailSampledSpans is called once they are flushed, so that a failed flush does not lose them.
+func (r *Client) GetTailSampledSpans(ctx context.Context, traceKey string) (map[string]string, error) {
+	return r.Client.HGetAll(ctx, TailSamplingSpansKey(traceKey)).Result()
+}
+
+// RemoveTailSampledSpans removes the buffered spans of a trace, as returned by GetTailSampledSpans.
+// Spans buffered since they were read are kept for the next decision.
+func (r *Client) RemoveTailSampledSpans(ctx context.Context, traceKey string, spanIDs []string) error {
+	if len(spanIDs) == 0 {
+		return nil
+	}
+	return r.Client.HDel(ctx, TailSamplingSpansKey(traceKey), spanIDs...).Err()
+}
+
+// RetryTailSampledTrace schedules the tail sampling decision of a trace again after a failed flush.
+func (r *Client) RetryTailSampledTrace(ctx context.Context, traceKey string, delay time.Duration) error {
+	return r.Client.ZAddNX(ctx, TailSamplingTracesKey(tailSamplingShard(traceKey)), redis.Z{
+		Score:  float64(time.Now().Add(delay).Unix()),
+		Member: traceKey,
+	}).Err()
+}
+
+// ClaimAlertEvaluation takes the lease of an alert evaluation tick for the owner. Only one owner
+// claims each tick until the lease expires, so each tick is evaluated by a single worker.
+func (r *Client) ClaimAlertEvaluation(ctx context.Context, kind string, alertID int, tick time.Time, owner string, expiry time.Duration) (bool, error) {
+	claimed, err := r.Client.SetNX(ctx, AlertEvaluationClaimKey(kind, alertID, tick), owner, expiry).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "error claiming alert evaluation")
+	}
+	return claimed, nil
+}
+
+// Extends the expiry of a lease, if it is still held by the owner.
+var renewLease = redis.NewScript(`
+	local key = KEYS[1]
+	local owner = ARGV[1]
+	local expiry = tonumber(ARGV[2])
+	if redis.call("GET", key) == owner then
+		return redis.call("PEXPIRE", key, expiry)
+	end
+	return 0
+`)
+
+// Deletes a lease, if it is still held by the owner.
+var releaseLease = redis.NewScript(`
+	local key = KEYS[1]
+	local owner = ARGV[1]
+	if redis.call("GET", key) == owner then
+		return redis.call("DEL", key)
+	end
+	return 0
+`)
+
+// RenewAlertEvaluation extends the lease of an alert evaluation tick while it is being evaluated.
+// Returns false if the lease expired and is no longer held by the owner.
+func (r *Client) RenewAlertEvaluation(ctx context.Context, kind string, alertID int, tick time.Time, owner string, expiry time.Duration) (bool, error) {
+	renewed, err := renewLease.Run(ctx, r.Client, []string{AlertEvaluationClaimKey(kind, alertID, tick)}, owner, expiry.Milliseconds()).Int64()
+	if err != nil {
+		return false, errors.Wrap(err, "error renewing alert evaluation")
+	}
+	return renewed == 1, nil
+}
+
+// ReleaseAlertEvaluation gives up the lease of an alert evaluation tick held by the owner so that it can be claimed again.
+func (r *Client) ReleaseAlertEvaluation(ctx context.Context, kind string, alertID int, tick time.Time, owner string) error {
+	if err := releaseLease.Run(ctx, r.Client, []string{AlertEvaluationClaimKey(kind, alertID, tick)}, owner).Err(); err != nil {
+		return errors.Wrap(err, "error releasing alert evaluation")
+	}
+	return nil
+}
+
+// GetLastAlertEvaluationTick returns the last evaluated tick of an alert, or nil if it was never evaluated.
+func (r *Client) GetLastAlertEvaluationTick(ctx context.Context, kind string, alertID int) (*time.Time, error) {
+	val, err := r.getString(ctx, AlertEvaluationLastTickKey(kind, alertID))
+	if err != nil || val == "" {
+		return nil, err
+	}
+	seconds, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing last alert evaluation tick")
+	}
+	tick := time.Unix(seconds, 0)
+	return &tick, nil
+}
+
+func (r *Client) SetLastAlertEvaluationTick(ctx context.Context, kind string, alertID int, tick time.Time, expiry time.Duration) error {
+	return set(ctx, r, AlertEvaluationLastTickKey(kind, alertID), tick.Unix(), expiry)
+}
+
+// SetLiveTailActive marks a project as tailed until the expiry, so that ingest publishes its rows.
+// Tails refresh the flag while connected.
+func (r *Client) SetLiveTailActive(ctx context.Context, productType model.PricingProductType, projectID int, expiry time.Duration) error {
+	return r.setFlag(ctx, LiveTailActiveKey(productType, projectID), true, expiry)
+}
+
+func (r *Client) IsLiveTailActive(ctx context.Context, productType model.PricingProductType, projectID int) (bool, error) {
+	return r.getFlag(ctx, LiveTailActiveKey(productType, projectID))
+}
+
+func (r *Client) PublishLiveTail(ctx context.Context, productType model.PricingProductType, projectID int, payload []byte) error {
+	if err := r.Client.Publish(ctx, LiveTailChannel(productType, projectID), payload).Err(); err != nil {
+		return errors.Wrap(err, "error publishing live tail rows")
+	}
+	return nil
+}
+
+// SubscribeLiveTail subscribes to the rows published for a tailed project. The subscription must be closed by the caller.
+func (r *Client) SubscribeLiveTail(ctx context.Context, productType model.PricingProductType, projectID int) (*redis.PubSub, error) {
+	subscriber, ok := r.Client.(interface {
+		Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	})
+	if !ok {
+		return nil, errors.New("redis client does not support pub/sub")
+	}
+	pubsub := subscriber.Subscribe(ctx, LiveTailChannel(productType, projectID))
+	// wait for the subscription to be confirmed so that no rows published afterwards are missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, errors.Wrap(err, "error subscribing to live tail")
+	}
+	return pubsub, nil
+}
+
 func (r *Client) FlushDB(ctx context.Context) error {
 	if env.IsDevOrTestEnv() {
 		return r.Client.FlushAll(ctx).Err()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/go-redsync/redsync/v4"
 	"github.com/openlyinc/pointy"
+	"github.com/samber/lo"
 	e "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
@@ -51,6 +52,46 @@ func Test_CachedEval(t *testing.T) {
 	assert.NotEqualValues(t, e1, e2)
 }
 
+func Test_TailSamplingDecision(t *testing.T) {
+	ctx := context.TODO()
+	r := NewClient()
+	traceKey, _ := randomString()
+
+	decisions, err := r.GetTailSamplingDecisions(ctx, *traceKey)
+	assert.NoError(t, err)
+	assert.Empty(t, decisions)
+
+	assert.NoError(t, r.SetTailSamplingDecision(ctx, *traceKey, 1, time.Minute, true))
+	assert.NoError(t, r.SetTailSamplingDecision(ctx, *traceKey, 2, time.Minute, false))
+	decisions, err = r.GetTailSamplingDecisions(ctx, *traceKey)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 2: false}, decisions)
+
+	ttl, err := r.Client.TTL(ctx, TailSamplingDecisionKey(*traceKey)).Result()
+	assert.NoError(t, err)
+	assert.Greater(t, ttl, 9*time.Minute)
+}
+
+func Test_TailSampledSpans(t *testing.T) {
+	ctx := context.TODO()
+	r := NewClient()
+	traceKey, _ := randomString()
+
+	assert.NoError(t, r.AddTailSampledSpans(ctx, *traceKey, time.Minute, map[string][]byte{"1": []byte("a"), "2": []byte("b")}))
+	// a retried export buffers the same spans again
+	assert.NoError(t, r.AddTailSampledSpans(ctx, *traceKey, time.Minute, map[string][]byte{"1": []byte("a")}))
+	spans, err := r.GetTailSampledSpans(ctx, *traceKey)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": "a", "2": "b"}, spans)
+
+	// spans buffered after the read are kept once the read spans are flushed
+	assert.NoError(t, r.AddTailSampledSpans(ctx, *traceKey, time.Minute, map[string][]byte{"3": []byte("c")}))
+	assert.NoError(t, r.RemoveTailSampledSpans(ctx, *traceKey, lo.Keys(spans)))
+	spans, err = r.GetTailSampledSpans(ctx, *traceKey)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"3": "c"}, spans)
+}
+
 func TestLock(t *testing.T) {
 	r := NewClient()
 
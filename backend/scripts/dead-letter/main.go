@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	log "github.com/sirupsen/logrus"
+)
+
+// Lists, inspects and re-submits kafka messages that exhausted their retries.
+//
+//	go run ./scripts/dead-letter -action list -topic default -since 24h
+//	go run ./scripts/dead-letter -action inspect -offset 1234
+//	go run ./scripts/dead-letter -action replay -type 5 -since 2h
+func main() {
+	ctx := context.TODO()
+
+	action := flag.String("action", "list", "one of list, inspect or replay")
+	topicType := flag.String("topic", string(kafkaqueue.TopicTypeDefault), "the topic type whose dead letters to read")
+	since := flag.Duration("since", 24*time.Hour, "read dead letters from this long ago; ignored if -start is set")
+	startFlag := flag.String("start", "", "RFC3339 start of the time range")
+	endFlag := flag.String("end", "", "RFC3339 end of the time range; defaults to now")
+	payloadType := flag.Int("type", -1, "only include dead letters with this payload type")
+	offset := flag.Int64("offset", -1, "only include the dead letter at this dead letter topic offset")
+	flag.Parse()
+
+	end := time.Now()
+	start := end.Add(-*since)
+	var err error
+	if *startFlag != "" {
+		if start, err = time.Parse(time.RFC3339, *startFlag); err != nil {
+			log.WithContext(ctx).Fatal(err)
+		}
+	}
+	if *endFlag != "" {
+		if end, err = time.Parse(time.RFC3339, *endFlag); err != nil {
+			log.WithContext(ctx).Fatal(err)
+		}
+	}
+
+	// list and inspect only read the dead letter topic, without joining the topic's consumer group
+	var mode kafkaqueue.Mode
+	if *action == "replay" {
+		mode = kafkaqueue.Producer
+	} else if *action != "list" && *action != "inspect" {
+		log.WithContext(ctx).Fatalf("unknown action %s", *action)
+	}
+	k := kafkaqueue.New(ctx, kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicType(*topicType)}), mode, nil)
+	defer k.Stop(ctx)
+
+	count := 0
+	err = k.ReadDeadLetters(ctx, start, end, func(dl *kafkaqueue.DeadLetter) error {
+		if *payloadType >= 0 && dl.Type != *payloadType {
+			return nil
+		}
+		if *offset >= 0 && dl.DeadLetterOffset != *offset {
+			return nil
+		}
+		count++
+		switch *action {
+		case "list":
+			log.WithContext(ctx).Infof("%d: %s", dl.DeadLetterOffset, dl)
+		case "inspect":
+			fmt.Printf("%s\nkey: %s\nheaders:\n", dl, dl.Key)
+			for _, h := range dl.Headers {
+				fmt.Printf("\t%s: %s\n", h.Key, h.Value)
+			}
+			fmt.Printf("value:\n%s\n", dl.Value)
+		case "replay":
+			if err := k.Redeliver(ctx, dl); err != nil {
+				return err
+			}
+			log.WithContext(ctx).Infof("redelivered %d: %s", dl.DeadLetterOffset, dl)
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithContext(ctx).Fatal(err)
+	}
+	log.WithContext(ctx).Infof("%s: %d dead letters between %s and %s", *action, count, start, end)
+}
@@ -21,6 +21,9 @@ const Python Language = "python"
 const Golang Language = "golang"
 const DotNET Language = "dotnet"
 const Ruby Language = "ruby"
+const JVM Language = "jvm"
+const PHP Language = "php"
+const Rust Language = "rust"
 
 var (
 	jsPattern               = regexp.MustCompile(` {4}at ((.+) )?\(?(.+):(\d+):(\d+)\)?`)
@@ -38,6 +41,20 @@ var (
 	dotnetExceptionPattern  = regexp.MustCompile(`^([\w.]+: .+?)( at .+)?$`)
 	dotnetFilePattern       = regexp.MustCompile(`^\s*at (.+?)(?: in (.+?)(?::line (\d+))?)?$`)
 	generalPattern          = regexp.MustCompile(`^(.+)`)
+	// at com.foo.Bar.baz(Bar.java:42), optionally prefixed by a module or class loader and suffixed by a jar
+	jvmFramePattern      = regexp.MustCompile(`^\s*at (?:\S*/)?([^\s/(]+)\((Native Method|Unknown Source|[^\s():]+)(?::(\d+))?\)(?: ~?\[.*])?\s*$`)
+	jvmDetectPattern     = regexp.MustCompile(`(?m)^\s*at (?:\S*/)?[^\s/(]+\((?:Native Method|Unknown Source|[^\s():]+(?::\d+)?)\)`)
+	jvmCausePattern      = regexp.MustCompile(`^Caused by: (.+)$`)
+	jvmSuppressedPattern = regexp.MustCompile(`^\s+Suppressed: `)
+	jvmMorePattern       = regexp.MustCompile(`^\s*\.\.\. \d+ (more|common frames omitted)$`)
+	phpFramePattern      = regexp.MustCompile(`^#\d+ (?:(.+?)\((\d+)\)|\[internal function]|\{main}): ?(.*)$`)
+	phpMainPattern       = regexp.MustCompile(`^#\d+ \{main}$`)
+	phpDetectPattern     = regexp.MustCompile(`(?m)^#\d+ (?:.+\(\d+\)|\[internal function]): `)
+	phpNextPattern       = regexp.MustCompile(`^Next (.+)$`)
+	phpThrownPattern     = regexp.MustCompile(`^\s*thrown in .+ on line \d+$`)
+	rustFramePattern     = regexp.MustCompile(`^\s*\d+:\s+(?:0x[0-9a-f]+ - )?(.+)$`)
+	rustLocationPattern  = regexp.MustCompile(`^\s+at (.+?):(\d+)(?::(\d+))?$`)
+	rustDetectPattern    = regexp.MustCompile(`(?m)^\s*\d+:\s+(?:0x[0-9a-f]+ - )?.+\n\s+at .+:\d+(?::\d+)?$`)
 )
 
 // StructureOTELStackTrace processes a backend opentelemetry stacktrace into a structured ErrorTraces.
@@ -55,6 +72,21 @@ func StructureOTELStackTrace(stackTrace string, opts ...StructureStackTraceOptio
 	var language Language
 	if m := dotnetCSPattern.Find([]byte(stackTrace)); m != nil {
 		language = DotNET
+	} else if jvmDetectPattern.MatchString(stackTrace) {
+		language = JVM
+	} else if phpDetectPattern.MatchString(stackTrace) {
+		language = PHP
+	} else if rustDetectPattern.MatchString(stackTrace) {
+		language = Rust
+	}
+
+	switch language {
+	case JVM:
+		return structureJVMStackTrace(stackTrace), nil
+	case PHP:
+		return structurePHPStackTrace(stackTrace), nil
+	case Rust:
+		return structureRustStackTrace(stackTrace), nil
 	}
 
 	var errMsg string
@@ -197,6 +229,163 @@ func StructureOTELStackTrace(stackTrace string, opts ...StructureStackTraceOptio
 	return frames, nil
 }
 
+// structureJVMStackTrace processes a Java / Kotlin / Scala stacktrace, including its `Caused by:` chain.
+// Each frame references the message of the exception it belongs to. The root cause is the deepest,
+// so its frames are returned first, followed by the frames of the exceptions that wrap it.
+// Frames elided by `... N more` are already part of the enclosing exception and suppressed exceptions are skipped.
+func structureJVMStackTrace(stackTrace string) []*publicModel.ErrorTrace {
+	var segments [][]*publicModel.ErrorTrace
+	var errMsg *string
+	var suppressed bool
+	for _, line := range strings.Split(stackTrace, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || jvmMorePattern.MatchString(line) {
+			continue
+		}
+		if jvmSuppressedPattern.MatchString(line) {
+			suppressed = true
+			continue
+		}
+		if matches := jvmCausePattern.FindStringSubmatch(line); matches != nil {
+			suppressed = false
+			errMsg = pointy.String(matches[1])
+			segments = append(segments, []*publicModel.ErrorTrace{})
+			continue
+		}
+		if suppressed {
+			continue
+		}
+		if matches := jvmFramePattern.FindStringSubmatch(line); matches != nil {
+			if errMsg == nil {
+				errMsg = pointy.String("")
+			}
+			if len(segments) == 0 {
+				segments = append(segments, []*publicModel.ErrorTrace{})
+			}
+			frame := &publicModel.ErrorTrace{
+				Error:        errMsg,
+				FunctionName: pointy.String(matches[1]),
+			}
+			if matches[2] != "Native Method" && matches[2] != "Unknown Source" {
+				frame.FileName = pointy.String(matches[2])
+			}
+			if matches[3] != "" {
+				l, _ := strconv.ParseInt(matches[3], 10, 32)
+				frame.LineNumber = pointy.Int(int(l))
+			}
+			segments[len(segments)-1] = append(segments[len(segments)-1], frame)
+			continue
+		}
+		if errMsg == nil {
+			// the first line is the exception, ie. `Exception in thread "main" java.lang.IllegalStateException: oh no`
+			errMsg = pointy.String(strings.TrimSpace(line))
+			segments = append(segments, []*publicModel.ErrorTrace{})
+		}
+	}
+
+	frames := []*publicModel.ErrorTrace{}
+	for i := len(segments) - 1; i >= 0; i-- {
+		frames = append(frames, segments[i]...)
+	}
+	return frames
+}
+
+// structurePHPStackTrace processes a PHP stacktrace as formatted by Exception::getTraceAsString
+// or an uncaught exception, where `#0` is the deepest frame. A `Next` exception wraps the previous one,
+// so the frames are returned in the order they are printed.
+func structurePHPStackTrace(stackTrace string) []*publicModel.ErrorTrace {
+	var errMsg *string
+	frames := []*publicModel.ErrorTrace{}
+	for _, line := range strings.Split(stackTrace, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || line == "Stack trace:" || phpThrownPattern.MatchString(line) {
+			continue
+		}
+		if matches := phpNextPattern.FindStringSubmatch(line); matches != nil {
+			errMsg = pointy.String(matches[1])
+			continue
+		}
+		if phpMainPattern.MatchString(line) {
+			if errMsg == nil {
+				errMsg = pointy.String("")
+			}
+			frames = append(frames, &publicModel.ErrorTrace{
+				Error:        errMsg,
+				FunctionName: pointy.String("{main}"),
+			})
+			continue
+		}
+		if matches := phpFramePattern.FindStringSubmatch(line); matches != nil {
+			if errMsg == nil {
+				errMsg = pointy.String("")
+			}
+			frame := &publicModel.ErrorTrace{
+				Error: errMsg,
+			}
+			if matches[3] != "" {
+				// strip the call arguments, ie. `App\Http\Controller->show('1')`
+				fn := matches[3]
+				if idx := strings.Index(fn, "("); idx > 0 {
+					fn = fn[:idx]
+				}
+				frame.FunctionName = pointy.String(fn)
+				frame.LineContent = pointy.String(matches[3])
+			}
+			if matches[1] != "" {
+				frame.FileName = pointy.String(matches[1])
+				l, _ := strconv.ParseInt(matches[2], 10, 32)
+				frame.LineNumber = pointy.Int(int(l))
+			}
+			frames = append(frames, frame)
+			continue
+		}
+		if errMsg == nil {
+			errMsg = pointy.String(strings.TrimSpace(line))
+		}
+	}
+	return frames
+}
+
+// structureRustStackTrace processes a Rust panic backtrace or a `backtrace` crate backtrace,
+// where frame `0` is the deepest. The panic message preceding the backtrace is the error of every frame.
+func structureRustStackTrace(stackTrace string) []*publicModel.ErrorTrace {
+	var header []string
+	var frame *publicModel.ErrorTrace
+	errMsg := pointy.String("")
+	frames := []*publicModel.ErrorTrace{}
+	for _, line := range strings.Split(stackTrace, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if matches := rustLocationPattern.FindStringSubmatch(line); matches != nil && frame != nil {
+			if frame.FileName == nil {
+				frame.FileName = pointy.String(matches[1])
+				l, _ := strconv.ParseInt(matches[2], 10, 32)
+				frame.LineNumber = pointy.Int(int(l))
+				if matches[3] != "" {
+					col, _ := strconv.ParseInt(matches[3], 10, 32)
+					frame.ColumnNumber = pointy.Int(int(col))
+				}
+			}
+			continue
+		}
+		if matches := rustFramePattern.FindStringSubmatch(line); matches != nil {
+			frame = &publicModel.ErrorTrace{
+				Error:        errMsg,
+				FunctionName: pointy.String(strings.TrimSpace(matches[1])),
+			}
+			frames = append(frames, frame)
+			continue
+		}
+		if len(frames) == 0 && line != "stack backtrace:" && !strings.HasPrefix(line, "note: ") {
+			header = append(header, strings.TrimSpace(line))
+		}
+	}
+	*errMsg = strings.Join(header, " ")
+	return frames
+}
+
 func FormatStructureStackTrace(ctx context.Context, stackTrace string, opts ...StructureStackTraceOption) string {
 	frames, err := StructureOTELStackTrace(stackTrace, opts...)
 	if err != nil {
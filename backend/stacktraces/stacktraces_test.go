@@ -41,6 +41,11 @@ func TestFormatStructureStackTrace(t *testing.T) {
 		{language: ".NET", stacktrace: "System.Exception: oh no, a random error occurred 1a77a6d6-4803-4de8-822b-13a62397b9d3\n   at Program.<>c__DisplayClass0_0.<<Main>$>b__2() in /home/vkorolik/work/highlight/e2e/dotnet/Program.cs:line 89\n   at lambda_method3(Closure, Object, HttpContext)\n   at Microsoft.AspNetCore.HttpsPolicy.HttpsRedirectionMiddleware.Invoke(HttpContext context) in /_/src/aspnetcore/artifacts/source-build/self/src/src/Middleware/HttpsPolicy/src/HttpsRedirectionMiddleware.cs:line 88\n   at Microsoft.AspNetCore.StaticFiles.StaticFileMiddleware.Invoke(HttpContext context) in /_/src/aspnetcore/artifacts/source-build/self/src/src/Middleware/StaticFiles/src/StaticFileMiddleware.cs:line 82\n   at Swashbuckle.AspNetCore.SwaggerUI.SwaggerUIMiddleware.Invoke(HttpContext httpContext)\n   at Swashbuckle.AspNetCore.Swagger.SwaggerMiddleware.Invoke(HttpContext httpContext, ISwaggerProvider swaggerProvider)\n   at Microsoft.AspNetCore.Diagnostics.DeveloperExceptionPageMiddlewareImpl.Invoke(HttpContext context) in /_/src/aspnetcore/artifacts/source", expectedFrameError: "System.Exception: oh no, a random error occurred 1a77a6d6-4803-4de8-822b-13a62397b9d3", expectedFrameCount: 7, expectedFramesWithFileNames: []bool{true, false, true, true, false, false, true}, expectedFramesWithLineNumbers: []bool{true, true, true, true, true, true, false}},
 		{language: ".NET Azure Functions", stacktrace: "System.NullReferenceException: Object reference not set to an instance of an object. at FooMgmt.LibraryV2.Services.FooService.GetAllCountries() in C:\\BarRepo\\ops-foomanagement-automation\\FunctionApps\\FooMgmt\\FooMgmt.LibraryV2\\Services\\FooService.cs:line 466 at FooMgmt.Function.WorkflowsV2.UserWorkflow.GetAllCountries.Run(HttpRequest req, ILogger log) in C:\\BarRepo\\ops-foomanagement-automation\\FunctionApps\\FooMgmt\\FooMgmt.Function\\WorkflowsV2\\UserWorkflow\\GetAllCountries.cs:line 43\n", expectedFrameError: "System.NullReferenceException: Object reference not set to an instance of an object.", expectedFrameCount: 2, expectedFramesWithFileNames: []bool{true, true}, expectedFramesWithLineNumbers: []bool{true, true}},
 		{language: "OTeL Web.js", stacktrace: "O@https://www.foo.com/_next/static/chunks/107-60134c870dee3eda.js:1:24365\n@https://www.foo.com/_next/static/chunks/107-60134c870dee3eda.js:1:24567\n@https://www.foo.com/_next/static/chunks/1621-5b42b9472d365188.js:1:4158\nrW@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:44417\nuseState@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:50596\nO@https://www.foo.com/_next/static/chunks/1621-5b42b9472d365188.js:1:4130\n@https://www.foo.com/_next/static/chunks/107-60134c870dee3eda.js:1:24532\nT@https://www.foo.com/_next/static/chunks/107-60134c870dee3eda.js:1:77654\nrE@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:40343\nl$@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:59319\niZ@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:117682\nia@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:95165\n@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:94987\nil@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:94992\noJ@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:92350\noZ@https://www.foo.com/_next/static/chunks/1dd3208c-335eaf9e3a8a834b.js:1:91769\noZ@[native code]\nT@https://www.foo.com/_next/static/chunks/286-4bf9fb5921165e1e.js:1:84044", expectedFrameError: "O@https://www.foo.com/_next/static/chunks/107-60134c870dee3eda.js:1:24365", expectedFrameCount: 18, expectedFramesWithFileNames: []bool{true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, false, true}, expectedFramesWithLineNumbers: []bool{true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, false, true}},
+		{language: "Java", stacktrace: "Exception in thread \"main\" java.lang.IllegalStateException: oh no\n\tat com.example.checkout.CartService.total(CartService.java:42)\n\tat com.example.checkout.CartController.show(CartController.java:18)\n\tat java.base/jdk.internal.reflect.NativeMethodAccessorImpl.invoke0(Native Method)\n\tat java.base/java.lang.Thread.run(Thread.java:833)\n", expectedFrameError: "Exception in thread \"main\" java.lang.IllegalStateException: oh no", expectedFrameCount: 4, expectedFramesWithFileNames: []bool{true, true, false, true}, expectedFramesWithLineNumbers: []bool{true, true, false, true}},
+		{language: "Kotlin", stacktrace: "kotlin.KotlinNullPointerException\n\tat app//com.example.api.UserRepository.find(UserRepository.kt:27) ~[api.jar:1.2.0]\n\tat com.example.api.UserRoutesKt$userRoutes$1$1.invokeSuspend(UserRoutes.kt:14)\n\tat kotlin.coroutines.jvm.internal.BaseContinuationImpl.resumeWith(ContinuationImpl.kt:33)\n", expectedFrameError: "kotlin.KotlinNullPointerException", expectedFrameCount: 3},
+		{language: "PHP", stacktrace: "PHP Fatal error:  Uncaught Exception: oh no in /var/www/app/src/Cart.php:12\nStack trace:\n#0 /var/www/app/src/Controller.php(20): App\\Cart->total(Array)\n#1 [internal function]: App\\Controller->show('1')\n#2 /var/www/app/public/index.php(7): call_user_func(Array)\n#3 {main}\n  thrown in /var/www/app/src/Cart.php on line 12", expectedFrameError: "PHP Fatal error:  Uncaught Exception: oh no in /var/www/app/src/Cart.php:12", expectedFrameCount: 4, expectedInnerFrameCode: "App\\Cart->total(Array)", expectedFramesWithFileNames: []bool{true, false, true, false}, expectedFramesWithLineNumbers: []bool{true, false, true, false}},
+		{language: "Rust panic", stacktrace: "thread 'main' panicked at src/main.rs:4:5:\nexplicit panic\nstack backtrace:\n   0: rust_begin_unwind\n             at /rustc/07dca489ac2d933c78d3c5158e3f43beefeb02ce/library/std/src/panicking.rs:645:5\n   1: core::panicking::panic_fmt\n             at /rustc/07dca489ac2d933c78d3c5158e3f43beefeb02ce/library/core/src/panicking.rs:72:14\n   2: playground::main\n             at ./src/main.rs:4:5\n   3: core::ops::function::FnOnce::call_once\n             at /rustc/07dca489ac2d933c78d3c5158e3f43beefeb02ce/library/core/src/ops/function.rs:250:5\nnote: Some details are omitted, run with `RUST_BACKTRACE=full` for a verbose backtrace.\n", expectedFrameError: "thread 'main' panicked at src/main.rs:4:5: explicit panic", expectedFrameCount: 4},
+		{language: "Rust backtrace", stacktrace: "failed to connect to database\n   0:     0x55d5c5e8b9a1 - api::db::connect::h3c1a6f1f0e9b1c2d\n                               at /app/src/db.rs:31:17\n   1:     0x55d5c5e8b5f2 - api::main::h9d4e2b7a1c3f5e6d\n                               at /app/src/main.rs:12:5\n   2:     0x7f1a2b3c4d5e - __libc_start_main\n", expectedFrameError: "failed to connect to database", expectedFrameCount: 3, expectedFramesWithFileNames: []bool{true, true, false}, expectedFramesWithLineNumbers: []bool{true, true, false}},
 	}
 	for _, input := range inputs {
 		t.Run(input.language, func(t *testing.T) {
@@ -76,3 +81,62 @@ func TestFormatStructureStackTrace(t *testing.T) {
 		})
 	}
 }
+
+func TestStructureJVMStackTraceCauses(t *testing.T) {
+	stackTrace := "com.example.ServiceException: failed to load cart\n" +
+		"\tat com.example.CartService.load(CartService.java:51)\n" +
+		"\tat com.example.CartController.show(CartController.java:18)\n" +
+		"\tSuppressed: java.io.IOException: failed to close\n" +
+		"\t\tat com.example.Db.close(Db.java:90)\n" +
+		"\t\t... 2 more\n" +
+		"Caused by: java.sql.SQLException: connection refused\n" +
+		"\tat com.example.Db.query(Db.java:12)\n" +
+		"\tat com.example.CartService.load(CartService.java:49)\n" +
+		"\t... 1 more\n"
+
+	frames, err := StructureOTELStackTrace(stackTrace)
+	assert.NoError(t, err)
+	if !assert.Len(t, frames, 4) {
+		return
+	}
+
+	// the root cause is the deepest exception so its frames come first
+	assert.Equal(t, "com.example.Db.query", *frames[0].FunctionName)
+	assert.Equal(t, "Db.java", *frames[0].FileName)
+	assert.Equal(t, 12, *frames[0].LineNumber)
+	assert.Equal(t, "com.example.CartService.load", *frames[1].FunctionName)
+	assert.Equal(t, "com.example.CartService.load", *frames[2].FunctionName)
+	assert.Equal(t, "com.example.CartController.show", *frames[3].FunctionName)
+
+	for _, frame := range frames[:2] {
+		assert.Equal(t, "java.sql.SQLException: connection refused", *frame.Error)
+	}
+	for _, frame := range frames[2:] {
+		assert.Equal(t, "com.example.ServiceException: failed to load cart", *frame.Error)
+	}
+}
+
+func TestStructurePHPStackTraceNext(t *testing.T) {
+	stackTrace := "PHP Fatal error:  Uncaught InvalidArgumentException: bad id in /app/src/Repo.php:8\n" +
+		"Stack trace:\n" +
+		"#0 /app/src/Service.php(14): App\\Repo->find('x')\n" +
+		"#1 {main}\n" +
+		"\n" +
+		"Next RuntimeException: lookup failed in /app/src/Service.php:16\n" +
+		"Stack trace:\n" +
+		"#0 /app/public/index.php(5): App\\Service->get('x')\n" +
+		"#1 {main}\n" +
+		"  thrown in /app/src/Service.php on line 16"
+
+	frames, err := StructureOTELStackTrace(stackTrace)
+	assert.NoError(t, err)
+	if !assert.Len(t, frames, 4) {
+		return
+	}
+	assert.Equal(t, "App\\Repo->find", *frames[0].FunctionName)
+	assert.Equal(t, "/app/src/Service.php", *frames[0].FileName)
+	assert.Equal(t, 14, *frames[0].LineNumber)
+	assert.Equal(t, "PHP Fatal error:  Uncaught InvalidArgumentException: bad id in /app/src/Repo.php:8", *frames[0].Error)
+	assert.Equal(t, "App\\Service->get", *frames[2].FunctionName)
+	assert.Equal(t, "RuntimeException: lookup failed in /app/src/Service.php:16", *frames[2].Error)
+}
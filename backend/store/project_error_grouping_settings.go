@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/openlyinc/pointy"
+	e "github.com/pkg/errors"
+
+	"github.com/highlight-run/highlight/backend/errorgroups"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/highlight-run/highlight/backend/redis"
+)
+
+func getErrorGroupingSettingsKey(projectID int) string {
+	return fmt.Sprintf("project-error-grouping-settings-%d", projectID)
+}
+
+func (store *Store) GetProjectErrorGroupingSettings(ctx context.Context, projectID int) (*model.ProjectErrorGroupingSettings, error) {
+	return redis.CachedEval(ctx, store.Redis, getErrorGroupingSettingsKey(projectID), 250*time.Millisecond, time.Minute, func() (*model.ProjectErrorGroupingSettings, error) {
+		var settings model.ProjectErrorGroupingSettings
+		if err := store.DB.WithContext(ctx).Where(&model.ProjectErrorGroupingSettings{ProjectID: projectID}).FirstOrCreate(&settings).Error; err != nil {
+			return nil, err
+		}
+		return &settings, nil
+	})
+}
+
+type UpdateProjectErrorGroupingSettingsParams struct {
+	Rules               []errorgroups.Rule
+	EmbeddingsThreshold *float64
+}
+
+// UpdateProjectErrorGroupingSettings replaces the grouping rules of the project, validating them first.
+// Rules only apply to errors ingested after the update; existing error groups are not regrouped.
+func (store *Store) UpdateProjectErrorGroupingSettings(ctx context.Context, projectID int, updates UpdateProjectErrorGroupingSettingsParams) (*model.ProjectErrorGroupingSettings, error) {
+	settings, err := store.GetProjectErrorGroupingSettings(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if updates.Rules != nil {
+		if _, err := errorgroups.NewRules(updates.Rules); err != nil {
+			return nil, err
+		}
+		rules, err := json.Marshal(updates.Rules)
+		if err != nil {
+			return nil, err
+		}
+		settings.Rules = pointy.String(string(rules))
+	}
+
+	if updates.EmbeddingsThreshold != nil {
+		if *updates.EmbeddingsThreshold <= 0 {
+			return nil, e.New("error grouping embeddings threshold must be positive")
+		}
+		settings.EmbeddingsThreshold = updates.EmbeddingsThreshold
+	}
+
+	if err := store.DB.WithContext(ctx).Save(settings).Error; err != nil {
+		return nil, err
+	}
+
+	return settings, store.Redis.Del(ctx, getErrorGroupingSettingsKey(projectID))
+}
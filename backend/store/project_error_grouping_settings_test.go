@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/highlight-run/highlight/backend/errorgroups"
+	"github.com/highlight-run/highlight/backend/model"
+	"github.com/openlyinc/pointy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateProjectErrorGroupingSettings(t *testing.T) {
+	ctx := context.TODO()
+	defer teardown(t)
+
+	workspace := model.Workspace{}
+	store.DB.Create(&workspace)
+
+	project := model.Project{WorkspaceID: workspace.ID}
+	store.DB.Create(&project)
+
+	settings, err := store.GetProjectErrorGroupingSettings(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, settings.Rules)
+
+	_, err = store.UpdateProjectErrorGroupingSettings(ctx, project.ID, UpdateProjectErrorGroupingSettingsParams{
+		Rules: []errorgroups.Rule{{Type: errorgroups.RuleStripMessage, Pattern: "("}},
+	})
+	assert.Error(t, err)
+
+	_, err = store.UpdateProjectErrorGroupingSettings(ctx, project.ID, UpdateProjectErrorGroupingSettingsParams{
+		Rules:               []errorgroups.Rule{{Type: errorgroups.RuleIgnoreFrames, Pattern: "node_modules"}},
+		EmbeddingsThreshold: pointy.Float64(0.1),
+	})
+	assert.NoError(t, err)
+
+	settings, err = store.GetProjectErrorGroupingSettings(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, *settings.EmbeddingsThreshold)
+	rules, err := errorgroups.ParseRules(settings)
+	assert.NoError(t, err)
+	assert.Len(t, rules.FilterFrames(nil), 0)
+}
@@ -2,9 +2,13 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/openlyinc/pointy"
+	e "github.com/pkg/errors"
+
 	modelInputs "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	"github.com/highlight-run/highlight/backend/redis"
 
@@ -29,6 +33,16 @@ type UpdateProjectFilterSettingsParams struct {
 	AutoResolveStaleErrorsDayInterval *int
 	FilterSessionsWithoutError        *bool
 	Sampling                          *modelInputs.SamplingInput
+	TraceTailSampling                 *TraceTailSamplingParams
+}
+
+type TraceTailSamplingParams struct {
+	Enabled            *bool
+	WindowSeconds      *int
+	KeepErrors         *bool
+	LatencyThresholdMs *int64
+	KeepQuery          *string
+	ServiceRates       map[string]float64
 }
 
 func (store *Store) UpdateProjectFilterSettings(ctx context.Context, projectID int, updates UpdateProjectFilterSettingsParams) (*model.ProjectFilterSettings, error) {
@@ -64,6 +78,9 @@ func (store *Store) UpdateProjectFilterSettings(ctx context.Context, projectID i
 			if updates.Sampling.TraceSamplingRate != nil {
 				projectFilterSettings.TraceSamplingRate = *updates.Sampling.TraceSamplingRate
 			}
+			if updates.Sampling.MetricSamplingRate != nil {
+				projectFilterSettings.MetricSamplingRate = *updates.Sampling.MetricSamplingRate
+			}
 			if updates.Sampling.SessionMinuteRateLimit != nil {
 				projectFilterSettings.SessionMinuteRateLimit = updates.Sampling.SessionMinuteRateLimit
 			}
@@ -76,6 +93,9 @@ func (store *Store) UpdateProjectFilterSettings(ctx context.Context, projectID i
 			if updates.Sampling.TraceMinuteRateLimit != nil {
 				projectFilterSettings.TraceMinuteRateLimit = updates.Sampling.TraceMinuteRateLimit
 			}
+			if updates.Sampling.MetricMinuteRateLimit != nil {
+				projectFilterSettings.MetricMinuteRateLimit = updates.Sampling.MetricMinuteRateLimit
+			}
 		}
 		if updates.Sampling.SessionExclusionQuery != nil {
 			projectFilterSettings.SessionExclusionQuery = updates.Sampling.SessionExclusionQuery
@@ -89,6 +109,38 @@ func (store *Store) UpdateProjectFilterSettings(ctx context.Context, projectID i
 		if updates.Sampling.TraceExclusionQuery != nil {
 			projectFilterSettings.TraceExclusionQuery = updates.Sampling.TraceExclusionQuery
 		}
+		if updates.Sampling.MetricExclusionQuery != nil {
+			projectFilterSettings.MetricExclusionQuery = updates.Sampling.MetricExclusionQuery
+		}
+	}
+
+	if updates.TraceTailSampling != nil && workspaceSettings.EnableIngestSampling {
+		tailSampling := updates.TraceTailSampling
+		if tailSampling.Enabled != nil {
+			projectFilterSettings.TraceTailSamplingEnabled = *tailSampling.Enabled
+		}
+		if tailSampling.WindowSeconds != nil {
+			if *tailSampling.WindowSeconds <= 0 {
+				return nil, e.New("trace tail sampling window must be positive")
+			}
+			projectFilterSettings.TraceTailSamplingWindowSeconds = *tailSampling.WindowSeconds
+		}
+		if tailSampling.KeepErrors != nil {
+			projectFilterSettings.TraceTailSamplingKeepErrors = *tailSampling.KeepErrors
+		}
+		if tailSampling.LatencyThresholdMs != nil {
+			projectFilterSettings.TraceTailSamplingLatencyThresholdMs = tailSampling.LatencyThresholdMs
+		}
+		if tailSampling.KeepQuery != nil {
+			projectFilterSettings.TraceTailSamplingKeepQuery = tailSampling.KeepQuery
+		}
+		if tailSampling.ServiceRates != nil {
+			serviceRates, err := json.Marshal(tailSampling.ServiceRates)
+			if err != nil {
+				return nil, err
+			}
+			projectFilterSettings.TraceTailSamplingServiceRates = pointy.String(string(serviceRates))
+		}
 	}
 
 	result := store.DB.Save(&projectFilterSettings)
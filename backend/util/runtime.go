@@ -57,11 +57,12 @@ const (
 	AutoResolveStaleErrors      Handler = "auto-resolve-stale-errors"
 	StartSessionDeleteJob       Handler = "start-session-delete-job"
 	ScheduledTasks              Handler = "scheduled-tasks"
+	TailSampleTraces            Handler = "tail-sample-traces"
 )
 
 func (lt Handler) IsValid() bool {
 	switch lt {
-	case ReportStripeUsage, MigrateDB, MetricMonitors, LogAlerts, BackfillStackFrames, RefreshMaterializedViews, PublicWorkerMain, PublicWorkerBatched, PublicWorkerDataSync, PublicWorkerTraces, PublicWorkerMetricSum, PublicWorkerMetricHistogram, PublicWorkerMetricSummary, AutoResolveStaleErrors:
+	case ReportStripeUsage, MigrateDB, MetricMonitors, LogAlerts, BackfillStackFrames, RefreshMaterializedViews, PublicWorkerMain, PublicWorkerBatched, PublicWorkerDataSync, PublicWorkerTraces, PublicWorkerMetricSum, PublicWorkerMetricHistogram, PublicWorkerMetricSummary, AutoResolveStaleErrors, TailSampleTraces:
 		return true
 	}
 	return false
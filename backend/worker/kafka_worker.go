@@ -13,6 +13,8 @@ import (
 	"github.com/highlight-run/highlight/backend/env"
 	kafka_queue "github.com/highlight-run/highlight/backend/kafka-queue"
 	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	"github.com/highlight-run/highlight/backend/livetail"
+	"github.com/highlight-run/highlight/backend/logpattern"
 	"github.com/highlight-run/highlight/backend/model"
 	privateModel "github.com/highlight-run/highlight/backend/private-graph/graph/model"
 	pubgraph "github.com/highlight-run/highlight/backend/public-graph/graph"
@@ -20,7 +22,9 @@ import (
 	hmetric "github.com/highlight/highlight/sdk/highlight-go/metric"
 	e "github.com/pkg/errors"
 	"github.com/samber/lo"
+	"github.com/segmentio/kafka-go"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -100,7 +104,8 @@ func (k *KafkaWorker) ProcessMessages() {
 				start := time.Now()
 				publicWorkerMessage, ok := task.(*kafka_queue.Message)
 				if !ok {
-					k.processWorkerError(ctx, task, e.New("failed to cast as publicWorkerMessage"), start)
+					err = e.New("failed to cast as publicWorkerMessage")
+					k.processWorkerError(ctx, task, err, start)
 					break
 				}
 				if err = k.Worker.processPublicWorkerMessage(sCtx, publicWorkerMessage); err != nil {
@@ -113,6 +118,11 @@ func (k *KafkaWorker) ProcessMessages() {
 			s.SetAttribute("taskFailures", task.GetFailures())
 			s2.Finish(err)
 
+			// the task exhausted its retries. keep it in the dead letter topic so that it can be replayed.
+			if err != nil {
+				submitDeadLetter(ctx, k.KafkaQueue, task, err)
+			}
+
 			s3, _ := util.StartSpanFromContext(ctx, "worker.kafka.commitMessage")
 			k.KafkaQueue.Commit(ctx, task.GetKafkaMessage())
 			k.log(ctx, task, "committed")
@@ -130,9 +140,46 @@ const SessionsMaxRowsPostgres = 500
 const ErrorGroupsMaxRowsPostgres = 500
 const ErrorObjectsMaxRowsPostgres = 500
 const MinRetryDelay = 250 * time.Millisecond
+const MaxDeadLetterRetryDelay = time.Minute
+const MaxDeadLetterAttempts = 10
+
+// submitDeadLetter writes a task that exhausted its retries to the dead letter topic of the queue, retrying transient
+// failures up to MaxDeadLetterAttempts times. The task is committed afterwards, so a task that cannot be dead lettered
+// is dropped and counted rather than blocking the partition. Queues without a dead letter topic drop the task.
+func submitDeadLetter(ctx context.Context, queue kafkaqueue.ConsumerQueue, task kafkaqueue.RetryableMessage, cause error) {
+	logger := log.WithContext(ctx).WithField("type", task.GetType())
+	dropped := func(reason string) {
+		hmetric.Incr(ctx, "worker.kafka.deadLetter.dropped.count", []attribute.KeyValue{attribute.String("reason", reason)}, 1)
+	}
+	for attempt := 0; attempt < MaxDeadLetterAttempts; attempt++ {
+		err := queue.SubmitDeadLetter(ctx, task, cause)
+		if err == nil {
+			return
+		}
+		if e.Is(err, kafkaqueue.ErrDeadLetterNotConfigured) {
+			logger.WithError(cause).Error("dropping task without a dead letter topic")
+			return
+		}
+		if e.Is(err, kafkaqueue.ErrDeadLetterUndeliverable) {
+			logger.WithError(err).Error("dropping task that cannot be dead lettered")
+			dropped("undeliverable")
+			return
+		}
+		logger.WithError(err).WithField("attempt", attempt).Error("failed to submit task to dead letter topic")
+		select {
+		case <-ctx.Done():
+			logger.WithError(ctx.Err()).Error("dropping task after the dead letter submission was cancelled")
+			dropped("cancelled")
+			return
+		case <-time.After(min(MinRetryDelay*time.Duration(math.Pow(2, float64(attempt))), MaxDeadLetterRetryDelay)):
+		}
+	}
+	logger.WithError(cause).Error("dropping task after exhausting the dead letter submission attempts")
+	dropped("exhausted")
+}
 
 type KafkaWorker struct {
-	KafkaQueue   *kafkaqueue.Queue
+	KafkaQueue   kafkaqueue.ConsumerQueue
 	Worker       *Worker
 	WorkerThread int
 }
@@ -259,8 +306,6 @@ func (k *KafkaBatchWorker) flush(ctx context.Context) error {
 		"KafkaBatchWorker organized messages",
 	)
 
-	k.messages = []kafka_queue.RetryableMessage{}
-
 	readSpan.SetAttribute("MaxIngestDelay", time.Since(oldestMsg).Seconds())
 	readSpan.Finish()
 
@@ -298,9 +343,7 @@ func (k *KafkaBatchWorker) flush(ctx context.Context) error {
 	workSpan.Finish()
 
 	commitSpan, sCtx := util.StartSpanFromContext(ctx, fmt.Sprintf("worker.kafka.%s.flush.commit", k.Name))
-	if lastMsg != nil {
-		k.KafkaQueue.Commit(sCtx, lastMsg.GetKafkaMessage())
-	}
+	k.commit(sCtx)
 	commitSpan.Finish()
 
 	return nil
@@ -431,6 +474,7 @@ func (k *KafkaBatchWorker) flushLogs(ctx context.Context, logRows []*clickhouse.
 		// Temporarily filter NextJS logs
 		// TODO - remove this condition when https://github.com/highlight/highlight/issues/6181 is fixed
 		if !strings.HasPrefix(logRow.Body, "ENOENT: no such file or directory") && !strings.HasPrefix(logRow.Body, "connect ECONNREFUSED") {
+			logRow.PatternId = logpattern.ID(logRow.Body)
 			filteredRows = append(filteredRows, logRow)
 		}
 	}
@@ -455,6 +499,11 @@ func (k *KafkaBatchWorker) flushLogs(ctx context.Context, logRows []*clickhouse.
 		log.WithContext(ctxT).WithError(err).Error("failed to batch write logs to clickhouse")
 		return err
 	}
+
+	// live tail is best effort and must not fail the ingest of the batch
+	if err := livetail.PublishLogs(wCtx, k.Worker.Resolver.Redis, filteredRows); err != nil {
+		log.WithContext(wCtx).WithError(err).Error("failed to publish logs to live tail")
+	}
 	wSpan.Finish()
 	return nil
 }
@@ -494,6 +543,11 @@ func (k *KafkaBatchWorker) flushTraces(ctx context.Context, traceRows []*clickho
 		return err
 	}
 
+	// live tail is best effort and must not fail the ingest of the batch
+	if err := livetail.PublishTraces(ctx, k.Worker.Resolver.Redis, filteredTraceRows); err != nil {
+		log.WithContext(ctx).WithError(err).Error("failed to publish traces to live tail")
+	}
+
 	for projectId := range markBackendSetupProjectIds {
 		err := k.Worker.PublicResolver.MarkBackendSetupImpl(ctx, int(projectId), model.MarkBackendSetupTypeTraces)
 		if err != nil {
@@ -662,6 +716,40 @@ func (k *KafkaBatchWorker) processWorkerError(ctx context.Context, attempt int,
 	time.Sleep(MinRetryDelay * time.Duration(math.Pow(2, float64(attempt))))
 }
 
+// deadLetter submits the messages of a batch which failed to flush to the dead letter topic and commits them.
+func (k *KafkaBatchWorker) deadLetter(ctx context.Context, cause error) {
+	s, ctx := util.StartSpanFromContext(ctx, fmt.Sprintf("worker.kafka.%s.deadLetter", k.Name))
+	s.SetAttribute("BatchSize", len(k.messages))
+	defer s.Finish()
+
+	for _, msg := range k.messages {
+		submitDeadLetter(ctx, k.KafkaQueue, msg, cause)
+	}
+	k.commit(ctx)
+}
+
+// commit commits the batch at the last message of each partition, as a batch may hold the messages
+// of several partitions and a commit only advances the partition of its message.
+func (k *KafkaBatchWorker) commit(ctx context.Context) {
+	last := map[int]*kafka.Message{}
+	var partitions []int
+	for _, msg := range k.messages {
+		m := msg.GetKafkaMessage()
+		if m == nil {
+			continue
+		}
+		if prev, ok := last[m.Partition]; !ok {
+			partitions = append(partitions, m.Partition)
+		} else if prev.Offset > m.Offset {
+			continue
+		}
+		last[m.Partition] = m
+	}
+	for _, partition := range partitions {
+		k.KafkaQueue.Commit(ctx, last[partition])
+	}
+}
+
 func (k *KafkaBatchWorker) ProcessMessages() {
 	for {
 		func(ctx context.Context) {
@@ -690,13 +778,19 @@ func (k *KafkaBatchWorker) ProcessMessages() {
 			if time.Since(k.lastFlush) > k.BatchedFlushTimeout || len(k.messages) >= k.BatchFlushSize {
 				s.SetAttribute("FlushDelay", time.Since(k.lastFlush).Seconds())
 
+				var err error
 				for i := 0; i <= kafkaqueue.TaskRetries; i++ {
-					if err := k.flush(ctx); err != nil {
+					if err = k.flush(ctx); err != nil {
 						k.processWorkerError(ctx, i, err)
 					} else {
 						break
 					}
 				}
+				// the batch exhausted its retries. keep its messages in the dead letter topic so that they can be replayed.
+				if err != nil {
+					k.deadLetter(ctx, err)
+				}
+				k.messages = []kafkaqueue.RetryableMessage{}
 				k.lastFlush = time.Now()
 			}
 		}(context.Background())
@@ -704,7 +798,7 @@ func (k *KafkaBatchWorker) ProcessMessages() {
 }
 
 type KafkaBatchWorker struct {
-	KafkaQueue          *kafkaqueue.Queue
+	KafkaQueue          kafkaqueue.ConsumerQueue
 	Worker              *Worker
 	WorkerThread        int
 	BatchFlushSize      int
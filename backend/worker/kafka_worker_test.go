@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	kafkaqueue "github.com/highlight-run/highlight/backend/kafka-queue"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyDeadLetterQueue fails the first dead letter submissions, as when the dead letter topic is unavailable.
+type flakyDeadLetterQueue struct {
+	*kafkaqueue.MemoryQueue
+	failures int
+}
+
+func (q *flakyDeadLetterQueue) SubmitDeadLetter(ctx context.Context, msg kafkaqueue.RetryableMessage, cause error) error {
+	if q.failures > 0 {
+		q.failures--
+		return errors.New("dead letter topic unavailable")
+	}
+	return q.MemoryQueue.SubmitDeadLetter(ctx, msg, cause)
+}
+
+func TestKafkaBatchWorker_DeadLetter(t *testing.T) {
+	ctx := context.Background()
+	broker := kafkaqueue.NewMemoryBroker(1)
+	queue := &flakyDeadLetterQueue{MemoryQueue: broker.Queue("batched"), failures: 2}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, queue.Submit(ctx, "key", &kafkaqueue.Message{Type: kafkaqueue.PushLogsFlattened}))
+	}
+
+	k := &KafkaBatchWorker{KafkaQueue: queue, Name: "test"}
+	for i := 0; i < 3; i++ {
+		_, msg := queue.Receive(ctx)
+		k.messages = append(k.messages, msg)
+	}
+
+	k.deadLetter(ctx, errors.New("failed to flush"))
+	// every message of the failed batch is kept before the batch is committed
+	assert.Len(t, broker.Messages(kafkaqueue.GetDeadLetterTopic("batched")), 3)
+	assert.Equal(t, int64(0), broker.Lag("batched"))
+}
+
+func TestSubmitDeadLetter_Undeliverable(t *testing.T) {
+	ctx := context.Background()
+	broker := kafkaqueue.NewMemoryBroker(1)
+	queue := broker.Queue("batched")
+
+	// a message that was not received from a queue can never be dead lettered and must not block the worker
+	submitDeadLetter(ctx, queue, &kafkaqueue.Message{Type: kafkaqueue.PushLogsFlattened}, errors.New("failed to process"))
+	assert.Empty(t, broker.Messages(kafkaqueue.GetDeadLetterTopic("batched")))
+}
+
+func TestSubmitDeadLetter_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	broker := kafkaqueue.NewMemoryBroker(1)
+	queue := &flakyDeadLetterQueue{MemoryQueue: broker.Queue("batched"), failures: MaxDeadLetterAttempts}
+	assert.NoError(t, queue.Submit(ctx, "key", &kafkaqueue.Message{Type: kafkaqueue.PushLogsFlattened}))
+	_, msg := queue.Receive(ctx)
+
+	cancel()
+	submitDeadLetter(ctx, queue, msg, errors.New("failed to process"))
+	assert.Equal(t, MaxDeadLetterAttempts-1, queue.failures)
+	assert.Empty(t, broker.Messages(kafkaqueue.GetDeadLetterTopic("batched")))
+}
+
+func TestKafkaBatchWorker_FlushCommitsEveryPartition(t *testing.T) {
+	ctx := context.Background()
+	broker := kafkaqueue.NewMemoryBroker(4)
+	queue := broker.Queue("batched")
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, queue.Submit(ctx, fmt.Sprintf("key-%d", i), &kafkaqueue.Message{Type: kafkaqueue.HealthCheck}))
+	}
+
+	k := &KafkaBatchWorker{KafkaQueue: queue, Name: "test"}
+	for i := 0; i < 20; i++ {
+		_, msg := queue.Receive(ctx)
+		k.messages = append(k.messages, msg)
+	}
+	assert.Greater(t, len(lo.Uniq(lo.Map(k.messages, func(msg kafkaqueue.RetryableMessage, _ int) int {
+		return msg.GetKafkaMessage().Partition
+	}))), 1)
+
+	assert.NoError(t, k.flush(ctx))
+	// the batch spans several partitions, each of which is committed
+	drainCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.NoError(t, broker.Drain(drainCtx))
+}
@@ -76,6 +76,8 @@ type Worker struct {
 	Resolver       *mgraph.Resolver
 	PublicResolver *pubgraph.Resolver
 	StorageClient  storage.Client
+	// MemoryBroker replaces kafka for the public workers when set, ie. for local development.
+	MemoryBroker *kafkaqueue.MemoryBroker
 }
 
 func (w *Worker) pushToObjectStorage(ctx context.Context, s *model.Session, payloadManager *payload.PayloadManager) error {
@@ -533,9 +535,7 @@ func (w *Worker) PublicWorker(ctx context.Context, topic kafkaqueue.TopicType) {
 			if cfg.Topic == kafkaqueue.TopicTypeDefault {
 				go func(config WorkerConfig, workerId int) {
 					k := KafkaWorker{
-						KafkaQueue: kafkaqueue.New(ctx,
-							kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: kafkaqueue.TopicTypeDefault}),
-							kafkaqueue.Consumer,
+						KafkaQueue: w.newConsumerQueue(ctx, kafkaqueue.TopicTypeDefault,
 							&kafkaqueue.ConfigOverride{
 								QueueCapacity:    pointy.Int(config.QueueSize),
 								MessageSizeBytes: config.MessageSizeBytes,
@@ -552,10 +552,8 @@ func (w *Worker) PublicWorker(ctx context.Context, topic kafkaqueue.TopicType) {
 			} else {
 				go func(config WorkerConfig, workerId int) {
 					k := KafkaBatchWorker{
-						KafkaQueue: kafkaqueue.New(
-							ctx,
-							kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: config.Topic}),
-							kafkaqueue.Consumer, &kafkaqueue.ConfigOverride{QueueCapacity: pointy.Int(config.QueueSize)},
+						KafkaQueue: w.newConsumerQueue(ctx, config.Topic,
+							&kafkaqueue.ConfigOverride{QueueCapacity: pointy.Int(config.QueueSize)},
 						),
 						Worker:              w,
 						BatchFlushSize:      config.FlushSize,
@@ -573,6 +571,14 @@ func (w *Worker) PublicWorker(ctx context.Context, topic kafkaqueue.TopicType) {
 	wg.Wait()
 }
 
+func (w *Worker) newConsumerQueue(ctx context.Context, topic kafkaqueue.TopicType, configOverride *kafkaqueue.ConfigOverride) kafkaqueue.ConsumerQueue {
+	name := kafkaqueue.GetTopic(kafkaqueue.GetTopicOptions{Type: topic})
+	if w.MemoryBroker != nil {
+		return w.MemoryBroker.Queue(name)
+	}
+	return kafkaqueue.New(ctx, name, kafkaqueue.Consumer, configOverride)
+}
+
 // Autoresolves error groups that have not had any recent instances
 func (w *Worker) AutoResolveStaleErrors(ctx context.Context) {
 	autoResolver := NewAutoResolver(w.PublicResolver.Store, w.PublicResolver.DB)
@@ -1210,7 +1216,7 @@ func (w *Worker) StartLogAlertWatcher(ctx context.Context) {
 }
 
 func (w *Worker) StartMetricAlertWatcher(ctx context.Context) {
-	metric_alerts.WatchMetricAlerts(ctx, w.Resolver.DB, w.Resolver.MailClient, w.Resolver.ClickhouseClient, w.Resolver.LambdaClient)
+	metric_alerts.WatchMetricAlerts(ctx, w.Resolver.DB, w.Resolver.MailClient, w.Resolver.Redis, w.Resolver.ClickhouseClient, w.Resolver.LambdaClient)
 }
 
 func (w *Worker) StartSessionDeleteJob(ctx context.Context) {
@@ -1224,10 +1230,22 @@ func (w *Worker) StartSessionDeleteJob(ctx context.Context) {
 	}
 }
 
+// StartTailSamplingJob decides the buffered traces of tail sampled projects once their window elapses.
+func (w *Worker) StartTailSamplingJob(ctx context.Context) {
+	for range time.Tick(time.Second) {
+		count, err := w.PublicResolver.FlushTailSampledTraces(ctx)
+		if err != nil {
+			log.WithContext(ctx).WithError(err).Error("failed to flush tail sampled traces")
+		}
+		hmetric.Histogram(ctx, "worker.tailSampling.traces.count", float64(count), nil, 1)
+	}
+}
+
 func (w *Worker) ScheduledTasks(ctx context.Context) {
 	go w.StartLogAlertWatcher(ctx)
 	go w.StartMetricAlertWatcher(ctx)
 	go w.StartSessionDeleteJob(ctx)
+	go w.StartTailSamplingJob(ctx)
 	go func() {
 		w.ReportStripeUsage(ctx)
 		for range time.Tick(time.Hour) {
@@ -1456,6 +1474,8 @@ func (w *Worker) GetHandler(ctx context.Context, handlerFlag util.Handler) func(
 		return w.StartSessionDeleteJob
 	case util.ScheduledTasks:
 		return w.ScheduledTasks
+	case util.TailSampleTraces:
+		return w.StartTailSamplingJob
 	case "":
 		// no handler provided defaults to the session worker
 		return w.Start
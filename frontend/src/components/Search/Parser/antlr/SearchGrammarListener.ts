@@ -17,6 +17,7 @@ import { And_search_exprContext } from './SearchGrammarParser.js'
 import { Or_search_exprContext } from './SearchGrammarParser.js'
 import { Implicit_and_search_exprContext } from './SearchGrammarParser.js'
 import { Exists_search_exprContext } from './SearchGrammarParser.js'
+import { In_search_exprContext } from './SearchGrammarParser.js'
 import { Key_val_search_exprContext } from './SearchGrammarParser.js'
 import { Paren_search_exprContext } from './SearchGrammarParser.js'
 import { Search_keyContext } from './SearchGrammarParser.js'
@@ -27,6 +28,12 @@ import { Exists_opContext } from './SearchGrammarParser.js'
 import { Negation_opContext } from './SearchGrammarParser.js'
 import { Bin_opContext } from './SearchGrammarParser.js'
 import { Search_valueContext } from './SearchGrammarParser.js'
+import { In_opContext } from './SearchGrammarParser.js'
+import { In_listContext } from './SearchGrammarParser.js'
+import { PipelineContext } from './SearchGrammarParser.js'
+import { Pipe_stageContext } from './SearchGrammarParser.js'
+import { Pipe_commandContext } from './SearchGrammarParser.js'
+import { Pipe_argContext } from './SearchGrammarParser.js'
 
 /**
  * This interface defines a complete listener for a parse tree produced by
@@ -215,6 +222,18 @@ export default class SearchGrammarListener extends ParseTreeListener {
 	 * @param ctx the parse tree
 	 */
 	exitExists_search_expr?: (ctx: Exists_search_exprContext) => void
+	/**
+	 * Enter a parse tree produced by the `in_search_expr`
+	 * labeled alternative in `SearchGrammarParser.search_expr`.
+	 * @param ctx the parse tree
+	 */
+	enterIn_search_expr?: (ctx: In_search_exprContext) => void
+	/**
+	 * Exit a parse tree produced by the `in_search_expr`
+	 * labeled alternative in `SearchGrammarParser.search_expr`.
+	 * @param ctx the parse tree
+	 */
+	exitIn_search_expr?: (ctx: In_search_exprContext) => void
 	/**
 	 * Enter a parse tree produced by the `key_val_search_expr`
 	 * labeled alternative in `SearchGrammarParser.search_expr`.
@@ -319,4 +338,64 @@ export default class SearchGrammarListener extends ParseTreeListener {
 	 * @param ctx the parse tree
 	 */
 	exitSearch_value?: (ctx: Search_valueContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.in_op`.
+	 * @param ctx the parse tree
+	 */
+	enterIn_op?: (ctx: In_opContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.in_op`.
+	 * @param ctx the parse tree
+	 */
+	exitIn_op?: (ctx: In_opContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.in_list`.
+	 * @param ctx the parse tree
+	 */
+	enterIn_list?: (ctx: In_listContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.in_list`.
+	 * @param ctx the parse tree
+	 */
+	exitIn_list?: (ctx: In_listContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.pipeline`.
+	 * @param ctx the parse tree
+	 */
+	enterPipeline?: (ctx: PipelineContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.pipeline`.
+	 * @param ctx the parse tree
+	 */
+	exitPipeline?: (ctx: PipelineContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.pipe_stage`.
+	 * @param ctx the parse tree
+	 */
+	enterPipe_stage?: (ctx: Pipe_stageContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.pipe_stage`.
+	 * @param ctx the parse tree
+	 */
+	exitPipe_stage?: (ctx: Pipe_stageContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.pipe_command`.
+	 * @param ctx the parse tree
+	 */
+	enterPipe_command?: (ctx: Pipe_commandContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.pipe_command`.
+	 * @param ctx the parse tree
+	 */
+	exitPipe_command?: (ctx: Pipe_commandContext) => void
+	/**
+	 * Enter a parse tree produced by `SearchGrammarParser.pipe_arg`.
+	 * @param ctx the parse tree
+	 */
+	enterPipe_arg?: (ctx: Pipe_argContext) => void
+	/**
+	 * Exit a parse tree produced by `SearchGrammarParser.pipe_arg`.
+	 * @param ctx the parse tree
+	 */
+	exitPipe_arg?: (ctx: Pipe_argContext) => void
 }
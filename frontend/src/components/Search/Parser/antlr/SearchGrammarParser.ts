@@ -33,21 +33,23 @@ export default class SearchGrammarParser extends Parser {
 	public static readonly OR = 2
 	public static readonly NOT = 3
 	public static readonly EXISTS = 4
-	public static readonly BANG = 5
-	public static readonly EQ = 6
-	public static readonly NEQ = 7
-	public static readonly LT = 8
-	public static readonly LTE = 9
-	public static readonly GT = 10
-	public static readonly GTE = 11
-	public static readonly LPAREN = 12
-	public static readonly RPAREN = 13
-	public static readonly COLON = 14
-	public static readonly ID = 15
-	public static readonly STRING = 16
-	public static readonly VALUE = 17
-	public static readonly WS = 18
-	public static readonly ERROR_CHARACTERS = 19
+	public static readonly IN = 5
+	public static readonly BANG = 6
+	public static readonly EQ = 7
+	public static readonly NEQ = 8
+	public static readonly LT = 9
+	public static readonly LTE = 10
+	public static readonly GT = 11
+	public static readonly GTE = 12
+	public static readonly LPAREN = 13
+	public static readonly RPAREN = 14
+	public static readonly COLON = 15
+	public static readonly PIPE = 16
+	public static readonly ID = 17
+	public static readonly STRING = 18
+	public static readonly VALUE = 19
+	public static readonly WS = 20
+	public static readonly ERROR_CHARACTERS = 21
 	public static override readonly EOF = Token.EOF
 	public static readonly RULE_search_query = 0
 	public static readonly RULE_top_col_expr = 1
@@ -61,12 +63,19 @@ export default class SearchGrammarParser extends Parser {
 	public static readonly RULE_negation_op = 9
 	public static readonly RULE_bin_op = 10
 	public static readonly RULE_search_value = 11
+	public static readonly RULE_in_op = 12
+	public static readonly RULE_in_list = 13
+	public static readonly RULE_pipeline = 14
+	public static readonly RULE_pipe_stage = 15
+	public static readonly RULE_pipe_command = 16
+	public static readonly RULE_pipe_arg = 17
 	public static readonly literalNames: (string | null)[] = [
 		null,
 		"'AND'",
 		"'OR'",
 		"'NOT'",
 		"'EXISTS'",
+		"'IN'",
 		"'!'",
 		"'='",
 		"'!='",
@@ -84,6 +93,7 @@ export default class SearchGrammarParser extends Parser {
 		'OR',
 		'NOT',
 		'EXISTS',
+		'IN',
 		'BANG',
 		'EQ',
 		'NEQ',
@@ -94,6 +104,7 @@ export default class SearchGrammarParser extends Parser {
 		'LPAREN',
 		'RPAREN',
 		'COLON',
+		'PIPE',
 		'ID',
 		'STRING',
 		'VALUE',
@@ -114,6 +125,12 @@ export default class SearchGrammarParser extends Parser {
 		'negation_op',
 		'bin_op',
 		'search_value',
+		'in_op',
+		'in_list',
+		'pipeline',
+		'pipe_stage',
+		'pipe_command',
+		'pipe_arg',
 	]
 	public get grammarFileName(): string {
 		return 'SearchGrammar.g4'
@@ -155,6 +172,7 @@ export default class SearchGrammarParser extends Parser {
 			this.state,
 		)
 		this.enterRule(localctx, 0, SearchGrammarParser.RULE_search_query)
+		let _la: number
 		try {
 			this.state = 28
 			this._errHandler.sync(this)
@@ -167,18 +185,38 @@ export default class SearchGrammarParser extends Parser {
 					}
 					break
 				case 3:
-				case 12:
-				case 15:
-				case 16:
+				case 5:
+				case 13:
 				case 17:
+				case 18:
+				case 19:
 					this.enterOuterAlt(localctx, 2)
 					{
 						this.state = 25
 						this.search_expr(0)
+						this.state = 147
+						this._errHandler.sync(this)
+						_la = this._input.LA(1)
+						if (_la === 16) {
+							{
+								this.state = 148
+								this.pipeline()
+							}
+						}
+
 						this.state = 26
 						this.match(SearchGrammarParser.EOF)
 					}
 					break
+				case 16:
+					this.enterOuterAlt(localctx, 3)
+					{
+						this.state = 150
+						this.pipeline()
+						this.state = 151
+						this.match(SearchGrammarParser.EOF)
+					}
+					break
 				default:
 					throw new NoViableAltException(this)
 			}
@@ -207,7 +245,7 @@ export default class SearchGrammarParser extends Parser {
 			this.state = 38
 			this._errHandler.sync(this)
 			switch (this._input.LA(1)) {
-				case 12:
+				case 13:
 					localctx = new Top_paren_col_exprContext(this, localctx)
 					this.enterOuterAlt(localctx, 1)
 					{
@@ -229,9 +267,10 @@ export default class SearchGrammarParser extends Parser {
 						this.top_col_expr()
 					}
 					break
-				case 15:
-				case 16:
+				case 5:
 				case 17:
+				case 18:
+				case 19:
 					localctx = new Top_col_search_valueContext(this, localctx)
 					this.enterOuterAlt(localctx, 3)
 					{
@@ -286,7 +325,7 @@ export default class SearchGrammarParser extends Parser {
 				this.state = 49
 				this._errHandler.sync(this)
 				switch (this._input.LA(1)) {
-					case 12:
+					case 13:
 						{
 							localctx = new Col_paren_exprContext(this, localctx)
 							this._ctx = localctx
@@ -314,9 +353,10 @@ export default class SearchGrammarParser extends Parser {
 							this.col_expr(4)
 						}
 						break
-					case 15:
-					case 16:
+					case 5:
 					case 17:
+					case 18:
+					case 19:
 						{
 							localctx = new Col_search_valueContext(
 								this,
@@ -525,6 +565,22 @@ export default class SearchGrammarParser extends Parser {
 						}
 						break
 					case 4:
+						{
+							localctx = new In_search_exprContext(
+								this,
+								localctx,
+							)
+							this._ctx = localctx
+							_prevctx = localctx
+							this.state = 129
+							this.search_key()
+							this.state = 130
+							this.in_op()
+							this.state = 131
+							this.in_list()
+						}
+						break
+					case 5:
 						{
 							localctx = new Exists_search_exprContext(
 								this,
@@ -538,7 +594,7 @@ export default class SearchGrammarParser extends Parser {
 							this.exists_op()
 						}
 						break
-					case 5:
+					case 6:
 						{
 							localctx = new Body_search_exprContext(
 								this,
@@ -872,7 +928,7 @@ export default class SearchGrammarParser extends Parser {
 				this.state = 116
 				this._errHandler.sync(this)
 				_la = this._input.LA(1)
-				while (_la === 18) {
+				while (_la === 20) {
 					{
 						{
 							this.state = 113
@@ -885,7 +941,7 @@ export default class SearchGrammarParser extends Parser {
 				}
 				this.state = 119
 				_la = this._input.LA(1)
-				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 20448) !== 0)) {
+				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 40896) !== 0)) {
 					this._errHandler.recoverInline(this)
 				} else {
 					this._errHandler.reportMatch(this)
@@ -939,7 +995,223 @@ export default class SearchGrammarParser extends Parser {
 			{
 				this.state = 126
 				_la = this._input.LA(1)
-				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 229376) !== 0)) {
+				if (!((_la & ~0x1f) === 0 && ((1 << _la) & 917536) !== 0)) {
+					this._errHandler.recoverInline(this)
+				} else {
+					this._errHandler.reportMatch(this)
+					this.consume()
+				}
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+
+	// @RuleVersion(0)
+	public in_op(): In_opContext {
+		let localctx: In_opContext = new In_opContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 133, SearchGrammarParser.RULE_in_op)
+		try {
+			this.enterOuterAlt(localctx, 1)
+			{
+				this.state = 137
+				this.match(SearchGrammarParser.IN)
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+	// @RuleVersion(0)
+	public in_list(): In_listContext {
+		let localctx: In_listContext = new In_listContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 135, SearchGrammarParser.RULE_in_list)
+		let _la: number
+		try {
+			this.enterOuterAlt(localctx, 1)
+			{
+				this.state = 139
+				this.match(SearchGrammarParser.LPAREN)
+				this.state = 141
+				this._errHandler.sync(this)
+				_la = this._input.LA(1)
+				do {
+					{
+						{
+							this.state = 140
+							this.search_value()
+						}
+					}
+					this.state = 143
+					this._errHandler.sync(this)
+					_la = this._input.LA(1)
+				} while ((_la & ~0x1f) === 0 && ((1 << _la) & 917536) !== 0)
+				this.state = 145
+				this.match(SearchGrammarParser.RPAREN)
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+
+	// @RuleVersion(0)
+	public pipeline(): PipelineContext {
+		let localctx: PipelineContext = new PipelineContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 153, SearchGrammarParser.RULE_pipeline)
+		let _la: number
+		try {
+			this.enterOuterAlt(localctx, 1)
+			{
+				this.state = 162
+				this._errHandler.sync(this)
+				_la = this._input.LA(1)
+				do {
+					{
+						{
+							this.state = 161
+							this.pipe_stage()
+						}
+					}
+					this.state = 164
+					this._errHandler.sync(this)
+					_la = this._input.LA(1)
+				} while (_la === 16)
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+	// @RuleVersion(0)
+	public pipe_stage(): Pipe_stageContext {
+		let localctx: Pipe_stageContext = new Pipe_stageContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 155, SearchGrammarParser.RULE_pipe_stage)
+		let _la: number
+		try {
+			this.enterOuterAlt(localctx, 1)
+			{
+				this.state = 166
+				this.pipe_command()
+				this.state = 170
+				this._errHandler.sync(this)
+				_la = this._input.LA(1)
+				while ((_la & ~0x1f) === 0 && ((1 << _la) & 4128766) !== 0) {
+					{
+						{
+							this.state = 167
+							this.pipe_arg()
+						}
+					}
+					this.state = 172
+					this._errHandler.sync(this)
+					_la = this._input.LA(1)
+				}
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+	// @RuleVersion(0)
+	public pipe_command(): Pipe_commandContext {
+		let localctx: Pipe_commandContext = new Pipe_commandContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 157, SearchGrammarParser.RULE_pipe_command)
+		try {
+			this.enterOuterAlt(localctx, 1)
+			{
+				this.state = 173
+				this.match(SearchGrammarParser.PIPE)
+			}
+		} catch (re) {
+			if (re instanceof RecognitionException) {
+				localctx.exception = re
+				this._errHandler.reportError(this, re)
+				this._errHandler.recover(this, re)
+			} else {
+				throw re
+			}
+		} finally {
+			this.exitRule()
+		}
+		return localctx
+	}
+	// @RuleVersion(0)
+	public pipe_arg(): Pipe_argContext {
+		let localctx: Pipe_argContext = new Pipe_argContext(
+			this,
+			this._ctx,
+			this.state,
+		)
+		this.enterRule(localctx, 159, SearchGrammarParser.RULE_pipe_arg)
+		let _la: number
+		try {
+			this.enterOuterAlt(localctx, 1)
+			{
+				this.state = 175
+				_la = this._input.LA(1)
+				if (_la <= 0 || _la === 16) {
 					this._errHandler.recoverInline(this)
 				} else {
 					this._errHandler.reportMatch(this)
@@ -1007,7 +1279,7 @@ export default class SearchGrammarParser extends Parser {
 	}
 
 	public static readonly _serializedATN: number[] = [
-		4, 1, 19, 129, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 21, 177, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 29, 8, 0, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 39, 8, 1, 1, 2, 1, 2, 1, 2, 1, 2,
@@ -1019,47 +1291,69 @@ export default class SearchGrammarParser extends Parser {
 		4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 110, 8,
 		8, 1, 9, 1, 9, 1, 10, 5, 10, 115, 8, 10, 10, 10, 12, 10, 118, 9, 10, 1,
 		10, 1, 10, 5, 10, 122, 8, 10, 10, 10, 12, 10, 125, 9, 10, 1, 11, 1, 11,
-		1, 11, 0, 2, 4, 6, 12, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 0, 2,
-		2, 0, 5, 11, 14, 14, 1, 0, 15, 17, 134, 0, 28, 1, 0, 0, 0, 2, 38, 1, 0,
-		0, 0, 4, 49, 1, 0, 0, 0, 6, 79, 1, 0, 0, 0, 8, 98, 1, 0, 0, 0, 10, 100,
-		1, 0, 0, 0, 12, 102, 1, 0, 0, 0, 14, 104, 1, 0, 0, 0, 16, 109, 1, 0, 0,
-		0, 18, 111, 1, 0, 0, 0, 20, 116, 1, 0, 0, 0, 22, 126, 1, 0, 0, 0, 24,
-		29, 5, 0, 0, 1, 25, 26, 3, 6, 3, 0, 26, 27, 5, 0, 0, 1, 27, 29, 1, 0, 0,
-		0, 28, 24, 1, 0, 0, 0, 28, 25, 1, 0, 0, 0, 29, 1, 1, 0, 0, 0, 30, 31, 5,
-		12, 0, 0, 31, 32, 3, 4, 2, 0, 32, 33, 5, 13, 0, 0, 33, 39, 1, 0, 0, 0,
-		34, 35, 3, 18, 9, 0, 35, 36, 3, 2, 1, 0, 36, 39, 1, 0, 0, 0, 37, 39, 3,
-		22, 11, 0, 38, 30, 1, 0, 0, 0, 38, 34, 1, 0, 0, 0, 38, 37, 1, 0, 0, 0,
-		39, 3, 1, 0, 0, 0, 40, 41, 6, 2, -1, 0, 41, 42, 5, 12, 0, 0, 42, 43, 3,
-		4, 2, 0, 43, 44, 5, 13, 0, 0, 44, 50, 1, 0, 0, 0, 45, 46, 3, 18, 9, 0,
-		46, 47, 3, 4, 2, 4, 47, 50, 1, 0, 0, 0, 48, 50, 3, 22, 11, 0, 49, 40, 1,
-		0, 0, 0, 49, 45, 1, 0, 0, 0, 49, 48, 1, 0, 0, 0, 50, 59, 1, 0, 0, 0, 51,
-		52, 10, 3, 0, 0, 52, 53, 5, 1, 0, 0, 53, 58, 3, 4, 2, 4, 54, 55, 10, 2,
-		0, 0, 55, 56, 5, 2, 0, 0, 56, 58, 3, 4, 2, 3, 57, 51, 1, 0, 0, 0, 57,
-		54, 1, 0, 0, 0, 58, 61, 1, 0, 0, 0, 59, 57, 1, 0, 0, 0, 59, 60, 1, 0, 0,
-		0, 60, 5, 1, 0, 0, 0, 61, 59, 1, 0, 0, 0, 62, 63, 6, 3, -1, 0, 63, 64,
-		5, 12, 0, 0, 64, 65, 3, 6, 3, 0, 65, 66, 5, 13, 0, 0, 66, 80, 1, 0, 0,
-		0, 67, 68, 3, 18, 9, 0, 68, 69, 3, 6, 3, 7, 69, 80, 1, 0, 0, 0, 70, 71,
-		3, 8, 4, 0, 71, 73, 3, 20, 10, 0, 72, 74, 3, 2, 1, 0, 73, 72, 1, 0, 0,
-		0, 73, 74, 1, 0, 0, 0, 74, 80, 1, 0, 0, 0, 75, 76, 3, 8, 4, 0, 76, 77,
-		3, 16, 8, 0, 77, 80, 1, 0, 0, 0, 78, 80, 3, 2, 1, 0, 79, 62, 1, 0, 0, 0,
-		79, 67, 1, 0, 0, 0, 79, 70, 1, 0, 0, 0, 79, 75, 1, 0, 0, 0, 79, 78, 1,
-		0, 0, 0, 80, 95, 1, 0, 0, 0, 81, 82, 10, 6, 0, 0, 82, 83, 3, 10, 5, 0,
-		83, 84, 3, 6, 3, 7, 84, 94, 1, 0, 0, 0, 85, 86, 10, 5, 0, 0, 86, 87, 3,
-		14, 7, 0, 87, 88, 3, 6, 3, 6, 88, 94, 1, 0, 0, 0, 89, 90, 10, 4, 0, 0,
-		90, 91, 3, 12, 6, 0, 91, 92, 3, 6, 3, 5, 92, 94, 1, 0, 0, 0, 93, 81, 1,
-		0, 0, 0, 93, 85, 1, 0, 0, 0, 93, 89, 1, 0, 0, 0, 94, 97, 1, 0, 0, 0, 95,
-		93, 1, 0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 7, 1, 0, 0, 0, 97, 95, 1, 0, 0,
-		0, 98, 99, 5, 15, 0, 0, 99, 9, 1, 0, 0, 0, 100, 101, 5, 1, 0, 0, 101,
-		11, 1, 0, 0, 0, 102, 103, 1, 0, 0, 0, 103, 13, 1, 0, 0, 0, 104, 105, 5,
-		2, 0, 0, 105, 15, 1, 0, 0, 0, 106, 110, 5, 4, 0, 0, 107, 108, 5, 3, 0,
-		0, 108, 110, 5, 4, 0, 0, 109, 106, 1, 0, 0, 0, 109, 107, 1, 0, 0, 0,
-		110, 17, 1, 0, 0, 0, 111, 112, 5, 3, 0, 0, 112, 19, 1, 0, 0, 0, 113,
-		115, 5, 18, 0, 0, 114, 113, 1, 0, 0, 0, 115, 118, 1, 0, 0, 0, 116, 114,
-		1, 0, 0, 0, 116, 117, 1, 0, 0, 0, 117, 119, 1, 0, 0, 0, 118, 116, 1, 0,
-		0, 0, 119, 123, 7, 0, 0, 0, 120, 122, 5, 18, 0, 0, 121, 120, 1, 0, 0, 0,
-		122, 125, 1, 0, 0, 0, 123, 121, 1, 0, 0, 0, 123, 124, 1, 0, 0, 0, 124,
-		21, 1, 0, 0, 0, 125, 123, 1, 0, 0, 0, 126, 127, 7, 1, 0, 0, 127, 23, 1,
-		0, 0, 0, 12, 28, 38, 49, 57, 59, 73, 79, 93, 95, 109, 116, 123,
+		1, 11, 1, 3, 1, 3, 1, 3, 1, 3, 2, 12, 7, 12, 2, 13, 7, 13, 1, 12, 1, 12,
+		1, 13, 1, 13, 4, 13, 142, 8, 13, 11, 13, 12, 13, 143, 1, 13, 1, 13, 3,
+		0, 149, 1, 0, 8, 0, 1, 0, 1, 0, 1, 0, 2, 14, 7, 14, 2, 15, 7, 15, 2, 16,
+		7, 16, 2, 17, 7, 17, 1, 14, 4, 14, 163, 8, 14, 11, 14, 12, 14, 164, 1,
+		15, 1, 15, 5, 15, 169, 8, 15, 10, 15, 12, 15, 172, 9, 15, 1, 16, 1, 16,
+		1, 17, 1, 17, 0, 2, 4, 6, 18, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
+		133, 135, 153, 155, 157, 159, 0, 3, 2, 0, 6, 12, 15, 15, 2, 0, 5, 5, 17,
+		19, 1, 0, 16, 16, 182, 0, 28, 1, 0, 0, 0, 2, 38, 1, 0, 0, 0, 4, 49, 1,
+		0, 0, 0, 6, 79, 1, 0, 0, 0, 8, 98, 1, 0, 0, 0, 10, 100, 1, 0, 0, 0, 12,
+		102, 1, 0, 0, 0, 14, 104, 1, 0, 0, 0, 16, 109, 1, 0, 0, 0, 18, 111, 1,
+		0, 0, 0, 20, 116, 1, 0, 0, 0, 22, 126, 1, 0, 0, 0, 24, 29, 5, 0, 0, 1,
+		25, 147, 3, 6, 3, 0, 26, 27, 5, 0, 0, 1, 27, 29, 1, 0, 0, 0, 28, 24, 1,
+		0, 0, 0, 28, 25, 1, 0, 0, 0, 28, 150, 1, 0, 0, 0, 29, 1, 1, 0, 0, 0, 30,
+		31, 5, 13, 0, 0, 31, 32, 3, 4, 2, 0, 32, 33, 5, 14, 0, 0, 33, 39, 1, 0,
+		0, 0, 34, 35, 3, 18, 9, 0, 35, 36, 3, 2, 1, 0, 36, 39, 1, 0, 0, 0, 37,
+		39, 3, 22, 11, 0, 38, 30, 1, 0, 0, 0, 38, 34, 1, 0, 0, 0, 38, 37, 1, 0,
+		0, 0, 39, 3, 1, 0, 0, 0, 40, 41, 6, 2, -1, 0, 41, 42, 5, 13, 0, 0, 42,
+		43, 3, 4, 2, 0, 43, 44, 5, 14, 0, 0, 44, 50, 1, 0, 0, 0, 45, 46, 3, 18,
+		9, 0, 46, 47, 3, 4, 2, 4, 47, 50, 1, 0, 0, 0, 48, 50, 3, 22, 11, 0, 49,
+		40, 1, 0, 0, 0, 49, 45, 1, 0, 0, 0, 49, 48, 1, 0, 0, 0, 50, 59, 1, 0, 0,
+		0, 51, 52, 10, 3, 0, 0, 52, 53, 5, 1, 0, 0, 53, 58, 3, 4, 2, 4, 54, 55,
+		10, 2, 0, 0, 55, 56, 5, 2, 0, 0, 56, 58, 3, 4, 2, 3, 57, 51, 1, 0, 0, 0,
+		57, 54, 1, 0, 0, 0, 58, 61, 1, 0, 0, 0, 59, 57, 1, 0, 0, 0, 59, 60, 1,
+		0, 0, 0, 60, 5, 1, 0, 0, 0, 61, 59, 1, 0, 0, 0, 62, 63, 6, 3, -1, 0, 63,
+		64, 5, 13, 0, 0, 64, 65, 3, 6, 3, 0, 65, 66, 5, 14, 0, 0, 66, 80, 1, 0,
+		0, 0, 67, 68, 3, 18, 9, 0, 68, 69, 3, 6, 3, 7, 69, 80, 1, 0, 0, 0, 70,
+		71, 3, 8, 4, 0, 71, 73, 3, 20, 10, 0, 72, 74, 3, 2, 1, 0, 73, 72, 1, 0,
+		0, 0, 73, 74, 1, 0, 0, 0, 74, 80, 1, 0, 0, 0, 75, 76, 3, 8, 4, 0, 76,
+		77, 3, 16, 8, 0, 77, 80, 1, 0, 0, 0, 78, 80, 3, 2, 1, 0, 79, 62, 1, 0,
+		0, 0, 79, 67, 1, 0, 0, 0, 79, 70, 1, 0, 0, 0, 79, 129, 1, 0, 0, 0, 79,
+		75, 1, 0, 0, 0, 79, 78, 1, 0, 0, 0, 80, 95, 1, 0, 0, 0, 81, 82, 10, 6,
+		0, 0, 82, 83, 3, 10, 5, 0, 83, 84, 3, 6, 3, 7, 84, 94, 1, 0, 0, 0, 85,
+		86, 10, 5, 0, 0, 86, 87, 3, 14, 7, 0, 87, 88, 3, 6, 3, 6, 88, 94, 1, 0,
+		0, 0, 89, 90, 10, 4, 0, 0, 90, 91, 3, 12, 6, 0, 91, 92, 3, 6, 3, 5, 92,
+		94, 1, 0, 0, 0, 93, 81, 1, 0, 0, 0, 93, 85, 1, 0, 0, 0, 93, 89, 1, 0, 0,
+		0, 94, 97, 1, 0, 0, 0, 95, 93, 1, 0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 7, 1,
+		0, 0, 0, 97, 95, 1, 0, 0, 0, 98, 99, 5, 17, 0, 0, 99, 9, 1, 0, 0, 0,
+		100, 101, 5, 1, 0, 0, 101, 11, 1, 0, 0, 0, 102, 103, 1, 0, 0, 0, 103,
+		13, 1, 0, 0, 0, 104, 105, 5, 2, 0, 0, 105, 15, 1, 0, 0, 0, 106, 110, 5,
+		4, 0, 0, 107, 108, 5, 3, 0, 0, 108, 110, 5, 4, 0, 0, 109, 106, 1, 0, 0,
+		0, 109, 107, 1, 0, 0, 0, 110, 17, 1, 0, 0, 0, 111, 112, 5, 3, 0, 0, 112,
+		19, 1, 0, 0, 0, 113, 115, 5, 20, 0, 0, 114, 113, 1, 0, 0, 0, 115, 118,
+		1, 0, 0, 0, 116, 114, 1, 0, 0, 0, 116, 117, 1, 0, 0, 0, 117, 119, 1, 0,
+		0, 0, 118, 116, 1, 0, 0, 0, 119, 123, 7, 0, 0, 0, 120, 122, 5, 20, 0, 0,
+		121, 120, 1, 0, 0, 0, 122, 125, 1, 0, 0, 0, 123, 121, 1, 0, 0, 0, 123,
+		124, 1, 0, 0, 0, 124, 21, 1, 0, 0, 0, 125, 123, 1, 0, 0, 0, 126, 127, 7,
+		1, 0, 0, 127, 23, 1, 0, 0, 0, 129, 130, 3, 8, 4, 0, 130, 131, 3, 133,
+		12, 0, 131, 132, 3, 135, 13, 0, 132, 80, 1, 0, 0, 0, 133, 137, 1, 0, 0,
+		0, 137, 138, 5, 5, 0, 0, 138, 134, 1, 0, 0, 0, 135, 139, 1, 0, 0, 0,
+		139, 141, 5, 13, 0, 0, 140, 142, 3, 22, 11, 0, 141, 140, 1, 0, 0, 0,
+		142, 143, 1, 0, 0, 0, 143, 141, 1, 0, 0, 0, 143, 144, 1, 0, 0, 0, 144,
+		145, 1, 0, 0, 0, 145, 146, 5, 14, 0, 0, 146, 136, 1, 0, 0, 0, 147, 148,
+		1, 0, 0, 0, 147, 149, 1, 0, 0, 0, 148, 149, 3, 153, 14, 0, 149, 26, 1,
+		0, 0, 0, 150, 151, 3, 153, 14, 0, 151, 152, 5, 0, 0, 1, 152, 29, 1, 0,
+		0, 0, 153, 162, 1, 0, 0, 0, 162, 161, 1, 0, 0, 0, 161, 163, 3, 155, 15,
+		0, 163, 164, 1, 0, 0, 0, 164, 162, 1, 0, 0, 0, 164, 165, 1, 0, 0, 0,
+		165, 154, 1, 0, 0, 0, 155, 166, 1, 0, 0, 0, 166, 170, 3, 157, 16, 0,
+		170, 168, 1, 0, 0, 0, 170, 171, 1, 0, 0, 0, 168, 167, 1, 0, 0, 0, 167,
+		169, 3, 159, 17, 0, 169, 172, 1, 0, 0, 0, 172, 170, 1, 0, 0, 0, 171,
+		156, 1, 0, 0, 0, 157, 173, 1, 0, 0, 0, 173, 174, 5, 16, 0, 0, 174, 158,
+		1, 0, 0, 0, 159, 175, 1, 0, 0, 0, 175, 176, 8, 2, 0, 0, 176, 160, 1, 0,
+		0, 0, 16, 28, 38, 49, 57, 59, 73, 79, 93, 95, 109, 116, 123, 143, 147,
+		164, 170,
 	]
 
 	private static __ATN: ATN
@@ -1096,6 +1390,9 @@ export class Search_queryContext extends ParserRuleContext {
 			0,
 		) as Search_exprContext
 	}
+	public pipeline(): PipelineContext {
+		return this.getTypedRuleContext(PipelineContext, 0) as PipelineContext
+	}
 	public get ruleIndex(): number {
 		return SearchGrammarParser.RULE_search_query
 	}
@@ -1526,6 +1823,34 @@ export class Exists_search_exprContext extends Search_exprContext {
 		}
 	}
 }
+export class In_search_exprContext extends Search_exprContext {
+	constructor(parser: SearchGrammarParser, ctx: Search_exprContext) {
+		super(parser, ctx.parentCtx, ctx.invokingState)
+		super.copyFrom(ctx)
+	}
+	public search_key(): Search_keyContext {
+		return this.getTypedRuleContext(
+			Search_keyContext,
+			0,
+		) as Search_keyContext
+	}
+	public in_op(): In_opContext {
+		return this.getTypedRuleContext(In_opContext, 0) as In_opContext
+	}
+	public in_list(): In_listContext {
+		return this.getTypedRuleContext(In_listContext, 0) as In_listContext
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterIn_search_expr) {
+			listener.enterIn_search_expr(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitIn_search_expr) {
+			listener.exitIn_search_expr(this)
+		}
+	}
+}
 export class Key_val_search_exprContext extends Search_exprContext {
 	constructor(parser: SearchGrammarParser, ctx: Search_exprContext) {
 		super(parser, ctx.parentCtx, ctx.invokingState)
@@ -1820,6 +2145,9 @@ export class Search_valueContext extends ParserRuleContext {
 	public VALUE(): TerminalNode {
 		return this.getToken(SearchGrammarParser.VALUE, 0)
 	}
+	public IN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.IN, 0)
+	}
 	public get ruleIndex(): number {
 		return SearchGrammarParser.RULE_search_value
 	}
@@ -1834,3 +2162,196 @@ export class Search_valueContext extends ParserRuleContext {
 		}
 	}
 }
+
+export class In_opContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public IN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.IN, 0)
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_in_op
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterIn_op) {
+			listener.enterIn_op(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitIn_op) {
+			listener.exitIn_op(this)
+		}
+	}
+}
+
+export class In_listContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public LPAREN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.LPAREN, 0)
+	}
+	public RPAREN(): TerminalNode {
+		return this.getToken(SearchGrammarParser.RPAREN, 0)
+	}
+	public search_value_list(): Search_valueContext[] {
+		return this.getTypedRuleContexts(
+			Search_valueContext,
+		) as Search_valueContext[]
+	}
+	public search_value(i: number): Search_valueContext {
+		return this.getTypedRuleContext(
+			Search_valueContext,
+			i,
+		) as Search_valueContext
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_in_list
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterIn_list) {
+			listener.enterIn_list(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitIn_list) {
+			listener.exitIn_list(this)
+		}
+	}
+}
+
+export class PipelineContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public pipe_stage_list(): Pipe_stageContext[] {
+		return this.getTypedRuleContexts(
+			Pipe_stageContext,
+		) as Pipe_stageContext[]
+	}
+	public pipe_stage(i: number): Pipe_stageContext {
+		return this.getTypedRuleContext(
+			Pipe_stageContext,
+			i,
+		) as Pipe_stageContext
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_pipeline
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterPipeline) {
+			listener.enterPipeline(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitPipeline) {
+			listener.exitPipeline(this)
+		}
+	}
+}
+
+export class Pipe_stageContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public pipe_command(): Pipe_commandContext {
+		return this.getTypedRuleContext(
+			Pipe_commandContext,
+			0,
+		) as Pipe_commandContext
+	}
+	public pipe_arg_list(): Pipe_argContext[] {
+		return this.getTypedRuleContexts(Pipe_argContext) as Pipe_argContext[]
+	}
+	public pipe_arg(i: number): Pipe_argContext {
+		return this.getTypedRuleContext(Pipe_argContext, i) as Pipe_argContext
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_pipe_stage
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterPipe_stage) {
+			listener.enterPipe_stage(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitPipe_stage) {
+			listener.exitPipe_stage(this)
+		}
+	}
+}
+
+export class Pipe_commandContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public PIPE(): TerminalNode {
+		return this.getToken(SearchGrammarParser.PIPE, 0)
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_pipe_command
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterPipe_command) {
+			listener.enterPipe_command(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitPipe_command) {
+			listener.exitPipe_command(this)
+		}
+	}
+}
+
+export class Pipe_argContext extends ParserRuleContext {
+	constructor(
+		parser?: SearchGrammarParser,
+		parent?: ParserRuleContext,
+		invokingState?: number,
+	) {
+		super(parent, invokingState)
+		this.parser = parser
+	}
+	public PIPE(): TerminalNode {
+		return this.getToken(SearchGrammarParser.PIPE, 0)
+	}
+	public get ruleIndex(): number {
+		return SearchGrammarParser.RULE_pipe_arg
+	}
+	public enterRule(listener: SearchGrammarListener): void {
+		if (listener.enterPipe_arg) {
+			listener.enterPipe_arg(this)
+		}
+	}
+	public exitRule(listener: SearchGrammarListener): void {
+		if (listener.exitPipe_arg) {
+			listener.exitPipe_arg(this)
+		}
+	}
+}
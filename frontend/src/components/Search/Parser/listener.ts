@@ -7,6 +7,9 @@ import {
 	Body_search_exprContext,
 	Exists_opContext,
 	Exists_search_exprContext,
+	In_listContext,
+	In_opContext,
+	In_search_exprContext,
 	Key_val_search_exprContext,
 	Or_opContext,
 	Search_keyContext,
@@ -85,6 +88,13 @@ export class SearchListener extends SearchGrammarListener {
 		this.currentExpression = { start, stop, text } as SearchExpression
 	}
 
+	enterIn_search_expr = (ctx: In_search_exprContext) => {
+		const start = ctx.start.start
+		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
+		const text = this.queryString.substring(start, stop + 1)
+		this.currentExpression = { start, stop, text } as SearchExpression
+	}
+
 	enterBody_search_expr = (ctx: Body_search_exprContext) => {
 		const start = ctx.start.start
 		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
@@ -110,6 +120,20 @@ export class SearchListener extends SearchGrammarListener {
 		this.currentExpression.operator = ctx.getText() as SearchOperator
 	}
 
+	enterIn_op = (_ctx: In_opContext) => {
+		this.currentExpression.operator = 'IN'
+	}
+
+	exitIn_list = (ctx: In_listContext) => {
+		// getText drops the hidden whitespace between values
+		const start = ctx.start.start
+		const stop = ctx.stop ? ctx.stop.stop : ctx.start.stop
+		this.currentExpression.value = this.queryString.substring(
+			start,
+			stop + 1,
+		)
+	}
+
 	enterSearch_value = (ctx: Search_valueContext) => {
 		this.currentExpression.value = ctx.getText()
 	}
@@ -156,6 +180,11 @@ export class SearchListener extends SearchGrammarListener {
 		this.currentExpression = { ...DEFAULT_EXPRESSION }
 	}
 
+	exitIn_search_expr = (_ctx: In_search_exprContext) => {
+		this.expressions.push(this.currentExpression)
+		this.currentExpression = { ...DEFAULT_EXPRESSION }
+	}
+
 	exitBody_search_expr = (_ctx: Body_search_exprContext) => {
 		this.currentExpression.value = this.currentExpression.text
 		this.expressions.push(this.currentExpression)
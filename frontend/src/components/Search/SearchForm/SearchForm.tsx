@@ -81,12 +81,14 @@ const EQUAL_OPERATOR = ['='] as const
 const BOOLEAN_OPERATORS = ['=', '!='] as const
 const CONTAINS_OPERATOR = ['="**"', '!="**"'] as const
 const MATCHES_OPERATOR = ['="//"', '!="//"'] as const
+const IN_OPERATOR = ['IN'] as const
 export const SEARCH_OPERATORS = [
 	...BOOLEAN_OPERATORS,
 	...NUMERIC_OPERATORS,
 	...EXISTS_OPERATORS,
 	...CONTAINS_OPERATOR,
 	...MATCHES_OPERATOR,
+	...IN_OPERATOR,
 ] as const
 export type SearchOperator = (typeof SEARCH_OPERATORS)[number]
 
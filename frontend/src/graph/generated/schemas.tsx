@@ -130,7 +130,9 @@ export enum AdminRole {
 
 export type Alert = {
 	__typename?: 'Alert'
+	composite_condition?: Maybe<CompositeCondition>
 	destinations: Array<Maybe<AlertDestination>>
+	disable_resolved_notifications: Scalars['Boolean']
 	disabled: Scalars['Boolean']
 	function_column?: Maybe<Scalars['String']>
 	function_type: MetricAggregator
@@ -142,7 +144,10 @@ export type Alert = {
 	product_type: ProductType
 	project_id: Scalars['ID']
 	query?: Maybe<Scalars['String']>
+	severity?: Maybe<AlertSeverity>
 	sql?: Maybe<Scalars['String']>
+	threshold_change_type?: Maybe<ChangeType>
+	threshold_change_window?: Maybe<Scalars['Int']>
 	threshold_condition?: Maybe<ThresholdCondition>
 	threshold_cooldown?: Maybe<Scalars['Int']>
 	threshold_type?: Maybe<ThresholdType>
@@ -151,17 +156,61 @@ export type Alert = {
 	updated_at: Scalars['Timestamp']
 }
 
+export type AlertBacktest = {
+	__typename?: 'AlertBacktest'
+	evaluation_interval: Scalars['Int']
+	evaluations: Scalars['Int']
+	notifications: Array<AlertBacktestNotification>
+	state_changes: Array<AlertStateChange>
+}
+
+export type AlertBacktestNotification = {
+	__typename?: 'AlertBacktestNotification'
+	firing_since?: Maybe<Scalars['Timestamp']>
+	group_by_key: Scalars['String']
+	timestamp: Scalars['Timestamp']
+	type: AlertBacktestNotificationType
+	value?: Maybe<Scalars['Float']>
+}
+
+export enum AlertBacktestNotificationType {
+	Alert = 'Alert',
+	Resolved = 'Resolved',
+}
+
+export type AlertDefinitionInput = {
+	function_column?: InputMaybe<Scalars['String']>
+	function_type: MetricAggregator
+	group_by_key?: InputMaybe<Scalars['String']>
+	product_type: ProductType
+	query?: InputMaybe<Scalars['String']>
+	sql?: InputMaybe<Scalars['String']>
+	threshold_change_type?: InputMaybe<ChangeType>
+	threshold_change_window?: InputMaybe<Scalars['Int']>
+	threshold_condition?: InputMaybe<ThresholdCondition>
+	threshold_cooldown?: InputMaybe<Scalars['Int']>
+	threshold_type?: InputMaybe<ThresholdType>
+	threshold_value?: InputMaybe<Scalars['Float']>
+	threshold_window?: InputMaybe<Scalars['Int']>
+}
+
 export type AlertDestination = {
 	__typename?: 'AlertDestination'
 	alert_id: Scalars['ID']
+	body_template?: Maybe<Scalars['String']>
 	destination_type: AlertDestinationType
 	id: Scalars['ID']
+	signing_secret_hint?: Maybe<Scalars['String']>
+	title_template?: Maybe<Scalars['String']>
 	type_id: Scalars['String']
 	type_name: Scalars['String']
 }
 
 export type AlertDestinationInput = {
+	authorization?: InputMaybe<Scalars['String']>
+	body_template?: InputMaybe<Scalars['String']>
 	destination_type: AlertDestinationType
+	title_template?: InputMaybe<Scalars['String']>
 	type_id: Scalars['String']
 	type_name: Scalars['String']
 }
@@ -170,10 +219,48 @@ export enum AlertDestinationType {
 	Discord = 'Discord',
 	Email = 'Email',
 	MicrosoftTeams = 'MicrosoftTeams',
+	Opsgenie = 'Opsgenie',
+	PagerDuty = 'PagerDuty',
 	Slack = 'Slack',
 	Webhook = 'Webhook',
 }
 
+export enum AlertSeverity {
+	Critical = 'critical',
+	Error = 'error',
+	Info = 'info',
+	Warning = 'warning',
+}
+
+export type AlertSilence = {
+	__typename?: 'AlertSilence'
+	alert_id?: Maybe<Scalars['ID']>
+	created_at: Scalars['Timestamp']
+	creator_id: Scalars['ID']
+	cron?: Maybe<Scalars['String']>
+	duration_minutes?: Maybe<Scalars['Int']>
+	ends_at?: Maybe<Scalars['Timestamp']>
+	group_by_key?: Maybe<Scalars['String']>
+	group_by_value?: Maybe<Scalars['String']>
+	id: Scalars['ID']
+	product_type?: Maybe<ProductType>
+	project_id: Scalars['ID']
+	reason: Scalars['String']
+	starts_at?: Maybe<Scalars['Timestamp']>
+}
+
+export type AlertSilenceInput = {
+	alert_id?: InputMaybe<Scalars['ID']>
+	cron?: InputMaybe<Scalars['String']>
+	duration_minutes?: InputMaybe<Scalars['Int']>
+	ends_at?: InputMaybe<Scalars['Timestamp']>
+	group_by_key?: InputMaybe<Scalars['String']>
+	group_by_value?: InputMaybe<Scalars['String']>
+	product_type?: InputMaybe<ProductType>
+	reason: Scalars['String']
+	starts_at?: InputMaybe<Scalars['Timestamp']>
+}
+
 export enum AlertState {
 	Alerting = 'Alerting',
 	AlertingSilently = 'AlertingSilently',
@@ -191,6 +278,7 @@ export type AlertStateChange = {
 	projectID: Scalars['ID']
 	state: AlertState
 	timestamp: Scalars['Timestamp']
+	value?: Maybe<Scalars['Float']>
 }
 
 export type AlertStateChangeResults = {
@@ -199,6 +287,12 @@ export type AlertStateChangeResults = {
 	totalCount: Scalars['Int64']
 }
 
+export type AlertTemplatePreview = {
+	__typename?: 'AlertTemplatePreview'
+	body?: Maybe<Scalars['String']>
+	title?: Maybe<Scalars['String']>
+}
+
 export type AllProjectSettings = {
 	__typename?: 'AllProjectSettings'
 	autoResolveStaleErrorsDayInterval: Scalars['Int']
@@ -279,6 +373,11 @@ export type CategoryHistogramPayload = {
 	buckets: Array<CategoryHistogramBucket>
 }
 
+export enum ChangeType {
+	Percent = 'Percent',
+	Rate = 'Rate',
+}
+
 export type ClickUpFolder = {
 	__typename?: 'ClickUpFolder'
 	id: Scalars['String']
@@ -337,6 +436,38 @@ export type CommentReply = {
 	updated_at: Scalars['Timestamp']
 }
 
+export type CompositeCondition = {
+	__typename?: 'CompositeCondition'
+	alert_id?: Maybe<Scalars['ID']>
+	conditions?: Maybe<Array<CompositeCondition>>
+	operator?: Maybe<CompositeOperator>
+}
+
+export type CompositeConditionInput = {
+	alert_id?: InputMaybe<Scalars['ID']>
+	conditions?: InputMaybe<Array<CompositeConditionInput>>
+	inline?: InputMaybe<CompositeInlineConditionInput>
+	operator?: InputMaybe<CompositeOperator>
+}
+
+export type CompositeInlineConditionInput = {
+	function_column?: InputMaybe<Scalars['String']>
+	function_type: MetricAggregator
+	group_by_key?: InputMaybe<Scalars['String']>
+	name?: InputMaybe<Scalars['String']>
+	product_type: ProductType
+	query?: InputMaybe<Scalars['String']>
+	threshold_condition?: InputMaybe<ThresholdCondition>
+	threshold_value: Scalars['Float']
+	threshold_window?: InputMaybe<Scalars['Int']>
+}
+
+export enum CompositeOperator {
+	And = 'And',
+	Not = 'Not',
+	Or = 'Or',
+}
+
 export type Connection = {
 	pageInfo: PageInfo
 }
@@ -603,6 +734,34 @@ export type ErrorGroupTagAggregationBucket = {
 	percent: Scalars['Float']
 }
 
+export type ErrorGroupingRule = {
+	__typename?: 'ErrorGroupingRule'
+	attribute?: Maybe<Scalars['String']>
+	fingerprint?: Maybe<Scalars['String']>
+	pattern?: Maybe<Scalars['String']>
+	type: ErrorGroupingRuleType
+}
+
+export type ErrorGroupingRuleInput = {
+	attribute?: InputMaybe<Scalars['String']>
+	fingerprint?: InputMaybe<Scalars['String']>
+	pattern?: InputMaybe<Scalars['String']>
+	type: ErrorGroupingRuleType
+}
+
+export enum ErrorGroupingRuleType {
+	Fingerprint = 'fingerprint',
+	GroupByAttribute = 'group_by_attribute',
+	IgnoreFrames = 'ignore_frames',
+	StripMessage = 'strip_message',
+}
+
+export type ErrorGroupingSettings = {
+	__typename?: 'ErrorGroupingSettings'
+	embeddings_threshold?: Maybe<Scalars['Float']>
+	rules: Array<ErrorGroupingRule>
+}
+
 export type ErrorInstance = {
 	__typename?: 'ErrorInstance'
 	error_object: ErrorObject
@@ -1058,6 +1217,17 @@ export type LogLine = {
 	timestamp: Scalars['Timestamp']
 }
 
+export type LogPattern = {
+	__typename?: 'LogPattern'
+	count: Scalars['UInt64']
+	firstSeen: Scalars['Timestamp']
+	lastSeen: Scalars['Timestamp']
+	pattern: Scalars['String']
+	patternID: Scalars['String']
+	patternIDs: Array<Scalars['String']>
+	sample: Scalars['String']
+}
+
 export enum LogSource {
 	Backend = 'backend',
 	Frontend = 'frontend',
@@ -1083,6 +1253,12 @@ export type LogsHistogramBucketCount = {
 	level: LogLevel
 }
 
+export type LogsTailPayload = {
+	__typename?: 'LogsTailPayload'
+	dropped: Scalars['Int']
+	logs: Array<Log>
+}
+
 export type MatchedErrorObject = {
 	__typename?: 'MatchedErrorObject'
 	event: Array<Maybe<Scalars['String']>>
@@ -1275,7 +1451,9 @@ export type Mutation = {
 	changeProjectMembership: WorkspaceAdminRole
 	createAdmin: Admin
 	createAlert?: Maybe<Alert>
+	createAlertSilence: AlertSilence
 	createCloudflareProxy: Scalars['String']
+	createCompositeAlert?: Maybe<Alert>
 	createErrorComment?: Maybe<ErrorComment>
 	createErrorCommentForExistingIssue?: Maybe<ErrorComment>
 	createErrorTag: ErrorTag
@@ -1290,6 +1468,7 @@ export type Mutation = {
 	createWorkspace?: Maybe<Workspace>
 	deleteAdminFromWorkspace?: Maybe<Scalars['ID']>
 	deleteAlert: Scalars['Boolean']
+	deleteAlertSilence: Scalars['Boolean']
 	deleteDashboard: Scalars['Boolean']
 	deleteErrorAlert?: Maybe<ErrorAlert>
 	deleteErrorComment?: Maybe<Scalars['Boolean']>
@@ -1303,6 +1482,7 @@ export type Mutation = {
 	deleteSessionComment?: Maybe<Scalars['Boolean']>
 	deleteSessions: Scalars['Boolean']
 	deleteVisualization: Scalars['Boolean']
+	editErrorGroupingSettings: ErrorGroupingSettings
 	editProject?: Maybe<Project>
 	editProjectPlatforms: Scalars['Boolean']
 	editProjectSettings?: Maybe<AllProjectSettings>
@@ -1321,12 +1501,15 @@ export type Mutation = {
 	modifyClearbitIntegration?: Maybe<Scalars['Boolean']>
 	muteErrorCommentThread?: Maybe<Scalars['Boolean']>
 	muteSessionCommentThread?: Maybe<Scalars['Boolean']>
+	previewAlertTemplate: AlertTemplatePreview
+	redeliverWebhook: WebhookDelivery
 	removeErrorIssue?: Maybe<Scalars['Boolean']>
 	removeIntegrationFromProject: Scalars['Boolean']
 	removeIntegrationFromWorkspace: Scalars['Boolean']
 	replyToErrorComment?: Maybe<CommentReply>
 	replyToSessionComment?: Maybe<CommentReply>
 	requestAccess?: Maybe<Scalars['Boolean']>
+	rotateWebhookSigningSecret: WebhookSigningSecret
 	saveBillingPlan?: Maybe<Scalars['Boolean']>
 	sendAdminWorkspaceInvite?: Maybe<Scalars['String']>
 	submitRegistrationForm?: Maybe<Scalars['Boolean']>
@@ -1336,10 +1519,12 @@ export type Mutation = {
 	updateAdminAndCreateWorkspace?: Maybe<Project>
 	updateAlert?: Maybe<Alert>
 	updateAlertDisabled: Scalars['Boolean']
+	updateAlertSilence: AlertSilence
 	updateAllowMeterOverage?: Maybe<Workspace>
 	updateAllowedEmailOrigins?: Maybe<Scalars['ID']>
 	updateBillingDetails?: Maybe<Scalars['Boolean']>
 	updateClickUpProjectMappings: Scalars['Boolean']
+	updateCompositeAlert?: Maybe<Alert>
 	updateEmailOptOut: Scalars['Boolean']
 	updateErrorAlert?: Maybe<ErrorAlert>
 	updateErrorAlertIsDisabled?: Maybe<ErrorAlert>
@@ -1394,6 +1579,7 @@ export type MutationChangeProjectMembershipArgs = {
 export type MutationCreateAlertArgs = {
 	default?: InputMaybe<Scalars['Boolean']>
 	destinations: Array<AlertDestinationInput>
+	disable_resolved_notifications?: InputMaybe<Scalars['Boolean']>
 	function_column?: InputMaybe<Scalars['String']>
 	function_type: MetricAggregator
 	group_by_key?: InputMaybe<Scalars['String']>
@@ -1401,7 +1587,10 @@ export type MutationCreateAlertArgs = {
 	product_type: ProductType
 	project_id: Scalars['ID']
 	query?: InputMaybe<Scalars['String']>
+	severity?: InputMaybe<AlertSeverity>
 	sql?: InputMaybe<Scalars['String']>
+	threshold_change_type?: InputMaybe<ChangeType>
+	threshold_change_window?: InputMaybe<Scalars['Int']>
 	threshold_condition?: InputMaybe<ThresholdCondition>
 	threshold_cooldown?: InputMaybe<Scalars['Int']>
 	threshold_type?: InputMaybe<ThresholdType>
@@ -1409,11 +1598,24 @@ export type MutationCreateAlertArgs = {
 	threshold_window?: InputMaybe<Scalars['Int']>
 }
 
+export type MutationCreateAlertSilenceArgs = {
+	project_id: Scalars['ID']
+	silence: AlertSilenceInput
+}
+
 export type MutationCreateCloudflareProxyArgs = {
 	proxy_subdomain: Scalars['String']
 	workspace_id: Scalars['ID']
 }
 
+export type MutationCreateCompositeAlertArgs = {
+	condition: CompositeConditionInput
+	destinations: Array<AlertDestinationInput>
+	name: Scalars['String']
+	project_id: Scalars['ID']
+	threshold_cooldown?: InputMaybe<Scalars['Int']>
+}
+
 export type MutationCreateErrorCommentArgs = {
 	author_name: Scalars['String']
 	error_group_secure_id: Scalars['String']
@@ -1568,6 +1770,11 @@ export type MutationDeleteAlertArgs = {
 	project_id: Scalars['ID']
 }
 
+export type MutationDeleteAlertSilenceArgs = {
+	id: Scalars['ID']
+	project_id: Scalars['ID']
+}
+
 export type MutationDeleteDashboardArgs = {
 	id: Scalars['ID']
 }
@@ -1627,6 +1834,12 @@ export type MutationDeleteVisualizationArgs = {
 	id: Scalars['ID']
 }
 
+export type MutationEditErrorGroupingSettingsArgs = {
+	embeddings_threshold?: InputMaybe<Scalars['Float']>
+	project_id: Scalars['ID']
+	rules?: InputMaybe<Array<ErrorGroupingRuleInput>>
+}
+
 export type MutationEditProjectArgs = {
 	billing_email?: InputMaybe<Scalars['String']>
 	id: Scalars['ID']
@@ -1748,6 +1961,19 @@ export type MutationMuteSessionCommentThreadArgs = {
 	id: Scalars['ID']
 }
 
+export type MutationPreviewAlertTemplateArgs = {
+	destination: AlertDestinationInput
+	product_type?: InputMaybe<ProductType>
+	project_id: Scalars['ID']
+	resolved?: InputMaybe<Scalars['Boolean']>
+	threshold_type?: InputMaybe<ThresholdType>
+}
+
+export type MutationRedeliverWebhookArgs = {
+	id: Scalars['ID']
+	project_id: Scalars['ID']
+}
+
 export type MutationRemoveErrorIssueArgs = {
 	error_issue_id: Scalars['ID']
 }
@@ -1784,6 +2010,11 @@ export type MutationRequestAccessArgs = {
 	project_id: Scalars['ID']
 }
 
+export type MutationRotateWebhookSigningSecretArgs = {
+	destination_id: Scalars['ID']
+	project_id: Scalars['ID']
+}
+
 export type MutationSaveBillingPlanArgs = {
 	errorsLimitCents?: InputMaybe<Scalars['Int']>
 	errorsRetention: RetentionPeriod
@@ -1837,6 +2068,7 @@ export type MutationUpdateAdminAndCreateWorkspaceArgs = {
 export type MutationUpdateAlertArgs = {
 	alert_id: Scalars['ID']
 	destinations?: InputMaybe<Array<AlertDestinationInput>>
+	disable_resolved_notifications?: InputMaybe<Scalars['Boolean']>
 	function_column?: InputMaybe<Scalars['String']>
 	function_type?: InputMaybe<MetricAggregator>
 	group_by_key?: InputMaybe<Scalars['String']>
@@ -1844,7 +2076,10 @@ export type MutationUpdateAlertArgs = {
 	product_type?: InputMaybe<ProductType>
 	project_id: Scalars['ID']
 	query?: InputMaybe<Scalars['String']>
+	severity?: InputMaybe<AlertSeverity>
 	sql?: InputMaybe<Scalars['String']>
+	threshold_change_type?: InputMaybe<ChangeType>
+	threshold_change_window?: InputMaybe<Scalars['Int']>
 	threshold_condition?: InputMaybe<ThresholdCondition>
 	threshold_cooldown?: InputMaybe<Scalars['Int']>
 	threshold_type?: InputMaybe<ThresholdType>
@@ -1858,6 +2093,12 @@ export type MutationUpdateAlertDisabledArgs = {
 	project_id: Scalars['ID']
 }
 
+export type MutationUpdateAlertSilenceArgs = {
+	id: Scalars['ID']
+	project_id: Scalars['ID']
+	silence: AlertSilenceInput
+}
+
 export type MutationUpdateAllowMeterOverageArgs = {
 	allow_meter_overage: Scalars['Boolean']
 	workspace_id: Scalars['ID']
@@ -1877,6 +2118,15 @@ export type MutationUpdateClickUpProjectMappingsArgs = {
 	workspace_id: Scalars['ID']
 }
 
+export type MutationUpdateCompositeAlertArgs = {
+	alert_id: Scalars['ID']
+	condition?: InputMaybe<CompositeConditionInput>
+	destinations?: InputMaybe<Array<AlertDestinationInput>>
+	name?: InputMaybe<Scalars['String']>
+	project_id: Scalars['ID']
+	threshold_cooldown?: InputMaybe<Scalars['Int']>
+}
+
 export type MutationUpdateEmailOptOutArgs = {
 	admin_id?: InputMaybe<Scalars['ID']>
 	category: EmailOptOutCategory
@@ -2138,6 +2388,8 @@ export type Query = {
 	admin_role_by_project?: Maybe<WorkspaceAdminRole>
 	ai_query_suggestion: QueryOutput
 	alert: Alert
+	alert_backtest: AlertBacktest
+	alert_silences: Array<AlertSilence>
 	alerting_alert_state_changes: AlertStateChangeResults
 	alerts: Array<Maybe<Alert>>
 	api_key_to_org_id?: Maybe<Scalars['ID']>
@@ -2164,6 +2416,7 @@ export type Query = {
 	error_comments_for_admin: Array<Maybe<ErrorComment>>
 	error_comments_for_project: Array<Maybe<ErrorComment>>
 	error_group?: Maybe<ErrorGroup>
+	error_grouping_settings: ErrorGroupingSettings
 	error_groups: ErrorResults
 	error_groups_clickhouse: ErrorResults
 	error_instance?: Maybe<ErrorInstance>
@@ -2213,6 +2466,7 @@ export type Query = {
 	log_alert: LogAlert
 	log_alerts: Array<Maybe<LogAlert>>
 	log_lines: Array<LogLine>
+	log_patterns: Array<LogPattern>
 	logs: LogConnection
 	logsIntegration: IntegrationStatus
 	logs_error_objects: Array<ErrorObject>
@@ -2282,11 +2536,13 @@ export type Query = {
 	usageHistory: UsageHistory
 	userFingerprintCount?: Maybe<UserFingerprintCount>
 	user_properties_alerts: Array<Maybe<SessionAlert>>
+	validate_search_query: Array<SearchQueryError>
 	vercel_project_mappings: Array<VercelProjectMapping>
 	vercel_projects: Array<VercelProject>
 	visualization: Visualization
 	visualizations: VisualizationsResponse
 	web_vitals: MetricsBuckets
+	webhook_deliveries: Array<WebhookDelivery>
 	websocket_events?: Maybe<Array<Maybe<Scalars['Any']>>>
 	workspace?: Maybe<Workspace>
 	workspacePendingInvites: Array<Maybe<WorkspaceInviteLink>>
@@ -2327,6 +2583,16 @@ export type QueryAlertArgs = {
 	id: Scalars['ID']
 }
 
+export type QueryAlert_BacktestArgs = {
+	alert: AlertDefinitionInput
+	date_range: DateRangeRequiredInput
+	project_id: Scalars['ID']
+}
+
+export type QueryAlert_SilencesArgs = {
+	project_id: Scalars['ID']
+}
+
 export type QueryAlerting_Alert_State_ChangesArgs = {
 	alert_id: Scalars['ID']
 	count?: InputMaybe<Scalars['Int']>
@@ -2439,6 +2705,10 @@ export type QueryError_GroupArgs = {
 	use_clickhouse?: InputMaybe<Scalars['Boolean']>
 }
 
+export type QueryError_Grouping_SettingsArgs = {
+	project_id: Scalars['ID']
+}
+
 export type QueryError_GroupsArgs = {
 	count: Scalars['Int']
 	page?: InputMaybe<Scalars['Int']>
@@ -2710,6 +2980,12 @@ export type QueryLog_LinesArgs = {
 	project_id: Scalars['ID']
 }
 
+export type QueryLog_PatternsArgs = {
+	limit?: InputMaybe<Scalars['Int']>
+	params: QueryInput
+	project_id: Scalars['ID']
+}
+
 export type QueryLogsArgs = {
 	after?: InputMaybe<Scalars['String']>
 	at?: InputMaybe<Scalars['String']>
@@ -3097,6 +3373,12 @@ export type QueryUser_Properties_AlertsArgs = {
 	project_id: Scalars['ID']
 }
 
+export type QueryValidate_Search_QueryArgs = {
+	product_type: ProductType
+	project_id: Scalars['ID']
+	query: Scalars['String']
+}
+
 export type QueryVercel_Project_MappingsArgs = {
 	project_id: Scalars['ID']
 }
@@ -3120,6 +3402,12 @@ export type QueryWeb_VitalsArgs = {
 	session_secure_id: Scalars['String']
 }
 
+export type QueryWebhook_DeliveriesArgs = {
+	alert_id?: InputMaybe<Scalars['ID']>
+	count?: InputMaybe<Scalars['Int']>
+	project_id: Scalars['ID']
+}
+
 export type QueryWebsocket_EventsArgs = {
 	session_secure_id: Scalars['String']
 }
@@ -3272,6 +3560,7 @@ export enum ReservedLogKey {
 	Environment = 'environment',
 	Level = 'level',
 	Message = 'message',
+	PatternId = 'pattern_id',
 	SecureSessionId = 'secure_session_id',
 	ServiceName = 'service_name',
 	ServiceVersion = 'service_version',
@@ -3395,6 +3684,7 @@ export type Sampling = {
 	trace_exclusion_query?: Maybe<Scalars['String']>
 	trace_minute_rate_limit?: Maybe<Scalars['Int64']>
 	trace_sampling_rate: Scalars['Float']
+	trace_tail_sampling?: Maybe<TraceTailSampling>
 }
 
 export type SamplingInput = {
@@ -3413,6 +3703,7 @@ export type SamplingInput = {
 	trace_exclusion_query?: InputMaybe<Scalars['String']>
 	trace_minute_rate_limit?: InputMaybe<Scalars['Int64']>
 	trace_sampling_rate?: InputMaybe<Scalars['Float']>
+	trace_tail_sampling?: InputMaybe<TraceTailSamplingInput>
 }
 
 export type SanitizedAdmin = {
@@ -3461,6 +3752,28 @@ export type SearchParams = {
 	query?: Maybe<Scalars['String']>
 }
 
+export type SearchQueryError = {
+	__typename?: 'SearchQueryError'
+	column: Scalars['Int']
+	length: Scalars['Int']
+	line: Scalars['Int']
+	message: Scalars['String']
+	severity: SearchQueryErrorSeverity
+	type: SearchQueryErrorType
+}
+
+export enum SearchQueryErrorSeverity {
+	Error = 'Error',
+	Warning = 'Warning',
+}
+
+export enum SearchQueryErrorType {
+	InvalidOperator = 'InvalidOperator',
+	InvalidPipeline = 'InvalidPipeline',
+	Syntax = 'Syntax',
+	UnknownKey = 'UnknownKey',
+}
+
 export type Service = {
 	__typename?: 'Service'
 	buildPrefix?: Maybe<Scalars['String']>
@@ -3497,6 +3810,17 @@ export type ServiceNode = {
 	status: ServiceStatus
 }
 
+export type ServiceSamplingRate = {
+	__typename?: 'ServiceSamplingRate'
+	rate: Scalars['Float']
+	service_name: Scalars['String']
+}
+
+export type ServiceSamplingRateInput = {
+	rate: Scalars['Float']
+	service_name: Scalars['String']
+}
+
 export enum ServiceStatus {
 	Created = 'created',
 	Error = 'error',
@@ -3817,7 +4141,14 @@ export enum SourceMappingErrorCode {
 
 export type Subscription = {
 	__typename?: 'Subscription'
+	logs_tail?: Maybe<LogsTailPayload>
 	session_payload_appended?: Maybe<SessionPayload>
+	traces_tail?: Maybe<TracesTailPayload>
+}
+
+export type SubscriptionLogs_TailArgs = {
+	project_id: Scalars['ID']
+	query: Scalars['String']
 }
 
 export type SubscriptionSession_Payload_AppendedArgs = {
@@ -3825,6 +4156,11 @@ export type SubscriptionSession_Payload_AppendedArgs = {
 	session_secure_id: Scalars['String']
 }
 
+export type SubscriptionTraces_TailArgs = {
+	project_id: Scalars['ID']
+	query: Scalars['String']
+}
+
 export type SubscriptionDetails = {
 	__typename?: 'SubscriptionDetails'
 	baseAmount: Scalars['Int64']
@@ -3867,6 +4203,7 @@ export enum ThresholdCondition {
 
 export enum ThresholdType {
 	Anomaly = 'Anomaly',
+	Change = 'Change',
 	Constant = 'Constant',
 }
 
@@ -3960,6 +4297,31 @@ export type TracePayload = {
 	trace: Array<Trace>
 }
 
+export type TraceTailSampling = {
+	__typename?: 'TraceTailSampling'
+	enabled: Scalars['Boolean']
+	keep_errors: Scalars['Boolean']
+	keep_query?: Maybe<Scalars['String']>
+	latency_threshold_ms?: Maybe<Scalars['Int64']>
+	service_rates: Array<ServiceSamplingRate>
+	window_seconds: Scalars['Int']
+}
+
+export type TraceTailSamplingInput = {
+	enabled?: InputMaybe<Scalars['Boolean']>
+	keep_errors?: InputMaybe<Scalars['Boolean']>
+	keep_query?: InputMaybe<Scalars['String']>
+	latency_threshold_ms?: InputMaybe<Scalars['Int64']>
+	service_rates?: InputMaybe<Array<ServiceSamplingRateInput>>
+	window_seconds?: InputMaybe<Scalars['Int']>
+}
+
+export type TracesTailPayload = {
+	__typename?: 'TracesTailPayload'
+	dropped: Scalars['Int']
+	traces: Array<Trace>
+}
+
 export type TrackProperty = {
 	__typename?: 'TrackProperty'
 	id: Scalars['ID']
@@ -4087,6 +4449,30 @@ export type WebSocketEvent = {
 	type: Scalars['String']
 }
 
+export type WebhookDelivery = {
+	__typename?: 'WebhookDelivery'
+	alert_id: Scalars['ID']
+	attempt: Scalars['Int']
+	created_at: Scalars['Timestamp']
+	delivery_id: Scalars['String']
+	destination_id: Scalars['ID']
+	error?: Maybe<Scalars['String']>
+	id: Scalars['ID']
+	latency_ms: Scalars['Int']
+	payload: Scalars['String']
+	redelivery: Scalars['Boolean']
+	response_excerpt: Scalars['String']
+	status_code?: Maybe<Scalars['Int']>
+	success: Scalars['Boolean']
+	url: Scalars['String']
+}
+
+export type WebhookSigningSecret = {
+	__typename?: 'WebhookSigningSecret'
+	destination_id: Scalars['ID']
+	signing_secret: Scalars['String']
+}
+
 export type WebhookDestination = {
 	__typename?: 'WebhookDestination'
 	authorization?: Maybe<Scalars['String']>
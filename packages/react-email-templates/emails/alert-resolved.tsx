@@ -0,0 +1,86 @@
+import { Column, Row, Text } from '@react-email/components'
+import * as React from 'react'
+
+import {
+	AlertContainer,
+	Break,
+	CtaLink,
+	Footer,
+	highlightedTextStyle,
+	Subtitle,
+	textStyle,
+	Title,
+} from '../components/alerts'
+import { EmailHtml, HighlightLogo } from '../components/common'
+
+export interface AlertResolvedEmailProps {
+	alertLink?: string
+	alertName?: string
+	duration?: string
+	functionName?: string
+	functionValue?: string
+	groupValue?: string
+	projectName?: string
+}
+
+export const AlertResolvedEmail = ({
+	alertLink = 'https://localhost:3000/1/alerts/1',
+	alertName = 'Log Alert',
+	duration = '12m',
+	functionName = 'Count',
+	functionValue = '4',
+	groupValue = '',
+	projectName = 'Highlight Production (app.highlight.io)',
+}: AlertResolvedEmailProps) => (
+	<EmailHtml previewText={`${alertName} Alert Resolved`}>
+		<HighlightLogo />
+		<Title>
+			<span style={highlightedTextStyle}>{alertName}</span> Alert Resolved
+		</Title>
+		<Subtitle>{projectName}</Subtitle>
+
+		<AlertContainer>
+			<Text style={textStyle}>
+				{groupValue ? (
+					<span style={highlightedTextStyle}>{groupValue}</span>
+				) : (
+					'The alert'
+				)}{' '}
+				returned to normal after alerting for {duration}.
+			</Text>
+
+			<Break />
+
+			<Row style={statContainer}>
+				<Column>
+					<Text style={leftStat}>
+						<span style={statHeader}>{functionName}</span>
+						{functionValue}
+					</Text>
+				</Column>
+			</Row>
+			<CtaLink href={alertLink} label="View alert" />
+		</AlertContainer>
+
+		<Break />
+
+		<Footer alertLink={alertLink} />
+	</EmailHtml>
+)
+
+const statContainer = {
+	marginBottom: '12px',
+}
+
+const leftStat = {
+	...textStyle,
+	textAlign: 'left' as const,
+}
+
+const statHeader = {
+	...textStyle,
+	color: '#9d97aa',
+	marginRight: '8px',
+}
+
+export default AlertResolvedEmail
@@ -0,0 +1,117 @@
+import { Column, Row, Text } from '@react-email/components'
+import * as React from 'react'
+
+import {
+	AlertContainer,
+	Break,
+	CtaLink,
+	Footer,
+	highlightedTextStyle,
+	Subtitle,
+	textStyle,
+	Title,
+} from '../components/alerts'
+import { EmailHtml, HighlightLogo } from '../components/common'
+
+export interface ChangeAlertEmailProps {
+	alertLink?: string
+	alertName?: string
+	changeText?: string
+	changeWindowText?: string
+	functionName?: string
+	groupValue?: string
+	previousValue?: string
+	projectName?: string
+	query?: string
+	thresholdText?: string
+	value?: string
+}
+
+export const ChangeAlertEmail = ({
+	alertLink = 'https://localhost:3000/1/alerts/1',
+	alertName = 'Error Spike',
+	changeText = '+350%',
+	changeWindowText = '1 day earlier',
+	functionName = 'Count',
+	groupValue = '',
+	previousValue = '10',
+	projectName = 'Highlight Production (app.highlight.io)',
+	query = 'service_name:checkout',
+	thresholdText = 'increase of 300%',
+	value = '45',
+}: ChangeAlertEmailProps) => (
+	<EmailHtml previewText={`${alertName} Alert`}>
+		<HighlightLogo />
+		<Title>
+			<span style={highlightedTextStyle}>{alertName}</span> Alert
+			{groupValue ? ` for ${groupValue}` : ''}
+		</Title>
+		<Subtitle>{projectName}</Subtitle>
+
+		<AlertContainer>
+			<Text style={textStyle}>
+				{functionName} for query{' '}
+				<span style={highlightedTextStyle}>{query}</span> changed by{' '}
+				{changeText} compared with {changeWindowText}.
+			</Text>
+
+			<Break />
+
+			<Row style={statContainer}>
+				<Column>
+					<Text style={leftStat}>
+						<span style={statHeader}>{functionName}</span>
+						{value}
+					</Text>
+				</Column>
+				<Column>
+					<Text style={rightStat}>
+						<span style={statHeader}>{changeWindowText}</span>
+						{previousValue}
+					</Text>
+				</Column>
+			</Row>
+			<Row style={statContainer}>
+				<Column>
+					<Text style={leftStat}>
+						<span style={statHeader}>Change</span>
+						{changeText}
+					</Text>
+				</Column>
+				<Column>
+					<Text style={rightStat}>
+						<span style={statHeader}>Threshold</span>
+						{thresholdText}
+					</Text>
+				</Column>
+			</Row>
+			<CtaLink href={alertLink} label="View alert" />
+		</AlertContainer>
+
+		<Break />
+
+		<Footer alertLink={alertLink} />
+	</EmailHtml>
+)
+
+const statContainer = {
+	marginBottom: '12px',
+}
+
+const leftStat = {
+	...textStyle,
+	textAlign: 'left' as const,
+}
+
+const rightStat = {
+	...textStyle,
+	textAlign: 'right' as const,
+}
+
+const statHeader = {
+	...textStyle,
+	color: '#9d97aa',
+	marginRight: '8px',
+}
+
+export default ChangeAlertEmail
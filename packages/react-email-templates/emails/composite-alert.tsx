@@ -0,0 +1,96 @@
+import { Column, Row, Text } from '@react-email/components'
+import * as React from 'react'
+
+import {
+	AlertContainer,
+	Break,
+	CtaLink,
+	Footer,
+	highlightedTextStyle,
+	Subtitle,
+	textStyle,
+	Title,
+} from '../components/alerts'
+import { EmailHtml, HighlightLogo } from '../components/common'
+
+export interface CompositeAlertCondition {
+	name: string
+	value: string
+	alerting: boolean
+}
+
+export interface CompositeAlertEmailProps {
+	alertLink?: string
+	alertName?: string
+	conditions?: CompositeAlertCondition[]
+	projectName?: string
+}
+
+export const CompositeAlertEmail = ({
+	alertLink = 'https://localhost:3000/1/alerts/1',
+	alertName = 'Checkout Degraded',
+	conditions = [
+		{ name: 'Checkout error rate', value: '0.031000', alerting: true },
+		{ name: 'Checkout p95 latency', value: '912.000000', alerting: true },
+	],
+	projectName = 'Highlight Production (app.highlight.io)',
+}: CompositeAlertEmailProps) => (
+	<EmailHtml previewText={`${alertName} Alert`}>
+		<HighlightLogo />
+		<Title>
+			<span style={highlightedTextStyle}>{alertName}</span> Alert
+		</Title>
+		<Subtitle>{projectName}</Subtitle>
+
+		<AlertContainer>
+			<Text style={textStyle}>
+				The combined conditions of the alert were met.
+			</Text>
+
+			<Break />
+
+			{conditions.map((condition) => (
+				<Row style={statContainer} key={condition.name}>
+					<Column>
+						<Text style={leftStat}>
+							<span style={statHeader}>{condition.name}</span>
+							{condition.value}
+						</Text>
+					</Column>
+					<Column>
+						<Text style={rightStat}>
+							{condition.alerting ? 'Alerting' : 'Normal'}
+						</Text>
+					</Column>
+				</Row>
+			))}
+			<CtaLink href={alertLink} label="View alert" />
+		</AlertContainer>
+
+		<Break />
+
+		<Footer alertLink={alertLink} />
+	</EmailHtml>
+)
+
+const statContainer = {
+	marginBottom: '12px',
+}
+
+const leftStat = {
+	...textStyle,
+	textAlign: 'left' as const,
+}
+
+const rightStat = {
+	...textStyle,
+	textAlign: 'right' as const,
+}
+
+const statHeader = {
+	...textStyle,
+	color: '#9d97aa',
+	marginRight: '8px',
+}
+
+export default CompositeAlertEmail
@@ -1,4 +1,7 @@
+import { AlertResolvedEmail } from './alert-resolved'
 import { AlertUpsertEmail } from './alert-upsert'
+import { ChangeAlertEmail } from './change-alert'
+import { CompositeAlertEmail } from './composite-alert'
 import { ErrorAlertEmail } from './error-alert'
 import { ErrorsAlertV2Email } from './errors-alert-v2'
 import { LogAlertEmail } from './log-alert'
@@ -15,7 +18,10 @@ import { TrackEventPropertiesAlertEmail } from './track-event-properties-alert'
 import { TrackUserPropertiesAlertEmail } from './track-user-properties-alert'
 
 export {
+	AlertResolvedEmail,
 	AlertUpsertEmail,
+	ChangeAlertEmail,
+	CompositeAlertEmail,
 	ErrorAlertEmail,
 	ErrorsAlertV2Email,
 	LogAlertEmail,
@@ -1,7 +1,10 @@
 import { render } from '@react-email/render'
 import { APIGatewayEvent } from 'aws-lambda'
 import {
+	AlertResolvedEmail,
 	AlertUpsertEmail,
+	ChangeAlertEmail,
+	CompositeAlertEmail,
 	ErrorAlertEmail,
 	ErrorsAlertV2Email,
 	LogAlertEmail,
@@ -69,8 +72,14 @@ const getEmailTemplate = (template: string) => {
 			return MetricsAlertV2Email
 		case 'events-alert':
 			return EventsAlertV2Email
+		case 'composite-alert':
+			return CompositeAlertEmail
+		case 'change-alert':
+			return ChangeAlertEmail
 		case 'alert-upsert':
 			return AlertUpsertEmail
+		case 'alert-resolved':
+			return AlertResolvedEmail
 		default:
 			console.error('No email template found for ', template)
 	}